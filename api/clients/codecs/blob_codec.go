@@ -12,8 +12,30 @@ const (
 	//
 	// Each group of 32 bytes starts with a 0x00 byte so that they can be parsed as valid bn254 field elements.
 	PayloadEncodingVersion0 PayloadEncodingVersion = 0x0
+	// PayloadEncodingVersion1 compresses the payload with zstd before applying the same field element packing as
+	// PayloadEncodingVersion0. It entails a 32 byte header =
+	// [0x00, version byte, big-endian uint32 len of compressed payload, big-endian uint32 len of payload, 0x00,...]
+	// followed by the encoded compressed data [0x00, 31 bytes of data, 0x00, 31 bytes of data,...]
+	//
+	// This version is only supported by EigenDA V2 payloads. It is an opt-in extension of the Go clients, and is not
+	// part of the integration spec: the Rust verification crate (and so secure integrations built on it) reject it.
+	// Clients only decode it when configured with it, see [DecodablePayloadEncodingVersions].
+	PayloadEncodingVersion1 PayloadEncodingVersion = 0x1
 )
 
+// SupportedPayloadEncodingVersions lists the PayloadEncodingVersions that V2 clients can be configured with.
+var SupportedPayloadEncodingVersions = []PayloadEncodingVersion{PayloadEncodingVersion0, PayloadEncodingVersion1}
+
+// DecodablePayloadEncodingVersions returns the PayloadEncodingVersions that a V2 client configured with the given
+// version decodes. PayloadEncodingVersion0 is always decodable, while other versions must be opted into, since they
+// aren't supported by every reader.
+func DecodablePayloadEncodingVersions(configured PayloadEncodingVersion) []PayloadEncodingVersion {
+	if configured == PayloadEncodingVersion0 {
+		return []PayloadEncodingVersion{PayloadEncodingVersion0}
+	}
+	return []PayloadEncodingVersion{PayloadEncodingVersion0, configured}
+}
+
 type BlobCodec interface {
 	DecodeBlob(encodedData []byte) ([]byte, error)
 	EncodeBlob(rawData []byte) ([]byte, error)
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	gomath "math"
+	"slices"
 
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/common/math"
@@ -61,21 +62,39 @@ func (ep *EncodedPayload) LenSymbols() uint32 {
 	return uint32(len(ep.bytes)) / encoding.BYTES_PER_SYMBOL
 }
 
-// Decode applies the inverse of PayloadEncodingVersion0 to an EncodedPayload, and returns the decoded Payload
+// Decode applies the inverse of the [codecs.PayloadEncodingVersion0] encoding, and returns the decoded Payload.
+//
+// Encoded payloads with any other version in their header fail to decode, see [EncodedPayload.DecodeAllowingVersion].
 func (ep *EncodedPayload) Decode() (Payload, error) {
+	return ep.DecodeAllowingVersion(codecs.PayloadEncodingVersion0)
+}
+
+// DecodeAllowingVersion applies the inverse of the encoding version found in the header of an EncodedPayload, and
+// returns the decoded Payload. The header version must be one of [codecs.DecodablePayloadEncodingVersions] for the
+// given version, i.e. either [codecs.PayloadEncodingVersion0] or the version itself.
+func (ep *EncodedPayload) DecodeAllowingVersion(version codecs.PayloadEncodingVersion) (Payload, error) {
 	err := ep.checkLenInvariant()
 	if err != nil {
 		return nil, fmt.Errorf("check length invariant: %w", err)
 	}
-	payloadLenInHeader, err := ep.decodeHeader()
+	header, err := ep.decodeHeader()
 	if err != nil {
 		return nil, fmt.Errorf("decodeHeader: %w", err)
 	}
-	payload, err := ep.decodePayload(payloadLenInHeader)
+	if !slices.Contains(codecs.DecodablePayloadEncodingVersions(version), header.version) {
+		return nil, fmt.Errorf("encoded payload header version %x is not enabled", header.version)
+	}
+	data, err := ep.decodePayload(header.dataLen)
 	if err != nil {
 		return nil, fmt.Errorf("decodePayload: %w", err)
 	}
-	return payload, nil
+	if header.version == codecs.PayloadEncodingVersion1 {
+		data, err = decompressPayload(data, header.decompressedLen)
+		if err != nil {
+			return nil, fmt.Errorf("decompress payload: %w", err)
+		}
+	}
+	return data, nil
 }
 
 // ToBlob converts the EncodedPayload into a Blob
@@ -104,31 +123,48 @@ func (ep *EncodedPayload) ToBlob(payloadForm codecs.PolynomialForm) (*Blob, erro
 	return blobFromCoefficients(coeffPolynomial)
 }
 
+// encodedPayloadHeader contains the values decoded from the header of an encoded payload.
+type encodedPayloadHeader struct {
+	version codecs.PayloadEncodingVersion
+	// length of the data packed in the body of the encoded payload
+	dataLen uint32
+	// length of the payload once the data is decompressed. Only set for codecs.PayloadEncodingVersion1.
+	decompressedLen uint32
+}
+
 // decodeHeader validates the header (first field element = 32 bytes) of the encoded payload,
-// and returns the claimed length of the payload if the header is valid.
-func (ep *EncodedPayload) decodeHeader() (uint32, error) {
+// and returns its contents if the header is valid.
+func (ep *EncodedPayload) decodeHeader() (encodedPayloadHeader, error) {
 	if len(ep.bytes) < codec.EncodedPayloadHeaderLenBytes {
-		return 0, fmt.Errorf("encoded payload must be at least %d bytes long to contain a header, but got %d bytes",
+		return encodedPayloadHeader{}, fmt.Errorf(
+			"encoded payload must be at least %d bytes long to contain a header, but got %d bytes",
 			codec.EncodedPayloadHeaderLenBytes, len(ep.bytes))
 	}
 	if ep.bytes[0] != 0x00 {
-		return 0, fmt.Errorf("encoded payload header first byte must be 0x00, but got %x", ep.bytes[0])
+		return encodedPayloadHeader{}, fmt.Errorf(
+			"encoded payload header first byte must be 0x00, but got %x", ep.bytes[0])
 	}
-	var payloadLength uint32
-	switch ep.bytes[1] {
-	case byte(codecs.PayloadEncodingVersion0):
-		payloadLength = binary.BigEndian.Uint32(ep.bytes[2:6])
+	header := encodedPayloadHeader{version: codecs.PayloadEncodingVersion(ep.bytes[1])}
+	var paddingStart int
+	switch header.version {
+	case codecs.PayloadEncodingVersion0:
+		header.dataLen = binary.BigEndian.Uint32(ep.bytes[2:6])
+		paddingStart = 6
+	case codecs.PayloadEncodingVersion1:
+		header.dataLen = binary.BigEndian.Uint32(ep.bytes[2:6])
+		header.decompressedLen = binary.BigEndian.Uint32(ep.bytes[6:10])
+		paddingStart = 10
 	default:
-		return 0, fmt.Errorf("unknown encoded payload header version: %x", ep.bytes[1])
+		return encodedPayloadHeader{}, fmt.Errorf("unknown encoded payload header version: %x", ep.bytes[1])
 	}
 
-	for _, b := range ep.bytes[6:codec.EncodedPayloadHeaderLenBytes] {
+	for _, b := range ep.bytes[paddingStart:codec.EncodedPayloadHeaderLenBytes] {
 		if b != 0x00 {
-			return 0, fmt.Errorf("padding in encoded payload header must be 0x00: %x", b)
+			return encodedPayloadHeader{}, fmt.Errorf("padding in encoded payload header must be 0x00: %x", b)
 		}
 	}
 
-	return payloadLength, nil
+	return header, nil
 }
 
 // decodePayload decodes the body by checking for and removing internal zero-byte padding,
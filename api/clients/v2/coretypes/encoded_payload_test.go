@@ -88,9 +88,18 @@ func TestDecodePayloadErrors(t *testing.T) {
 			encodedPayloadHex: "0100000000000000000000000000000000000000000000000000000000000000",
 		},
 		{
-			name:              "Only version 0x00 is supported",
+			name:              "Only versions 0x00 and 0x01 are supported",
+			encodedPayloadHex: "0002000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:              "Version 0x01 must contain a zstd frame",
 			encodedPayloadHex: "0001000000000000000000000000000000000000000000000000000000000000",
 		},
+		{
+			name: "Version 0x01 data must be valid zstd",
+			encodedPayloadHex: "0001000000030000000300000000000000000000000000000000000000000000" +
+				"0001020300000000000000000000000000000000000000000000000000000000",
+		},
 		{
 			name:              "Payload length must be a multiple of 32 bytes",
 			encodedPayloadHex: "0000000000010000000000000000000000000000000000000000000000000000" + "000100",
@@ -2,6 +2,7 @@ package coretypes
 
 import (
 	"encoding/binary"
+	"fmt"
 
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/common/math"
@@ -14,8 +15,42 @@ type Payload []byte
 
 // ToEncodedPayload performs the [codecs.PayloadEncodingVersion0] encoding to create an encoded payload.
 func (p Payload) ToEncodedPayload() *EncodedPayload {
+	return encodePayloadData(codecs.PayloadEncodingVersion0, p, 0)
+}
+
+// ToEncodedPayloadWithVersion encodes the payload with the given [codecs.PayloadEncodingVersion].
+//
+// The version is written into the header of the encoded payload, so readers that have enabled it can decode it with
+// [EncodedPayload.DecodeAllowingVersion]. If compressing the payload with [codecs.PayloadEncodingVersion1] doesn't
+// make it smaller, the payload is encoded with [codecs.PayloadEncodingVersion0] instead. The encoded payload is thus
+// never larger than the [codecs.PayloadEncodingVersion0] encoding, so any payload that fits in a blob uncompressed
+// also fits in it with compression enabled.
+func (p Payload) ToEncodedPayloadWithVersion(version codecs.PayloadEncodingVersion) (*EncodedPayload, error) {
+	switch version {
+	case codecs.PayloadEncodingVersion0:
+		return p.ToEncodedPayload(), nil
+	case codecs.PayloadEncodingVersion1:
+		if len(p) > MaxDecompressedPayloadBytes {
+			return nil, fmt.Errorf("payload of %d bytes exceeds the max of %d bytes for compressed payloads",
+				len(p), MaxDecompressedPayloadBytes)
+		}
+		compressed := compressPayload(p)
+		if len(compressed) >= len(p) {
+			// incompressible data, e.g. payloads that are already compressed
+			return p.ToEncodedPayload(), nil
+		}
+		return encodePayloadData(codecs.PayloadEncodingVersion1, compressed, uint32(len(p))), nil
+	default:
+		return nil, fmt.Errorf("unsupported payload encoding version: %d", version)
+	}
+}
+
+// encodePayloadData packs data into an encoded payload with the given version.
+//
+// decompressedLen is written into the header for versions that compress the payload, and is ignored otherwise.
+func encodePayloadData(version codecs.PayloadEncodingVersion, data []byte, decompressedLen uint32) *EncodedPayload {
 	// Encode payload modulo bn254, and align to 32 bytes
-	encodedData := codec.PadPayload(p)
+	encodedData := codec.PadPayload(data)
 
 	// Calculate the length of the EncodedPayload in symbols (including the header) which has to be a power of 2.
 	encodedDataLenSymbols := uint32(len(encodedData)) / encoding.BYTES_PER_SYMBOL
@@ -27,11 +62,14 @@ func (p Payload) ToEncodedPayload() *EncodedPayload {
 	// Write the header
 	encodedPayloadHeader := encodedPayloadBytes[:codec.EncodedPayloadHeaderLenBytes]
 	// first byte is always 0 to ensure the payloadHeader is a valid bn254 element
-	encodedPayloadHeader[1] = byte(codecs.PayloadEncodingVersion0) // encode version byte
-	// encode payload length as uint32
+	encodedPayloadHeader[1] = byte(version) // encode version byte
+	// encode data length as uint32
 	binary.BigEndian.PutUint32(
 		encodedPayloadHeader[2:6],
-		uint32(len(p))) // uint32 should be more than enough to store the length (approx 4gb)
+		uint32(len(data))) // uint32 should be more than enough to store the length (approx 4gb)
+	if version == codecs.PayloadEncodingVersion1 {
+		binary.BigEndian.PutUint32(encodedPayloadHeader[6:10], decompressedLen)
+	}
 
 	// Write the encoded data, starting after the header
 	copy(encodedPayloadBytes[codec.EncodedPayloadHeaderLenBytes:], encodedData)
@@ -0,0 +1,67 @@
+package coretypes
+
+import (
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// MaxDecompressedPayloadBytes is the largest payload that can be encoded with [codecs.PayloadEncodingVersion1].
+//
+// Decoding refuses to decompress encoded payloads that claim a larger size, so that a small blob can't be used to
+// make readers allocate unbounded amounts of memory.
+const MaxDecompressedPayloadBytes = 128 * 1024 * 1024
+
+var (
+	// Both of these are safe for concurrent use when only EncodeAll and DecodeAll are called.
+	zstdEncoder = mustNewZstdEncoder()
+	zstdDecoder = mustNewZstdDecoder()
+)
+
+func mustNewZstdEncoder() *zstd.Encoder {
+	// Concurrency is set to 1 since EncodeAll only ever uses a single goroutine. Zero frames are enabled so that an
+	// empty payload still compresses to a valid frame, which decoding requires.
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithZeroFrames(true))
+	if err != nil {
+		panic(fmt.Sprintf("create zstd encoder: %v", err))
+	}
+	return encoder
+}
+
+func mustNewZstdDecoder() *zstd.Decoder {
+	decoder, err := zstd.NewReader(nil,
+		zstd.WithDecoderConcurrency(0),
+		zstd.WithDecoderMaxMemory(MaxDecompressedPayloadBytes))
+	if err != nil {
+		panic(fmt.Sprintf("create zstd decoder: %v", err))
+	}
+	return decoder
+}
+
+// compressPayload compresses the payload with zstd.
+func compressPayload(payload []byte) []byte {
+	return zstdEncoder.EncodeAll(payload, nil)
+}
+
+// decompressPayload decompresses zstd data, and checks that it decompresses to exactly decompressedLen bytes.
+func decompressPayload(compressed []byte, decompressedLen uint32) (Payload, error) {
+	if decompressedLen > MaxDecompressedPayloadBytes {
+		return nil, fmt.Errorf("decompressed payload length %d in header exceeds the max of %d bytes",
+			decompressedLen, MaxDecompressedPayloadBytes)
+	}
+
+	// DecodeAll accepts empty input, but compressPayload always produces a zstd frame.
+	if len(compressed) == 0 {
+		return nil, fmt.Errorf("compressed payload is empty")
+	}
+
+	payload, err := zstdDecoder.DecodeAll(compressed, make([]byte, 0, decompressedLen))
+	if err != nil {
+		return nil, fmt.Errorf("zstd decompress: %w", err)
+	}
+	if uint32(len(payload)) != decompressedLen {
+		return nil, fmt.Errorf("payload decompressed to %d bytes, but header claims %d bytes",
+			len(payload), decompressedLen)
+	}
+	return payload, nil
+}
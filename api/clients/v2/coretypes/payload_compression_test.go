@@ -0,0 +1,143 @@
+package coretypes
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/encoding/codec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeCompressedPayload(t *testing.T) {
+	testCases := []struct {
+		name    string
+		payload Payload
+	}{
+		{name: "compressible", payload: Payload(bytes.Repeat([]byte("rollup batch "), 10_000))},
+		{name: "barely compressible", payload: Payload(bytes.Repeat([]byte{0x01}, 64))},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encodedPayload, err := tc.payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion1)
+			require.NoError(t, err)
+			require.NoError(t, encodedPayload.checkLenInvariant())
+			require.Equal(t, byte(codecs.PayloadEncodingVersion1), encodedPayload.bytes[1])
+			require.Equal(t, uint32(len(tc.payload)), binary.BigEndian.Uint32(encodedPayload.bytes[6:10]))
+
+			decodedPayload, err := encodedPayload.DecodeAllowingVersion(codecs.PayloadEncodingVersion1)
+			require.NoError(t, err)
+			require.Equal(t, []byte(tc.payload), []byte(decodedPayload))
+
+			// compressed payloads must be opted into
+			_, err = encodedPayload.Decode()
+			require.Error(t, err)
+
+			for _, form := range []codecs.PolynomialForm{codecs.PolynomialFormEval, codecs.PolynomialFormCoeff} {
+				blob, err := encodedPayload.ToBlob(form)
+				require.NoError(t, err)
+				decodedPayload, err = blob.ToEncodedPayloadUnchecked(form).
+					DecodeAllowingVersion(codecs.PayloadEncodingVersion1)
+				require.NoError(t, err)
+				require.Equal(t, []byte(tc.payload), []byte(decodedPayload))
+			}
+		})
+	}
+}
+
+func TestIncompressiblePayloadFallsBackToVersion0(t *testing.T) {
+	random := make([]byte, 1000)
+	_, err := rand.Read(random)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		payload Payload
+	}{
+		{name: "empty", payload: Payload{}},
+		{name: "single byte", payload: Payload{0x01}},
+		{name: "random", payload: Payload(random)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encodedPayload, err := tc.payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion1)
+			require.NoError(t, err)
+			require.Equal(t, tc.payload.ToEncodedPayload().Serialize(), encodedPayload.Serialize())
+
+			// version 0 payloads decode without opting into compression
+			decodedPayload, err := encodedPayload.Decode()
+			require.NoError(t, err)
+			require.Equal(t, []byte(tc.payload), []byte(decodedPayload))
+		})
+	}
+}
+
+func TestCompressedPayloadIsSmaller(t *testing.T) {
+	payload := Payload(bytes.Repeat([]byte("rollup batch "), 10_000))
+
+	uncompressed, err := payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion0)
+	require.NoError(t, err)
+	compressed, err := payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion1)
+	require.NoError(t, err)
+	require.Less(t, compressed.LenSymbols(), uncompressed.LenSymbols())
+}
+
+func TestCompressedPayloadNeverLargerThanVersion0(t *testing.T) {
+	// the largest payload that fits in a blob of 1024 symbols, which is how proxy sizes the chunks of large payloads
+	maxPayloadSize, err := codec.BlobSymbolsToMaxPayloadSize(1024)
+	require.NoError(t, err)
+
+	incompressible := make([]byte, maxPayloadSize)
+	_, err = rand.Read(incompressible)
+	require.NoError(t, err)
+	partlyCompressible := bytes.Clone(incompressible)
+	copy(partlyCompressible[maxPayloadSize/2:], bytes.Repeat([]byte{0x01}, int(maxPayloadSize/4)))
+
+	testCases := []struct {
+		payload         Payload
+		expectedVersion codecs.PayloadEncodingVersion
+	}{
+		{payload: incompressible, expectedVersion: codecs.PayloadEncodingVersion0},
+		{payload: partlyCompressible, expectedVersion: codecs.PayloadEncodingVersion1},
+	}
+
+	for _, tc := range testCases {
+		uncompressed := tc.payload.ToEncodedPayload()
+		compressed, err := tc.payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion1)
+		require.NoError(t, err)
+		require.Equal(t, byte(tc.expectedVersion), compressed.bytes[1])
+		require.LessOrEqual(t, compressed.LenSymbols(), uncompressed.LenSymbols())
+		require.LessOrEqual(t, compressed.LenSymbols(), uint32(1024))
+	}
+}
+
+func TestDecodeCompressedPayloadErrors(t *testing.T) {
+	payload := Payload(bytes.Repeat([]byte("rollup batch "), 100))
+
+	t.Run("decompressed length mismatch", func(t *testing.T) {
+		encodedPayload, err := payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion1)
+		require.NoError(t, err)
+		binary.BigEndian.PutUint32(encodedPayload.bytes[6:10], uint32(len(payload)+1))
+
+		_, err = encodedPayload.DecodeAllowingVersion(codecs.PayloadEncodingVersion1)
+		require.Error(t, err)
+	})
+
+	t.Run("decompressed length exceeds max", func(t *testing.T) {
+		encodedPayload, err := payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion1)
+		require.NoError(t, err)
+		binary.BigEndian.PutUint32(encodedPayload.bytes[6:10], MaxDecompressedPayloadBytes+1)
+
+		_, err = encodedPayload.DecodeAllowingVersion(codecs.PayloadEncodingVersion1)
+		require.Error(t, err)
+	})
+
+	t.Run("unsupported encoding version", func(t *testing.T) {
+		_, err := payload.ToEncodedPayloadWithVersion(codecs.PayloadEncodingVersion(0x2))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,342 @@
+package dispersal
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// extension of the files that hold journal entries
+const journalEntryExtension = ".json"
+
+// DispersalJournalEntry describes a blob that has been accepted by a disperser, but for which no cert has been
+// built yet.
+type DispersalJournalEntry struct {
+	// The key of the dispersed blob.
+	BlobKey corev2.BlobKey
+	// The hash of the payload contained in the blob, see [PayloadHash].
+	PayloadHash [32]byte
+	// The ID of the disperser that accepted the blob.
+	DisperserID uint32
+	// The payment metadata that was debited for the blob.
+	PaymentMetadata core.PaymentMetadata
+	// The last known status of the blob.
+	BlobStatus dispgrpc.BlobStatus
+	// The time at which the blob was accepted by the disperser.
+	CreatedAt time.Time
+	// The number of times building the cert of the blob has been resumed from the journal.
+	ResumeAttempts uint32
+}
+
+// journalRecord is the JSON encoding of a DispersalJournalEntry.
+type journalRecord struct {
+	BlobKey         gethcommon.Hash      `json:"blob_key"`
+	PayloadHash     gethcommon.Hash      `json:"payload_hash"`
+	DisperserID     uint32               `json:"disperser_id"`
+	PaymentMetadata core.PaymentMetadata `json:"payment_metadata"`
+	BlobStatus      string               `json:"blob_status"`
+	CreatedAt       time.Time            `json:"created_at"`
+	ResumeAttempts  uint32               `json:"resume_attempts,omitempty"`
+}
+
+// DispersalJournal persists the blobs that have been accepted by a disperser, but for which no cert has been built
+// yet. Without it, a process that stops between these two steps loses the blob key of a blob that was already paid
+// for, and has to disperse (and pay for) the payload again.
+//
+// When a payload is sent, the PayloadDisperser first looks for a journal entry with the same payload hash. If there is
+// one, it resumes waiting for the signatures of the journaled blob, and builds the cert from it, instead of dispersing
+// the payload again. Callers that don't send the same payloads again can resume the journaled blobs with
+// [PayloadDisperser.ResumeJournaledDispersals] instead.
+//
+// Each entry is stored in its own JSON file, named after the blob key, in the journal directory. Entries are written
+// atomically and flushed to disk before the PayloadDisperser moves on. Entries older than the retention period are
+// dropped, since their blobs can no longer be certified: when the journal is opened, and when they are looked up.
+//
+// This struct is goroutine safe.
+type DispersalJournal struct {
+	logger    logging.Logger
+	directory string
+	retention time.Duration
+	clock     func() time.Time
+
+	lock sync.Mutex
+	// in-flight entries, by payload hash
+	entries map[[32]byte]*DispersalJournalEntry
+}
+
+// NewDispersalJournal opens the journal in the given directory, creating the directory if needed, and loads the
+// entries that were in flight when the journal was last used.
+func NewDispersalJournal(
+	logger logging.Logger,
+	directory string,
+	retention time.Duration,
+	clock func() time.Time,
+) (*DispersalJournal, error) {
+	err := os.MkdirAll(directory, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("create journal directory %s: %w", directory, err)
+	}
+
+	journal := &DispersalJournal{
+		logger:    logger,
+		directory: directory,
+		retention: retention,
+		clock:     clock,
+		entries:   make(map[[32]byte]*DispersalJournalEntry),
+	}
+
+	err = journal.load()
+	if err != nil {
+		return nil, fmt.Errorf("load journal %s: %w", directory, err)
+	}
+
+	if len(journal.entries) > 0 {
+		logger.Info("Loaded in-flight dispersals from journal",
+			"directory", directory, "count", len(journal.entries))
+	}
+
+	return journal, nil
+}
+
+// PayloadHash returns the hash used to match a payload with its journal entry.
+func PayloadHash(payload coretypes.Payload) [32]byte {
+	return sha256.Sum256(payload)
+}
+
+// load reads the entries in the journal directory, and deletes the ones that have expired. Swap files left behind
+// by a crash during a write are deleted as well.
+func (j *DispersalJournal) load() error {
+	now := j.clock()
+
+	files, err := os.ReadDir(j.directory)
+	if err != nil {
+		return fmt.Errorf("read journal directory: %w", err)
+	}
+
+	for _, file := range files {
+		path := filepath.Join(j.directory, file.Name())
+		if strings.HasSuffix(file.Name(), util.SwapFileExtension) {
+			err = os.Remove(path)
+			if err != nil {
+				return fmt.Errorf("remove swap file %s: %w", path, err)
+			}
+			continue
+		}
+		if file.IsDir() || !strings.HasSuffix(file.Name(), journalEntryExtension) {
+			continue
+		}
+
+		entry, err := readJournalEntry(path)
+		if err != nil {
+			return err
+		}
+
+		if j.expired(entry, now) {
+			j.logger.Info("Dropping expired dispersal from journal",
+				"blobKey", entry.BlobKey.Hex(), "createdAt", entry.CreatedAt)
+			err = os.Remove(path)
+			if err != nil {
+				return fmt.Errorf("remove expired entry %s: %w", path, err)
+			}
+			continue
+		}
+
+		// if the same payload was dispersed more than once, resume the latest dispersal
+		existing, ok := j.entries[entry.PayloadHash]
+		if !ok || entry.CreatedAt.After(existing.CreatedAt) {
+			j.entries[entry.PayloadHash] = entry
+		}
+	}
+
+	return nil
+}
+
+func readJournalEntry(path string) (*DispersalJournalEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read entry %s: %w", path, err)
+	}
+
+	var record journalRecord
+	err = json.Unmarshal(data, &record)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal entry %s: %w", path, err)
+	}
+
+	blobStatus, ok := dispgrpc.BlobStatus_value[record.BlobStatus]
+	if !ok {
+		return nil, fmt.Errorf("entry %s has unknown blob status %q", path, record.BlobStatus)
+	}
+
+	return &DispersalJournalEntry{
+		BlobKey:         corev2.BlobKey(record.BlobKey),
+		PayloadHash:     record.PayloadHash,
+		DisperserID:     record.DisperserID,
+		PaymentMetadata: record.PaymentMetadata,
+		BlobStatus:      dispgrpc.BlobStatus(blobStatus),
+		CreatedAt:       record.CreatedAt,
+		ResumeAttempts:  record.ResumeAttempts,
+	}, nil
+}
+
+// expired returns true if the entry is older than the retention period.
+func (j *DispersalJournal) expired(entry *DispersalJournalEntry, now time.Time) bool {
+	return now.Sub(entry.CreatedAt) > j.retention
+}
+
+// dropIfExpired removes the entry if it is older than the retention period, and returns true if it did. The caller
+// must hold the lock.
+func (j *DispersalJournal) dropIfExpired(entry *DispersalJournalEntry, now time.Time) bool {
+	if !j.expired(entry, now) {
+		return false
+	}
+
+	j.logger.Info("Dropping expired dispersal from journal",
+		"blobKey", entry.BlobKey.Hex(), "createdAt", entry.CreatedAt)
+	err := j.remove(entry.PayloadHash, entry.BlobKey)
+	if err != nil {
+		// the entry is ignored regardless, and its file is deleted the next time the journal is opened
+		j.logger.Error("failed to remove expired dispersal from journal", "blobKey", entry.BlobKey.Hex(), "err", err)
+	}
+	return true
+}
+
+// Get returns a copy of the in-flight entry for the given payload hash, if there is one. Expired entries are dropped
+// instead of being returned.
+func (j *DispersalJournal) Get(payloadHash [32]byte) (DispersalJournalEntry, bool) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	entry, ok := j.entries[payloadHash]
+	if !ok || j.dropIfExpired(entry, j.clock()) {
+		return DispersalJournalEntry{}, false
+	}
+	return *entry, true
+}
+
+// List returns copies of the in-flight entries, oldest first. Expired entries are dropped instead of being returned.
+func (j *DispersalJournal) List() []DispersalJournalEntry {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	now := j.clock()
+	entries := make([]DispersalJournalEntry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		if j.dropIfExpired(entry, now) {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+	slices.SortFunc(entries, func(a DispersalJournalEntry, b DispersalJournalEntry) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return entries
+}
+
+// Put writes an entry to disk, replacing any entry with the same blob key.
+func (j *DispersalJournal) Put(entry DispersalJournalEntry) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	return j.put(entry)
+}
+
+// UpdateStatus records a new status for the entry of a blob. It is a no-op if the blob isn't journaled, e.g. because
+// its entry has been removed in the meantime.
+func (j *DispersalJournal) UpdateStatus(blobKey corev2.BlobKey, status dispgrpc.BlobStatus) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	// there are only a handful of in-flight entries at any time, so a linear scan is fine
+	for _, entry := range j.entries {
+		if entry.BlobKey != blobKey {
+			continue
+		}
+		if entry.BlobStatus == status {
+			return nil
+		}
+		updated := *entry
+		updated.BlobStatus = status
+		return j.put(updated)
+	}
+	return nil
+}
+
+// IncrementResumeAttempts records that building the cert of a blob is being resumed from the journal once more. It is
+// a no-op if the blob isn't journaled.
+func (j *DispersalJournal) IncrementResumeAttempts(blobKey corev2.BlobKey) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	for _, entry := range j.entries {
+		if entry.BlobKey != blobKey {
+			continue
+		}
+		updated := *entry
+		updated.ResumeAttempts++
+		return j.put(updated)
+	}
+	return nil
+}
+
+// put writes an entry to disk. The caller must hold the lock.
+func (j *DispersalJournal) put(entry DispersalJournalEntry) error {
+	data, err := json.Marshal(journalRecord{
+		BlobKey:         gethcommon.Hash(entry.BlobKey),
+		PayloadHash:     entry.PayloadHash,
+		DisperserID:     entry.DisperserID,
+		PaymentMetadata: entry.PaymentMetadata,
+		BlobStatus:      entry.BlobStatus.String(),
+		CreatedAt:       entry.CreatedAt,
+		ResumeAttempts:  entry.ResumeAttempts,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal entry: %w", err)
+	}
+
+	err = util.AtomicWrite(j.entryPath(entry.BlobKey), data, true)
+	if err != nil {
+		return fmt.Errorf("write entry for blob %s: %w", entry.BlobKey.Hex(), err)
+	}
+	j.entries[entry.PayloadHash] = &entry
+	return nil
+}
+
+// Remove deletes an entry, once a cert has been built for its blob or its blob has failed.
+func (j *DispersalJournal) Remove(payloadHash [32]byte, blobKey corev2.BlobKey) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	return j.remove(payloadHash, blobKey)
+}
+
+// remove deletes an entry. The caller must hold the lock.
+func (j *DispersalJournal) remove(payloadHash [32]byte, blobKey corev2.BlobKey) error {
+	err := os.Remove(j.entryPath(blobKey))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove entry for blob %s: %w", blobKey.Hex(), err)
+	}
+	if entry, ok := j.entries[payloadHash]; ok && entry.BlobKey == blobKey {
+		delete(j.entries, payloadHash)
+	}
+	return nil
+}
+
+func (j *DispersalJournal) entryPath(blobKey corev2.BlobKey) string {
+	return filepath.Join(j.directory, blobKey.Hex()+journalEntryExtension)
+}
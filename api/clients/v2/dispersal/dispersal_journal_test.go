@@ -0,0 +1,185 @@
+package dispersal
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func testJournalEntry(blobKey byte, payload string, createdAt time.Time) DispersalJournalEntry {
+	return DispersalJournalEntry{
+		BlobKey:     corev2.BlobKey{blobKey},
+		PayloadHash: PayloadHash(coretypes.Payload(payload)),
+		DisperserID: 7,
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         gethcommon.HexToAddress("0x1234"),
+			Timestamp:         createdAt.UnixNano(),
+			CumulativePayment: big.NewInt(42),
+		},
+		BlobStatus: dispgrpc.BlobStatus_QUEUED,
+		CreatedAt:  createdAt,
+	}
+}
+
+func fixedClock(now time.Time) func() time.Time {
+	return func() time.Time {
+		return now
+	}
+}
+
+func TestDispersalJournal(t *testing.T) {
+	logger := common.TestLogger(t)
+	directory := t.TempDir()
+	now := time.Unix(1_700_000_000, 0).UTC()
+
+	journal, err := NewDispersalJournal(logger, directory, time.Hour, fixedClock(now))
+	require.NoError(t, err)
+
+	entry := testJournalEntry(1, "payload", now)
+	_, ok := journal.Get(entry.PayloadHash)
+	require.False(t, ok)
+
+	require.NoError(t, journal.Put(entry))
+	got, ok := journal.Get(entry.PayloadHash)
+	require.True(t, ok)
+	require.Equal(t, entry, got)
+
+	require.NoError(t, journal.UpdateStatus(entry.BlobKey, dispgrpc.BlobStatus_GATHERING_SIGNATURES))
+	// updating the status of a blob that isn't journaled is a no-op
+	require.NoError(t, journal.UpdateStatus(corev2.BlobKey{2}, dispgrpc.BlobStatus_COMPLETE))
+
+	// entries survive reopening the journal
+	reopened, err := NewDispersalJournal(logger, directory, time.Hour, fixedClock(now.Add(time.Minute)))
+	require.NoError(t, err)
+	got, ok = reopened.Get(entry.PayloadHash)
+	require.True(t, ok)
+	require.Equal(t, dispgrpc.BlobStatus_GATHERING_SIGNATURES, got.BlobStatus)
+	require.Equal(t, entry.BlobKey, got.BlobKey)
+	require.Equal(t, entry.DisperserID, got.DisperserID)
+	require.Equal(t, entry.PaymentMetadata, got.PaymentMetadata)
+	require.True(t, entry.CreatedAt.Equal(got.CreatedAt))
+
+	// removing with another blob key doesn't remove the entry of the payload
+	require.NoError(t, reopened.Remove(entry.PayloadHash, corev2.BlobKey{2}))
+	_, ok = reopened.Get(entry.PayloadHash)
+	require.True(t, ok)
+
+	require.NoError(t, reopened.Remove(entry.PayloadHash, entry.BlobKey))
+	_, ok = reopened.Get(entry.PayloadHash)
+	require.False(t, ok)
+	files, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestDispersalJournalLoad(t *testing.T) {
+	logger := common.TestLogger(t)
+	directory := t.TempDir()
+	now := time.Unix(1_700_000_000, 0).UTC()
+
+	journal, err := NewDispersalJournal(logger, directory, time.Hour, fixedClock(now))
+	require.NoError(t, err)
+
+	expired := testJournalEntry(1, "expired", now.Add(-2*time.Hour))
+	older := testJournalEntry(2, "redispersed", now.Add(-time.Minute))
+	newer := testJournalEntry(3, "redispersed", now)
+	require.NoError(t, journal.Put(expired))
+	require.NoError(t, journal.Put(older))
+	require.NoError(t, journal.Put(newer))
+
+	// a swap file left behind by a crash during a write
+	swapFile := filepath.Join(directory, "partial"+journalEntryExtension+util.SwapFileExtension)
+	require.NoError(t, os.WriteFile(swapFile, []byte("{"), 0o600))
+
+	reopened, err := NewDispersalJournal(logger, directory, time.Hour, fixedClock(now))
+	require.NoError(t, err)
+
+	_, ok := reopened.Get(expired.PayloadHash)
+	require.False(t, ok)
+	require.NoFileExists(t, reopened.entryPath(expired.BlobKey))
+	require.NoFileExists(t, swapFile)
+
+	// the latest dispersal of a payload is resumed
+	got, ok := reopened.Get(newer.PayloadHash)
+	require.True(t, ok)
+	require.Equal(t, newer.BlobKey, got.BlobKey)
+	require.FileExists(t, reopened.entryPath(older.BlobKey))
+}
+
+func TestDispersalJournalList(t *testing.T) {
+	logger := common.TestLogger(t)
+	now := time.Unix(1_700_000_000, 0).UTC()
+
+	journal, err := NewDispersalJournal(logger, t.TempDir(), time.Hour, fixedClock(now))
+	require.NoError(t, err)
+	require.Empty(t, journal.List())
+
+	second := testJournalEntry(1, "second", now)
+	first := testJournalEntry(2, "first", now.Add(-time.Minute))
+	require.NoError(t, journal.Put(second))
+	require.NoError(t, journal.Put(first))
+
+	require.Equal(t, []DispersalJournalEntry{first, second}, journal.List())
+}
+
+func TestDispersalJournalExpiry(t *testing.T) {
+	logger := common.TestLogger(t)
+	directory := t.TempDir()
+	now := time.Unix(1_700_000_000, 0).UTC()
+	clock := func() time.Time {
+		return now
+	}
+
+	journal, err := NewDispersalJournal(logger, directory, time.Hour, clock)
+	require.NoError(t, err)
+
+	first := testJournalEntry(1, "first", now)
+	second := testJournalEntry(2, "second", now.Add(30*time.Minute))
+	require.NoError(t, journal.Put(first))
+	require.NoError(t, journal.Put(second))
+
+	// entries that expire while the journal is open are dropped when they are looked up
+	now = now.Add(time.Hour + time.Minute)
+	_, ok := journal.Get(first.PayloadHash)
+	require.False(t, ok)
+	require.NoFileExists(t, journal.entryPath(first.BlobKey))
+	require.Equal(t, []DispersalJournalEntry{second}, journal.List())
+
+	now = now.Add(30 * time.Minute)
+	require.Empty(t, journal.List())
+	require.NoFileExists(t, journal.entryPath(second.BlobKey))
+}
+
+func TestDispersalJournalResumeAttempts(t *testing.T) {
+	logger := common.TestLogger(t)
+	directory := t.TempDir()
+	now := time.Unix(1_700_000_000, 0).UTC()
+
+	journal, err := NewDispersalJournal(logger, directory, time.Hour, fixedClock(now))
+	require.NoError(t, err)
+
+	entry := testJournalEntry(1, "payload", now)
+	require.NoError(t, journal.Put(entry))
+	require.NoError(t, journal.IncrementResumeAttempts(entry.BlobKey))
+	require.NoError(t, journal.IncrementResumeAttempts(entry.BlobKey))
+	// incrementing the attempts of a blob that isn't journaled is a no-op
+	require.NoError(t, journal.IncrementResumeAttempts(corev2.BlobKey{2}))
+
+	// the attempts survive reopening the journal
+	reopened, err := NewDispersalJournal(logger, directory, time.Hour, fixedClock(now))
+	require.NoError(t, err)
+	got, ok := reopened.Get(entry.PayloadHash)
+	require.True(t, ok)
+	require.Equal(t, uint32(2), got.ResumeAttempts)
+}
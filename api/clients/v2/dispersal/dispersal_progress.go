@@ -0,0 +1,55 @@
+package dispersal
+
+import (
+	"context"
+
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+)
+
+// DispersalProgress describes how far a call to [PayloadDisperser.SendPayload] has gotten.
+type DispersalProgress string
+
+const (
+	// The blob has been accepted by the disperser, and is waiting to be encoded.
+	DispersalProgressQueued DispersalProgress = "queued"
+	// The blob has been encoded, and the disperser is gathering signatures from validators.
+	DispersalProgressEncoded DispersalProgress = "encoded"
+	// Enough validators have signed the blob to meet the confirmation thresholds. What remains is building and
+	// verifying the cert.
+	DispersalProgressSigned DispersalProgress = "signed"
+)
+
+// DispersalProgressListener is called each time the status of a dispersal changes. It is called synchronously
+// from the dispersal goroutine, so it must not block.
+type DispersalProgressListener func(progress DispersalProgress)
+
+type dispersalProgressListenerKey struct{}
+
+// WithDispersalProgressListener returns a context that causes [PayloadDisperser.SendPayload] to report its
+// progress to the listener.
+//
+// A context is used instead of a SendPayload argument, so that callers that only see SendPayload through
+// several layers of wrappers (e.g. the proxy) can observe progress without every layer having to know about it.
+func WithDispersalProgressListener(ctx context.Context, listener DispersalProgressListener) context.Context {
+	return context.WithValue(ctx, dispersalProgressListenerKey{}, listener)
+}
+
+// reportDispersalProgress notifies the listener attached to ctx, if there is one.
+func reportDispersalProgress(ctx context.Context, progress DispersalProgress) {
+	listener, ok := ctx.Value(dispersalProgressListenerKey{}).(DispersalProgressListener)
+	if ok && listener != nil {
+		listener(progress)
+	}
+}
+
+// reportBlobStatusProgress reports the progress corresponding to a non-terminal blob status.
+func reportBlobStatusProgress(ctx context.Context, status dispgrpc.BlobStatus) {
+	switch status {
+	case dispgrpc.BlobStatus_QUEUED:
+		reportDispersalProgress(ctx, DispersalProgressQueued)
+	case dispgrpc.BlobStatus_ENCODED, dispgrpc.BlobStatus_GATHERING_SIGNATURES:
+		reportDispersalProgress(ctx, DispersalProgressEncoded)
+	default:
+		// terminal statuses are reported by the caller, once it knows whether the thresholds are met
+	}
+}
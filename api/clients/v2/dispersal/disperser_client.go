@@ -2,9 +2,11 @@ package dispersal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
@@ -20,10 +22,16 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding/v2/kzg/committer"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/docker/go-units"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const maxNumberOfConnections = 32
 
+// ErrBlobStatusSubscriptionUnsupported is returned by SubscribeBlobStatus when the disperser has previously answered
+// a SubscribeBlobStatus call with an UNIMPLEMENTED error. Callers should fall back to polling GetBlobStatus.
+var ErrBlobStatusSubscriptionUnsupported = errors.New("disperser does not support SubscribeBlobStatus")
+
 type DisperserClientConfig struct {
 	GrpcUri           string
 	UseSecureGrpcFlag bool
@@ -43,6 +51,9 @@ type DisperserClient struct {
 	clientPool *common.GRPCClientPool[disperser_rpc.DisperserClient]
 	committer  *committer.Committer
 	metrics    metrics.DispersalMetricer
+
+	// set once the disperser has answered a SubscribeBlobStatus call with an UNIMPLEMENTED error
+	blobStatusSubscriptionUnsupported atomic.Bool
 }
 
 // DisperserClient maintains a single underlying grpc connection to the disperser server,
@@ -257,6 +268,62 @@ func (c *DisperserClient) GetBlobStatus(
 	return reply, nil
 }
 
+// SubscribeBlobStatus opens a stream over which the disperser sends the status of the blob with the given blob key
+// every time it changes. The stream is closed by the disperser once a terminal status has been sent.
+//
+// Dispersers that don't support this method fail the first Recv call with an UNIMPLEMENTED error. This is remembered,
+// and later calls return ErrBlobStatusSubscriptionUnsupported without contacting the disperser.
+func (c *DisperserClient) SubscribeBlobStatus(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+) (disperser_rpc.Disperser_SubscribeBlobStatusClient, error) {
+	if c.blobStatusSubscriptionUnsupported.Load() {
+		return nil, ErrBlobStatusSubscriptionUnsupported
+	}
+
+	request := &disperser_rpc.BlobStatusRequest{
+		BlobKey: blobKey[:],
+	}
+
+	client, err := c.clientPool.GetClient()
+	if err != nil {
+		return nil, fmt.Errorf("get client: %w", err)
+	}
+
+	stream, err := client.SubscribeBlobStatus(ctx, request)
+	if err != nil {
+		c.checkBlobStatusSubscriptionSupport(err)
+		return nil, fmt.Errorf("error while calling SubscribeBlobStatus: %w", err)
+	}
+	return &blobStatusStream{Disperser_SubscribeBlobStatusClient: stream, client: c}, nil
+}
+
+// checkBlobStatusSubscriptionSupport records that the disperser doesn't support SubscribeBlobStatus if err is an
+// UNIMPLEMENTED error.
+func (c *DisperserClient) checkBlobStatusSubscriptionSupport(err error) {
+	if status.Code(err) == codes.Unimplemented {
+		c.logger.Info("Disperser does not support SubscribeBlobStatus, falling back to polling GetBlobStatus",
+			"disperserID", c.config.DisperserID)
+		c.blobStatusSubscriptionUnsupported.Store(true)
+	}
+}
+
+// blobStatusStream wraps a SubscribeBlobStatus stream, to detect dispersers that don't support the method.
+type blobStatusStream struct {
+	disperser_rpc.Disperser_SubscribeBlobStatusClient
+	client *DisperserClient
+}
+
+func (s *blobStatusStream) Recv() (*disperser_rpc.BlobStatusReply, error) {
+	reply, err := s.Disperser_SubscribeBlobStatusClient.Recv()
+	if err != nil {
+		s.client.checkBlobStatusSubscriptionSupport(err)
+		//nolint:wrapcheck
+		return nil, err
+	}
+	return reply, nil
+}
+
 // GetPaymentState returns the payment state of the disperser client
 func (c *DisperserClient) GetPaymentState(ctx context.Context) (*disperser_rpc.GetPaymentStateReply, error) {
 	accountID, err := c.signer.GetAccountID()
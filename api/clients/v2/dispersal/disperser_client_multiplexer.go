@@ -129,33 +129,60 @@ func (dcm *DisperserClientMultiplexer) GetDisperserClient(
 		return nil, fmt.Errorf("select disperser: %w", err)
 	}
 
-	dcm.cleanupOutdatedClient(selectedDisperserInfo.id, selectedDisperserInfo.grpcUri)
+	return dcm.getOrCreateClient(selectedDisperserInfo.id, selectedDisperserInfo.grpcUri)
+}
 
-	client, exists := dcm.clients[selectedDisperserInfo.id]
-	if !exists {
-		// create a new client for the selected disperser
-		clientConfig := &DisperserClientConfig{
-			GrpcUri:                  selectedDisperserInfo.grpcUri,
-			UseSecureGrpcFlag:        dcm.config.UseSecureGrpcFlag,
-			DisperserConnectionCount: dcm.config.DisperserConnectionCount,
-			DisperserID:              selectedDisperserInfo.id,
-			ChainID:                  dcm.config.ChainID,
-		}
+// Returns a client for the disperser with the given ID, regardless of its reputation and eligibility. This is used to
+// resume waiting for a blob that was dispersed to that disperser.
+func (dcm *DisperserClientMultiplexer) GetDisperserClientByID(
+	ctx context.Context,
+	disperserID uint32,
+) (*DisperserClient, error) {
+	dcm.lock.Lock()
+	defer dcm.lock.Unlock()
 
-		client, err = NewDisperserClient(
-			dcm.logger,
-			clientConfig,
-			dcm.signer,
-			dcm.committer,
-			dcm.dispersalMetrics,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("create disperser client for ID %d: %w", selectedDisperserInfo.id, err)
-		}
+	if dcm.closed {
+		return nil, fmt.Errorf("disperser client multiplexer is closed")
+	}
 
-		dcm.clients[selectedDisperserInfo.id] = client
+	grpcUri, err := dcm.disperserRegistry.GetDisperserGrpcUri(ctx, disperserID)
+	if err != nil {
+		return nil, fmt.Errorf("get URI for disperser ID %d: %w", disperserID, err)
+	}
+
+	return dcm.getOrCreateClient(disperserID, grpcUri)
+}
+
+// Returns the existing client for the given disperser, or creates a new one if there is none, or if the existing one
+// is outdated. The caller must hold the lock.
+func (dcm *DisperserClientMultiplexer) getOrCreateClient(disperserID uint32, grpcUri string) (*DisperserClient, error) {
+	dcm.cleanupOutdatedClient(disperserID, grpcUri)
+
+	client, exists := dcm.clients[disperserID]
+	if exists {
+		return client, nil
+	}
+
+	clientConfig := &DisperserClientConfig{
+		GrpcUri:                  grpcUri,
+		UseSecureGrpcFlag:        dcm.config.UseSecureGrpcFlag,
+		DisperserConnectionCount: dcm.config.DisperserConnectionCount,
+		DisperserID:              disperserID,
+		ChainID:                  dcm.config.ChainID,
+	}
+
+	client, err := NewDisperserClient(
+		dcm.logger,
+		clientConfig,
+		dcm.signer,
+		dcm.committer,
+		dcm.dispersalMetrics,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create disperser client for ID %d: %w", disperserID, err)
 	}
 
+	dcm.clients[disperserID] = client
 	return client, nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
@@ -32,6 +33,8 @@ type PayloadDisperser struct {
 	certVerifier               *verification.CertVerifier
 	stageTimer                 *common.StageTimer
 	clientLedger               *clientledger.ClientLedger
+	// if nil, then dispersals are not journaled
+	journal *DispersalJournal
 }
 
 // NewPayloadDisperser creates a PayloadDisperser from subcomponents that have already been constructed and initialized.
@@ -55,6 +58,18 @@ func NewPayloadDisperser(
 
 	stageTimer := common.NewStageTimer(registry, "PayloadDisperser", "SendPayload", false)
 
+	var journal *DispersalJournal
+	if payloadDisperserConfig.DispersalJournalPath != "" {
+		journal, err = NewDispersalJournal(
+			logger,
+			payloadDisperserConfig.DispersalJournalPath,
+			payloadDisperserConfig.DispersalJournalRetention,
+			time.Now)
+		if err != nil {
+			return nil, fmt.Errorf("open dispersal journal: %w", err)
+		}
+	}
+
 	return &PayloadDisperser{
 		logger:                     logger,
 		config:                     payloadDisperserConfig,
@@ -64,6 +79,7 @@ func NewPayloadDisperser(
 		certVerifier:               certVerifier,
 		stageTimer:                 stageTimer,
 		clientLedger:               clientLedger,
+		journal:                    journal,
 	}, nil
 }
 
@@ -71,10 +87,19 @@ func NewPayloadDisperser(
 //
 //  1. Encode payload into a blob
 //  2. Disperse the blob
-//  3. Poll the disperser with GetBlobStatus until a terminal status is reached, or until the polling timeout is reached
+//  3. Wait for the blob to be signed, by subscribing to its status with SubscribeBlobStatus, or by polling the
+//     disperser with GetBlobStatus if the disperser doesn't support subscriptions. Waiting stops once a terminal
+//     status is reached, or when the timeout is reached
 //  4. Construct an EigenDACert if dispersal is successful
 //  5. Verify the constructed cert via an eth_call to the EigenDACertVerifier contract
 //  6. Return the valid cert
+//
+// If a DispersalJournalPath is configured, the blob is journaled once it has been accepted by the disperser, until a
+// cert is built for it or it fails. If the same payload is sent again while its blob is journaled, e.g. after a
+// restart or a timeout, the payload is not dispersed (nor paid for) again: steps 1 and 2 are skipped, and the cert of
+// the journaled blob is returned instead. If building the cert of the journaled blob has already been resumed
+// DispersalJournalMaxResumeAttempts times, the journaled blob is abandoned, and the payload is dispersed again. See
+// also ResumeJournaledDispersals.
 func (pd *PayloadDisperser) SendPayload(
 	ctx context.Context,
 	// payload is the raw data to be stored on eigenDA
@@ -83,11 +108,26 @@ func (pd *PayloadDisperser) SendPayload(
 
 	probe := pd.stageTimer.NewSequence()
 	defer probe.End()
+
+	payloadHash := PayloadHash(payload)
+	if pd.journal != nil {
+		if entry, ok := pd.journal.Get(payloadHash); ok {
+			cert, err := pd.resumeDispersal(ctx, entry, probe)
+			if !errors.Is(err, errResumeAttemptsExhausted) {
+				return cert, err
+			}
+		}
+	}
+
 	probe.SetStage("convert_to_blob")
 
 	// convert the payload into an EigenDA blob by interpreting the payload in polynomial form,
 	// which means the encoded payload will need to be IFFT'd since EigenDA blobs are in coefficient form.
-	blob, err := payload.ToBlob(pd.config.PayloadPolynomialForm)
+	encodedPayload, err := payload.ToEncodedPayloadWithVersion(pd.config.PayloadEncodingVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	blob, err := encodedPayload.ToBlob(pd.config.PayloadPolynomialForm)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert payload to blob: %w", err)
 	}
@@ -161,7 +201,17 @@ func (pd *PayloadDisperser) SendPayload(
 		return nil, fmt.Errorf("verify received blob key: %w", err)
 	}
 
+	pd.journalDispersal(DispersalJournalEntry{
+		BlobKey:         blobKey,
+		PayloadHash:     payloadHash,
+		DisperserID:     disperserID,
+		PaymentMetadata: *paymentMetadata,
+		BlobStatus:      reply.GetResult(),
+		CreatedAt:       time.Now(),
+	})
+
 	cert, err := pd.buildEigenDACert(ctx, disperserClient, reply.GetResult(), blobKey, probe)
+	pd.finishJournalEntry(payloadHash, blobKey, err)
 	if err != nil {
 		return cert, err
 	}
@@ -170,6 +220,140 @@ func (pd *PayloadDisperser) SendPayload(
 	return cert, nil
 }
 
+// ResumedDispersal is the outcome of resuming a journaled dispersal with ResumeJournaledDispersals.
+type ResumedDispersal struct {
+	// The journal entry of the dispersal.
+	Entry DispersalJournalEntry
+	// The cert of the journaled blob. It is nil if Err is not nil.
+	Cert coretypes.EigenDACert
+	// The error that prevented building the cert, if any.
+	Err error
+}
+
+// PendingDispersals returns the dispersals that are journaled, oldest first. These are the blobs that have been
+// accepted by a disperser, but for which no cert has been built yet. It returns nil if no DispersalJournalPath is
+// configured.
+func (pd *PayloadDisperser) PendingDispersals() []DispersalJournalEntry {
+	if pd.journal == nil {
+		return nil
+	}
+	return pd.journal.List()
+}
+
+// ResumeJournaledDispersals builds the certs of all the journaled dispersals, e.g. after a restart, without needing
+// their payloads. The dispersals are resumed concurrently, and the outcomes are returned in the order of
+// PendingDispersals.
+//
+// Like with SendPayload, the entry of a dispersal is removed from the journal once its cert is built, or once its
+// blob can't be certified anymore. The returned certs are not kept anywhere else: sending the payload of a resumed
+// dispersal again disperses it again. Callers that resend their payloads, such as clients retrying failed requests,
+// can instead rely on SendPayload returning the cert of the journaled blob.
+func (pd *PayloadDisperser) ResumeJournaledDispersals(ctx context.Context) []ResumedDispersal {
+	entries := pd.PendingDispersals()
+	resumed := make([]ResumedDispersal, len(entries))
+
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			probe := pd.stageTimer.NewSequence()
+			defer probe.End()
+
+			cert, err := pd.resumeDispersal(ctx, entry, probe)
+			resumed[i] = ResumedDispersal{Entry: entry, Cert: cert, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	return resumed
+}
+
+// errResumeAttemptsExhausted is returned when a journaled dispersal has been resumed too many times.
+var errResumeAttemptsExhausted = errors.New("journaled dispersal has exhausted its resume attempts")
+
+// resumeDispersal builds the cert of a blob that was journaled by an earlier call to SendPayload, instead of
+// dispersing its payload again. If the dispersal has already been resumed DispersalJournalMaxResumeAttempts times, it
+// is removed from the journal, and errResumeAttemptsExhausted is returned.
+func (pd *PayloadDisperser) resumeDispersal(
+	ctx context.Context,
+	entry DispersalJournalEntry,
+	probe *common.SequenceProbe,
+) (coretypes.EigenDACert, error) {
+	if entry.ResumeAttempts >= pd.config.DispersalJournalMaxResumeAttempts {
+		pd.logger.Warn("Abandoning journaled dispersal",
+			"blobKey", entry.BlobKey.Hex(),
+			"status", entry.BlobStatus.String(),
+			"resumeAttempts", entry.ResumeAttempts)
+		err := pd.journal.Remove(entry.PayloadHash, entry.BlobKey)
+		if err != nil {
+			pd.logger.Error("failed to remove dispersal from journal", "blobKey", entry.BlobKey.Hex(), "err", err)
+		}
+		return nil, fmt.Errorf("blob %s: %w", entry.BlobKey.Hex(), errResumeAttemptsExhausted)
+	}
+
+	pd.logger.Info("Resuming journaled dispersal",
+		"blobKey", entry.BlobKey.Hex(),
+		"disperserID", entry.DisperserID,
+		"status", entry.BlobStatus.String(),
+		"createdAt", entry.CreatedAt,
+		"resumeAttempts", entry.ResumeAttempts)
+
+	// the attempt is recorded up front, so that attempts interrupted by a restart count as well
+	err := pd.journal.IncrementResumeAttempts(entry.BlobKey)
+	if err != nil {
+		pd.logger.Error("failed to journal resume attempt", "blobKey", entry.BlobKey.Hex(), "err", err)
+	}
+
+	probe.SetStage("get disperser client")
+	disperserClient, err := pd.disperserClientMultiplexer.GetDisperserClientByID(ctx, entry.DisperserID)
+	if err != nil {
+		return nil, fmt.Errorf("get disperser client for journaled blob %s: %w", entry.BlobKey.Hex(), err)
+	}
+
+	cert, err := pd.buildEigenDACert(ctx, disperserClient, entry.BlobStatus, entry.BlobKey, probe)
+	pd.finishJournalEntry(entry.PayloadHash, entry.BlobKey, err)
+	return cert, err
+}
+
+// journalDispersal journals a blob that has been accepted by the disperser. The blob has already been paid for, so
+// failing to journal it doesn't fail the dispersal.
+func (pd *PayloadDisperser) journalDispersal(entry DispersalJournalEntry) {
+	if pd.journal == nil {
+		return
+	}
+	err := pd.journal.Put(entry)
+	if err != nil {
+		pd.logger.Error("failed to journal dispersal", "blobKey", entry.BlobKey.Hex(), "err", err)
+	}
+}
+
+// finishJournalEntry removes the journal entry of a blob once building its cert is over, i.e. when the cert has been
+// built, or when the blob can't be certified anymore. The entry is kept if the blob may still be certified, e.g. after
+// a timeout while the blob was gathering signatures, so that sending the payload again resumes the dispersal.
+func (pd *PayloadDisperser) finishJournalEntry(payloadHash [32]byte, blobKey corev2.BlobKey, buildErr error) {
+	if pd.journal == nil {
+		return
+	}
+
+	if buildErr != nil {
+		var failoverErr *api.ErrorFailover
+		entry, ok := pd.journal.Get(payloadHash)
+		if ok && entry.BlobKey == blobKey &&
+			(!isTerminalBlobStatus(entry.BlobStatus) || !errors.As(buildErr, &failoverErr)) {
+			pd.logger.Info("Keeping dispersal in journal",
+				"blobKey", blobKey.Hex(), "status", entry.BlobStatus.String(), "err", buildErr)
+			return
+		}
+	}
+
+	err := pd.journal.Remove(payloadHash, blobKey)
+	if err != nil {
+		pd.logger.Error("failed to remove dispersal from journal", "blobKey", blobKey.Hex(), "err", err)
+	}
+}
+
 // Waits for a blob to be signed, and builds the EigenDA cert with the operator signatures
 //
 // If the blob does not become fully signed before the BlobCompleteTimeout timeout elapses, returns an error
@@ -182,17 +366,20 @@ func (pd *PayloadDisperser) buildEigenDACert(
 ) (coretypes.EigenDACert, error) {
 
 	probe.SetStage("QUEUED")
+	reportBlobStatusProgress(ctx, initialBlobStatus)
 
-	// poll the disperser for the status of the blob until it's received adequate signatures in regards to
+	// wait for the status of the blob until it's received adequate signatures in regards to
 	// confirmation thresholds, a terminal error, or a timeout
 	timeoutCtx, cancel := context.WithTimeout(ctx, pd.config.BlobCompleteTimeout)
 	defer cancel()
-	blobStatusReply, err := pd.pollBlobStatusUntilSigned(timeoutCtx, disperserClient, blobKey, initialBlobStatus, probe)
+	blobStatusReply, err := pd.waitForBlobStatusUntilSigned(
+		timeoutCtx, disperserClient, blobKey, initialBlobStatus, probe)
 	if err != nil {
-		return nil, fmt.Errorf("poll blob status until signed: %w", err)
+		return nil, fmt.Errorf("wait for blob status until signed: %w", err)
 	}
 
 	pd.logSigningPercentages(blobKey, blobStatusReply)
+	reportDispersalProgress(ctx, DispersalProgressSigned)
 
 	probe.SetStage("wait_for_block_number")
 	// TODO: given the repeated context timeout declaration in this method we should consider creating some
@@ -290,12 +477,18 @@ func (pd *PayloadDisperser) Close() error {
 	return nil
 }
 
-// pollBlobStatusUntilSigned polls the disperser for the status of a blob that has been dispersed
+// errBlobStatusSubscriptionFailed is returned by subscribeBlobStatusUntilSigned when the status of the blob can't be
+// followed over a SubscribeBlobStatus stream, and polling should be used instead.
+var errBlobStatusSubscriptionFailed = errors.New("blob status subscription failed")
+
+// waitForBlobStatusUntilSigned waits for a blob that has been dispersed to be signed. If the disperser supports it,
+// the status of the blob is streamed with SubscribeBlobStatus. Otherwise, or if the stream fails, the disperser is
+// polled with GetBlobStatus.
 //
 // This method will only return a non-nil BlobStatusReply if all quorums meet the required confirmation threshold prior
 // to timeout. In all other cases, this method will return a nil BlobStatusReply, along with an error describing the
 // failure.
-func (pd *PayloadDisperser) pollBlobStatusUntilSigned(
+func (pd *PayloadDisperser) waitForBlobStatusUntilSigned(
 	ctx context.Context,
 	disperserClient *DisperserClient,
 	blobKey corev2.BlobKey,
@@ -305,18 +498,73 @@ func (pd *PayloadDisperser) pollBlobStatusUntilSigned(
 
 	previousStatus := initialStatus
 
+	blobStatusReply, err := pd.subscribeBlobStatusUntilSigned(ctx, disperserClient, blobKey, &previousStatus, probe)
+	if !errors.Is(err, errBlobStatusSubscriptionFailed) {
+		return blobStatusReply, err
+	}
+	pd.logger.Debug("Polling blob status", "blobKey", blobKey.Hex(), "reason", err)
+
+	return pd.pollBlobStatusUntilSigned(ctx, disperserClient, blobKey, &previousStatus, probe)
+}
+
+// subscribeBlobStatusUntilSigned follows the status of a blob that has been dispersed over a SubscribeBlobStatus
+// stream, until the blob is signed, a terminal error occurs, or the context times out.
+//
+// If the stream can't be opened, or ends before the blob is signed, an error wrapping errBlobStatusSubscriptionFailed
+// is returned.
+func (pd *PayloadDisperser) subscribeBlobStatusUntilSigned(
+	ctx context.Context,
+	disperserClient *DisperserClient,
+	blobKey corev2.BlobKey,
+	previousStatus *dispgrpc.BlobStatus,
+	probe *common.SequenceProbe,
+) (*dispgrpc.BlobStatusReply, error) {
+
+	// closes the stream once the blob is signed, which may be before the disperser sends a terminal status
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := disperserClient.SubscribeBlobStatus(streamCtx, blobKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errBlobStatusSubscriptionFailed, err)
+	}
+
+	for {
+		blobStatusReply, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, blobStatusTimeoutError(ctx, *previousStatus)
+			}
+			return nil, fmt.Errorf("%w: receive blob status: %w", errBlobStatusSubscriptionFailed, err)
+		}
+
+		signed, err := pd.handleBlobStatusReply(ctx, blobKey, blobStatusReply, previousStatus, probe)
+		if err != nil {
+			return nil, err
+		}
+		if signed {
+			return blobStatusReply, nil
+		}
+	}
+}
+
+// pollBlobStatusUntilSigned polls the disperser for the status of a blob that has been dispersed, until the blob is
+// signed, a terminal error occurs, or the context times out.
+func (pd *PayloadDisperser) pollBlobStatusUntilSigned(
+	ctx context.Context,
+	disperserClient *DisperserClient,
+	blobKey corev2.BlobKey,
+	previousStatus *dispgrpc.BlobStatus,
+	probe *common.SequenceProbe,
+) (*dispgrpc.BlobStatusReply, error) {
+
 	ticker := time.NewTicker(pd.config.BlobStatusPollInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			// Failover to another DA layer because EigenDA is not completing its signing duty in time.
-			return nil, api.NewErrorFailover(fmt.Errorf(
-				"timed out waiting for %v blob status, final status was %v: %w",
-				dispgrpc.BlobStatus_COMPLETE.String(),
-				previousStatus.String(),
-				ctx.Err()))
+			return nil, blobStatusTimeoutError(ctx, *previousStatus)
 		case <-ticker.C:
 			// This call to the disperser doesn't have a dedicated timeout configured.
 			// If this call fails to return in a timely fashion, the timeout configured for the poll loop will trigger
@@ -327,65 +575,113 @@ func (pd *PayloadDisperser) pollBlobStatusUntilSigned(
 				continue
 			}
 
-			newStatus := blobStatusReply.GetStatus()
-			if newStatus != previousStatus {
-				pd.logger.Debug(
-					"Blob status changed",
-					"blob key", blobKey.Hex(),
-					"previous status", previousStatus.String(),
-					"new status", newStatus.String())
-				previousStatus = newStatus
+			signed, err := pd.handleBlobStatusReply(ctx, blobKey, blobStatusReply, previousStatus, probe)
+			if err != nil {
+				return nil, err
+			}
+			if signed {
+				return blobStatusReply, nil
 			}
+		}
+	}
+}
 
-			switch newStatus {
-			case dispgrpc.BlobStatus_COMPLETE:
-				err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
-				if err != nil {
-					// TODO(samlaf): checkThresholds should return more fine-grained errors
-					// For now, we only failover if thresholds were unmet, not anything else.
-					// The risk of failing over for everything is that eth-rpc calls could fail
-					// for networking reasons, which we don't want to failover to eth for!
-					var thresholdNotMetErr *thresholdNotMetError
-					if errors.As(err, &thresholdNotMetErr) {
-						return nil, api.NewErrorFailover(fmt.Errorf("check thresholds: %w", err))
-					}
-					return nil, fmt.Errorf("check thresholds: %w", err)
-				}
+// handleBlobStatusReply processes a status update for a blob that has been dispersed, and updates previousStatus.
+//
+// Returns true if the blob has received adequate signatures in regards to confirmation thresholds. Returns an error
+// if the dispersal has failed, in which case waiting for the blob status should stop.
+func (pd *PayloadDisperser) handleBlobStatusReply(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	blobStatusReply *dispgrpc.BlobStatusReply,
+	previousStatus *dispgrpc.BlobStatus,
+	probe *common.SequenceProbe,
+) (bool, error) {
+
+	newStatus := blobStatusReply.GetStatus()
+	if newStatus != *previousStatus {
+		pd.logger.Debug(
+			"Blob status changed",
+			"blob key", blobKey.Hex(),
+			"previous status", previousStatus.String(),
+			"new status", newStatus.String())
+		*previousStatus = newStatus
+		reportBlobStatusProgress(ctx, newStatus)
+		if pd.journal != nil {
+			err := pd.journal.UpdateStatus(blobKey, newStatus)
+			if err != nil {
+				pd.logger.Warn("failed to journal blob status", "blobKey", blobKey.Hex(), "err", err)
+			}
+		}
+	}
 
-				return blobStatusReply, nil
-			case dispgrpc.BlobStatus_QUEUED, dispgrpc.BlobStatus_ENCODED:
-				// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
-				probe.SetStage(newStatus.String())
-				continue
-			case dispgrpc.BlobStatus_GATHERING_SIGNATURES:
-				// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
-				probe.SetStage(newStatus.String())
-
-				err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
-				if err == nil {
-					// If there's no error, then all thresholds are met, so we can stop polling
-					return blobStatusReply, nil
-				}
-
-				var thresholdNotMetErr *thresholdNotMetError
-				if !errors.As(err, &thresholdNotMetErr) {
-					// an error occurred which was unrelated to an unmet threshold: something went wrong while checking!
-					pd.logger.Warnf("error checking thresholds: %v", err)
-				}
-
-				// thresholds weren't met yet. that's ok, since signature gathering is still in progress
-				continue
-			default:
-				// Failover to another DA layer because something is wrong with EigenDA.
-				return nil, api.NewErrorFailover(
-					fmt.Errorf("terminal dispersal failure for blobKey %v. blob status: %v",
-						blobKey.Hex(),
-						newStatus.String()))
+	switch newStatus {
+	case dispgrpc.BlobStatus_COMPLETE:
+		err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
+		if err != nil {
+			// TODO(samlaf): checkThresholds should return more fine-grained errors
+			// For now, we only failover if thresholds were unmet, not anything else.
+			// The risk of failing over for everything is that eth-rpc calls could fail
+			// for networking reasons, which we don't want to failover to eth for!
+			var thresholdNotMetErr *thresholdNotMetError
+			if errors.As(err, &thresholdNotMetErr) {
+				return false, api.NewErrorFailover(fmt.Errorf("check thresholds: %w", err))
 			}
+			return false, fmt.Errorf("check thresholds: %w", err)
+		}
+
+		return true, nil
+	case dispgrpc.BlobStatus_QUEUED, dispgrpc.BlobStatus_ENCODED:
+		// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
+		probe.SetStage(newStatus.String())
+		return false, nil
+	case dispgrpc.BlobStatus_GATHERING_SIGNATURES:
+		// Report all non-terminal statuses to the probe. Repeat reports are no-ops.
+		probe.SetStage(newStatus.String())
+
+		err := checkThresholds(ctx, pd.certVerifier, blobStatusReply, blobKey.Hex())
+		if err == nil {
+			// If there's no error, then all thresholds are met, so we can stop waiting
+			return true, nil
+		}
+
+		var thresholdNotMetErr *thresholdNotMetError
+		if !errors.As(err, &thresholdNotMetErr) {
+			// an error occurred which was unrelated to an unmet threshold: something went wrong while checking!
+			pd.logger.Warnf("error checking thresholds: %v", err)
 		}
+
+		// thresholds weren't met yet. that's ok, since signature gathering is still in progress
+		return false, nil
+	default:
+		// Failover to another DA layer because something is wrong with EigenDA.
+		return false, api.NewErrorFailover(
+			fmt.Errorf("terminal dispersal failure for blobKey %v. blob status: %v",
+				blobKey.Hex(),
+				newStatus.String()))
 	}
 }
 
+// isTerminalBlobStatus returns true if the status of a blob will not be updated anymore.
+func isTerminalBlobStatus(blobStatus dispgrpc.BlobStatus) bool {
+	switch blobStatus {
+	case dispgrpc.BlobStatus_QUEUED, dispgrpc.BlobStatus_ENCODED, dispgrpc.BlobStatus_GATHERING_SIGNATURES:
+		return false
+	default:
+		return true
+	}
+}
+
+// blobStatusTimeoutError is returned when a blob isn't signed before the context is done.
+func blobStatusTimeoutError(ctx context.Context, finalStatus dispgrpc.BlobStatus) error {
+	// Failover to another DA layer because EigenDA is not completing its signing duty in time.
+	return api.NewErrorFailover(fmt.Errorf(
+		"timed out waiting for %v blob status, final status was %v: %w",
+		dispgrpc.BlobStatus_COMPLETE.String(),
+		finalStatus.String(),
+		ctx.Err()))
+}
+
 // verifyReceivedBlobKey computes the BlobKey from the BlobHeader which was sent to the disperser, and compares it with
 // the BlobKey which was returned by the disperser in the DisperseBlobReply
 //
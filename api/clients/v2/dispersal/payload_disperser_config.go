@@ -1,8 +1,11 @@
 package dispersal
 
 import (
+	"fmt"
+	"slices"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 )
 
@@ -15,16 +18,28 @@ type PayloadDisperserConfig struct {
 	// blob
 	DisperseBlobTimeout time.Duration
 
-	// BlobCompleteTimeout is the duration after which the PayloadDisperser will time out, while polling
-	// the disperser for blob status, waiting for BlobStatus_COMPLETE
+	// BlobCompleteTimeout is the duration after which the PayloadDisperser will time out, while waiting for the
+	// blob to be signed
 	BlobCompleteTimeout time.Duration
 
 	// BlobStatusPollInterval is the tick rate for the PayloadDisperser to use, while polling the disperser with
-	// GetBlobStatus.
+	// GetBlobStatus. Polling is only used if the disperser doesn't support SubscribeBlobStatus.
 	BlobStatusPollInterval time.Duration
 
 	// The timeout duration for contract calls
 	ContractCallTimeout time.Duration
+
+	// DispersalJournalPath is the directory of the DispersalJournal. If empty, dispersals aren't journaled, and a
+	// process that stops while waiting for a blob to be signed has to disperse (and pay for) the payload again.
+	DispersalJournalPath string
+
+	// DispersalJournalRetention is how long a journaled dispersal can be resumed. Older entries are dropped from the
+	// journal.
+	DispersalJournalRetention time.Duration
+
+	// DispersalJournalMaxResumeAttempts is how many times building the cert of a journaled dispersal is resumed. Once
+	// exhausted, the dispersal is dropped from the journal, and sending its payload again disperses it again.
+	DispersalJournalMaxResumeAttempts uint32
 }
 
 // getDefaultPayloadDisperserConfig creates a PayloadDisperserConfig with default values
@@ -35,6 +50,9 @@ func getDefaultPayloadDisperserConfig() *PayloadDisperserConfig {
 		BlobCompleteTimeout:    2 * time.Minute,
 		BlobStatusPollInterval: 1 * time.Second,
 		ContractCallTimeout:    5 * time.Second,
+		// a blob that isn't signed within an hour won't be signed anymore
+		DispersalJournalRetention:         time.Hour,
+		DispersalJournalMaxResumeAttempts: 3,
 	}
 }
 
@@ -59,5 +77,17 @@ func (dc *PayloadDisperserConfig) checkAndSetDefaults() error {
 		dc.ContractCallTimeout = defaultConfig.ContractCallTimeout
 	}
 
+	if dc.DispersalJournalRetention == 0 {
+		dc.DispersalJournalRetention = defaultConfig.DispersalJournalRetention
+	}
+
+	if dc.DispersalJournalMaxResumeAttempts == 0 {
+		dc.DispersalJournalMaxResumeAttempts = defaultConfig.DispersalJournalMaxResumeAttempts
+	}
+
+	if !slices.Contains(codecs.SupportedPayloadEncodingVersions, dc.PayloadEncodingVersion) {
+		return fmt.Errorf("unsupported payload encoding version: %d", dc.PayloadEncodingVersion)
+	}
+
 	return nil
 }
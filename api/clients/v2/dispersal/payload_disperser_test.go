@@ -1,15 +1,26 @@
 package dispersal
 
 import (
+	"context"
+	"errors"
 	"math/big"
+	"net"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/Layr-Labs/eigenda/api"
+	clients "github.com/Layr-Labs/eigenda/api/clients/v2"
 	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/docker/go-units"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 func TestVerifyReceivedBlobKey(t *testing.T) {
@@ -51,3 +62,234 @@ func TestVerifyReceivedBlobKey(t *testing.T) {
 	_, err = verifyReceivedBlobKey(blobHeader, &reply)
 	require.Error(t, err, "Any modification to the header should cause verification to fail")
 }
+
+// fakeDisperserServer streams statuses to SubscribeBlobStatus calls and answers GetBlobStatus calls with
+// polledStatus. If streamedStatuses is nil, SubscribeBlobStatus is left unimplemented.
+type fakeDisperserServer struct {
+	dispgrpc.UnimplementedDisperserServer
+	streamedStatuses []dispgrpc.BlobStatus
+	polledStatus     dispgrpc.BlobStatus
+	subscribeCalls   atomic.Int32
+	getStatusCalls   atomic.Int32
+}
+
+func (s *fakeDisperserServer) SubscribeBlobStatus(
+	req *dispgrpc.BlobStatusRequest,
+	stream dispgrpc.Disperser_SubscribeBlobStatusServer,
+) error {
+	s.subscribeCalls.Add(1)
+	if s.streamedStatuses == nil {
+		return s.UnimplementedDisperserServer.SubscribeBlobStatus(req, stream)
+	}
+	for _, blobStatus := range s.streamedStatuses {
+		err := stream.Send(&dispgrpc.BlobStatusReply{Status: blobStatus})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeDisperserServer) GetBlobStatus(
+	context.Context, *dispgrpc.BlobStatusRequest,
+) (*dispgrpc.BlobStatusReply, error) {
+	s.getStatusCalls.Add(1)
+	return &dispgrpc.BlobStatusReply{Status: s.polledStatus}, nil
+}
+
+func TestWaitForBlobStatusUntilSigned(t *testing.T) {
+	logger := test.GetLogger()
+
+	newDisperser := func(t *testing.T, server *fakeDisperserServer) (*PayloadDisperser, *DisperserClient) {
+		listener, err := net.Listen("tcp", "localhost:0")
+		require.NoError(t, err)
+		grpcServer := grpc.NewServer()
+		dispgrpc.RegisterDisperserServer(grpcServer, server)
+		go func() {
+			_ = grpcServer.Serve(listener)
+		}()
+		t.Cleanup(grpcServer.Stop)
+
+		clientPool, err := common.NewGRPCClientPool(logger, dispgrpc.NewDisperserClient, 1,
+			listener.Addr().String(), clients.GetGrpcDialOptions(false, 4*units.MiB)...)
+		require.NoError(t, err)
+		disperserClient := &DisperserClient{
+			logger:     logger,
+			config:     &DisperserClientConfig{GrpcUri: listener.Addr().String()},
+			clientPool: clientPool,
+		}
+		t.Cleanup(func() {
+			_ = disperserClient.Close()
+		})
+
+		payloadDisperser := &PayloadDisperser{
+			logger: logger,
+			config: PayloadDisperserConfig{BlobStatusPollInterval: 10 * time.Millisecond},
+		}
+		return payloadDisperser, disperserClient
+	}
+
+	wait := func(
+		t *testing.T, payloadDisperser *PayloadDisperser, disperserClient *DisperserClient,
+	) ([]DispersalProgress, error) {
+		var progress []DispersalProgress
+		ctx := WithDispersalProgressListener(t.Context(), func(p DispersalProgress) {
+			progress = append(progress, p)
+		})
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		_, err := payloadDisperser.waitForBlobStatusUntilSigned(
+			ctx, disperserClient, corev2.BlobKey{1}, dispgrpc.BlobStatus_QUEUED, nil)
+		return progress, err
+	}
+
+	t.Run("streamed statuses", func(t *testing.T) {
+		server := &fakeDisperserServer{
+			streamedStatuses: []dispgrpc.BlobStatus{
+				dispgrpc.BlobStatus_QUEUED, dispgrpc.BlobStatus_ENCODED, dispgrpc.BlobStatus_FAILED},
+		}
+		payloadDisperser, disperserClient := newDisperser(t, server)
+
+		progress, err := wait(t, payloadDisperser, disperserClient)
+		require.ErrorContains(t, err, dispgrpc.BlobStatus_FAILED.String())
+		var failoverErr *api.ErrorFailover
+		require.ErrorAs(t, err, &failoverErr)
+		require.Equal(t, []DispersalProgress{DispersalProgressEncoded}, progress)
+		require.Zero(t, server.getStatusCalls.Load(), "the disperser should not be polled")
+	})
+
+	t.Run("falls back to polling when subscriptions are unsupported", func(t *testing.T) {
+		server := &fakeDisperserServer{polledStatus: dispgrpc.BlobStatus_FAILED}
+		payloadDisperser, disperserClient := newDisperser(t, server)
+
+		_, err := wait(t, payloadDisperser, disperserClient)
+		require.ErrorContains(t, err, dispgrpc.BlobStatus_FAILED.String())
+		require.Equal(t, int32(1), server.subscribeCalls.Load())
+		require.Equal(t, int32(1), server.getStatusCalls.Load())
+
+		// the missing support is remembered, such that the disperser isn't asked to subscribe again
+		_, err = wait(t, payloadDisperser, disperserClient)
+		require.ErrorContains(t, err, dispgrpc.BlobStatus_FAILED.String())
+		require.Equal(t, int32(1), server.subscribeCalls.Load())
+		require.Equal(t, int32(2), server.getStatusCalls.Load())
+	})
+
+	t.Run("falls back to polling when the stream ends early", func(t *testing.T) {
+		server := &fakeDisperserServer{
+			streamedStatuses: []dispgrpc.BlobStatus{dispgrpc.BlobStatus_ENCODED},
+			polledStatus:     dispgrpc.BlobStatus_FAILED,
+		}
+		payloadDisperser, disperserClient := newDisperser(t, server)
+
+		progress, err := wait(t, payloadDisperser, disperserClient)
+		require.ErrorContains(t, err, dispgrpc.BlobStatus_FAILED.String())
+		require.Equal(t, []DispersalProgress{DispersalProgressEncoded}, progress)
+		require.Equal(t, int32(1), server.getStatusCalls.Load())
+		require.False(t, disperserClient.blobStatusSubscriptionUnsupported.Load())
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		server := &fakeDisperserServer{polledStatus: dispgrpc.BlobStatus_ENCODED}
+		payloadDisperser, disperserClient := newDisperser(t, server)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+		defer cancel()
+		_, err := payloadDisperser.waitForBlobStatusUntilSigned(
+			ctx, disperserClient, corev2.BlobKey{1}, dispgrpc.BlobStatus_QUEUED, nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "final status was "+dispgrpc.BlobStatus_ENCODED.String())
+	})
+}
+
+func TestFinishJournalEntry(t *testing.T) {
+	logger := common.TestLogger(t)
+	now := time.Now()
+
+	journal, err := NewDispersalJournal(logger, t.TempDir(), time.Hour, fixedClock(now))
+	require.NoError(t, err)
+	payloadDisperser := &PayloadDisperser{logger: logger, journal: journal}
+
+	timedOutCtx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	tests := []struct {
+		name     string
+		status   dispgrpc.BlobStatus
+		buildErr error
+		kept     bool
+	}{
+		{"cert built", dispgrpc.BlobStatus_COMPLETE, nil, false},
+		{"timed out gathering signatures", dispgrpc.BlobStatus_GATHERING_SIGNATURES,
+			blobStatusTimeoutError(timedOutCtx, dispgrpc.BlobStatus_GATHERING_SIGNATURES), true},
+		{"blob failed", dispgrpc.BlobStatus_FAILED, api.NewErrorFailover(errors.New("failed")), false},
+		{"eth rpc error after signing", dispgrpc.BlobStatus_COMPLETE, errors.New("get certificate version"), true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := testJournalEntry(byte(i+1), tt.name, now)
+			entry.BlobStatus = tt.status
+			require.NoError(t, journal.Put(entry))
+
+			payloadDisperser.finishJournalEntry(entry.PayloadHash, entry.BlobKey, tt.buildErr)
+
+			_, ok := journal.Get(entry.PayloadHash)
+			require.Equal(t, tt.kept, ok)
+		})
+	}
+}
+
+func TestResumeJournaledDispersals(t *testing.T) {
+	logger := common.TestLogger(t)
+	now := time.Now()
+
+	// without a journal, there is nothing to resume
+	payloadDisperser := &PayloadDisperser{logger: logger}
+	require.Nil(t, payloadDisperser.PendingDispersals())
+	require.Empty(t, payloadDisperser.ResumeJournaledDispersals(t.Context()))
+
+	journal, err := NewDispersalJournal(logger, t.TempDir(), time.Hour, fixedClock(now))
+	require.NoError(t, err)
+	payloadDisperser = &PayloadDisperser{
+		logger:  logger,
+		config:  PayloadDisperserConfig{DispersalJournalMaxResumeAttempts: 2},
+		journal: journal,
+		// the disperser of the journaled blobs can't be reached
+		disperserClientMultiplexer: &DisperserClientMultiplexer{closed: true},
+	}
+
+	entries := []DispersalJournalEntry{
+		testJournalEntry(1, "first", now.Add(-time.Minute)),
+		testJournalEntry(2, "second", now),
+	}
+	for _, entry := range entries {
+		require.NoError(t, journal.Put(entry))
+	}
+	require.Equal(t, entries, payloadDisperser.PendingDispersals())
+
+	for attempt := uint32(1); attempt <= 2; attempt++ {
+		resumed := payloadDisperser.ResumeJournaledDispersals(t.Context())
+		require.Len(t, resumed, len(entries))
+		for i, dispersal := range resumed {
+			require.Equal(t, entries[i], dispersal.Entry)
+			require.Nil(t, dispersal.Cert)
+			require.ErrorContains(t, dispersal.Err, "get disperser client for journaled blob")
+		}
+
+		// the blobs may still be certified, so they stay journaled
+		pending := payloadDisperser.PendingDispersals()
+		require.Len(t, pending, len(entries))
+		for i := range entries {
+			entries[i].ResumeAttempts = attempt
+			require.Equal(t, entries[i], pending[i])
+		}
+	}
+
+	// once the resume attempts are exhausted, the blobs are dropped from the journal
+	resumed := payloadDisperser.ResumeJournaledDispersals(t.Context())
+	require.Len(t, resumed, len(entries))
+	for _, dispersal := range resumed {
+		require.ErrorIs(t, dispersal.Err, errResumeAttemptsExhausted)
+	}
+	require.Empty(t, payloadDisperser.PendingDispersals())
+}
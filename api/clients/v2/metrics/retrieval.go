@@ -9,14 +9,36 @@ const (
 	retrievalSubsystem = "retrieval"
 )
 
+// Retrieval sources, i.e. the values of the source label of the payloads_retrieved metric.
+const (
+	RetrievalSourceRelay     = "relay"
+	RetrievalSourceValidator = "validator"
+	// RetrievalSourceNone is recorded when neither the relays nor the validators returned a valid payload.
+	RetrievalSourceNone = "none"
+)
+
+// Hedge reasons, i.e. the values of the reason label of the hedged_retrievals metric.
+const (
+	// HedgeReasonDelay is recorded when the relays didn't return a valid payload within the hedge delay.
+	HedgeReasonDelay = "delay"
+	// HedgeReasonRelayFailure is recorded when the relays failed before the hedge delay elapsed.
+	HedgeReasonRelayFailure = "relay_failure"
+)
+
 type RetrievalMetricer interface {
 	RecordPayloadSizeBytes(size int)
+	// RecordRetrievalSource records which retrieval path served a request, see the RetrievalSource constants.
+	RecordRetrievalSource(source string)
+	// RecordHedge records that retrieval from the validators was started, see the HedgeReason constants.
+	RecordHedge(reason string)
 
 	Document() []metrics.DocumentedMetric
 }
 
 type RetrievalMetrics struct {
-	PayloadSize prometheus.Histogram
+	PayloadSize       prometheus.Histogram
+	PayloadsRetrieved *prometheus.CounterVec
+	HedgedRetrievals  *prometheus.CounterVec
 
 	factory *metrics.Documentor
 }
@@ -36,6 +58,18 @@ func NewRetrievalMetrics(registry *prometheus.Registry) RetrievalMetricer {
 			Help:      "Size of decoded payloads in bytes",
 			Buckets:   blobSizeBuckets,
 		}),
+		PayloadsRetrieved: factory.NewCounterVec(prometheus.CounterOpts{
+			Name:      "payloads_retrieved_total",
+			Namespace: namespace,
+			Subsystem: retrievalSubsystem,
+			Help:      "Number of payload retrievals by hedging retrievers, by the path (relay or validator) that served them",
+		}, []string{"source"}),
+		HedgedRetrievals: factory.NewCounterVec(prometheus.CounterOpts{
+			Name:      "hedged_retrievals_total",
+			Namespace: namespace,
+			Subsystem: retrievalSubsystem,
+			Help:      "Number of payload retrievals by hedging retrievers that were hedged to the validators, by reason",
+		}, []string{"reason"}),
 		factory: factory,
 	}
 }
@@ -44,6 +78,14 @@ func (m *RetrievalMetrics) RecordPayloadSizeBytes(size int) {
 	m.PayloadSize.Observe(float64(size))
 }
 
+func (m *RetrievalMetrics) RecordRetrievalSource(source string) {
+	m.PayloadsRetrieved.WithLabelValues(source).Inc()
+}
+
+func (m *RetrievalMetrics) RecordHedge(reason string) {
+	m.HedgedRetrievals.WithLabelValues(reason).Inc()
+}
+
 func (m *RetrievalMetrics) Document() []metrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -56,6 +98,12 @@ var NoopRetrievalMetrics RetrievalMetricer = new(noopRetrievalMetricer)
 func (n *noopRetrievalMetricer) RecordPayloadSizeBytes(_ int) {
 }
 
+func (n *noopRetrievalMetricer) RecordRetrievalSource(_ string) {
+}
+
+func (n *noopRetrievalMetricer) RecordHedge(_ string) {
+}
+
 func (n *noopRetrievalMetricer) Document() []metrics.DocumentedMetric {
 	return []metrics.DocumentedMetric{}
 }
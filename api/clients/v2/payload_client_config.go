@@ -22,6 +22,15 @@ type PayloadClientConfig struct {
 	// entirety to perform a verification that any part of the data matches the KZG commitment.
 	PayloadPolynomialForm codecs.PolynomialForm
 
+	// PayloadEncodingVersion is the encoding applied to payloads before they are converted into blobs. When
+	// retrieving, payloads encoded with this version or with [codecs.PayloadEncodingVersion0] are decoded, see
+	// [codecs.DecodablePayloadEncodingVersions].
+	//
+	// Readers must enable the chosen version, so [codecs.PayloadEncodingVersion1] (compression) should only be
+	// enabled once every reader of the dispersed payloads has enabled it. It is not part of the integration spec, so
+	// secure integrations can't use it.
+	PayloadEncodingVersion codecs.PayloadEncodingVersion
+
 	// The BlobVersion to use when creating new blobs, or interpreting blob bytes.
 	//
 	// BlobVersion needs to point to a version defined in the threshold registry contract.
@@ -32,7 +41,8 @@ type PayloadClientConfig struct {
 // GetDefaultPayloadClientConfig creates a PayloadClientConfig with default values
 func GetDefaultPayloadClientConfig() *PayloadClientConfig {
 	return &PayloadClientConfig{
-		PayloadPolynomialForm: codecs.PolynomialFormEval,
-		BlobVersion:           0,
+		PayloadPolynomialForm:  codecs.PolynomialFormEval,
+		PayloadEncodingVersion: codecs.PayloadEncodingVersion0,
+		BlobVersion:            0,
 	}
 }
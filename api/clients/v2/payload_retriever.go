@@ -12,10 +12,8 @@ import (
 // This interface may be implemented to provide alternate retrieval methods, for example payload retrieval from an S3
 // bucket instead of from EigenDA relays or nodes.
 //
-// TODO(samlaf): I don't think we need this interface. We probably shouldn't have the separate relay
-// and validator retrieval clients that implement this interface. Instead,
-// we should have a single PayloadRetriever that knows how to retrieve blobs from either
-// relays or validators, and then decodes them to (encoded) payloads.
+// payloadretrieval.PayloadRetriever retrieves payloads from both relays and validators, hedging between the two.
+// The relay and validator retrievers it is built from also implement this interface, and can be used on their own.
 type PayloadRetriever interface {
 	// GetPayload retrieves a payload from some backend, using the provided certificate
 	// GetPayload should return a [coretypes.ErrBlobDecodingFailedDerivationError] if the blob cannot be decoding according
@@ -0,0 +1,199 @@
+package payloadretrieval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	clients "github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// PayloadRetriever retrieves payloads from both the relays and the validators.
+//
+// Blobs are first requested from the relays. If the relays fail, or haven't returned a valid blob within
+// [PayloadRetrieverConfig.HedgeDelay], the blob is also reconstructed from the chunks held by the validators.
+// The first verified blob returned by either path is used, and the other path is cancelled.
+//
+// This struct is goroutine safe.
+type PayloadRetriever struct {
+	log                logging.Logger
+	config             PayloadRetrieverConfig
+	relayRetriever     clients.PayloadRetriever
+	validatorRetriever clients.PayloadRetriever
+	metrics            metrics.RetrievalMetricer
+}
+
+var _ clients.PayloadRetriever = &PayloadRetriever{}
+
+// retrievalResult is the outcome of retrieving a blob through a single path.
+type retrievalResult struct {
+	source         string
+	encodedPayload *coretypes.EncodedPayload
+	err            error
+}
+
+// NewPayloadRetriever assembles a PayloadRetriever from a relay and a validator retriever, which are typically a
+// RelayPayloadRetriever and a ValidatorPayloadRetriever. Both retrievers must verify the blobs they return against
+// the cert. One of them may be nil, in which case only the other one is used and no hedging takes place.
+func NewPayloadRetriever(
+	log logging.Logger,
+	config PayloadRetrieverConfig,
+	relayRetriever clients.PayloadRetriever,
+	validatorRetriever clients.PayloadRetriever,
+	metrics metrics.RetrievalMetricer,
+) (*PayloadRetriever, error) {
+	err := config.checkAndSetDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("check and set PayloadRetrieverConfig config: %w", err)
+	}
+	if relayRetriever == nil && validatorRetriever == nil {
+		return nil, errors.New("at least one of the relay and validator retrievers is required")
+	}
+
+	return &PayloadRetriever{
+		log:                log,
+		config:             config,
+		relayRetriever:     relayRetriever,
+		validatorRetriever: validatorRetriever,
+		metrics:            metrics,
+	}, nil
+}
+
+// GetPayload retrieves the blob of the EigenDACert from the relays and/or the validators, see [PayloadRetriever].
+// The verified blob is decoded to yield the payload (the original user data, with no padding or any modification),
+// and the payload is returned.
+//
+// This method does NOT verify the eigenDACert on chain: it is assumed that the input eigenDACert has already been
+// verified prior to calling this method.
+func (pr *PayloadRetriever) GetPayload(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+) (coretypes.Payload, error) {
+
+	encodedPayload, err := pr.GetEncodedPayload(ctx, eigenDACert)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := encodedPayload.DecodeAllowingVersion(pr.config.PayloadEncodingVersion)
+	if err != nil {
+		// If we successfully compute the blob key, we add it to the error message to help with debugging.
+		blobKey, keyErr := eigenDACert.ComputeBlobKey()
+		if keyErr == nil {
+			err = fmt.Errorf("blob %v: %w", blobKey.Hex(), err)
+		}
+		return nil, coretypes.ErrBlobDecodingFailedDerivationError.WithMessage(err.Error())
+	}
+
+	pr.metrics.RecordPayloadSizeBytes(len(payload))
+
+	return payload, nil
+}
+
+// GetEncodedPayload retrieves the blob of the EigenDACert from the relays and/or the validators,
+// see [PayloadRetriever], and converts it to an encoded payload.
+//
+// If both paths fail, their errors are joined and returned.
+//
+// This method does NOT verify the eigenDACert on chain: it is assumed that the input
+// eigenDACert has already been verified prior to calling this method.
+func (pr *PayloadRetriever) GetEncodedPayload(
+	ctx context.Context,
+	eigenDACert coretypes.EigenDACert,
+) (*coretypes.EncodedPayload, error) {
+
+	// cancels the path that is still running once the other one has returned a valid blob
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// buffered such that the losing path doesn't block once this method has returned
+	results := make(chan retrievalResult, 2)
+	retrieve := func(source string, retriever clients.PayloadRetriever) {
+		go func() {
+			encodedPayload, err := retriever.GetEncodedPayload(ctx, eigenDACert)
+			results <- retrievalResult{source: source, encodedPayload: encodedPayload, err: err}
+		}()
+	}
+
+	pending := 0
+	validatorsStarted := false
+	startValidators := func(reason string) {
+		validatorsStarted = true
+		pending++
+		if reason != "" {
+			pr.metrics.RecordHedge(reason)
+			pr.log.Debug("Hedging payload retrieval to validators", "reason", reason)
+		}
+		retrieve(metrics.RetrievalSourceValidator, pr.validatorRetriever)
+	}
+
+	// a nil channel blocks forever, such that the hedge timer never fires when there is nothing to hedge to
+	var hedgeTimer <-chan time.Time
+	if pr.relayRetriever != nil {
+		pending++
+		retrieve(metrics.RetrievalSourceRelay, pr.relayRetriever)
+		if pr.validatorRetriever != nil {
+			if pr.config.HedgeDelay == 0 {
+				startValidators(metrics.HedgeReasonDelay)
+			} else {
+				timer := time.NewTimer(pr.config.HedgeDelay)
+				defer timer.Stop()
+				hedgeTimer = timer.C
+			}
+		}
+	} else {
+		startValidators("")
+	}
+
+	var errs []error
+	for pending > 0 {
+		select {
+		case <-hedgeTimer:
+			hedgeTimer = nil
+			if !validatorsStarted {
+				startValidators(metrics.HedgeReasonDelay)
+			}
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				pr.metrics.RecordRetrievalSource(result.source)
+				return result.encodedPayload, nil
+			}
+
+			pr.log.Warn("Payload retrieval failed", "source", result.source, "err", result.err)
+			errs = append(errs, fmt.Errorf("retrieve from %s: %w", result.source, result.err))
+			if result.source == metrics.RetrievalSourceRelay && pr.validatorRetriever != nil && !validatorsStarted {
+				hedgeTimer = nil
+				startValidators(metrics.HedgeReasonRelayFailure)
+			}
+		}
+	}
+
+	pr.metrics.RecordRetrievalSource(metrics.RetrievalSourceNone)
+	return nil, errors.Join(errs...)
+}
+
+// Close closes the relay and validator retrievers that have a Close method. This method will do its best to close
+// both, even if one of them fails.
+//
+// Any and all errors returned from closing the retrievers will be joined and returned.
+//
+// This method should only be called once.
+func (pr *PayloadRetriever) Close() error {
+	var errs []error
+	for _, retriever := range []clients.PayloadRetriever{pr.relayRetriever, pr.validatorRetriever} {
+		closer, ok := retriever.(interface{ Close() error })
+		if !ok {
+			continue
+		}
+		err := closer.Close()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,48 @@
+package payloadretrieval
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+)
+
+// PayloadRetrieverConfig contains the configuration values needed by a PayloadRetriever, in addition to those of the
+// relay and validator retrievers it is built from.
+type PayloadRetrieverConfig struct {
+	// The duration to wait for the relays before also retrieving the blob from the validators. Validator retrieval
+	// starts earlier if the relays fail before this delay has elapsed.
+	//
+	// 0 means that the blob is retrieved from the relays and the validators at the same time. Set to UnsetHedgeDelay
+	// to use the default delay.
+	HedgeDelay time.Duration
+
+	// The PayloadEncodingVersion that retrieved payloads may be encoded with, in addition to
+	// codecs.PayloadEncodingVersion0. See codecs.DecodablePayloadEncodingVersions.
+	PayloadEncodingVersion codecs.PayloadEncodingVersion
+}
+
+// UnsetHedgeDelay is the PayloadRetrieverConfig.HedgeDelay value that is replaced with the default hedge delay. It is
+// needed since 0 is a valid hedge delay.
+const UnsetHedgeDelay time.Duration = -1
+
+// getDefaultPayloadRetrieverConfig creates a PayloadRetrieverConfig with default values
+func getDefaultPayloadRetrieverConfig() *PayloadRetrieverConfig {
+	return &PayloadRetrieverConfig{
+		HedgeDelay: 2 * time.Second,
+	}
+}
+
+// checkAndSetDefaults checks an existing config struct. If HedgeDelay is UnsetHedgeDelay, then this method sets it to
+// the default.
+func (rc *PayloadRetrieverConfig) checkAndSetDefaults() error {
+	defaultConfig := getDefaultPayloadRetrieverConfig()
+	if rc.HedgeDelay == UnsetHedgeDelay {
+		rc.HedgeDelay = defaultConfig.HedgeDelay
+	}
+	if rc.HedgeDelay < 0 {
+		return fmt.Errorf("hedge delay must not be negative, got %s", rc.HedgeDelay)
+	}
+
+	return nil
+}
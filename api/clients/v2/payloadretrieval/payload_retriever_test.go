@@ -0,0 +1,244 @@
+package payloadretrieval
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/metrics"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/stretchr/testify/require"
+)
+
+// eventLog records the order in which the retrievers are called and retrievals are hedged.
+type eventLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *eventLog) record(event string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *eventLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.events...)
+}
+
+// fakeRetriever returns encodedPayload or err after delay, unless its context is cancelled first.
+type fakeRetriever struct {
+	delay          time.Duration
+	encodedPayload *coretypes.EncodedPayload
+	err            error
+	calls          atomic.Int32
+	cancelled      atomic.Bool
+	// if set, the source is recorded in events when the retriever is called
+	source string
+	events *eventLog
+}
+
+func (r *fakeRetriever) GetPayload(context.Context, coretypes.EigenDACert) (coretypes.Payload, error) {
+	panic("GetPayload should not be called")
+}
+
+func (r *fakeRetriever) GetEncodedPayload(
+	ctx context.Context, _ coretypes.EigenDACert,
+) (*coretypes.EncodedPayload, error) {
+	r.calls.Add(1)
+	r.events.record(r.source)
+	select {
+	case <-time.After(r.delay):
+		return r.encodedPayload, r.err
+	case <-ctx.Done():
+		r.cancelled.Store(true)
+		return nil, ctx.Err()
+	}
+}
+
+// recordingMetrics records the retrieval sources and hedge reasons.
+type recordingMetrics struct {
+	metrics.RetrievalMetricer
+	sources chan string
+	hedges  chan string
+	// if set, hedges are recorded in events
+	events *eventLog
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{
+		RetrievalMetricer: metrics.NoopRetrievalMetrics,
+		sources:           make(chan string, 1),
+		hedges:            make(chan string, 1),
+	}
+}
+
+func (m *recordingMetrics) RecordRetrievalSource(source string) {
+	m.sources <- source
+}
+
+func (m *recordingMetrics) RecordHedge(reason string) {
+	m.events.record("hedge: " + reason)
+	m.hedges <- reason
+}
+
+func TestPayloadRetrieverHedging(t *testing.T) {
+	payload := coretypes.Payload("some payload")
+	blob, err := payload.ToBlob(codecs.PolynomialFormEval)
+	require.NoError(t, err)
+	encodedPayload := blob.ToEncodedPayloadUnchecked(codecs.PolynomialFormEval)
+	cert := &coretypes.EigenDACertV3{}
+	hedgeDelay := 50 * time.Millisecond
+
+	newRetrieverWithDelay := func(t *testing.T, delay time.Duration, relay, validator *fakeRetriever) (
+		*PayloadRetriever, *recordingMetrics,
+	) {
+		recorder := newRecordingMetrics()
+		// avoid typed nil interfaces
+		var relayRetriever, validatorRetriever clients.PayloadRetriever
+		if relay != nil {
+			relayRetriever = relay
+		}
+		if validator != nil {
+			validatorRetriever = validator
+		}
+		retriever, err := NewPayloadRetriever(test.GetLogger(), PayloadRetrieverConfig{HedgeDelay: delay},
+			relayRetriever, validatorRetriever, recorder)
+		require.NoError(t, err)
+		return retriever, recorder
+	}
+	newRetriever := func(t *testing.T, relay, validator *fakeRetriever) (*PayloadRetriever, *recordingMetrics) {
+		return newRetrieverWithDelay(t, hedgeDelay, relay, validator)
+	}
+
+	t.Run("relay answers before the hedge delay", func(t *testing.T) {
+		relay := &fakeRetriever{encodedPayload: encodedPayload}
+		validator := &fakeRetriever{encodedPayload: encodedPayload}
+		retriever, recorder := newRetriever(t, relay, validator)
+
+		retrieved, err := retriever.GetPayload(t.Context(), cert)
+		require.NoError(t, err)
+		require.Equal(t, payload, retrieved)
+		require.Equal(t, metrics.RetrievalSourceRelay, <-recorder.sources)
+		require.Empty(t, recorder.hedges)
+		require.Zero(t, validator.calls.Load())
+	})
+
+	t.Run("slow relay is hedged after the delay", func(t *testing.T) {
+		relay := &fakeRetriever{delay: time.Minute, encodedPayload: encodedPayload}
+		validator := &fakeRetriever{encodedPayload: encodedPayload}
+		retriever, recorder := newRetriever(t, relay, validator)
+
+		start := time.Now()
+		retrieved, err := retriever.GetEncodedPayload(t.Context(), cert)
+		require.NoError(t, err)
+		require.Equal(t, encodedPayload, retrieved)
+		require.GreaterOrEqual(t, time.Since(start), hedgeDelay)
+		require.Equal(t, metrics.HedgeReasonDelay, <-recorder.hedges)
+		require.Equal(t, metrics.RetrievalSourceValidator, <-recorder.sources)
+		require.Eventually(t, relay.cancelled.Load, time.Second, time.Millisecond, "relay should be cancelled")
+	})
+
+	t.Run("relay failure is hedged immediately", func(t *testing.T) {
+		events := &eventLog{}
+		relay := &fakeRetriever{err: errors.New("relay unavailable"), source: "relay", events: events}
+		validator := &fakeRetriever{encodedPayload: encodedPayload, source: "validator", events: events}
+		// the hedge delay never elapses during the test, so only the relay failure can start validator retrieval
+		retriever, recorder := newRetrieverWithDelay(t, time.Hour, relay, validator)
+		recorder.events = events
+
+		_, err := retriever.GetEncodedPayload(t.Context(), cert)
+		require.NoError(t, err)
+		require.Equal(t, metrics.HedgeReasonRelayFailure, <-recorder.hedges)
+		require.Equal(t, metrics.RetrievalSourceValidator, <-recorder.sources)
+		require.Equal(t,
+			[]string{"relay", "hedge: " + metrics.HedgeReasonRelayFailure, "validator"},
+			events.get())
+	})
+
+	t.Run("zero hedge delay retrieves from both paths at once", func(t *testing.T) {
+		relay := &fakeRetriever{delay: time.Minute, encodedPayload: encodedPayload}
+		validator := &fakeRetriever{encodedPayload: encodedPayload}
+		retriever, recorder := newRetrieverWithDelay(t, 0, relay, validator)
+
+		_, err := retriever.GetEncodedPayload(t.Context(), cert)
+		require.NoError(t, err)
+		require.Equal(t, metrics.HedgeReasonDelay, <-recorder.hedges)
+		require.Equal(t, metrics.RetrievalSourceValidator, <-recorder.sources)
+		require.Eventually(t, relay.cancelled.Load, time.Second, time.Millisecond, "relay should be cancelled")
+	})
+
+	t.Run("relay wins after hedging", func(t *testing.T) {
+		relay := &fakeRetriever{delay: 2 * hedgeDelay, encodedPayload: encodedPayload}
+		validator := &fakeRetriever{delay: time.Minute, encodedPayload: encodedPayload}
+		retriever, recorder := newRetriever(t, relay, validator)
+
+		_, err := retriever.GetEncodedPayload(t.Context(), cert)
+		require.NoError(t, err)
+		require.Equal(t, metrics.HedgeReasonDelay, <-recorder.hedges)
+		require.Equal(t, metrics.RetrievalSourceRelay, <-recorder.sources)
+		require.Eventually(t, validator.cancelled.Load, time.Second, time.Millisecond,
+			"validators should be cancelled")
+	})
+
+	t.Run("both paths fail", func(t *testing.T) {
+		relay := &fakeRetriever{err: errors.New("relay unavailable")}
+		validator := &fakeRetriever{err: coretypes.ErrCertCommitmentBlobLengthNotPowerOf2MaliciousOperatorsError}
+		retriever, recorder := newRetriever(t, relay, validator)
+
+		_, err := retriever.GetEncodedPayload(t.Context(), cert)
+		require.ErrorContains(t, err, "relay unavailable")
+		require.ErrorIs(t, err, coretypes.ErrCertCommitmentBlobLengthNotPowerOf2MaliciousOperatorsError)
+		require.Equal(t, metrics.RetrievalSourceNone, <-recorder.sources)
+	})
+
+	t.Run("validators only", func(t *testing.T) {
+		validator := &fakeRetriever{encodedPayload: encodedPayload}
+		retriever, recorder := newRetriever(t, nil, validator)
+
+		_, err := retriever.GetEncodedPayload(t.Context(), cert)
+		require.NoError(t, err)
+		require.Equal(t, metrics.RetrievalSourceValidator, <-recorder.sources)
+		require.Empty(t, recorder.hedges, "nothing to hedge from")
+	})
+
+	t.Run("relays only", func(t *testing.T) {
+		relay := &fakeRetriever{err: errors.New("relay unavailable")}
+		retriever, recorder := newRetriever(t, relay, nil)
+
+		_, err := retriever.GetEncodedPayload(t.Context(), cert)
+		require.ErrorContains(t, err, "relay unavailable")
+		require.Equal(t, metrics.RetrievalSourceNone, <-recorder.sources)
+		require.Empty(t, recorder.hedges, "nothing to hedge to")
+	})
+
+	t.Run("no retrievers", func(t *testing.T) {
+		_, err := NewPayloadRetriever(test.GetLogger(), PayloadRetrieverConfig{}, nil, nil,
+			metrics.NoopRetrievalMetrics)
+		require.Error(t, err)
+	})
+}
+
+func TestPayloadRetrieverConfigHedgeDelay(t *testing.T) {
+	config := PayloadRetrieverConfig{HedgeDelay: UnsetHedgeDelay}
+	require.NoError(t, config.checkAndSetDefaults())
+	require.Equal(t, getDefaultPayloadRetrieverConfig().HedgeDelay, config.HedgeDelay)
+
+	config = PayloadRetrieverConfig{HedgeDelay: 0}
+	require.NoError(t, config.checkAndSetDefaults())
+	require.Zero(t, config.HedgeDelay, "0 means hedging immediately")
+
+	config = PayloadRetrieverConfig{HedgeDelay: -time.Second}
+	require.Error(t, config.checkAndSetDefaults())
+}
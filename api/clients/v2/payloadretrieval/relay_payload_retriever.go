@@ -9,9 +9,11 @@ import (
 	"github.com/Layr-Labs/eigenda/api/clients/v2/metrics"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/math"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 )
 
 // RelayPayloadRetriever provides the ability to get payloads from the relay subsystem.
@@ -50,6 +52,36 @@ func NewRelayPayloadRetriever(
 	}, nil
 }
 
+// BuildRelayPayloadRetriever constructs a RelayPayloadRetriever, along with the relay client it fetches blobs with. The
+// relay client discovers relay URLs from the RelayRegistry contract at relayRegistryAddr.
+func BuildRelayPayloadRetriever(
+	log logging.Logger,
+	relayPayloadRetrieverConfig RelayPayloadRetrieverConfig,
+	relayClientConfig *relay.RelayClientConfig,
+	ethClient common.EthClient,
+	relayRegistryAddr gethcommon.Address,
+	g1Srs []bn254.G1Affine,
+	metrics metrics.RetrievalMetricer,
+) (*RelayPayloadRetriever, error) {
+	relayURLProvider, err := relay.NewRelayUrlProvider(ethClient, relayRegistryAddr)
+	if err != nil {
+		return nil, fmt.Errorf("new relay url provider: %w", err)
+	}
+
+	relayClient, err := relay.NewRelayClient(relayClientConfig, log, relayURLProvider)
+	if err != nil {
+		return nil, fmt.Errorf("new relay client: %w", err)
+	}
+
+	relayPayloadRetriever, err := NewRelayPayloadRetriever(
+		log, relayPayloadRetrieverConfig, relayClient, g1Srs, metrics)
+	if err != nil {
+		return nil, fmt.Errorf("new relay payload retriever: %w", err)
+	}
+
+	return relayPayloadRetriever, nil
+}
+
 // GetPayload retrieves a blob from the relay specified in the EigenDACert.
 //
 // If the blob is successfully retrieved, then the blob is verified against the certificate. If the verification
@@ -68,7 +100,7 @@ func (pr *RelayPayloadRetriever) GetPayload(
 		return nil, err
 	}
 
-	payload, err := encodedPayload.Decode()
+	payload, err := encodedPayload.DecodeAllowingVersion(pr.config.PayloadEncodingVersion)
 	if err != nil {
 		// If we successfully compute the blob key, we add it to the error message to help with debugging.
 		blobKey, keyErr := eigenDACert.ComputeBlobKey()
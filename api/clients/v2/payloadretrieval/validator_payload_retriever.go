@@ -69,7 +69,7 @@ func (pr *ValidatorPayloadRetriever) GetPayload(
 		return nil, err
 	}
 
-	payload, err := encodedPayload.Decode()
+	payload, err := encodedPayload.DecodeAllowingVersion(pr.config.PayloadEncodingVersion)
 	if err != nil {
 		// If we successfully compute the blob key, we add it to the error message to help with debugging.
 		blobKey, keyErr := eigenDACert.ComputeBlobKey()
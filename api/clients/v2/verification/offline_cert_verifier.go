@@ -0,0 +1,523 @@
+package verification
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	certTypesBinding "github.com/Layr-Labs/eigenda/contracts/bindings/IEigenDACertTypeBindings"
+	"github.com/Layr-Labs/eigenda/core"
+	bn254utils "github.com/Layr-Labs/eigenda/core/bn254"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The constants below mirror the ones hardcoded in the EigenDACertVerifier contract and its libraries.
+const (
+	// maxCalldataBytesLength is the maximum length of an abi encoded cert for it to be considered valid.
+	maxCalldataBytesLength = 262_144
+	// maxQuorumCount is the maximum number of signed quorums in a cert.
+	maxQuorumCount = 5
+	// maxNonSignerCountAllQuorum is the maximum number of non-signers summed over all signed quorums. An operator that
+	// doesn't sign for multiple quorums is counted once per quorum.
+	maxNonSignerCountAllQuorum = 415
+	// thresholdDenominator is the denominator of the threshold percentages.
+	thresholdDenominator = 100
+)
+
+// maxUint96 is the maximum value of the uint96 type that the contracts use for stakes.
+var maxUint96 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 96), big.NewInt(1))
+
+// OfflineCertVerifierConfig contains the parameters that an EigenDACertVerifier contract is deployed with, along with
+// the blob version parameters registered in the EigenDAThresholdRegistry.
+type OfflineCertVerifierConfig struct {
+	// ConfirmationThreshold is the percentage of the stake of a quorum that must have signed for it to be confirmed.
+	ConfirmationThreshold uint8
+	// AdversaryThreshold is the maximum percentage of the stake of a quorum that is assumed to be adversarial.
+	AdversaryThreshold uint8
+	// QuorumNumbersRequired are the quorums that every blob must be dispersed to.
+	QuorumNumbersRequired []core.QuorumID
+	// OffchainDerivationVersion is the offchain derivation version that V4 certs must have. It is ignored for older
+	// certs, which were verified by contracts that predate it.
+	OffchainDerivationVersion coretypes.OffchainDerivationVersion
+	// BlobVersionParameters are the parameters of each blob version. A version that is missing from the map is treated
+	// as not registered in the EigenDAThresholdRegistry.
+	BlobVersionParameters map[corev2.BlobVersion]*core.BlobVersionParameters
+}
+
+// OfflineCertVerifier is a pure-Go implementation of EigenDACertVerifier.checkDACert. Instead of making an eth_call,
+// it checks the blob inclusion proof, the security parameters, the aggregate BLS signature and the quorum thresholds of
+// a cert against an operator state snapshot provided by the caller.
+//
+// Status codes mirror the ones returned by the contract, see [CheckDACertStatusCode]. The differences are:
+//   - The stake and APK history indices carried by the cert are not checked, as the snapshot already contains the
+//     operator state at the reference block number.
+//   - The reference block number is not compared to the current block number.
+//
+// The contract is authoritative: this verifier is meant for light clients, fraud proof tooling and tests, which need
+// to validate certs without an RPC node.
+//
+// This struct is goroutine safe.
+type OfflineCertVerifier struct {
+	config OfflineCertVerifierConfig
+}
+
+// OperatorStateSnapshot is the operator state at the reference block number of a cert, which the OfflineCertVerifier
+// checks the cert against.
+type OperatorStateSnapshot struct {
+	// IndexedOperatorState is the state of the signed quorums of the cert, such as the one returned by
+	// [core.IndexedChainState.GetIndexedOperatorState].
+	*core.IndexedOperatorState
+	// QuorumBitmaps holds the bitmap of all the quorums that each operator is registered in, including the quorums that
+	// the cert isn't signed for. It must contain every operator that registered at or before the reference block
+	// number, with an empty bitmap for operators that have since deregistered from all quorums. Non-signers that are
+	// missing from it are treated as never registered, which the contract rejects.
+	QuorumBitmaps map[core.OperatorID]*big.Int
+}
+
+// NewOfflineCertVerifier constructs a new OfflineCertVerifier. The config is validated with the same rules as the
+// constructor of the EigenDACertVerifier contract.
+func NewOfflineCertVerifier(config OfflineCertVerifierConfig) (*OfflineCertVerifier, error) {
+	if config.ConfirmationThreshold <= config.AdversaryThreshold {
+		return nil, fmt.Errorf("confirmation threshold %d must be greater than adversary threshold %d",
+			config.ConfirmationThreshold, config.AdversaryThreshold)
+	}
+	if len(config.QuorumNumbersRequired) == 0 || len(config.QuorumNumbersRequired) > 256 {
+		return nil, fmt.Errorf("expected 1 to 256 required quorums, got %d", len(config.QuorumNumbersRequired))
+	}
+	return &OfflineCertVerifier{config: config}, nil
+}
+
+// CheckDACert verifies the cert against operatorState, which must be the operator state at its reference block
+// number.
+//
+// This method returns nil if the certificate is successfully verified. Otherwise, it returns a
+// [CertVerifierInvalidCertError] holding the status code that the contract would have returned, or a
+// [CertVerifierInternalError] if the cert couldn't be checked against operatorState.
+func (v *OfflineCertVerifier) CheckDACert(
+	cert coretypes.EigenDACert,
+	operatorState *OperatorStateSnapshot,
+) error {
+	if operatorState == nil || operatorState.IndexedOperatorState == nil ||
+		operatorState.OperatorState == nil {
+		return &CertVerifierInternalError{Msg: "operator state is nil"}
+	}
+	if uint64(operatorState.BlockNumber) != cert.ReferenceBlockNumber() {
+		return &CertVerifierInternalError{Msg: fmt.Sprintf(
+			"operator state is at block %d, but cert has reference block number %d",
+			operatorState.BlockNumber, cert.ReferenceBlockNumber())}
+	}
+
+	certBytes, err := SerializeCert(cert)
+	if err != nil {
+		return &CertVerifierInternalError{Msg: "serialize cert", Err: err}
+	}
+	if len(certBytes) > maxCalldataBytesLength {
+		return invalidCertError("abi encoded cert is %d bytes, maximum is %d", len(certBytes), maxCalldataBytesLength)
+	}
+
+	var certV3 *coretypes.EigenDACertV3
+	switch cert := cert.(type) {
+	case *coretypes.EigenDACertV2:
+		certV3 = cert.ToV3()
+	case *coretypes.EigenDACertV3:
+		certV3 = cert
+	case *coretypes.EigenDACertV4:
+		if cert.OffchainDerivationVersion != v.config.OffchainDerivationVersion {
+			return invalidCertError("offchain derivation version %d, required %d",
+				cert.OffchainDerivationVersion, v.config.OffchainDerivationVersion)
+		}
+		certV3 = &coretypes.EigenDACertV3{
+			BatchHeader:                 cert.BatchHeader,
+			BlobInclusionInfo:           cert.BlobInclusionInfo,
+			NonSignerStakesAndSignature: cert.NonSignerStakesAndSignature,
+			SignedQuorumNumbers:         cert.SignedQuorumNumbers,
+		}
+	default:
+		return &CertVerifierInternalError{Msg: fmt.Sprintf("unsupported cert type %T", cert)}
+	}
+
+	err = checkBlobInclusion(certV3)
+	if err != nil {
+		return err
+	}
+
+	err = v.checkSecurityParams(certV3.BlobInclusionInfo.BlobCertificate.BlobHeader.Version)
+	if err != nil {
+		return err
+	}
+
+	batchHeaderHash, err := corev2.BatchHeader{
+		BatchRoot:            certV3.BatchHeader.BatchRoot,
+		ReferenceBlockNumber: uint64(certV3.BatchHeader.ReferenceBlockNumber),
+	}.Hash()
+	if err != nil {
+		return &CertVerifierInternalError{Msg: "hash batch header", Err: err}
+	}
+
+	confirmedQuorumsBitmap, err := v.checkSignaturesAndBuildConfirmedQuorums(
+		batchHeaderHash, certV3.SignedQuorumNumbers, &certV3.NonSignerStakesAndSignature, operatorState)
+	if err != nil {
+		return err
+	}
+
+	return v.checkQuorumSubsets(certV3.BlobInclusionInfo.BlobCertificate.BlobHeader.QuorumNumbers,
+		confirmedQuorumsBitmap)
+}
+
+// checkBlobInclusion checks the merkle proof of the blob certificate against the batch root.
+func checkBlobInclusion(cert *coretypes.EigenDACertV3) error {
+	blobKey, err := cert.ComputeBlobKey()
+	if err != nil {
+		return invalidCertError("compute blob key: %v", err)
+	}
+	blobCertificate := cert.BlobInclusionInfo.BlobCertificate
+	blobCertHash, err := corev2.ComputeBlobCertificateHash(
+		blobKey, blobCertificate.Signature, blobCertificate.RelayKeys)
+	if err != nil {
+		return &CertVerifierInternalError{Msg: "hash blob certificate", Err: err}
+	}
+
+	proof := cert.BlobInclusionInfo.InclusionProof
+	if len(proof)%32 != 0 {
+		return invalidCertError("inclusion proof length %d is not a multiple of 32", len(proof))
+	}
+
+	// mirrors Merkle.verifyInclusionKeccak
+	computedHash := crypto.Keccak256(blobCertHash[:])
+	index := cert.BlobInclusionInfo.BlobIndex
+	for i := 0; i < len(proof); i += 32 {
+		if index%2 == 0 {
+			computedHash = crypto.Keccak256(computedHash, proof[i:i+32])
+		} else {
+			computedHash = crypto.Keccak256(proof[i:i+32], computedHash)
+		}
+		index /= 2
+	}
+	if !bytes.Equal(computedHash, cert.BatchHeader.BatchRoot[:]) {
+		return invalidCertError("invalid inclusion proof for blob index %d", cert.BlobInclusionInfo.BlobIndex)
+	}
+	return nil
+}
+
+// checkSecurityParams checks that the blob version is registered, and that its parameters satisfy the security
+// condition
+//
+//	codingRate * (numChunks - maxNumOperators) * (confirmationThreshold - adversaryThreshold) >= 100 * numChunks
+func (v *OfflineCertVerifier) checkSecurityParams(blobVersion corev2.BlobVersion) error {
+	params, ok := v.config.BlobVersionParameters[blobVersion]
+	if !ok || params == nil {
+		return invalidCertError("blob version %d is not registered", blobVersion)
+	}
+
+	if params.MaxNumOperators > params.NumChunks || v.config.ConfirmationThreshold < v.config.AdversaryThreshold {
+		return invalidCertError("security assumptions not met for blob version %d", blobVersion)
+	}
+
+	// The contract computes both sides of the inequality with uint32 arithmetic, which panics on overflow.
+	lhs, ok := mulUint32(uint64(params.CodingRate), uint64(params.NumChunks-params.MaxNumOperators))
+	if ok {
+		lhs, ok = mulUint32(lhs, uint64(v.config.ConfirmationThreshold-v.config.AdversaryThreshold))
+	}
+	if !ok {
+		return contractPanicError("overflow computing security condition for blob version %d", blobVersion)
+	}
+	rhs, ok := mulUint32(thresholdDenominator, uint64(params.NumChunks))
+	if !ok {
+		return contractPanicError("overflow computing security condition for blob version %d", blobVersion)
+	}
+
+	if lhs < rhs {
+		return invalidCertError("security assumptions not met for blob version %d", blobVersion)
+	}
+	return nil
+}
+
+// checkSignaturesAndBuildConfirmedQuorums checks the aggregate signature of the batch header hash against
+// operatorState, and returns the bitmap of the signed quorums whose signed stake meets the confirmation threshold.
+//
+// It mirrors the checks of BLSSignatureChecker.checkSignatures, which is called by the contract.
+func (v *OfflineCertVerifier) checkSignaturesAndBuildConfirmedQuorums(
+	batchHeaderHash [32]byte,
+	signedQuorumNumbers []byte,
+	params *certTypesBinding.EigenDATypesV1NonSignerStakesAndSignature,
+	operatorState *OperatorStateSnapshot,
+) (*big.Int, error) {
+	if len(signedQuorumNumbers) > maxQuorumCount {
+		return nil, invalidCertError("%d signed quorums, maximum is %d", len(signedQuorumNumbers), maxQuorumCount)
+	}
+	totalNonSignerCount := 0
+	for _, indices := range params.NonSignerStakeIndices {
+		totalNonSignerCount += len(indices)
+	}
+	if totalNonSignerCount > maxNonSignerCountAllQuorum {
+		return nil, invalidCertError("%d non-signers across all quorums, maximum is %d",
+			totalNonSignerCount, maxNonSignerCountAllQuorum)
+	}
+
+	quorumCount := len(signedQuorumNumbers)
+	if quorumCount == 0 {
+		return nil, invalidCertError("empty quorum input")
+	}
+	if len(params.QuorumApks) != quorumCount || len(params.QuorumApkIndices) != quorumCount ||
+		len(params.TotalStakeIndices) != quorumCount || len(params.NonSignerStakeIndices) != quorumCount {
+		return nil, invalidCertError("input quorum length mismatch")
+	}
+	if len(params.NonSignerPubkeys) != len(params.NonSignerQuorumBitmapIndices) {
+		return nil, invalidCertError("input nonsigner length mismatch")
+	}
+	signedQuorumsBitmap, err := orderedBytesArrayToBitmap(signedQuorumNumbers)
+	if err != nil {
+		return nil, invalidCertError("signed quorums: %v", err)
+	}
+	for _, quorum := range signedQuorumNumbers {
+		if totals, ok := operatorState.Totals[quorum]; !ok || totals == nil {
+			return nil, invalidCertError("signed quorum %d not found in operator state", quorum)
+		}
+	}
+
+	// apk is the aggregate key of the signers, computed by subtracting the keys of the non-signers from the aggregate
+	// keys of the signed quorums. Non-signers are subtracted once for each signed quorum they are a member of.
+	var apk bn254.G1Affine
+	nonSignerIDs := make([]core.OperatorID, len(params.NonSignerPubkeys))
+	nonSignerQuorumBitmaps := make([]*big.Int, len(params.NonSignerPubkeys))
+	for i, pubkey := range params.NonSignerPubkeys {
+		point, err := toG1Point(pubkey)
+		if err != nil {
+			return nil, invalidCertError("non-signer pubkey %d: %v", i, err)
+		}
+		nonSignerIDs[i] = core.OperatorID(crypto.Keccak256Hash(
+			pubkey.X.FillBytes(make([]byte, 32)), pubkey.Y.FillBytes(make([]byte, 32))))
+		if i > 0 && bytes.Compare(nonSignerIDs[i][:], nonSignerIDs[i-1][:]) <= 0 {
+			return nil, invalidCertError("nonSignerPubkeys not sorted")
+		}
+
+		// The contract looks up the quorum bitmap of each non-signer at the reference block number, which reverts for
+		// operators that were never registered. Operators that are only registered in other quorums are ignored.
+		quorumBitmap, ok := operatorState.QuorumBitmaps[nonSignerIDs[i]]
+		if !ok || quorumBitmap == nil {
+			return nil, invalidCertError("non-signer %x was never registered", nonSignerIDs[i])
+		}
+		nonSignerQuorumBitmaps[i] = quorumBitmap
+		signedQuorumMemberships := popCount(new(big.Int).And(quorumBitmap, signedQuorumsBitmap))
+		var weighted bn254.G1Affine
+		weighted.ScalarMultiplication(point, big.NewInt(int64(signedQuorumMemberships)))
+		apk.Add(&apk, &weighted)
+	}
+	apk.Neg(&apk)
+
+	confirmedQuorumsBitmap := new(big.Int)
+	for i, quorum := range signedQuorumNumbers {
+		quorumApk, err := toG1Point(params.QuorumApks[i])
+		if err != nil {
+			return nil, invalidCertError("quorum %d apk: %v", quorum, err)
+		}
+		expectedApk, ok := operatorState.AggKeys[quorum]
+		if !ok || expectedApk == nil || !quorumApk.Equal(expectedApk.G1Affine) {
+			return nil, invalidCertError("quorumApk hash mismatch for quorum %d", quorum)
+		}
+		apk.Add(&apk, quorumApk)
+
+		totalStake := operatorState.Totals[quorum].Stake
+		signedStake := new(big.Int).Set(totalStake)
+		nonSignerForQuorumIndex := 0
+		for j, nonSignerID := range nonSignerIDs {
+			if nonSignerQuorumBitmaps[j].Bit(int(quorum)) == 0 {
+				continue
+			}
+			// The contract reads the stake index of each non-signer from this array without a bounds check,
+			// which panics when it is too short.
+			if nonSignerForQuorumIndex >= len(params.NonSignerStakeIndices[i]) {
+				return nil, contractPanicError("missing non-signer stake index for quorum %d", quorum)
+			}
+			operator, ok := operatorState.Operators[quorum][nonSignerID]
+			if !ok || operator == nil {
+				return nil, &CertVerifierInternalError{Msg: fmt.Sprintf(
+					"non-signer %x is registered in quorum %d, but is missing from its operator state",
+					nonSignerID, quorum)}
+			}
+			nonSignerForQuorumIndex++
+			signedStake.Sub(signedStake, operator.Stake)
+		}
+		if signedStake.Sign() < 0 {
+			return nil, contractPanicError("signed stake underflow for quorum %d", quorum)
+		}
+
+		// The right hand side is computed with uint96 arithmetic by the contract, which panics on overflow.
+		requiredStake := new(big.Int).Mul(totalStake, big.NewInt(int64(v.config.ConfirmationThreshold)))
+		if requiredStake.Cmp(maxUint96) > 0 {
+			return nil, contractPanicError("overflow computing required stake for quorum %d", quorum)
+		}
+		if new(big.Int).Mul(signedStake, big.NewInt(thresholdDenominator)).Cmp(requiredStake) >= 0 {
+			confirmedQuorumsBitmap.SetBit(confirmedQuorumsBitmap, int(quorum), 1)
+		}
+	}
+
+	err = verifySignature(batchHeaderHash, &apk, params.ApkG2, params.Sigma)
+	if err != nil {
+		return nil, err
+	}
+
+	return confirmedQuorumsBitmap, nil
+}
+
+// verifySignature mirrors BLSSignatureChecker.trySignatureAndApkVerification: it checks that sigma is a signature of
+// msgHash by the G1 key apk and its G2 counterpart apkG2, with a single pairing check.
+func verifySignature(
+	msgHash [32]byte,
+	apk *bn254.G1Affine,
+	apkG2Binding certTypesBinding.BN254G2Point,
+	sigmaBinding certTypesBinding.BN254G1Point,
+) error {
+	sigma, err := toG1Point(sigmaBinding)
+	if err != nil {
+		return invalidCertError("sigma: %v", err)
+	}
+	apkG2, err := toG2Point(apkG2Binding)
+	if err != nil {
+		return invalidCertError("pairing precompile call failed: apkG2: %v", err)
+	}
+
+	gammaBytes := crypto.Keccak256(
+		msgHash[:],
+		apk.X.Marshal(), apk.Y.Marshal(),
+		apkG2Binding.X[0].FillBytes(make([]byte, 32)), apkG2Binding.X[1].FillBytes(make([]byte, 32)),
+		apkG2Binding.Y[0].FillBytes(make([]byte, 32)), apkG2Binding.Y[1].FillBytes(make([]byte, 32)),
+		sigmaBinding.X.FillBytes(make([]byte, 32)), sigmaBinding.Y.FillBytes(make([]byte, 32)),
+	)
+	gamma := new(big.Int).Mod(new(big.Int).SetBytes(gammaBytes), fr.Modulus())
+
+	// e(sigma + gamma * apk, -g2) * e(H(m) + gamma * g1, apkG2) == 1
+	var lhs, rhs, tmp bn254.G1Affine
+	tmp.ScalarMultiplication(apk, gamma)
+	lhs.Add(sigma, &tmp)
+	tmp.ScalarMultiplication(bn254utils.GetG1Generator(), gamma)
+	rhs.Add(bn254utils.MapToCurve(msgHash), &tmp)
+	var negG2 bn254.G2Affine
+	negG2.Neg(bn254utils.GetG2Generator())
+
+	ok, err := bn254.PairingCheck([]bn254.G1Affine{lhs, rhs}, []bn254.G2Affine{negG2, *apkG2})
+	if err != nil {
+		return invalidCertError("pairing precompile call failed: %v", err)
+	}
+	if !ok {
+		return invalidCertError("signature is invalid")
+	}
+	return nil
+}
+
+// checkQuorumSubsets checks that requiredQuorums ⊆ blobQuorums ⊆ confirmedQuorums.
+func (v *OfflineCertVerifier) checkQuorumSubsets(blobQuorumNumbers []byte, confirmedQuorumsBitmap *big.Int) error {
+	blobQuorumsBitmap, err := orderedBytesArrayToBitmap(blobQuorumNumbers)
+	if err != nil {
+		return invalidCertError("blob quorums: %v", err)
+	}
+	if !isSubsetOf(blobQuorumsBitmap, confirmedQuorumsBitmap) {
+		return invalidCertError("blob quorums %#x are not a subset of confirmed quorums %#x",
+			blobQuorumsBitmap, confirmedQuorumsBitmap)
+	}
+
+	requiredQuorumsBitmap, err := orderedBytesArrayToBitmap(v.config.QuorumNumbersRequired)
+	if err != nil {
+		return invalidCertError("required quorums: %v", err)
+	}
+	if !isSubsetOf(requiredQuorumsBitmap, blobQuorumsBitmap) {
+		return invalidCertError("required quorums %#x are not a subset of blob quorums %#x",
+			requiredQuorumsBitmap, blobQuorumsBitmap)
+	}
+	return nil
+}
+
+// orderedBytesArrayToBitmap mirrors BitmapUtils.orderedBytesArrayToBitmap, which requires the quorums to be strictly
+// ascending.
+func orderedBytesArrayToBitmap(quorums []byte) (*big.Int, error) {
+	if len(quorums) > 256 {
+		return nil, errors.New("orderedBytesArray is too long")
+	}
+	bitmap := new(big.Int)
+	for i, quorum := range quorums {
+		if i > 0 && quorum <= quorums[i-1] {
+			return nil, errors.New("orderedBytesArray is not ordered")
+		}
+		bitmap.SetBit(bitmap, int(quorum), 1)
+	}
+	return bitmap, nil
+}
+
+// popCount returns the number of bits set in bitmap, which must not be negative.
+func popCount(bitmap *big.Int) int {
+	count := 0
+	for _, word := range bitmap.Bits() {
+		count += bits.OnesCount(uint(word))
+	}
+	return count
+}
+
+// isSubsetOf returns whether all the bits set in a are also set in b.
+func isSubsetOf(a *big.Int, b *big.Int) bool {
+	return new(big.Int).And(a, b).Cmp(a) == 0
+}
+
+// toG1Point converts a G1 point of the contract bindings, and checks that it would be accepted by the ecAdd and
+// ecMul precompiles.
+func toG1Point(point certTypesBinding.BN254G1Point) (*bn254.G1Affine, error) {
+	if point.X == nil || point.Y == nil {
+		return nil, errors.New("missing coordinate")
+	}
+	if point.X.Cmp(fp.Modulus()) >= 0 || point.Y.Cmp(fp.Modulus()) >= 0 {
+		return nil, errors.New("coordinate not in field")
+	}
+	var g1 bn254.G1Affine
+	g1.X.SetBigInt(point.X)
+	g1.Y.SetBigInt(point.Y)
+	if !g1.IsOnCurve() {
+		return nil, errors.New("point not on curve")
+	}
+	return &g1, nil
+}
+
+// toG2Point converts a G2 point of the contract bindings, and checks that it would be accepted by the pairing
+// precompile. The order of the coordinates is reversed in the bindings, see bytesToBN254G2Point.
+func toG2Point(point certTypesBinding.BN254G2Point) (*bn254.G2Affine, error) {
+	for _, coordinate := range []*big.Int{point.X[0], point.X[1], point.Y[0], point.Y[1]} {
+		if coordinate == nil {
+			return nil, errors.New("missing coordinate")
+		}
+		if coordinate.Cmp(fp.Modulus()) >= 0 {
+			return nil, errors.New("coordinate not in field")
+		}
+	}
+	var g2 bn254.G2Affine
+	g2.X.A1.SetBigInt(point.X[0])
+	g2.X.A0.SetBigInt(point.X[1])
+	g2.Y.A1.SetBigInt(point.Y[0])
+	g2.Y.A0.SetBigInt(point.Y[1])
+	if !g2.IsOnCurve() || !g2.IsInSubGroup() {
+		return nil, errors.New("point not in G2")
+	}
+	return &g2, nil
+}
+
+// mulUint32 returns a * b, and whether the result fits in a uint32.
+func mulUint32(a uint64, b uint64) (uint64, bool) {
+	product := a * b
+	return product, product <= math.MaxUint32
+}
+
+// invalidCertError is returned for checks that revert in the contract, which it maps to StatusInvalidCert.
+func invalidCertError(format string, args ...any) *CertVerifierInvalidCertError {
+	return &CertVerifierInvalidCertError{StatusCode: StatusInvalidCert, Msg: fmt.Sprintf(format, args...)}
+}
+
+// contractPanicError is returned for checks that panic in the contract, which it maps to
+// StatusContractInternalError.
+func contractPanicError(format string, args ...any) *CertVerifierInvalidCertError {
+	return &CertVerifierInvalidCertError{StatusCode: StatusContractInternalError, Msg: fmt.Sprintf(format, args...)}
+}
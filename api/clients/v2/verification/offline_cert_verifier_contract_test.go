@@ -0,0 +1,346 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	certVerifierBinding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDACertVerifier"
+	opStateRetrieverBinding "github.com/Layr-Labs/eigenda/contracts/bindings/OperatorStateRetriever"
+	"github.com/Layr-Labs/eigenda/core"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/testbed"
+	blssigner "github.com/Layr-Labs/eigensdk-go/signer/bls"
+	blssignerTypes "github.com/Layr-Labs/eigensdk-go/signer/bls/types"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// contractCertFixture is an offlineCertFixture whose operators are registered with the EigenDA contracts deployed to
+// anvil, along with the verifiers to check its certs with.
+type contractCertFixture struct {
+	*offlineCertFixture
+
+	offlineVerifier  *OfflineCertVerifier
+	contractVerifier *CertVerifier
+
+	opStateRetriever    *opStateRetrieverBinding.ContractOperatorStateRetrieverCaller
+	registryCoordinator gethcommon.Address
+}
+
+// newContractCertFixture deploys the EigenDA contracts to anvil and registers the operators of testStakes in quorums 0
+// and 1. The operator state of the fixture is read from the chain once the operators are registered.
+func newContractCertFixture(t *testing.T) *contractCertFixture {
+	t.Helper()
+
+	ctx := t.Context()
+	logger := test.GetLogger()
+	quorums := []core.QuorumID{0, 1}
+	numOperators := len(testStakes[0])
+
+	anvil, err := testbed.NewAnvilContainerWithOptions(ctx, testbed.AnvilOptions{Logger: logger})
+	require.NoError(t, err, "failed to start anvil container")
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = anvil.Terminate(ctx)
+	})
+
+	privateKeys, err := testbed.LoadPrivateKeys(testbed.LoadPrivateKeysInput{NumOperators: numOperators})
+	require.NoError(t, err, "failed to load private keys")
+	deployerKey, _ := testbed.GetAnvilDefaultKeys()
+	stakes := make([]testbed.Stakes, len(quorums))
+	for _, quorum := range quorums {
+		stakes[quorum] = testbed.Stakes{Total: 100e18}
+		for _, stake := range testStakes[quorum] {
+			stakes[quorum].Distribution = append(stakes[quorum].Distribution, float32(stake))
+		}
+	}
+	contracts, err := testbed.DeployEigenDAContracts(testbed.DeploymentConfig{
+		AnvilRPCURL:  anvil.RpcURL(),
+		DeployerKey:  deployerKey,
+		NumOperators: numOperators,
+		Stakes:       stakes,
+		PrivateKeys:  privateKeys,
+		Logger:       logger,
+	})
+	require.NoError(t, err, "failed to deploy contracts")
+
+	newWriter := func(privateKey string) (*geth.MultiHomingClient, *coreeth.Writer) {
+		ethClient, err := geth.NewMultiHomingClient(geth.EthClientConfig{
+			RPCURLs:          []string{anvil.RpcURL()},
+			PrivateKeyString: strings.TrimPrefix(privateKey, "0x"),
+		}, gethcommon.Address{}, logger)
+		require.NoError(t, err, "failed to create eth client")
+		writer, err := coreeth.NewWriter(
+			logger, ethClient, contracts.EigenDA.OperatorStateRetriever, contracts.EigenDA.ServiceManager)
+		require.NoError(t, err, "failed to create eth writer")
+		return ethClient, writer
+	}
+
+	keyPairs := make([]*core.KeyPair, numOperators)
+	for i := range keyPairs {
+		operatorName := fmt.Sprintf("opr%d", i)
+		blsKey := privateKeys.BlsMap[operatorName].PrivateKey
+		ecdsaKey := privateKeys.EcdsaMap[operatorName].PrivateKey
+
+		keyPairs[i], err = core.MakeKeyPairFromString(blsKey)
+		require.NoError(t, err)
+		signer, err := blssigner.NewSigner(blssignerTypes.SignerConfig{
+			PrivateKey: blsKey,
+			SignerType: blssignerTypes.PrivateKey,
+		})
+		require.NoError(t, err)
+		operatorKey, err := crypto.HexToECDSA(strings.TrimPrefix(ecdsaKey, "0x"))
+		require.NoError(t, err)
+
+		_, writer := newWriter(ecdsaKey)
+		salt := [32]byte{}
+		copy(salt[:], crypto.Keccak256([]byte(operatorName), []byte(time.Now().String())))
+		expiry := big.NewInt(time.Now().Add(10 * time.Minute).Unix())
+		err = writer.RegisterOperator(
+			ctx, signer, fmt.Sprintf("localhost:%d:%d", 32000+i, 32100+i), quorums, operatorKey, salt, expiry)
+		require.NoError(t, err, "failed to register operator %d", i)
+	}
+
+	ethClient, writer := newWriter(deployerKey)
+
+	// The contract only accepts certs whose reference block is in the past, so a block is mined on top of it.
+	referenceBlockNumber, err := ethClient.BlockNumber(ctx)
+	require.NoError(t, err)
+	rpcClient, err := rpc.DialContext(ctx, anvil.RpcURL())
+	require.NoError(t, err)
+	defer rpcClient.Close()
+	require.NoError(t, rpcClient.CallContext(ctx, nil, "evm_mine"))
+
+	operatorState, err := coreeth.NewChainState(writer, ethClient).GetOperatorState(
+		ctx, uint(referenceBlockNumber), quorums)
+	require.NoError(t, err)
+	indexedOperatorState := &core.IndexedOperatorState{
+		OperatorState:    operatorState,
+		IndexedOperators: make(map[core.OperatorID]*core.IndexedOperatorInfo),
+		AggKeys:          make(map[core.QuorumID]*core.G1Point),
+	}
+	for _, keyPair := range keyPairs {
+		indexedOperatorState.IndexedOperators[keyPair.GetPubKeyG1().GetOperatorID()] = &core.IndexedOperatorInfo{
+			PubkeyG1: keyPair.GetPubKeyG1(),
+			PubkeyG2: keyPair.GetPubKeyG2(),
+		}
+	}
+	for _, quorum := range quorums {
+		indexedOperatorState.AggKeys[quorum] = &core.G1Point{G1Affine: new(bn254.G1Affine)}
+		for operatorID := range indexedOperatorState.Operators[quorum] {
+			indexedOperatorState.AggKeys[quorum].Add(indexedOperatorState.IndexedOperators[operatorID].PubkeyG1)
+		}
+	}
+
+	// the offline verifier is configured with the parameters of the deployed verifier
+	certVerifierAddress := gethcommon.HexToAddress(contracts.EigenDA.CertVerifier)
+	certVerifierCaller, err := certVerifierBinding.NewContractEigenDACertVerifierCaller(certVerifierAddress, ethClient)
+	require.NoError(t, err)
+	securityThresholds, err := certVerifierCaller.SecurityThresholds(&bind.CallOpts{Context: ctx})
+	require.NoError(t, err)
+	quorumNumbersRequired, err := certVerifierCaller.QuorumNumbersRequired(&bind.CallOpts{Context: ctx})
+	require.NoError(t, err)
+	offchainDerivationVersion, err := certVerifierCaller.OffchainDerivationVersion(&bind.CallOpts{Context: ctx})
+	require.NoError(t, err)
+	blobVersionParameters, err := writer.GetAllVersionedBlobParams(ctx)
+	require.NoError(t, err)
+
+	offlineVerifier, err := NewOfflineCertVerifier(OfflineCertVerifierConfig{
+		ConfirmationThreshold:     securityThresholds.ConfirmationThreshold,
+		AdversaryThreshold:        securityThresholds.AdversaryThreshold,
+		QuorumNumbersRequired:     quorumNumbersRequired,
+		OffchainDerivationVersion: offchainDerivationVersion,
+		BlobVersionParameters:     blobVersionParameters,
+	})
+	require.NoError(t, err)
+	contractVerifier, err := NewCertVerifier(
+		logger, ethClient, NewStaticCertVerifierAddressProvider(certVerifierAddress))
+	require.NoError(t, err)
+	opStateRetriever, err := opStateRetrieverBinding.NewContractOperatorStateRetrieverCaller(
+		gethcommon.HexToAddress(contracts.EigenDA.OperatorStateRetriever), ethClient)
+	require.NoError(t, err)
+
+	operatorIDs := make([][32]byte, len(keyPairs))
+	for i, keyPair := range keyPairs {
+		operatorIDs[i] = keyPair.GetPubKeyG1().GetOperatorID()
+	}
+	bitmaps, err := opStateRetriever.GetQuorumBitmapsAtBlockNumber(&bind.CallOpts{Context: ctx},
+		writer.GetRegistryCoordinatorAddress(), operatorIDs, uint32(referenceBlockNumber))
+	require.NoError(t, err)
+	state := &OperatorStateSnapshot{
+		IndexedOperatorState: indexedOperatorState,
+		QuorumBitmaps:        make(map[core.OperatorID]*big.Int),
+	}
+	for i, operatorID := range operatorIDs {
+		state.QuorumBitmaps[operatorID] = bitmaps[i]
+	}
+
+	return &contractCertFixture{
+		offlineCertFixture:  &offlineCertFixture{state: state, keyPairs: keyPairs},
+		offlineVerifier:     offlineVerifier,
+		contractVerifier:    contractVerifier,
+		opStateRetriever:    opStateRetriever,
+		registryCoordinator: writer.GetRegistryCoordinatorAddress(),
+	}
+}
+
+// newCert builds a cert like offlineCertFixture.newCert, with the offchain derivation version of the deployed verifier
+// and the stake and APK history indices that the contract needs to look up the operator state.
+func (f *contractCertFixture) newCert(t *testing.T, nonSigners ...int) *coretypes.EigenDACertV4 {
+	t.Helper()
+
+	cert := f.offlineCertFixture.newCert(t, nonSigners...)
+	cert.OffchainDerivationVersion = f.offlineVerifier.config.OffchainDerivationVersion
+
+	params := &cert.NonSignerStakesAndSignature
+	nonSignerIDs := make([][32]byte, len(params.NonSignerPubkeys))
+	for i, pubkey := range params.NonSignerPubkeys {
+		nonSignerIDs[i] = operatorIDOf(pubkey)
+	}
+	indices, err := f.opStateRetriever.GetCheckSignaturesIndices(&bind.CallOpts{Context: t.Context()},
+		f.registryCoordinator, cert.BatchHeader.ReferenceBlockNumber, cert.SignedQuorumNumbers, nonSignerIDs)
+	require.NoError(t, err)
+	params.NonSignerQuorumBitmapIndices = indices.NonSignerQuorumBitmapIndices
+	params.QuorumApkIndices = indices.QuorumApkIndices
+	params.TotalStakeIndices = indices.TotalStakeIndices
+	params.NonSignerStakeIndices = indices.NonSignerStakeIndices
+	return cert
+}
+
+// statusCode returns the status code of the result of a CheckDACert call.
+func statusCode(t *testing.T, err error) CheckDACertStatusCode {
+	t.Helper()
+
+	if err == nil {
+		return StatusSuccess
+	}
+	var invalidCertErr *CertVerifierInvalidCertError
+	if !errors.As(err, &invalidCertErr) {
+		require.FailNow(t, "unexpected error", err.Error())
+	}
+	return invalidCertErr.StatusCode
+}
+
+// TestOfflineCertVerifierMatchesContract checks the same certs with the OfflineCertVerifier and the EigenDACertVerifier
+// contract, and expects the same status codes.
+func TestOfflineCertVerifierMatchesContract(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping contract test in short mode")
+	}
+
+	f := newContractCertFixture(t)
+
+	testCases := []struct {
+		name     string
+		cert     func(t *testing.T) *coretypes.EigenDACertV4
+		expected CheckDACertStatusCode
+	}{
+		{
+			name:     "valid cert",
+			cert:     func(t *testing.T) *coretypes.EigenDACertV4 { return f.newCert(t, 0) },
+			expected: StatusSuccess,
+		},
+		{
+			name:     "valid cert without non-signers",
+			cert:     func(t *testing.T) *coretypes.EigenDACertV4 { return f.newCert(t) },
+			expected: StatusSuccess,
+		},
+		{
+			name: "calldata too large",
+			cert: func(t *testing.T) *coretypes.EigenDACertV4 {
+				cert := f.newCert(t)
+				cert.BlobInclusionInfo.InclusionProof = make([]byte, maxCalldataBytesLength)
+				return cert
+			},
+			expected: StatusInvalidCert,
+		},
+		{
+			name: "invalid inclusion proof",
+			cert: func(t *testing.T) *coretypes.EigenDACertV4 {
+				cert := f.newCert(t)
+				cert.BlobInclusionInfo.InclusionProof = crypto.Keccak256([]byte("inclusion proof"))
+				return cert
+			},
+			expected: StatusInvalidCert,
+		},
+		{
+			name: "invalid offchain derivation version",
+			cert: func(t *testing.T) *coretypes.EigenDACertV4 {
+				cert := f.newCert(t)
+				cert.OffchainDerivationVersion++
+				return cert
+			},
+			expected: StatusInvalidCert,
+		},
+		{
+			name: "signature over another batch",
+			cert: func(t *testing.T) *coretypes.EigenDACertV4 {
+				cert := f.newCert(t)
+				cert.NonSignerStakesAndSignature.Sigma = f.newCert(t).NonSignerStakesAndSignature.Sigma
+				return cert
+			},
+			expected: StatusInvalidCert,
+		},
+		{
+			name: "quorum apk mismatch",
+			cert: func(t *testing.T) *coretypes.EigenDACertV4 {
+				cert := f.newCert(t)
+				cert.NonSignerStakesAndSignature.QuorumApks[1] = toG1Binding(f.keyPairs[0].GetPubKeyG1().G1Affine)
+				return cert
+			},
+			expected: StatusInvalidCert,
+		},
+		{
+			name: "non-signers not sorted",
+			cert: func(t *testing.T) *coretypes.EigenDACertV4 {
+				cert := f.newCert(t, 2, 3)
+				pubkeys := cert.NonSignerStakesAndSignature.NonSignerPubkeys
+				pubkeys[0], pubkeys[1] = pubkeys[1], pubkeys[0]
+				return cert
+			},
+			expected: StatusInvalidCert,
+		},
+		{
+			name: "non-signer never registered",
+			cert: func(t *testing.T) *coretypes.EigenDACertV4 {
+				cert := f.newCert(t, 0)
+				unregistered, err := core.GenRandomBlsKeys()
+				require.NoError(t, err)
+				cert.NonSignerStakesAndSignature.NonSignerPubkeys[0] =
+					toG1Binding(unregistered.GetPubKeyG1().G1Affine)
+				return cert
+			},
+			expected: StatusInvalidCert,
+		},
+		{
+			name: "blob quorums not confirmed",
+			// operator 3 holds most of the stake of quorum 1, which isn't confirmed without it
+			cert:     func(t *testing.T) *coretypes.EigenDACertV4 { return f.newCert(t, 3) },
+			expected: StatusInvalidCert,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cert := testCase.cert(t)
+
+			contractStatus := statusCode(t, f.contractVerifier.CheckDACert(t.Context(), cert))
+			offlineStatus := statusCode(t, f.offlineVerifier.CheckDACert(cert, f.state))
+			require.Equal(t, contractStatus, offlineStatus)
+			require.Equal(t, testCase.expected, contractStatus)
+		})
+	}
+}
@@ -0,0 +1,420 @@
+package verification
+
+import (
+	"bytes"
+	"maps"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	disperserv2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	certTypesBinding "github.com/Layr-Labs/eigenda/contracts/bindings/IEigenDACertTypeBindings"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const testReferenceBlockNumber = 100
+
+// testStakes are the stakes of the test operators in quorums 0 and 1.
+var testStakes = map[core.QuorumID][]int64{
+	0: {4, 3, 2, 1},
+	1: {1, 2, 3, 10},
+}
+
+// defaultOfflineCertVerifierConfig mirrors the parameters that MockEigenDADeployer.sol deploys the verifier with.
+func defaultOfflineCertVerifierConfig() OfflineCertVerifierConfig {
+	return OfflineCertVerifierConfig{
+		ConfirmationThreshold: 55,
+		AdversaryThreshold:    33,
+		QuorumNumbersRequired: []core.QuorumID{0},
+		BlobVersionParameters: map[corev2.BlobVersion]*core.BlobVersionParameters{
+			0: {CodingRate: 8, MaxNumOperators: 3537, NumChunks: 8192},
+		},
+	}
+}
+
+// offlineCertFixture holds the operator state of testStakes at testReferenceBlockNumber, and the key pairs of the
+// operators.
+type offlineCertFixture struct {
+	state    *OperatorStateSnapshot
+	keyPairs []*core.KeyPair
+}
+
+func newOfflineCertFixture(t *testing.T) *offlineCertFixture {
+	keyPairs := make([]*core.KeyPair, len(testStakes[0]))
+	indexedOperators := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	quorumBitmaps := make(map[core.OperatorID]*big.Int)
+	for i := range keyPairs {
+		keyPair, err := core.GenRandomBlsKeys()
+		require.NoError(t, err)
+		keyPairs[i] = keyPair
+		indexedOperators[keyPair.GetPubKeyG1().GetOperatorID()] = &core.IndexedOperatorInfo{
+			PubkeyG1: keyPair.GetPubKeyG1(),
+			PubkeyG2: keyPair.GetPubKeyG2(),
+		}
+		// all operators are registered in quorums 0 and 1
+		quorumBitmaps[keyPair.GetPubKeyG1().GetOperatorID()] = big.NewInt(0b11)
+	}
+
+	state := &core.IndexedOperatorState{
+		OperatorState: &core.OperatorState{
+			Operators:   make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo),
+			Totals:      make(map[core.QuorumID]*core.OperatorInfo),
+			BlockNumber: testReferenceBlockNumber,
+		},
+		IndexedOperators: indexedOperators,
+		AggKeys:          make(map[core.QuorumID]*core.G1Point),
+	}
+	for quorum, stakes := range testStakes {
+		state.Operators[quorum] = make(map[core.OperatorID]*core.OperatorInfo)
+		totalStake := new(big.Int)
+		aggKey := &core.G1Point{G1Affine: new(bn254.G1Affine)}
+		for i, stake := range stakes {
+			state.Operators[quorum][keyPairs[i].GetPubKeyG1().GetOperatorID()] = &core.OperatorInfo{
+				Stake: big.NewInt(stake),
+				Index: core.OperatorIndex(i),
+			}
+			totalStake.Add(totalStake, big.NewInt(stake))
+			aggKey.Add(keyPairs[i].GetPubKeyG1())
+		}
+		state.Totals[quorum] = &core.OperatorInfo{Stake: totalStake, Index: core.OperatorIndex(len(stakes))}
+		state.AggKeys[quorum] = aggKey
+	}
+	return &offlineCertFixture{
+		state:    &OperatorStateSnapshot{IndexedOperatorState: state, QuorumBitmaps: quorumBitmaps},
+		keyPairs: keyPairs,
+	}
+}
+
+// newCert builds a V4 cert of a blob of a mock batch at the block of the operator state, signed by all operators of
+// quorums 0 and 1 but nonSigners, which are indices into keyPairs.
+func (f *offlineCertFixture) newCert(t *testing.T, nonSigners ...int) *coretypes.EigenDACertV4 {
+	_, batch, _ := nodemock.MockBatch(t)
+	batch.BatchHeader.ReferenceBlockNumber = uint64(f.state.BlockNumber)
+	tree, err := corev2.BuildMerkleTree(batch.BlobCertificates)
+	require.NoError(t, err)
+	copy(batch.BatchHeader.BatchRoot[:], tree.Root())
+
+	// the second blob is dispersed to quorums 0 and 1
+	blobIndex := uint32(1)
+	proof, err := tree.GenerateProofWithIndex(uint64(blobIndex), 0)
+	require.NoError(t, err)
+	blobCertificate, err := batch.BlobCertificates[blobIndex].ToProtobuf()
+	require.NoError(t, err)
+	inclusionInfo, err := coretypes.InclusionInfoProtoToIEigenDATypesBinding(&disperserv2.BlobInclusionInfo{
+		BlobCertificate: blobCertificate,
+		BlobIndex:       blobIndex,
+		InclusionProof:  core.SerializeMerkleProof(proof),
+	})
+	require.NoError(t, err)
+
+	batchHeaderHash, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+
+	// signatures and keys are aggregated once for each quorum an operator signs for
+	signedQuorums := []core.QuorumID{0, 1}
+	sigma := new(bn254.G1Affine)
+	apkG2 := new(bn254.G2Affine)
+	for i, keyPair := range f.keyPairs {
+		if slices.Contains(nonSigners, i) {
+			continue
+		}
+		for range signedQuorums {
+			sigma.Add(sigma, keyPair.SignMessage(batchHeaderHash).G1Affine)
+			apkG2.Add(apkG2, keyPair.GetPubKeyG2().G2Affine)
+		}
+	}
+
+	nonSignerKeys := make([]*core.G1Point, 0, len(nonSigners))
+	for _, i := range nonSigners {
+		nonSignerKeys = append(nonSignerKeys, f.keyPairs[i].GetPubKeyG1())
+	}
+	slices.SortFunc(nonSignerKeys, func(a *core.G1Point, b *core.G1Point) int {
+		aID, bID := a.GetOperatorID(), b.GetOperatorID()
+		return bytes.Compare(aID[:], bID[:])
+	})
+
+	nonSignerStakesAndSignature := certTypesBinding.EigenDATypesV1NonSignerStakesAndSignature{
+		NonSignerQuorumBitmapIndices: make([]uint32, len(nonSigners)),
+		ApkG2:                        toG2Binding(apkG2),
+		Sigma:                        toG1Binding(sigma),
+		QuorumApkIndices:             make([]uint32, len(signedQuorums)),
+		TotalStakeIndices:            make([]uint32, len(signedQuorums)),
+	}
+	for _, key := range nonSignerKeys {
+		nonSignerStakesAndSignature.NonSignerPubkeys = append(nonSignerStakesAndSignature.NonSignerPubkeys,
+			toG1Binding(key.G1Affine))
+	}
+	for _, quorum := range signedQuorums {
+		nonSignerStakesAndSignature.QuorumApks = append(nonSignerStakesAndSignature.QuorumApks,
+			toG1Binding(f.state.AggKeys[quorum].G1Affine))
+		nonSignerStakesAndSignature.NonSignerStakeIndices = append(nonSignerStakesAndSignature.NonSignerStakeIndices,
+			make([]uint32, len(nonSigners)))
+	}
+
+	return &coretypes.EigenDACertV4{
+		BatchHeader: certTypesBinding.EigenDATypesV2BatchHeaderV2{
+			BatchRoot:            batch.BatchHeader.BatchRoot,
+			ReferenceBlockNumber: uint32(batch.BatchHeader.ReferenceBlockNumber),
+		},
+		BlobInclusionInfo:           *inclusionInfo,
+		NonSignerStakesAndSignature: nonSignerStakesAndSignature,
+		SignedQuorumNumbers:         signedQuorums,
+	}
+}
+
+// operatorIDOf returns the ID of the operator with the given public key, as computed by the contract.
+func operatorIDOf(pubkey certTypesBinding.BN254G1Point) core.OperatorID {
+	return core.OperatorID(crypto.Keccak256Hash(
+		pubkey.X.FillBytes(make([]byte, 32)), pubkey.Y.FillBytes(make([]byte, 32))))
+}
+
+func toG1Binding(point *bn254.G1Affine) certTypesBinding.BN254G1Point {
+	return certTypesBinding.BN254G1Point{X: point.X.BigInt(new(big.Int)), Y: point.Y.BigInt(new(big.Int))}
+}
+
+func toG2Binding(point *bn254.G2Affine) certTypesBinding.BN254G2Point {
+	return certTypesBinding.BN254G2Point{
+		X: [2]*big.Int{point.X.A1.BigInt(new(big.Int)), point.X.A0.BigInt(new(big.Int))},
+		Y: [2]*big.Int{point.Y.A1.BigInt(new(big.Int)), point.Y.A0.BigInt(new(big.Int))},
+	}
+}
+
+// requireStatusCode checks that err is a CertVerifierInvalidCertError with the given status code.
+func requireStatusCode(t *testing.T, expected CheckDACertStatusCode, err error) {
+	var invalidCertErr *CertVerifierInvalidCertError
+	require.ErrorAs(t, err, &invalidCertErr)
+	require.Equal(t, expected, invalidCertErr.StatusCode, invalidCertErr.Msg)
+}
+
+// The test cases below mirror the ones of contracts/test/unit/EigenDACertVerifierV2Unit.t.sol, and expect the same
+// status codes as the contract.
+func TestOfflineCertVerifier(t *testing.T) {
+	f := newOfflineCertFixture(t)
+
+	check := func(t *testing.T, config OfflineCertVerifierConfig, cert coretypes.EigenDACert) error {
+		verifier, err := NewOfflineCertVerifier(config)
+		require.NoError(t, err)
+		return verifier.CheckDACert(cert, f.state)
+	}
+
+	t.Run("verifyDACert", func(t *testing.T) {
+		cert := f.newCert(t, 0)
+		require.NoError(t, check(t, defaultOfflineCertVerifierConfig(), cert))
+
+		certV3 := &coretypes.EigenDACertV3{
+			BatchHeader:                 cert.BatchHeader,
+			BlobInclusionInfo:           cert.BlobInclusionInfo,
+			NonSignerStakesAndSignature: cert.NonSignerStakesAndSignature,
+			SignedQuorumNumbers:         cert.SignedQuorumNumbers,
+		}
+		require.NoError(t, check(t, defaultOfflineCertVerifierConfig(), certV3))
+	})
+
+	t.Run("verifyDACert without non-signers", func(t *testing.T) {
+		require.NoError(t, check(t, defaultOfflineCertVerifierConfig(), f.newCert(t)))
+	})
+
+	t.Run("revert calldata size", func(t *testing.T) {
+		cert := f.newCert(t)
+		cert.BlobInclusionInfo.InclusionProof = make([]byte, maxCalldataBytesLength)
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("revert exceeding maximal quorum count", func(t *testing.T) {
+		cert := f.newCert(t)
+		cert.SignedQuorumNumbers = make([]byte, 6)
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("revert exceeding maximal non signers across all quorums", func(t *testing.T) {
+		cert := f.newCert(t)
+		cert.NonSignerStakesAndSignature.NonSignerStakeIndices = [][]uint32{make([]uint32, 208), make([]uint32, 208)}
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("revert InclusionProofInvalid", func(t *testing.T) {
+		cert := f.newCert(t)
+		cert.BlobInclusionInfo.InclusionProof = crypto.Keccak256([]byte("inclusion proof"))
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+
+		cert = f.newCert(t)
+		cert.BatchHeader.BatchRoot = [32]byte{0x1, 0x2, 0x3, 0x4}
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("revert OffchainDerivationVersionInvalid", func(t *testing.T) {
+		cert := f.newCert(t)
+		cert.OffchainDerivationVersion++
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("revert InvalidBlobVersion", func(t *testing.T) {
+		cert := f.newCert(t)
+		config := defaultOfflineCertVerifierConfig()
+		delete(config.BlobVersionParameters, 0)
+		requireStatusCode(t, StatusInvalidCert, check(t, config, cert))
+	})
+
+	t.Run("revert SecurityAssumptionsNotMet", func(t *testing.T) {
+		cert := f.newCert(t)
+
+		// maxNumOperators > numChunks
+		config := defaultOfflineCertVerifierConfig()
+		config.BlobVersionParameters[0] = &core.BlobVersionParameters{CodingRate: 8, MaxNumOperators: 100, NumChunks: 50}
+		requireStatusCode(t, StatusInvalidCert, check(t, config, cert))
+
+		// 2 * (16 - 3) * (55 - 33) < 100 * 16
+		config.BlobVersionParameters[0] = &core.BlobVersionParameters{CodingRate: 2, MaxNumOperators: 3, NumChunks: 16}
+		requireStatusCode(t, StatusInvalidCert, check(t, config, cert))
+	})
+
+	t.Run("panic on security condition overflow", func(t *testing.T) {
+		cert := f.newCert(t)
+		config := defaultOfflineCertVerifierConfig()
+		config.BlobVersionParameters[0] = &core.BlobVersionParameters{
+			CodingRate: 255, MaxNumOperators: 0, NumChunks: 1 << 30}
+		requireStatusCode(t, StatusContractInternalError, check(t, config, cert))
+	})
+
+	t.Run("revert invalid signature", func(t *testing.T) {
+		// operator 0 didn't sign, but is not listed as a non-signer
+		cert := f.newCert(t, 0)
+		sigma := cert.NonSignerStakesAndSignature.Sigma
+		apkG2 := cert.NonSignerStakesAndSignature.ApkG2
+		cert.NonSignerStakesAndSignature = f.newCert(t).NonSignerStakesAndSignature
+		cert.NonSignerStakesAndSignature.Sigma = sigma
+		cert.NonSignerStakesAndSignature.ApkG2 = apkG2
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+
+		// the signature is over another batch
+		cert = f.newCert(t)
+		otherCert := f.newCert(t)
+		cert.NonSignerStakesAndSignature.Sigma = otherCert.NonSignerStakesAndSignature.Sigma
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+
+		// sigma is not on the curve
+		cert = f.newCert(t)
+		cert.NonSignerStakesAndSignature.Sigma.Y = new(big.Int).Add(cert.NonSignerStakesAndSignature.Sigma.Y,
+			big.NewInt(1))
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("revert quorumApk hash mismatch", func(t *testing.T) {
+		cert := f.newCert(t)
+		cert.NonSignerStakesAndSignature.QuorumApks[1] = toG1Binding(f.keyPairs[0].GetPubKeyG1().G1Affine)
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("revert nonSignerPubkeys not sorted", func(t *testing.T) {
+		cert := f.newCert(t, 2, 3)
+		pubkeys := cert.NonSignerStakesAndSignature.NonSignerPubkeys
+		pubkeys[0], pubkeys[1] = pubkeys[1], pubkeys[0]
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("revert non-signer never registered", func(t *testing.T) {
+		cert := f.newCert(t, 0)
+		unregistered, err := core.GenRandomBlsKeys()
+		require.NoError(t, err)
+		cert.NonSignerStakesAndSignature.NonSignerPubkeys[0] = toG1Binding(unregistered.GetPubKeyG1().G1Affine)
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("non-signer not registered in any signed quorum", func(t *testing.T) {
+		// The contract ignores non-signers that are only registered in other quorums, or that deregistered from all
+		// quorums before the reference block.
+		other, err := core.GenRandomBlsKeys()
+		require.NoError(t, err)
+		otherID := other.GetPubKeyG1().GetOperatorID()
+
+		cert := f.newCert(t, 0)
+		params := &cert.NonSignerStakesAndSignature
+		params.NonSignerPubkeys = append(params.NonSignerPubkeys, toG1Binding(other.GetPubKeyG1().G1Affine))
+		params.NonSignerQuorumBitmapIndices = append(params.NonSignerQuorumBitmapIndices, 0)
+		slices.SortFunc(params.NonSignerPubkeys,
+			func(a certTypesBinding.BN254G1Point, b certTypesBinding.BN254G1Point) int {
+				aID, bID := operatorIDOf(a), operatorIDOf(b)
+				return bytes.Compare(aID[:], bID[:])
+			})
+
+		verifier, err := NewOfflineCertVerifier(defaultOfflineCertVerifierConfig())
+		require.NoError(t, err)
+		for _, quorumBitmap := range []*big.Int{big.NewInt(1 << 2), big.NewInt(0)} {
+			state := *f.state
+			state.QuorumBitmaps = maps.Clone(f.state.QuorumBitmaps)
+			state.QuorumBitmaps[otherID] = quorumBitmap
+			require.NoError(t, verifier.CheckDACert(cert, &state))
+		}
+	})
+
+	t.Run("operator state missing a registered non-signer", func(t *testing.T) {
+		// the quorum bitmap of the non-signer says that it is registered in quorum 1, but its stake is missing
+		cert := f.newCert(t, 0)
+		nonSignerID := operatorIDOf(cert.NonSignerStakesAndSignature.NonSignerPubkeys[0])
+		operatorState := *f.state.OperatorState
+		operatorState.Operators = maps.Clone(f.state.Operators)
+		operatorState.Operators[1] = maps.Clone(f.state.Operators[1])
+		delete(operatorState.Operators[1], nonSignerID)
+		indexedOperatorState := *f.state.IndexedOperatorState
+		indexedOperatorState.OperatorState = &operatorState
+
+		verifier, err := NewOfflineCertVerifier(defaultOfflineCertVerifierConfig())
+		require.NoError(t, err)
+		err = verifier.CheckDACert(cert, &OperatorStateSnapshot{
+			IndexedOperatorState: &indexedOperatorState,
+			QuorumBitmaps:        f.state.QuorumBitmaps,
+		})
+		var internalErr *CertVerifierInternalError
+		require.ErrorAs(t, err, &internalErr)
+	})
+
+	t.Run("revert signed quorums not ordered", func(t *testing.T) {
+		cert := f.newCert(t)
+		cert.SignedQuorumNumbers = []byte{1, 0}
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("revert BlobQuorumsNotSubset", func(t *testing.T) {
+		// operator 3 holds most of the stake of quorum 1, which isn't confirmed without it
+		cert := f.newCert(t, 3)
+		requireStatusCode(t, StatusInvalidCert, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("revert RequiredQuorumsNotSubset", func(t *testing.T) {
+		cert := f.newCert(t)
+		config := defaultOfflineCertVerifierConfig()
+		config.QuorumNumbersRequired = []core.QuorumID{0, 2}
+		requireStatusCode(t, StatusInvalidCert, check(t, config, cert))
+	})
+
+	t.Run("panic on missing non-signer stake index", func(t *testing.T) {
+		cert := f.newCert(t, 0)
+		cert.NonSignerStakesAndSignature.NonSignerStakeIndices[1] = nil
+		requireStatusCode(t, StatusContractInternalError, check(t, defaultOfflineCertVerifierConfig(), cert))
+	})
+
+	t.Run("operator state at another block", func(t *testing.T) {
+		cert := f.newCert(t)
+		cert.BatchHeader.ReferenceBlockNumber++
+		var internalErr *CertVerifierInternalError
+		require.ErrorAs(t, check(t, defaultOfflineCertVerifierConfig(), cert), &internalErr)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		config := defaultOfflineCertVerifierConfig()
+		config.AdversaryThreshold = config.ConfirmationThreshold
+		_, err := NewOfflineCertVerifier(config)
+		require.Error(t, err)
+
+		config = defaultOfflineCertVerifierConfig()
+		config.QuorumNumbersRequired = nil
+		_, err = NewOfflineCertVerifier(config)
+		require.Error(t, err)
+	})
+}
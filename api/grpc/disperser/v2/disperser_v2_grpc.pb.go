@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	Disperser_DisperseBlob_FullMethodName            = "/disperser.v2.Disperser/DisperseBlob"
 	Disperser_GetBlobStatus_FullMethodName           = "/disperser.v2.Disperser/GetBlobStatus"
+	Disperser_SubscribeBlobStatus_FullMethodName     = "/disperser.v2.Disperser/SubscribeBlobStatus"
 	Disperser_GetBlobCommitment_FullMethodName       = "/disperser.v2.Disperser/GetBlobCommitment"
 	Disperser_GetPaymentState_FullMethodName         = "/disperser.v2.Disperser/GetPaymentState"
 	Disperser_GetValidatorSigningRate_FullMethodName = "/disperser.v2.Disperser/GetValidatorSigningRate"
@@ -37,6 +38,16 @@ type DisperserClient interface {
 	DisperseBlob(ctx context.Context, in *DisperseBlobRequest, opts ...grpc.CallOption) (*DisperseBlobReply, error)
 	// GetBlobStatus is meant to be polled for the blob status.
 	GetBlobStatus(ctx context.Context, in *BlobStatusRequest, opts ...grpc.CallOption) (*BlobStatusReply, error)
+	// SubscribeBlobStatus is a streaming alternative to polling GetBlobStatus.
+	// The disperser immediately sends the current status of the blob, and then sends a new BlobStatusReply every time
+	// the status of the blob changes. While the blob is GATHERING_SIGNATURES, a new reply is also sent every time
+	// the attestation is updated with more signatures. The stream is closed by the disperser once a terminal status
+	// has been sent. Clients that don't need to wait for the COMPLETE status may close the stream earlier, e.g. once
+	// the attestation meets their confirmation thresholds.
+	//
+	// Dispersers that don't support this method return an UNIMPLEMENTED error, in which case clients
+	// should fall back to polling GetBlobStatus.
+	SubscribeBlobStatus(ctx context.Context, in *BlobStatusRequest, opts ...grpc.CallOption) (Disperser_SubscribeBlobStatusClient, error)
 	// GetBlobCommitment is a utility method that calculates commitment for a blob payload.
 	// It is provided to help clients who are trying to construct a DisperseBlobRequest.blob_header
 	// and don't have the ability to calculate the commitment themselves (expensive operation which requires SRS points).
@@ -82,6 +93,38 @@ func (c *disperserClient) GetBlobStatus(ctx context.Context, in *BlobStatusReque
 	return out, nil
 }
 
+func (c *disperserClient) SubscribeBlobStatus(ctx context.Context, in *BlobStatusRequest, opts ...grpc.CallOption) (Disperser_SubscribeBlobStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[0], Disperser_SubscribeBlobStatus_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserSubscribeBlobStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Disperser_SubscribeBlobStatusClient interface {
+	Recv() (*BlobStatusReply, error)
+	grpc.ClientStream
+}
+
+type disperserSubscribeBlobStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserSubscribeBlobStatusClient) Recv() (*BlobStatusReply, error) {
+	m := new(BlobStatusReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *disperserClient) GetBlobCommitment(ctx context.Context, in *BlobCommitmentRequest, opts ...grpc.CallOption) (*BlobCommitmentReply, error) {
 	out := new(BlobCommitmentReply)
 	err := c.cc.Invoke(ctx, Disperser_GetBlobCommitment_FullMethodName, in, out, opts...)
@@ -120,6 +163,16 @@ type DisperserServer interface {
 	DisperseBlob(context.Context, *DisperseBlobRequest) (*DisperseBlobReply, error)
 	// GetBlobStatus is meant to be polled for the blob status.
 	GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error)
+	// SubscribeBlobStatus is a streaming alternative to polling GetBlobStatus.
+	// The disperser immediately sends the current status of the blob, and then sends a new BlobStatusReply every time
+	// the status of the blob changes. While the blob is GATHERING_SIGNATURES, a new reply is also sent every time
+	// the attestation is updated with more signatures. The stream is closed by the disperser once a terminal status
+	// has been sent. Clients that don't need to wait for the COMPLETE status may close the stream earlier, e.g. once
+	// the attestation meets their confirmation thresholds.
+	//
+	// Dispersers that don't support this method return an UNIMPLEMENTED error, in which case clients
+	// should fall back to polling GetBlobStatus.
+	SubscribeBlobStatus(*BlobStatusRequest, Disperser_SubscribeBlobStatusServer) error
 	// GetBlobCommitment is a utility method that calculates commitment for a blob payload.
 	// It is provided to help clients who are trying to construct a DisperseBlobRequest.blob_header
 	// and don't have the ability to calculate the commitment themselves (expensive operation which requires SRS points).
@@ -150,6 +203,9 @@ func (UnimplementedDisperserServer) DisperseBlob(context.Context, *DisperseBlobR
 func (UnimplementedDisperserServer) GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobStatus not implemented")
 }
+func (UnimplementedDisperserServer) SubscribeBlobStatus(*BlobStatusRequest, Disperser_SubscribeBlobStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlobStatus not implemented")
+}
 func (UnimplementedDisperserServer) GetBlobCommitment(context.Context, *BlobCommitmentRequest) (*BlobCommitmentReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobCommitment not implemented")
 }
@@ -208,6 +264,27 @@ func _Disperser_GetBlobStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_SubscribeBlobStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlobStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DisperserServer).SubscribeBlobStatus(m, &disperserSubscribeBlobStatusServer{stream})
+}
+
+type Disperser_SubscribeBlobStatusServer interface {
+	Send(*BlobStatusReply) error
+	grpc.ServerStream
+}
+
+type disperserSubscribeBlobStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserSubscribeBlobStatusServer) Send(m *BlobStatusReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Disperser_GetBlobCommitment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlobCommitmentRequest)
 	if err := dec(in); err != nil {
@@ -290,6 +367,12 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Disperser_GetValidatorSigningRate_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlobStatus",
+			Handler:       _Disperser_SubscribeBlobStatus_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "disperser/v2/disperser_v2.proto",
 }
@@ -0,0 +1,269 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.23.4
+// source: proxy/proxy.proto
+
+package proxy
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Proxy_Put_FullMethodName                    = "/proxy.Proxy/Put"
+	Proxy_PutStream_FullMethodName              = "/proxy.Proxy/PutStream"
+	Proxy_Get_FullMethodName                    = "/proxy.Proxy/Get"
+	Proxy_GetCompatibilityConfig_FullMethodName = "/proxy.Proxy/GetCompatibilityConfig"
+)
+
+// ProxyClient is the client API for Proxy service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProxyClient interface {
+	// Put disperses a payload, and returns the commitment to it.
+	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutReply, error)
+	// PutStream disperses a payload sent as a stream of chunks, and returns the commitment to it.
+	// It is meant for payloads larger than the max gRPC message size of the client.
+	// The payload is only dispersed once the client closes the stream.
+	PutStream(ctx context.Context, opts ...grpc.CallOption) (Proxy_PutStreamClient, error)
+	// Get retrieves the payload committed to by a commitment.
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetReply, error)
+	// GetCompatibilityConfig returns values that clients can use to check their compatibility with the proxy.
+	// It returns the same values as the REST server's /config route.
+	GetCompatibilityConfig(ctx context.Context, in *GetCompatibilityConfigRequest, opts ...grpc.CallOption) (*GetCompatibilityConfigReply, error)
+}
+
+type proxyClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProxyClient(cc grpc.ClientConnInterface) ProxyClient {
+	return &proxyClient{cc}
+}
+
+func (c *proxyClient) Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutReply, error) {
+	out := new(PutReply)
+	err := c.cc.Invoke(ctx, Proxy_Put_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) PutStream(ctx context.Context, opts ...grpc.CallOption) (Proxy_PutStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Proxy_ServiceDesc.Streams[0], Proxy_PutStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &proxyPutStreamClient{stream}
+	return x, nil
+}
+
+type Proxy_PutStreamClient interface {
+	Send(*PutStreamRequest) error
+	CloseAndRecv() (*PutReply, error)
+	grpc.ClientStream
+}
+
+type proxyPutStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *proxyPutStreamClient) Send(m *PutStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *proxyPutStreamClient) CloseAndRecv() (*PutReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(PutReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *proxyClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetReply, error) {
+	out := new(GetReply)
+	err := c.cc.Invoke(ctx, Proxy_Get_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) GetCompatibilityConfig(ctx context.Context, in *GetCompatibilityConfigRequest, opts ...grpc.CallOption) (*GetCompatibilityConfigReply, error) {
+	out := new(GetCompatibilityConfigReply)
+	err := c.cc.Invoke(ctx, Proxy_GetCompatibilityConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProxyServer is the server API for Proxy service.
+// All implementations must embed UnimplementedProxyServer
+// for forward compatibility
+type ProxyServer interface {
+	// Put disperses a payload, and returns the commitment to it.
+	Put(context.Context, *PutRequest) (*PutReply, error)
+	// PutStream disperses a payload sent as a stream of chunks, and returns the commitment to it.
+	// It is meant for payloads larger than the max gRPC message size of the client.
+	// The payload is only dispersed once the client closes the stream.
+	PutStream(Proxy_PutStreamServer) error
+	// Get retrieves the payload committed to by a commitment.
+	Get(context.Context, *GetRequest) (*GetReply, error)
+	// GetCompatibilityConfig returns values that clients can use to check their compatibility with the proxy.
+	// It returns the same values as the REST server's /config route.
+	GetCompatibilityConfig(context.Context, *GetCompatibilityConfigRequest) (*GetCompatibilityConfigReply, error)
+	mustEmbedUnimplementedProxyServer()
+}
+
+// UnimplementedProxyServer must be embedded to have forward compatible implementations.
+type UnimplementedProxyServer struct {
+}
+
+func (UnimplementedProxyServer) Put(context.Context, *PutRequest) (*PutReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Put not implemented")
+}
+func (UnimplementedProxyServer) PutStream(Proxy_PutStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method PutStream not implemented")
+}
+func (UnimplementedProxyServer) Get(context.Context, *GetRequest) (*GetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedProxyServer) GetCompatibilityConfig(context.Context, *GetCompatibilityConfigRequest) (*GetCompatibilityConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompatibilityConfig not implemented")
+}
+func (UnimplementedProxyServer) mustEmbedUnimplementedProxyServer() {}
+
+// UnsafeProxyServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProxyServer will
+// result in compilation errors.
+type UnsafeProxyServer interface {
+	mustEmbedUnimplementedProxyServer()
+}
+
+func RegisterProxyServer(s grpc.ServiceRegistrar, srv ProxyServer) {
+	s.RegisterService(&Proxy_ServiceDesc, srv)
+}
+
+func _Proxy_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).Put(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Proxy_Put_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).Put(ctx, req.(*PutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_PutStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProxyServer).PutStream(&proxyPutStreamServer{stream})
+}
+
+type Proxy_PutStreamServer interface {
+	SendAndClose(*PutReply) error
+	Recv() (*PutStreamRequest, error)
+	grpc.ServerStream
+}
+
+type proxyPutStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *proxyPutStreamServer) SendAndClose(m *PutReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *proxyPutStreamServer) Recv() (*PutStreamRequest, error) {
+	m := new(PutStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Proxy_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Proxy_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_GetCompatibilityConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCompatibilityConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).GetCompatibilityConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Proxy_GetCompatibilityConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).GetCompatibilityConfig(ctx, req.(*GetCompatibilityConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Proxy_ServiceDesc is the grpc.ServiceDesc for Proxy service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Proxy_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proxy.Proxy",
+	HandlerType: (*ProxyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Put",
+			Handler:    _Proxy_Put_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _Proxy_Get_Handler,
+		},
+		{
+			MethodName: "GetCompatibilityConfig",
+			Handler:    _Proxy_GetCompatibilityConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PutStream",
+			Handler:       _Proxy_PutStream_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proxy/proxy.proto",
+}
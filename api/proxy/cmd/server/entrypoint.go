@@ -10,7 +10,10 @@ import (
 	proxy_logging "github.com/Layr-Labs/eigenda/api/proxy/logging"
 	proxy_metrics "github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/arbitrum_altda"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/grpc_server"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/dispersaljobs"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
 	common_eigenda "github.com/Layr-Labs/eigenda/common"
@@ -23,7 +26,7 @@ import (
 )
 
 // TODO: Explore better encapsulation patterns that binds common interfaces / usage patterns
-// across the three servers (arb-altda, rest, metrics) that can be spun-up under the proxy service.
+// across the four servers (arb-altda, rest, grpc, metrics) that can be spun-up under the proxy service.
 // Especially if there's ever a need for an additional stack specific ALT DA server type to be introduced.
 func StartProxyService(cliCtx *cli.Context) error {
 	logCfg, err := proxy_logging.ReadLoggerCLIConfig(cliCtx)
@@ -96,7 +99,7 @@ func StartProxyService(cliCtx *cli.Context) error {
 		return fmt.Errorf("build storage managers: %w", err)
 	}
 
-	// Construct the compatibility config for the rest and arb servers. This could not be done while reading configs
+	// Construct the compatibility config for the rest, arb and grpc servers. This could not be done while reading configs
 	// as ChainID is fetched from the ethClient afterwards.
 	compatibilityCfg, err := common.NewCompatibilityConfig(
 		Version,
@@ -109,9 +112,19 @@ func StartProxyService(cliCtx *cli.Context) error {
 		return fmt.Errorf("new compatibility config: %w", err)
 	}
 
+	// The REST and gRPC servers share the authenticator, so that each client has a single quota across both.
+	auth := middleware.NewAuthenticator(cfg.RestSvrCfg.Auth)
+
 	if cfg.EnabledServersConfig.RestAPIConfig.DAEndpointEnabled() {
 		cfg.RestSvrCfg.CompatibilityCfg = compatibilityCfg
-		restServer := rest.NewServer(cfg.RestSvrCfg, certMgr, keccakMgr, log, metrics)
+		var jobMgr *dispersaljobs.Manager
+		if cfg.RestSvrCfg.AsyncDispersal.Enabled {
+			jobMgr, err = dispersaljobs.NewManager(ctx, log, certMgr, cfg.RestSvrCfg.AsyncDispersal)
+			if err != nil {
+				return fmt.Errorf("new dispersal job manager: %w", err)
+			}
+		}
+		restServer := rest.NewServer(cfg.RestSvrCfg, certMgr, keccakMgr, jobMgr, auth, log, metrics)
 		router := mux.NewRouter()
 		restServer.RegisterRoutes(router)
 		if cfg.StoreBuilderConfig.MemstoreEnabled {
@@ -172,6 +185,28 @@ func StartProxyService(cliCtx *cli.Context) error {
 		log.Info("Started Arbitrum Custom DA JSON RPC server", "addr", arbitrumRpcServer.Addr())
 	}
 
+	if cfg.EnabledServersConfig.GRPC {
+		cfg.GRPCSvrCfg.CompatibilityCfg = compatibilityCfg
+		grpcServer, err := grpc_server.NewServer(cfg.GRPCSvrCfg, certMgr, keccakMgr, auth, log, metrics)
+		if err != nil {
+			return fmt.Errorf("new proxy grpc server: %w", err)
+		}
+
+		if err := grpcServer.Start(); err != nil {
+			return fmt.Errorf("start proxy grpc server: %w", err)
+		}
+
+		defer func() {
+			if err := grpcServer.Stop(); err != nil {
+				log.Error("failed to stop gRPC ALT DA server", "err", err)
+			} else {
+				log.Info("Successfully shutdown gRPC ALT DA server")
+			}
+		}()
+
+		log.Info("Started EigenDA Proxy gRPC ALT DA server", "addr", grpcServer.Addr())
+	}
+
 	if cfg.EnabledServersConfig.Metric {
 		log.Info("Starting metrics server", "addr", cfg.MetricsSvrConfig.Host, "port", cfg.MetricsSvrConfig.Port)
 		svr := proxy_metrics.NewServer(registry, cfg.MetricsSvrConfig)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/dispersal"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloadretrieval"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda/core/payments/clientledger"
 )
 
@@ -28,6 +29,10 @@ type ClientConfigV2 struct {
 	MaxBlobSizeBytes                   uint64
 	EigenDACertVerifierOrRouterAddress string // >= V3 cert
 
+	// Maximum number of blobs that a payload too large for a single blob is split into.
+	// Values <= 1 disable chunking, in which case such payloads are rejected.
+	MaxPayloadChunks int
+
 	// Number of GRPC connections to make to each relay
 	RelayConnectionPoolSize uint
 
@@ -38,6 +43,12 @@ type ClientConfigV2 struct {
 	// RetrieversToEnable specifies which retrievers should be enabled
 	RetrieversToEnable []RetrieverType
 
+	// If true, the relay and validator retrievers are combined into a single payloadretrieval.PayloadRetriever
+	// configured by PayloadRetrieverCfg, which hedges slow relay retrievals with validator retrievals. Otherwise,
+	// the retrievers are tried one after the other, in the order of RetrieversToEnable.
+	HedgedRetrievalEnabled bool
+	PayloadRetrieverCfg    payloadretrieval.PayloadRetrieverConfig
+
 	// EigenDADirectory address is used to get addresses for all EigenDA contracts needed.
 	EigenDADirectory string
 
@@ -85,10 +96,24 @@ func (cfg *ClientConfigV2) Check() error {
 		}
 	}
 
+	if cfg.HedgedRetrievalEnabled {
+		if !slices.Contains(cfg.RetrieversToEnable, RelayRetrieverType) ||
+			!slices.Contains(cfg.RetrieversToEnable, ValidatorRetrieverType) {
+			return fmt.Errorf("hedged retrieval requires both the relay and validator retrievers to be enabled")
+		}
+		if cfg.PayloadRetrieverCfg.HedgeDelay < 0 {
+			return fmt.Errorf("hedged retrieval delay must not be negative, got %s", cfg.PayloadRetrieverCfg.HedgeDelay)
+		}
+	}
+
 	if cfg.PutTries == 0 {
 		return fmt.Errorf("PutTries==0 is not permitted. >0 means 'try N times', <0 means 'retry indefinitely'")
 	}
 
+	if cfg.MaxPayloadChunks > certs.MaxManifestChunks {
+		return fmt.Errorf("max payload chunks must be <= %d, got %d", certs.MaxManifestChunks, cfg.MaxPayloadChunks)
+	}
+
 	if cfg.ClientLedgerMode == "" {
 		return fmt.Errorf("client ledger mode must be specified")
 	}
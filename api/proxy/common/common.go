@@ -10,8 +10,20 @@ import (
 const (
 	// limit requests to 16 MiB (max_blob_size) to mitigate potential DoS attacks
 	MaxServerPOSTRequestBodySize int64 = 1024 * 1024 * 16
+	// Batch requests carry several payloads hex encoded in a JSON body, so they are allowed to be larger than
+	// single-payload requests. The number of items in a batch is limited separately by MaxServerBatchSize.
+	MaxServerBatchPOSTRequestBodySize int64 = 4 * MaxServerPOSTRequestBodySize
+	// MaxServerBatchSize is the maximum number of items accepted in a single /put/batch, /get/batch or
+	// /admin/cache/warmup request.
+	MaxServerBatchSize = 64
 )
 
+// MaxPayloadPOSTRequestBodySize returns the body size limit for requests carrying a single payload. It is raised
+// above MaxServerPOSTRequestBodySize when payload chunking allows payloads larger than a single blob.
+func MaxPayloadPOSTRequestBodySize(maxPayloadSizeBytes uint32) int64 {
+	return max(MaxServerPOSTRequestBodySize, int64(maxPayloadSizeBytes))
+}
+
 // Helper utility functions //
 
 func ContainsDuplicates[P comparable](s []P) bool {
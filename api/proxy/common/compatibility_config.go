@@ -2,7 +2,9 @@ package common
 
 import (
 	"fmt"
+	"math"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/codec"
 )
@@ -23,12 +25,19 @@ type CompatibilityConfig struct {
 	// The cert verifier router or immutable contract address. This allows a service to verify the cert verifier being
 	// used by the proxy.
 	CertVerifierAddress string `json:"cert_verifier_address"`
-	// The max supported payload size in bytes supported by the proxy instance. Calculated from `MaxBlobSizeBytes`.
+	// The max supported payload size in bytes supported by the proxy instance. Calculated from `MaxBlobSizeBytes`,
+	// multiplied by `MaxPayloadChunks` when oversized payloads are split into several blobs.
 	MaxPayloadSizeBytes uint32 `json:"max_payload_size_bytes"`
 	// The APIs currently enabled on the rest server
 	APIsEnabled []string `json:"apis_enabled,omitempty"`
 	// Whether the proxy is in read-only mode (no signer payment key)
 	ReadOnlyMode bool `json:"read_only_mode"`
+	// The payload encoding version used when dispersing payloads (see codecs.PayloadEncodingVersion). Readers of
+	// the payloads dispersed by this proxy must support this version.
+	PayloadEncodingVersion uint8 `json:"payload_encoding_version"`
+	// The payload encoding versions that this proxy is able to decode on GET routes. Stored as ints so that they
+	// are serialized as a JSON array rather than as a base64 string.
+	SupportedPayloadEncodingVersions []int `json:"supported_payload_encoding_versions"`
 }
 
 func NewCompatibilityConfig(
@@ -48,6 +57,10 @@ func NewCompatibilityConfig(
 		if err != nil {
 			return CompatibilityConfig{}, fmt.Errorf("calculate max payload size: %w", err)
 		}
+		if clientConfigV2.MaxPayloadChunks > 1 {
+			chunkedMaxPayloadSize := uint64(maxPayloadSize) * uint64(clientConfigV2.MaxPayloadChunks)
+			maxPayloadSize = uint32(min(chunkedMaxPayloadSize, math.MaxUint32))
+		}
 	}
 
 	// Remove 'v' prefix from version string if present for compatibility with eigenda/common/version helper funcs
@@ -58,13 +71,23 @@ func NewCompatibilityConfig(
 		}
 	}
 
+	// the same version is configured for dispersal and retrieval, see eigendaflags.CompressPayloadsFlagName
+	payloadEncodingVersion := clientConfigV2.PayloadDisperserCfg.PayloadEncodingVersion
+	decodableEncodingVersions := codecs.DecodablePayloadEncodingVersions(payloadEncodingVersion)
+	supportedEncodingVersions := make([]int, 0, len(decodableEncodingVersions))
+	for _, encodingVersion := range decodableEncodingVersions {
+		supportedEncodingVersions = append(supportedEncodingVersions, int(encodingVersion))
+	}
+
 	return CompatibilityConfig{
-		Version:             version,
-		ChainID:             chainID,
-		DirectoryAddress:    clientConfigV2.EigenDADirectory,
-		CertVerifierAddress: clientConfigV2.EigenDACertVerifierOrRouterAddress,
-		MaxPayloadSizeBytes: maxPayloadSize,
-		APIsEnabled:         APIsEnabled,
-		ReadOnlyMode:        readOnly,
+		Version:                          version,
+		ChainID:                          chainID,
+		DirectoryAddress:                 clientConfigV2.EigenDADirectory,
+		CertVerifierAddress:              clientConfigV2.EigenDACertVerifierOrRouterAddress,
+		MaxPayloadSizeBytes:              maxPayloadSize,
+		APIsEnabled:                      APIsEnabled,
+		ReadOnlyMode:                     readOnly,
+		PayloadEncodingVersion:           uint8(payloadEncodingVersion),
+		SupportedPayloadEncodingVersions: supportedEncodingVersions,
 	}, nil
 }
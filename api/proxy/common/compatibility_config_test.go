@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/dispersal"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloadretrieval"
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
@@ -56,6 +57,20 @@ func TestNewCompatibilityConfig(t *testing.T) {
 	require.Equal(t, APIsEnabled, result.APIsEnabled)
 	require.Equal(t, readOnly, result.ReadOnlyMode)
 	require.Greater(t, result.MaxPayloadSizeBytes, uint32(0))
+	require.Equal(t, uint8(codecs.PayloadEncodingVersion0), result.PayloadEncodingVersion)
+	require.Equal(t, []int{0}, result.SupportedPayloadEncodingVersions)
+}
+
+func TestNewCompatibilityConfigCompressedPayloads(t *testing.T) {
+	t.Parallel()
+
+	clientConfig := validClientConfigV2()
+	clientConfig.PayloadDisperserCfg.PayloadEncodingVersion = codecs.PayloadEncodingVersion1
+
+	result, err := common.NewCompatibilityConfig("1.2.3", "12345", clientConfig, false, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint8(codecs.PayloadEncodingVersion1), result.PayloadEncodingVersion)
+	require.Equal(t, []int{0, 1}, result.SupportedPayloadEncodingVersions)
 }
 
 func TestNewCompatibilityConfigVersionPrefixRemoval(t *testing.T) {
@@ -20,6 +20,8 @@ func Is400(err error) bool {
 	var readRequestBodyErr ReadRequestBodyError
 	var s3KeccakKeyValueMismatchErr s3.Keccak256KeyValueMismatchError
 	return errors.Is(err, ErrProxyOversizedBlob) ||
+		errors.Is(err, ErrChunkedEncodedPayloadUnsupported) ||
+		errors.Is(err, ErrNoSecondaryStorage) ||
 		errors.As(err, &parsingError) ||
 		errors.As(err, &certHexDecodingError) ||
 		errors.As(err, &invalidBackendErr) ||
@@ -34,13 +36,33 @@ func Is400(err error) bool {
 // on the EigenDA disperser. The disperser returns a grpc RESOURCE_EXHAUSTED error, which we convert
 // to an HTTP error. It doesn't have any meaning other than to request the client to retry later,
 // and/or slow down their rate of requests.
+//
+// It is also returned when a client of the proxy exceeds its own request or byte quota,
+// or when too many asynchronous dispersal jobs are already in flight.
 func Is429(err error) bool {
+	var quotaExceededErr QuotaExceededError
+	if errors.As(err, &quotaExceededErr) || errors.Is(err, ErrAsyncDispersalQueueFull) {
+		return true
+	}
 	st, isGRPCError := status.FromError(err)
 	return isGRPCError && st.Code() == codes.ResourceExhausted
 }
 
+// 401 UNAUTHORIZED is returned when authentication is enabled and a request is missing credentials,
+// or carries an unknown API key or an invalid JWT.
+func Is401(err error) bool {
+	var unauthorizedErr UnauthorizedError
+	return errors.As(err, &unauthorizedErr)
+}
+
 var (
-	ErrProxyOversizedBlob = fmt.Errorf("encoded blob is larger than max blob size")
+	ErrProxyOversizedBlob      = fmt.Errorf("encoded blob is larger than max blob size")
+	ErrAsyncDispersalQueueFull = fmt.Errorf("too many asynchronous dispersal jobs in flight, retry later")
+	// A chunked payload is spread over several blobs, so there is no single encoded payload to return.
+	ErrChunkedEncodedPayloadUnsupported = fmt.Errorf(
+		"return_encoded_payload is not supported for chunked payload manifests, request each chunk's cert instead")
+	// Returned by the admin cache routes when no cache or fallback is configured.
+	ErrNoSecondaryStorage = fmt.Errorf("no secondary storage (cache or fallback) is configured")
 )
 
 type CertHexDecodingError struct {
@@ -134,3 +156,36 @@ func NewParsingError(err error) ParsingError {
 func (me ParsingError) Error() string {
 	return fmt.Sprintf("parsing error: %s", me.err.Error())
 }
+
+// UnauthorizedError is returned by the auth middleware when a request could not be authenticated.
+type UnauthorizedError struct {
+	err error
+}
+
+func NewUnauthorizedError(err error) UnauthorizedError {
+	return UnauthorizedError{
+		err: err,
+	}
+}
+
+func (me UnauthorizedError) Error() string {
+	return fmt.Sprintf("unauthorized: %s", me.err.Error())
+}
+
+// QuotaExceededError is returned by the auth middleware when an authenticated client
+// has used up its request or byte quota. The client should retry later.
+type QuotaExceededError struct {
+	client string
+	quota  string
+}
+
+func NewQuotaExceededError(client string, quota string) QuotaExceededError {
+	return QuotaExceededError{
+		client: client,
+		quota:  quota,
+	}
+}
+
+func (me QuotaExceededError) Error() string {
+	return fmt.Sprintf("client %s exceeded its %s quota", me.client, me.quota)
+}
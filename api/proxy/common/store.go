@@ -17,6 +17,9 @@ const (
 	MemstoreV1BackendType
 	MemstoreV2BackendType
 	S3BackendType
+	LittDBBackendType
+	FilesystemBackendType
+	PeerBackendType
 
 	UnknownBackendType
 )
@@ -33,6 +36,12 @@ func (b BackendType) String() string {
 		return "EigenDAV2Memstore"
 	case S3BackendType:
 		return "S3"
+	case LittDBBackendType:
+		return "LittDB"
+	case FilesystemBackendType:
+		return "Filesystem"
+	case PeerBackendType:
+		return "Peer"
 	case UnknownBackendType:
 		fallthrough
 	default:
@@ -54,6 +63,10 @@ func StringToBackendType(s string) BackendType {
 		return MemstoreV2BackendType
 	case "s3":
 		return S3BackendType
+	case "littdb":
+		return LittDBBackendType
+	case "filesystem":
+		return FilesystemBackendType
 	case "unknown":
 		fallthrough
 	default:
@@ -108,6 +121,10 @@ type EigenDAV2Store interface {
 	// VerifyCert verifies the cert validity and rbn recency.
 	VerifyCert(ctx context.Context, versionedCert *certs.VersionedCert,
 		serializationType coretypes.CertSerializationType, l1InclusionBlockNum uint64) error
+	// VerifyPayload verifies that the payload is the one committed to by the blob commitment of the cert.
+	// It is used to verify payloads read from sources that aren't trusted, such as peer proxies.
+	VerifyPayload(ctx context.Context, versionedCert *certs.VersionedCert,
+		serializationType coretypes.CertSerializationType, payload []byte) error
 }
 
 // SecondaryStore is the interface for a key-value data store that uses keccak(value) as the key.
@@ -120,4 +137,6 @@ type SecondaryStore interface {
 	Get(ctx context.Context, key []byte) ([]byte, error)
 	// Verify verifies the given key-value pair.
 	Verify(ctx context.Context, key []byte, value []byte) error
+	// Delete removes the given key from the key-value data store. Deleting a key that isn't present is a no-op.
+	Delete(ctx context.Context, key []byte) error
 }
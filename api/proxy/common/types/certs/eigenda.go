@@ -23,6 +23,10 @@ const (
 	V3VersionByte
 )
 
+// ManifestVersionByte identifies DA Commitments that hold a serialized [ChunkManifest] instead of a cert.
+// It is chosen far away from the cert version bytes so that future cert versions don't collide with it.
+const ManifestVersionByte VersionByte = 0xff
+
 // versionByteString returns a string representation of the version byte for display
 func (v VersionByte) VersionByteString() string {
 	switch v {
@@ -34,6 +38,8 @@ func (v VersionByte) VersionByteString() string {
 		return "EigenDA V2 with V3 Cert"
 	case V3VersionByte:
 		return "EigenDA V2 with V4 Cert"
+	case ManifestVersionByte:
+		return "EigenDA V2 Chunked Payload Manifest"
 	default:
 		return fmt.Sprintf("Unknown (0x%02x)", byte(v))
 	}
@@ -54,6 +60,8 @@ func (v VersionByte) IntoCertVersion() (coretypes.CertificateVersion, error) {
 		return coretypes.VersionThreeCert, nil
 	case V3VersionByte:
 		return coretypes.VersionFourCert, nil
+	case ManifestVersionByte:
+		return 0, fmt.Errorf("manifest DA Commit version does not correspond to a CertVersion")
 	default:
 		return 0, fmt.Errorf("unknown version byte (0x%02x)", byte(v))
 	}
@@ -71,6 +79,8 @@ func ByteToVersion(b byte) (VersionByte, error) {
 		return V2VersionByte, nil
 	case byte(V3VersionByte):
 		return V3VersionByte, nil
+	case byte(ManifestVersionByte):
+		return ManifestVersionByte, nil
 	default:
 		return 0, fmt.Errorf("unknown EigenDA cert version: %d", b)
 	}
@@ -0,0 +1,178 @@
+package certs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ManifestVersion identifies the serialization format of a [ChunkManifest].
+type ManifestVersion byte
+
+const (
+	// ManifestVersion0 serializes a ChunkManifest as follows, with all integers big-endian:
+	//
+	//	[ manifest_version (1 byte) | payload_length (4 bytes) | keccak256(payload) (32 bytes) | chunk_count (2 bytes) ]
+	//
+	// followed by chunk_count entries, in payload order:
+	//
+	//	[ chunk_length (4 bytes) | cert_version_byte (1 byte) | cert_length (4 bytes) | serialized_cert ]
+	ManifestVersion0 ManifestVersion = 0x0
+
+	// MaxManifestChunks is the maximum number of chunks a manifest can reference. With 16MiB blobs, this caps
+	// chunked payloads at roughly 1GiB, which keeps the payload length within a uint32.
+	MaxManifestChunks = 64
+
+	manifestHeaderLen      = 1 + 4 + 32 + 2
+	manifestChunkHeaderLen = 4 + 1 + 4
+)
+
+// ManifestChunk references one blob holding a contiguous chunk of a payload.
+type ManifestChunk struct {
+	// Length of the chunk of the payload held by the blob.
+	PayloadLength uint32
+	// Cert of the blob holding the chunk.
+	Cert VersionedCert
+}
+
+// ChunkManifest describes a payload that was too large for a single blob, and was split into several blobs.
+// It is returned to clients in place of a cert, under the [ManifestVersionByte] DA Commitment version.
+//
+// Deserializing is strict, so that derivation pipelines accept or reject a given manifest deterministically.
+type ChunkManifest struct {
+	PayloadLength uint32
+	// keccak256 of the full payload, checked once the chunks have been reassembled.
+	PayloadHash [32]byte
+	// Chunks in the order they have to be concatenated in.
+	Chunks []ManifestChunk
+}
+
+// NewChunkManifest creates a manifest for a payload that was dispersed as the given chunks.
+func NewChunkManifest(payload []byte, chunks []ManifestChunk) ChunkManifest {
+	return ChunkManifest{
+		PayloadLength: uint32(len(payload)),
+		PayloadHash:   crypto.Keccak256Hash(payload),
+		Chunks:        chunks,
+	}
+}
+
+// VersionedCert wraps the serialized manifest in a VersionedCert, so that it can be encoded into a commitment.
+func (m ChunkManifest) VersionedCert() *VersionedCert {
+	return NewVersionedCert(m.Serialize(), ManifestVersionByte)
+}
+
+// Serialize serializes the manifest with [ManifestVersion0].
+func (m ChunkManifest) Serialize() []byte {
+	size := manifestHeaderLen
+	for _, chunk := range m.Chunks {
+		size += manifestChunkHeaderLen + len(chunk.Cert.SerializedCert)
+	}
+
+	buf := make([]byte, 0, size)
+	buf = append(buf, byte(ManifestVersion0))
+	buf = binary.BigEndian.AppendUint32(buf, m.PayloadLength)
+	buf = append(buf, m.PayloadHash[:]...)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(m.Chunks)))
+	for _, chunk := range m.Chunks {
+		buf = binary.BigEndian.AppendUint32(buf, chunk.PayloadLength)
+		buf = append(buf, byte(chunk.Cert.Version))
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(chunk.Cert.SerializedCert)))
+		buf = append(buf, chunk.Cert.SerializedCert...)
+	}
+	return buf
+}
+
+// DeserializeChunkManifest parses a manifest serialized with [ChunkManifest.Serialize].
+//
+// It returns an error if the manifest is malformed: unknown manifest version, fewer than 2 or more than
+// [MaxManifestChunks] chunks, empty chunks, chunk certs that aren't EigenDA V2 certs, chunk lengths that don't
+// add up to the payload length, or trailing bytes.
+func DeserializeChunkManifest(data []byte) (ChunkManifest, error) {
+	if len(data) < manifestHeaderLen {
+		return ChunkManifest{}, fmt.Errorf("manifest must be at least %d bytes, got %d", manifestHeaderLen, len(data))
+	}
+	if ManifestVersion(data[0]) != ManifestVersion0 {
+		return ChunkManifest{}, fmt.Errorf("unknown manifest version: %d", data[0])
+	}
+
+	var m ChunkManifest
+	m.PayloadLength = binary.BigEndian.Uint32(data[1:5])
+	copy(m.PayloadHash[:], data[5:37])
+	chunkCount := int(binary.BigEndian.Uint16(data[37:39]))
+	if chunkCount < 2 || chunkCount > MaxManifestChunks {
+		return ChunkManifest{}, fmt.Errorf("manifest must have between 2 and %d chunks, got %d",
+			MaxManifestChunks, chunkCount)
+	}
+
+	reader := bytes.NewReader(data[manifestHeaderLen:])
+	var totalLength uint64
+	m.Chunks = make([]ManifestChunk, 0, chunkCount)
+	for i := range chunkCount {
+		chunk, err := readManifestChunk(reader)
+		if err != nil {
+			return ChunkManifest{}, fmt.Errorf("chunk %d: %w", i, err)
+		}
+		totalLength += uint64(chunk.PayloadLength)
+		m.Chunks = append(m.Chunks, chunk)
+	}
+	if reader.Len() != 0 {
+		return ChunkManifest{}, fmt.Errorf("manifest has %d trailing bytes", reader.Len())
+	}
+	if totalLength != uint64(m.PayloadLength) {
+		return ChunkManifest{}, fmt.Errorf("chunk lengths add up to %d, but manifest payload length is %d",
+			totalLength, m.PayloadLength)
+	}
+
+	return m, nil
+}
+
+func readManifestChunk(reader *bytes.Reader) (ManifestChunk, error) {
+	var header [manifestChunkHeaderLen]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return ManifestChunk{}, fmt.Errorf("read chunk header: %w", err)
+	}
+
+	payloadLength := binary.BigEndian.Uint32(header[0:4])
+	if payloadLength == 0 {
+		return ManifestChunk{}, fmt.Errorf("chunk length must be > 0")
+	}
+
+	version, err := ByteToVersion(header[4])
+	if err != nil {
+		return ManifestChunk{}, fmt.Errorf("chunk cert version: %w", err)
+	}
+	switch version {
+	case V1VersionByte, V2VersionByte, V3VersionByte:
+	default:
+		return ManifestChunk{}, fmt.Errorf("chunk cert version %s is not supported in manifests",
+			version.VersionByteString())
+	}
+
+	certLength := binary.BigEndian.Uint32(header[5:9])
+	if certLength == 0 || uint64(certLength) > uint64(reader.Len()) {
+		return ManifestChunk{}, fmt.Errorf("invalid cert length %d, %d bytes left", certLength, reader.Len())
+	}
+	serializedCert := make([]byte, certLength)
+	// cannot fail, since we checked that enough bytes are left above
+	_, _ = reader.Read(serializedCert)
+
+	return ManifestChunk{
+		PayloadLength: payloadLength,
+		Cert:          *NewVersionedCert(serializedCert, version),
+	}, nil
+}
+
+// VerifyPayload checks that a reassembled payload matches the length and hash recorded in the manifest.
+func (m ChunkManifest) VerifyPayload(payload []byte) error {
+	if uint64(len(payload)) != uint64(m.PayloadLength) {
+		return fmt.Errorf("reassembled payload is %d bytes, but manifest payload length is %d",
+			len(payload), m.PayloadLength)
+	}
+	if crypto.Keccak256Hash(payload) != m.PayloadHash {
+		return fmt.Errorf("reassembled payload hash does not match manifest payload hash %x", m.PayloadHash)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package certs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testManifest() ChunkManifest {
+	return NewChunkManifest([]byte("hello world"), []ManifestChunk{
+		{PayloadLength: 6, Cert: *NewVersionedCert([]byte("cert-1"), V2VersionByte)},
+		{PayloadLength: 5, Cert: *NewVersionedCert([]byte("cert-2"), V3VersionByte)},
+	})
+}
+
+func TestChunkManifestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	manifest := testManifest()
+	versionedCert := manifest.VersionedCert()
+	require.Equal(t, ManifestVersionByte, versionedCert.Version)
+
+	deserialized, err := DeserializeChunkManifest(versionedCert.SerializedCert)
+	require.NoError(t, err)
+	require.Equal(t, manifest, deserialized)
+
+	require.NoError(t, deserialized.VerifyPayload([]byte("hello world")))
+	require.Error(t, deserialized.VerifyPayload([]byte("hello there")))
+	require.Error(t, deserialized.VerifyPayload([]byte("hello")))
+}
+
+func TestDeserializeChunkManifestErrors(t *testing.T) {
+	t.Parallel()
+
+	valid := testManifest().Serialize()
+
+	testCases := []struct {
+		name   string
+		mutate func(ChunkManifest) []byte
+	}{
+		{
+			name: "too short",
+			mutate: func(ChunkManifest) []byte {
+				return valid[:10]
+			},
+		},
+		{
+			name: "unknown manifest version",
+			mutate: func(ChunkManifest) []byte {
+				data := append([]byte{}, valid...)
+				data[0] = 0x01
+				return data
+			},
+		},
+		{
+			name: "single chunk",
+			mutate: func(m ChunkManifest) []byte {
+				m.Chunks = m.Chunks[:1]
+				m.PayloadLength = m.Chunks[0].PayloadLength
+				return m.Serialize()
+			},
+		},
+		{
+			name: "chunk lengths don't add up",
+			mutate: func(m ChunkManifest) []byte {
+				m.PayloadLength++
+				return m.Serialize()
+			},
+		},
+		{
+			name: "empty chunk",
+			mutate: func(m ChunkManifest) []byte {
+				m.Chunks[0].PayloadLength = 0
+				m.PayloadLength = m.Chunks[1].PayloadLength
+				return m.Serialize()
+			},
+		},
+		{
+			name: "nested manifest",
+			mutate: func(m ChunkManifest) []byte {
+				m.Chunks[0].Cert.Version = ManifestVersionByte
+				return m.Serialize()
+			},
+		},
+		{
+			name: "EigenDA V1 cert",
+			mutate: func(m ChunkManifest) []byte {
+				m.Chunks[0].Cert.Version = V0VersionByte
+				return m.Serialize()
+			},
+		},
+		{
+			name: "truncated cert",
+			mutate: func(ChunkManifest) []byte {
+				return valid[:len(valid)-1]
+			},
+		},
+		{
+			name: "trailing bytes",
+			mutate: func(ChunkManifest) []byte {
+				return append(append([]byte{}, valid...), 0x00)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DeserializeChunkManifest(tc.mutate(testManifest()))
+			require.Error(t, err)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package commitments
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
@@ -43,3 +44,38 @@ func EncodeCommitment(
 	}
 	return nil, fmt.Errorf("unknown commitment mode")
 }
+
+// DecodeCommitment is the inverse of [EncodeCommitment] for the commitment modes that commit to a versionedCert,
+// i.e. standard and op generic mode. Op keccak commitments commit to a keccak256 hash instead.
+func DecodeCommitment(
+	commitment []byte,
+	commitmentMode CommitmentMode,
+) (*certs.VersionedCert, error) {
+	switch commitmentMode {
+	case StandardCommitmentMode:
+		// [version_byte | cert]
+	case OptimismGenericCommitmentMode:
+		// [commitment_type_byte | da_layer_byte | version_byte | cert]
+		if len(commitment) < 2 {
+			return nil, errors.New("op generic commitment is too short")
+		}
+		if commitment[0] != byte(OPGenericCommitmentByte) {
+			return nil, fmt.Errorf("unsupported commitment type %x", commitment[0])
+		}
+		if commitment[1] != EigenDALayerByte {
+			return nil, fmt.Errorf("unsupported DA layer byte %x", commitment[1])
+		}
+		commitment = commitment[2:]
+	default:
+		return nil, fmt.Errorf("commitment mode %s does not commit to a cert", commitmentMode)
+	}
+
+	if len(commitment) < 2 {
+		return nil, errors.New("commitment is too short")
+	}
+	certVersion, err := certs.ByteToVersion(commitment[0])
+	if err != nil {
+		return nil, fmt.Errorf("unsupported version byte %x: %w", commitment[0], err)
+	}
+	return certs.NewVersionedCert(commitment[1:], certVersion), nil
+}
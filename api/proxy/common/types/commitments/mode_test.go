@@ -0,0 +1,31 @@
+package commitments
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCommitment(t *testing.T) {
+	versionedCert := certs.NewVersionedCert([]byte("cert"), certs.V1VersionByte)
+	for _, mode := range []CommitmentMode{StandardCommitmentMode, OptimismGenericCommitmentMode} {
+		commitment, err := EncodeCommitment(versionedCert, mode)
+		require.NoError(t, err)
+		decoded, err := DecodeCommitment(commitment, mode)
+		require.NoError(t, err)
+		require.Equal(t, versionedCert, decoded)
+	}
+
+	// op generic commitments must have the generic commitment type byte and the EigenDA layer byte
+	_, err := DecodeCommitment([]byte{0x00, 0x00, 0x01, 0xaa}, OptimismGenericCommitmentMode)
+	require.Error(t, err)
+	_, err = DecodeCommitment([]byte{0x01, 0x01, 0x01, 0xaa}, OptimismGenericCommitmentMode)
+	require.Error(t, err)
+	// keccak commitments don't commit to a cert
+	_, err = DecodeCommitment([]byte{0x00, 0xaa}, OptimismKeccakCommitmentMode)
+	require.Error(t, err)
+	// a version byte without a cert
+	_, err = DecodeCommitment([]byte{0x01}, StandardCommitmentMode)
+	require.Error(t, err)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"path/filepath"
 	"slices"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
@@ -9,13 +10,15 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/config/v2/eigendaflags"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/arbitrum_altda"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/grpc_server"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/dispersaljobs"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
 	"github.com/urfave/cli/v2"
 )
 
 // AppConfig is the highest order config. Stores all relevant fields necessary for running
-// REST ALTDA, Arbitrum Custom DA, & metrics servers.
+// REST ALTDA, Arbitrum Custom DA, gRPC ALTDA, & metrics servers.
 type AppConfig struct {
 	StoreBuilderConfig builder.Config
 	SecretConfig       common.SecretConfigV2
@@ -24,6 +27,7 @@ type AppConfig struct {
 
 	ArbCustomDASvrCfg arbitrum_altda.Config
 	RestSvrCfg        rest.Config
+	GRPCSvrCfg        grpc_server.Config
 	MetricsSvrConfig  metrics.Config
 }
 
@@ -48,6 +52,11 @@ func (c AppConfig) Check() error {
 		return fmt.Errorf("check enabled APIs: %w", err)
 	}
 
+	err = c.RestSvrCfg.Check()
+	if err != nil {
+		return fmt.Errorf("check rest server config: %w", err)
+	}
+
 	return nil
 }
 
@@ -59,13 +68,29 @@ func ReadAppConfig(ctx *cli.Context) (AppConfig, error) {
 
 	enabledServersCfg := enablement.ReadEnabledServersCfg(ctx)
 
+	restSvrCfg, err := rest.ReadConfig(ctx, &enabledServersCfg.RestAPIConfig)
+	if err != nil {
+		return AppConfig{}, fmt.Errorf("read rest server config: %w", err)
+	}
+	// the same secret authenticates this proxy to its peers, and its peers to this proxy
+	restSvrCfg.PeeringSharedSecret = storeBuilderConfig.PeeringConfig.SharedSecret
+	// Persisted async dispersal jobs are restarted by sending their payload again. The dispersal journal lets the
+	// payload disperser resume the blob of a job that was interrupted, instead of dispersing it a second time.
+	payloadDisperserCfg := &storeBuilderConfig.ClientConfigV2.PayloadDisperserCfg
+	if restSvrCfg.AsyncDispersal.Enabled && restSvrCfg.AsyncDispersal.PersistencePath != "" &&
+		payloadDisperserCfg.DispersalJournalPath == "" {
+		payloadDisperserCfg.DispersalJournalPath = filepath.Join(
+			restSvrCfg.AsyncDispersal.PersistencePath, dispersaljobs.DispersalJournalDirectory)
+	}
+
 	return AppConfig{
 		StoreBuilderConfig:   storeBuilderConfig,
 		SecretConfig:         eigendaflags.ReadSecretConfigV2(ctx),
 		EnabledServersConfig: enabledServersCfg,
 
 		ArbCustomDASvrCfg: arbitrum_altda.ReadConfig(ctx),
-		RestSvrCfg:        rest.ReadConfig(ctx, &enabledServersCfg.RestAPIConfig),
+		RestSvrCfg:        restSvrCfg,
+		GRPCSvrCfg:        grpc_server.ReadConfig(ctx),
 		MetricsSvrConfig:  metrics.ReadConfig(ctx),
 	}, nil
 }
@@ -12,6 +12,7 @@ import (
 type EnabledServersConfig struct {
 	Metric      bool
 	ArbCustomDA bool
+	GRPC        bool
 
 	RestAPIConfig RestApisEnabled
 }
@@ -34,8 +35,8 @@ func (e *RestApisEnabled) DAEndpointEnabled() bool {
 
 // Check ... Ensures that expression of the enabled API set is correct
 func (e EnabledServersConfig) Check() error {
-	if !e.RestAPIConfig.DAEndpointEnabled() && !e.ArbCustomDA {
-		return fmt.Errorf("an `arb` or REST ALT DA Server api type must be provided to start application")
+	if !e.RestAPIConfig.DAEndpointEnabled() && !e.ArbCustomDA && !e.GRPC {
+		return fmt.Errorf("an `arb`, `grpc` or REST ALT DA Server api type must be provided to start application")
 	}
 
 	return nil
@@ -50,6 +51,9 @@ func (e EnabledServersConfig) ToAPIStrings() []string {
 	if e.ArbCustomDA {
 		enabled = append(enabled, string(ArbCustomDAServer))
 	}
+	if e.GRPC {
+		enabled = append(enabled, string(GRPCServer))
+	}
 	if e.RestAPIConfig.Admin {
 		enabled = append(enabled, string(Admin))
 	}
@@ -91,6 +95,7 @@ func APIStringsToEnabledServersConfig(strSlice []string) (*EnabledServersConfig,
 	return &EnabledServersConfig{
 		Metric:      common.Contains(apis, MetricsServer),
 		ArbCustomDA: common.Contains(apis, ArbCustomDAServer),
+		GRPC:        common.Contains(apis, GRPCServer),
 		RestAPIConfig: RestApisEnabled{
 			Admin:               common.Contains(apis, Admin),
 			OpGenericCommitment: common.Contains(apis, OpGenericCommitment),
@@ -109,14 +114,15 @@ const (
 	OpGenericCommitment API = "op-generic"
 	StandardCommitment  API = "standard"
 	ArbCustomDAServer   API = "arb"
+	GRPCServer          API = "grpc"
 	MetricsServer       API = "metrics"
 )
 
 func AllAPIsString() string {
 	return fmt.Sprintf(
-		"%s, %s, %s, %s, %s, %s", Admin, StandardCommitment,
+		"%s, %s, %s, %s, %s, %s, %s", Admin, StandardCommitment,
 		OpGenericCommitment, OpKeccakCommitment,
-		ArbCustomDAServer, MetricsServer)
+		ArbCustomDAServer, GRPCServer, MetricsServer)
 }
 
 func APIFromString(s string) (API, error) {
@@ -134,6 +140,8 @@ func APIFromString(s string) (API, error) {
 		return StandardCommitment, nil
 	case "arb":
 		return ArbCustomDAServer, nil
+	case "grpc":
+		return GRPCServer, nil
 	case "metrics":
 		return MetricsServer, nil
 	default:
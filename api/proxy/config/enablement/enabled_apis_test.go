@@ -20,6 +20,7 @@ func TestToAPIStrings(t *testing.T) {
 			config: enablement.EnabledServersConfig{
 				Metric:      true,
 				ArbCustomDA: true,
+				GRPC:        true,
 				RestAPIConfig: enablement.RestApisEnabled{
 					Admin:               true,
 					OpGenericCommitment: true,
@@ -27,7 +28,7 @@ func TestToAPIStrings(t *testing.T) {
 					StandardCommitment:  true,
 				},
 			},
-			expected: []string{"metrics", "arb", "admin", "op-generic", "op-keccak", "standard"},
+			expected: []string{"metrics", "arb", "grpc", "admin", "op-generic", "op-keccak", "standard"},
 		},
 		{
 			name: "No APIs enabled",
@@ -71,6 +72,13 @@ func TestToAPIStrings(t *testing.T) {
 			},
 			expected: []string{"arb"},
 		},
+		{
+			name: "Only gRPC enabled",
+			config: enablement.EnabledServersConfig{
+				GRPC: true,
+			},
+			expected: []string{"grpc"},
+		},
 		{
 			name: "Only REST APIs enabled",
 			config: enablement.EnabledServersConfig{
@@ -4,12 +4,17 @@ import (
 	enabled_apis "github.com/Layr-Labs/eigenda/api/proxy/config/enablement"
 	eigenda_v2_flags "github.com/Layr-Labs/eigenda/api/proxy/config/v2/eigendaflags"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/arbitrum_altda"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/grpc_server"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/dispersaljobs"
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/logging"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/filesystem"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/peer"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/redis"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/urfave/cli/v2"
@@ -19,6 +24,8 @@ const (
 	EnabledAPIsCategory     = "Enabled APIs"
 	ProxyRestServerCategory = "Proxy REST API Server (compatible with OP Stack ALT DA and standard commitment clients)"
 	ArbCustomDASvrCategory  = "Arbitrum Custom DA JSON RPC Server"
+	ProxyGRPCServerCategory = "Proxy gRPC API Server"
+	AsyncDispersalCategory  = "Asynchronous Dispersal"
 
 	LoggingFlagsCategory = "Logging"
 	MetricsFlagCategory  = "Metrics"
@@ -26,6 +33,9 @@ const (
 	StorageFlagsCategory  = "Storage"
 	MemstoreFlagsCategory = "Memstore (for testing purposes - replaces EigenDA backend)"
 	S3Category            = "S3 Cache/Fallback"
+	LittDBCategory        = "LittDB Cache/Fallback"
+	FilesystemCategory    = "Filesystem Cache/Fallback"
+	PeeringCategory       = "Proxy Peering"
 
 	EigenDAV2ClientCategory = "EigenDA V2 Client"
 
@@ -43,13 +53,18 @@ func init() {
 	Flags = append(Flags, enabled_apis.CLIFlags(EnabledAPIsCategory, GlobalEnvVarPrefix)...)
 
 	Flags = append(Flags, rest.CLIFlags(GlobalEnvVarPrefix, ProxyRestServerCategory)...)
+	Flags = append(Flags, dispersaljobs.CLIFlags(GlobalEnvVarPrefix, AsyncDispersalCategory)...)
 	Flags = append(Flags, arbitrum_altda.CLIFlags(GlobalEnvVarPrefix, ArbCustomDASvrCategory)...)
+	Flags = append(Flags, grpc_server.CLIFlags(GlobalEnvVarPrefix, ProxyGRPCServerCategory)...)
 	Flags = append(Flags, metrics.CLIFlags(GlobalEnvVarPrefix, MetricsFlagCategory)...)
 
 	Flags = append(Flags, logging.CLIFlags(GlobalEnvVarPrefix, LoggingFlagsCategory)...)
 	Flags = append(Flags, eigenda_v2_flags.CLIFlags(GlobalEnvVarPrefix, EigenDAV2ClientCategory)...)
 	Flags = append(Flags, store.CLIFlags(GlobalEnvVarPrefix, StorageFlagsCategory)...)
 	Flags = append(Flags, s3.CLIFlags(GlobalEnvVarPrefix, S3Category)...)
+	Flags = append(Flags, littdb.CLIFlags(GlobalEnvVarPrefix, LittDBCategory)...)
+	Flags = append(Flags, filesystem.CLIFlags(GlobalEnvVarPrefix, FilesystemCategory)...)
+	Flags = append(Flags, peer.CLIFlags(GlobalEnvVarPrefix, PeeringCategory)...)
 	Flags = append(Flags, memstore.CLIFlags(GlobalEnvVarPrefix, MemstoreFlagsCategory)...)
 
 	Flags = append(Flags, metrics.DeprecatedCLIFlags(GlobalEnvVarPrefix, MetricsFlagCategory)...)
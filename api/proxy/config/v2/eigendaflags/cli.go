@@ -9,6 +9,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/clients/v2/dispersal"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/payloadretrieval"
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda/core/payments/clientledger"
 	"github.com/urfave/cli/v2"
 )
@@ -18,6 +19,11 @@ var (
 	DisableTLSFlagName              = withFlagPrefix("disable-tls")
 	BlobStatusPollIntervalFlagName  = withFlagPrefix("blob-status-poll-interval")
 	PointEvaluationDisabledFlagName = withFlagPrefix("disable-point-evaluation")
+	CompressPayloadsFlagName        = withFlagPrefix("compress-payloads")
+
+	DispersalJournalPathFlagName              = withFlagPrefix("dispersal-journal-path")
+	DispersalJournalRetentionFlagName         = withFlagPrefix("dispersal-journal-retention")
+	DispersalJournalMaxResumeAttemptsFlagName = withFlagPrefix("dispersal-journal-max-resume-attempts")
 
 	PutRetriesFlagName                                = withFlagPrefix("put-retries")
 	PutRetryDelayIncrementFlagName                    = withFlagPrefix("put-retry-delay-increment")
@@ -30,12 +36,15 @@ var (
 	EigenDADirectoryFlagName          = withFlagPrefix("eigenda-directory")
 	RelayTimeoutFlagName              = withFlagPrefix("relay-timeout")
 	ValidatorTimeoutFlagName          = withFlagPrefix("validator-timeout")
+	HedgedRetrievalFlagName           = withFlagPrefix("hedged-retrieval")
+	HedgedRetrievalDelayFlagName      = withFlagPrefix("hedged-retrieval-delay")
 	ContractCallTimeoutFlagName       = withFlagPrefix("contract-call-timeout")
 	BlobParamsVersionFlagName         = withFlagPrefix("blob-version")
 	EthRPCURLFlagName                 = withFlagPrefix("eth-rpc")
 	EthRPCRetryCountFlagName          = withFlagPrefix("eth-rpc-retry-count")
 	EthRPCRetryDelayIncrementFlagName = withFlagPrefix("eth-rpc-retry-delay-increment")
 	MaxBlobLengthFlagName             = withFlagPrefix("max-blob-length")
+	MaxPayloadChunksFlagName          = withFlagPrefix("max-payload-chunks")
 	NetworkFlagName                   = withFlagPrefix("network")
 	RelayConnectionPoolSizeFlagName   = withFlagPrefix("relay-connection-pool-size")
 
@@ -83,6 +92,16 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Value:    false,
 			Category: category,
 		},
+		&cli.BoolFlag{
+			Name: CompressPayloadsFlagName,
+			Usage: "Compress payloads with zstd before dispersing them (payload encoding version 1), and decode " +
+				"compressed payloads on retrieval. Every reader of the dispersed payloads must enable this too, " +
+				"since version 1 payloads are rejected otherwise. Version 1 is not part of the EigenDA integration " +
+				"spec, so it must not be used by rollups with secure integrations (e.g. fault proofs).",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "COMPRESS_PAYLOADS")},
+			Value:    false,
+			Category: category,
+		},
 		&cli.StringFlag{
 			Name:     EthRPCURLFlagName,
 			Usage:    "URL of the Ethereum RPC endpoint.",
@@ -186,6 +205,23 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Value:    2 * time.Minute,
 			Required: false,
 		},
+		&cli.BoolFlag{
+			Name: HedgedRetrievalFlagName,
+			Usage: "Retrieve blobs from the relays and the validators concurrently, instead of only trying the " +
+				"validators once the relays have failed. Validator retrieval starts once the relays have failed, " +
+				"or haven't returned the blob within --" + HedgedRetrievalDelayFlagName + ".",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "HEDGED_RETRIEVAL")},
+			Value:    false,
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name: HedgedRetrievalDelayFlagName,
+			Usage: "How long to wait for the relays before also retrieving the blob from the validators. " +
+				"0 retrieves the blob from the relays and the validators at the same time.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "HEDGED_RETRIEVAL_DELAY")},
+			Value:    2 * time.Second,
+			Category: category,
+		},
 		&cli.DurationFlag{
 			Name:     BlobStatusPollIntervalFlagName,
 			Usage:    "Duration to query for blob status updates during dispersal.",
@@ -194,6 +230,32 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Value:    1 * time.Second,
 			Required: false,
 		},
+		&cli.StringFlag{
+			Name: DispersalJournalPathFlagName,
+			Usage: `Directory in which dispersals that are waiting for signatures are journaled. If the proxy restarts, or a
+PUT times out, while a blob is waiting for signatures, a PUT of the same payload returns the cert of the journaled blob
+instead of dispersing (and paying for) the payload again. Empty disables the journal.`,
+			EnvVars:  []string{withEnvPrefix(envPrefix, "DISPERSAL_JOURNAL_PATH")},
+			Category: category,
+			Required: false,
+		},
+		&cli.DurationFlag{
+			Name:     DispersalJournalRetentionFlagName,
+			Usage:    "Duration for which a journaled dispersal can be resumed.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "DISPERSAL_JOURNAL_RETENTION")},
+			Category: category,
+			Value:    time.Hour,
+			Required: false,
+		},
+		&cli.UintFlag{
+			Name: DispersalJournalMaxResumeAttemptsFlagName,
+			Usage: `Number of times waiting for the signatures of a journaled dispersal is resumed. Once exhausted, the
+dispersal is dropped from the journal, and a PUT of the same payload disperses it again.`,
+			EnvVars:  []string{withEnvPrefix(envPrefix, "DISPERSAL_JOURNAL_MAX_RESUME_ATTEMPTS")},
+			Category: category,
+			Value:    3,
+			Required: false,
+		},
 		&cli.UintFlag{
 			Name: BlobParamsVersionFlagName,
 			Usage: `Blob params version used when dispersing. This refers to a global version maintained by EigenDA
@@ -211,6 +273,15 @@ loaded into memory for KZG commitments. Example units: '15MiB', '4Kib'.`,
 			Value:    "16MiB",
 			Category: category,
 		},
+		&cli.IntFlag{
+			Name: MaxPayloadChunksFlagName,
+			Usage: fmt.Sprintf(`Maximum number of blobs that a payload too large for a single blob is split into.
+Chunked payloads are committed to with a manifest commitment, which GET routes reassemble and verify.
+1 disables chunking, in which case oversized payloads are rejected. Must be <= %d.`, certs.MaxManifestChunks),
+			EnvVars:  []string{withEnvPrefix(envPrefix, "MAX_PAYLOAD_CHUNKS")},
+			Value:    1,
+			Category: category,
+		},
 		&cli.StringFlag{
 			Name: NetworkFlagName,
 			Usage: fmt.Sprintf(`The EigenDA network that is being used. This is an optional flag, 
@@ -296,8 +367,11 @@ func ReadClientConfigV2(ctx *cli.Context) (common.ClientConfigV2, error) {
 		PayloadDisperserCfg:          readPayloadDisperserCfg(ctx),
 		RelayPayloadRetrieverCfg:     readRelayRetrievalConfig(ctx),
 		ValidatorPayloadRetrieverCfg: readValidatorRetrievalConfig(ctx),
+		HedgedRetrievalEnabled:       ctx.Bool(HedgedRetrievalFlagName),
+		PayloadRetrieverCfg:          readPayloadRetrieverConfig(ctx),
 		PutTries:                     ctx.Int(PutRetriesFlagName),
 		MaxBlobSizeBytes:             maxBlobLengthBytes,
+		MaxPayloadChunks:             ctx.Int(MaxPayloadChunksFlagName),
 		// we don't expose this configuration to users, as all production use cases should have
 		// both retrieval methods enabled. This could be exposed in the future, if necessary.
 		// Note the order of these retrievers, which is significant: the relay retriever will be
@@ -331,8 +405,15 @@ func readPayloadClientConfig(ctx *cli.Context) clients_v2.PayloadClientConfig {
 		polyForm = codecs.PolynomialFormCoeff
 	}
 
+	// compressed payloads are only decoded when compression is enabled, so the version is set for retrieval too
+	encodingVersion := codecs.PayloadEncodingVersion0
+	if ctx.Bool(CompressPayloadsFlagName) {
+		encodingVersion = codecs.PayloadEncodingVersion1
+	}
+
 	return clients_v2.PayloadClientConfig{
-		PayloadPolynomialForm: polyForm,
+		PayloadPolynomialForm:  polyForm,
+		PayloadEncodingVersion: encodingVersion,
 		// #nosec G115 - only overflow on incorrect user input
 		BlobVersion: uint16(ctx.Int(BlobParamsVersionFlagName)),
 	}
@@ -347,6 +428,10 @@ func readPayloadDisperserCfg(ctx *cli.Context) dispersal.PayloadDisperserConfig
 		BlobCompleteTimeout:    ctx.Duration(BlobCertifiedTimeoutFlagName),
 		BlobStatusPollInterval: ctx.Duration(BlobStatusPollIntervalFlagName),
 		ContractCallTimeout:    ctx.Duration(ContractCallTimeoutFlagName),
+
+		DispersalJournalPath:              ctx.String(DispersalJournalPathFlagName),
+		DispersalJournalRetention:         ctx.Duration(DispersalJournalRetentionFlagName),
+		DispersalJournalMaxResumeAttempts: uint32(ctx.Uint(DispersalJournalMaxResumeAttemptsFlagName)),
 	}
 }
 
@@ -380,6 +465,13 @@ func readRelayRetrievalConfig(ctx *cli.Context) payloadretrieval.RelayPayloadRet
 	}
 }
 
+func readPayloadRetrieverConfig(ctx *cli.Context) payloadretrieval.PayloadRetrieverConfig {
+	return payloadretrieval.PayloadRetrieverConfig{
+		HedgeDelay:             ctx.Duration(HedgedRetrievalDelayFlagName),
+		PayloadEncodingVersion: readPayloadClientConfig(ctx).PayloadEncodingVersion,
+	}
+}
+
 func readValidatorRetrievalConfig(ctx *cli.Context) payloadretrieval.ValidatorPayloadRetrieverConfig {
 	return payloadretrieval.ValidatorPayloadRetrieverConfig{
 		PayloadClientConfig: readPayloadClientConfig(ctx),
@@ -84,7 +84,8 @@ func (cm *CountMap) Get(labels ...string) (uint64, error) {
 // and is only used for E2E testing. This is needed since prometheus/client_golang doesn't provide
 // an interface for reading the count values from the codified metric.
 type EmulatedMetricer struct {
-	HTTPServerRequestsTotal *CountMap
+	HTTPServerRequestsTotal       *CountMap
+	HTTPServerClientRequestsTotal *CountMap
 	// secondary metrics
 	SecondaryRequestsTotal *CountMap
 }
@@ -92,8 +93,9 @@ type EmulatedMetricer struct {
 // NewEmulatedMetricer ... constructor
 func NewEmulatedMetricer() *EmulatedMetricer {
 	return &EmulatedMetricer{
-		HTTPServerRequestsTotal: NewCountMap(),
-		SecondaryRequestsTotal:  NewCountMap(),
+		HTTPServerRequestsTotal:       NewCountMap(),
+		HTTPServerClientRequestsTotal: NewCountMap(),
+		SecondaryRequestsTotal:        NewCountMap(),
 	}
 }
 
@@ -117,6 +119,14 @@ func (n *EmulatedMetricer) RecordRPCServerRequest(method string) func(status, mo
 	}
 }
 
+// RecordClientRequest ... updates client requests counter associated with label fingerprint
+func (n *EmulatedMetricer) RecordClientRequest(client string, status string) {
+	err := n.HTTPServerClientRequestsTotal.insert(client, status)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // RecordSecondaryRequest ... updates secondary insertion counter associated with label fingerprint
 func (n *EmulatedMetricer) RecordSecondaryRequest(x string, y string) func(status string) {
 	return func(z string) {
@@ -19,6 +19,7 @@ type Metricer interface {
 	RecordUp()
 
 	RecordRPCServerRequest(method string) func(status string, mode string, ver string)
+	RecordClientRequest(client string, status string)
 	RecordSecondaryRequest(bt string, method string) func(status string)
 
 	Document() []metrics.DocumentedMetric
@@ -33,6 +34,7 @@ type Metrics struct {
 	HTTPServerRequestsTotal          *prometheus.CounterVec
 	HTTPServerBadRequestHeader       *prometheus.CounterVec
 	HTTPServerRequestDurationSeconds *prometheus.HistogramVec
+	HTTPServerClientRequestsTotal    *prometheus.CounterVec
 
 	// secondary metrics
 	SecondaryRequestsTotal      *prometheus.CounterVec
@@ -95,6 +97,14 @@ func NewMetrics(registry *prometheus.Registry) Metricer {
 		}, []string{
 			"method", // no status on histograms because those are very expensive
 		}),
+		HTTPServerClientRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: httpServerSubsystem,
+			Name:      "client_requests_total",
+			Help:      "Total requests to the HTTP server per authenticated client",
+		}, []string{
+			"client", "status",
+		}),
 		SecondaryRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: secondarySubsystem,
@@ -141,6 +151,12 @@ func (m *Metrics) RecordRPCServerRequest(method string) func(status, mode, ver s
 	}
 }
 
+// RecordClientRequest records a request made by a client, when authentication is enabled.
+// Cardinality of the client label is bounded by the clients configured by the operator.
+func (m *Metrics) RecordClientRequest(client string, status string) {
+	m.HTTPServerClientRequestsTotal.WithLabelValues(client, status).Inc()
+}
+
 // RecordSecondaryRequest records a secondary put/get operation.
 func (m *Metrics) RecordSecondaryRequest(bt string, method string) func(status string) {
 	timer := prometheus.NewTimer(m.SecondaryRequestDurationSec.WithLabelValues(bt))
@@ -170,6 +186,9 @@ func (n *noopMetricer) RecordRPCServerRequest(string) func(status, mode, ver str
 	return func(string, string, string) {}
 }
 
+func (n *noopMetricer) RecordClientRequest(string, string) {
+}
+
 func (n *noopMetricer) RecordSecondaryRequest(string, string) func(status string) {
 	return func(string) {}
 }
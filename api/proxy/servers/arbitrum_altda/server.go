@@ -29,6 +29,9 @@ type Config struct {
 	CompatibilityCfg   common.CompatibilityConfig
 }
 
+// jsonRPCRequestOverheadBytes is the room left in request bodies for everything besides the hex encoded payload
+const jsonRPCRequestOverheadBytes = 64 * 1024
+
 type Server struct {
 	cfg      *Config
 	svr      *http.Server
@@ -47,9 +50,10 @@ func NewServer(ctx context.Context, cfg *Config, h IHandlers) (*Server, error) {
 		return nil, fmt.Errorf("failed to register daprovider: %w", err)
 	}
 
-	// TODO: understand if this can be set dynamically via the MaxPayloadSizeBytes
-	//       field in the CompatibilityCfg that's computed by the MaxBlobSizeBytes
-	rpcServer.SetHTTPBodyLimit(int(common.MaxServerPOSTRequestBodySize))
+	// payloads are hex encoded in the JSON-RPC request body, so the limit has to be twice the max payload size,
+	// plus some room for the rest of the request
+	maxPayloadBodySize := common.MaxPayloadPOSTRequestBodySize(cfg.CompatibilityCfg.MaxPayloadSizeBytes)
+	rpcServer.SetHTTPBodyLimit(int(2*maxPayloadBodySize + jsonRPCRequestOverheadBytes))
 
 	var handler http.Handler
 	// go-ethereum puts specific constraints on JWT usage; ie:
@@ -0,0 +1,114 @@
+package grpc_server
+
+import (
+	"context"
+	"strings"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/proxy"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// gRPC metadata keys are lowercase versions of the REST server's auth headers.
+var (
+	apiKeyMetadataKey        = strings.ToLower(middleware.APIKeyHeader)
+	authorizationMetadataKey = "authorization"
+)
+
+// requiresAuth returns true for the methods that are authenticated. Like the REST server's /config and /health
+// routes, GetCompatibilityConfig and the methods of the health and reflection services are left unauthenticated.
+func requiresAuth(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+pb.Proxy_ServiceDesc.ServiceName+"/") &&
+		fullMethod != pb.Proxy_GetCompatibilityConfig_FullMethodName
+}
+
+// authenticate returns the client that made the request, from the API key or JWT bearer token in the metadata of
+// the request. See middleware.Authenticator.
+func (svr *Server) authenticate(ctx context.Context) (middleware.ClientConfig, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	firstValue := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	client, err := svr.auth.Authenticate(firstValue(apiKeyMetadataKey), firstValue(authorizationMetadataKey))
+	if err != nil {
+		setClientID(ctx, middleware.UnauthenticatedClientID)
+		return middleware.ClientConfig{}, proxyerrors.NewUnauthorizedError(err)
+	}
+	setClientID(ctx, client.Name)
+	return client, nil
+}
+
+// authUnaryInterceptor authenticates requests and charges them against the client's quotas. It is the gRPC
+// equivalent of the REST server's auth middleware. Only Put payloads count towards the byte quota, since those are
+// what get dispersed and paid for with the proxy's EigenDA payment account.
+func (svr *Server) authUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if svr.auth == nil || !requiresAuth(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	client, err := svr.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var size int64
+	if putRequest, ok := req.(*pb.PutRequest); ok {
+		size = int64(len(putRequest.GetPayload()))
+	}
+	err = svr.auth.Charge(client, 1, size)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authStreamInterceptor is the streaming equivalent of authUnaryInterceptor. The request is charged when the stream
+// is opened, and the payload chunks are charged against the byte quota as they are received.
+func (svr *Server) authStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if svr.auth == nil || !requiresAuth(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	client, err := svr.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	err = svr.auth.Charge(client, 1, 0)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &meteredServerStream{ServerStream: ss, auth: svr.auth, client: client})
+}
+
+// meteredServerStream charges the payload chunks received on a PutStream against the client's byte quota.
+type meteredServerStream struct {
+	grpc.ServerStream
+	auth   *middleware.Authenticator
+	client middleware.ClientConfig
+}
+
+func (s *meteredServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+	if request, ok := m.(*pb.PutStreamRequest); ok {
+		return s.auth.Charge(s.client, 0, int64(len(request.GetPayloadChunk())))
+	}
+	return nil
+}
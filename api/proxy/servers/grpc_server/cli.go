@@ -0,0 +1,42 @@
+package grpc_server
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	ListenAddrFlagName = "grpc.addr"
+	PortFlagName       = "grpc.port"
+)
+
+func withEnvPrefix(prefix, s string) []string {
+	return []string{prefix + "_GRPC_" + s}
+}
+
+func CLIFlags(envPrefix string, category string) []cli.Flag {
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:     ListenAddrFlagName,
+			Usage:    "Server listening address",
+			Value:    "0.0.0.0",
+			EnvVars:  withEnvPrefix(envPrefix, "ADDR"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     PortFlagName,
+			Usage:    "Server listening port",
+			Value:    3102,
+			EnvVars:  withEnvPrefix(envPrefix, "PORT"),
+			Category: category,
+		},
+	}
+
+	return flags
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Host: ctx.String(ListenAddrFlagName),
+		Port: ctx.Int(PortFlagName),
+	}
+}
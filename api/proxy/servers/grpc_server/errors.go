@@ -0,0 +1,59 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorToStatus maps an internal error to the gRPC status returned to clients.
+// It mirrors the REST server's mapping of errors to HTTP status codes (see middleware.ErrorToHTTPStatus):
+//
+//   - 400 BAD_REQUEST -> INVALID_ARGUMENT
+//   - 401 UNAUTHORIZED -> UNAUTHENTICATED
+//   - 418 TEAPOT -> FAILED_PRECONDITION, with the same JSON body as the REST server as message,
+//     which derivation pipelines use to decide to drop the commitment
+//   - 429 TOO_MANY_REQUESTS -> RESOURCE_EXHAUSTED
+//   - 503 SERVICE_UNAVAILABLE -> UNAVAILABLE, which batchers use to failover to ethDA
+//   - 500 INTERNAL_SERVER_ERROR -> INTERNAL
+func errorToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var derivationErr coretypes.DerivationError
+	switch {
+	case proxyerrors.Is400(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case proxyerrors.Is401(err):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.As(err, &derivationErr):
+		return status.Error(codes.FailedPrecondition, derivationErr.MarshalToTeapotBody())
+	case proxyerrors.Is429(err):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case proxyerrors.Is503(err):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// isClientError returns true for the status codes that are caused by the client rather than by the proxy,
+// the equivalent of REST 4xx errors.
+func isClientError(code codes.Code) bool {
+	switch code {
+	case codes.InvalidArgument, codes.Unauthenticated, codes.FailedPrecondition,
+		codes.ResourceExhausted, codes.Canceled, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
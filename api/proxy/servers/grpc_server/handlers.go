@@ -0,0 +1,200 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/proxy"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+)
+
+// Put disperses a payload, and returns the commitment to it.
+func (svr *Server) Put(ctx context.Context, request *pb.PutRequest) (*pb.PutReply, error) {
+	mode, err := parseCommitmentMode(request.GetCommitmentMode())
+	if err != nil {
+		return nil, err
+	}
+	setCommitmentMode(ctx, mode)
+
+	if int64(len(request.GetPayload())) > svr.maxPayloadSize() {
+		return nil, fmt.Errorf("%w: payload is %d bytes, max is %d bytes",
+			proxyerrors.ErrProxyOversizedBlob, len(request.GetPayload()), svr.maxPayloadSize())
+	}
+
+	return svr.put(ctx, request.GetPayload(), mode)
+}
+
+// PutStream disperses a payload sent as a stream of chunks, and returns the commitment to it.
+func (svr *Server) PutStream(stream pb.Proxy_PutStreamServer) error {
+	var payload []byte
+	var mode commitments.CommitmentMode
+	for first := true; ; first = false {
+		request, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			if first {
+				return proxyerrors.NewParsingError(errors.New("stream closed before any message was received"))
+			}
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("receive payload chunk: %w", err)
+		}
+
+		if first {
+			mode, err = parseCommitmentMode(request.GetCommitmentMode())
+			if err != nil {
+				return err
+			}
+			setCommitmentMode(stream.Context(), mode)
+		}
+
+		if int64(len(payload)+len(request.GetPayloadChunk())) > svr.maxPayloadSize() {
+			return fmt.Errorf("%w: streamed payload exceeds the max of %d bytes",
+				proxyerrors.ErrProxyOversizedBlob, svr.maxPayloadSize())
+		}
+		payload = append(payload, request.GetPayloadChunk()...)
+	}
+
+	reply, err := svr.put(stream.Context(), payload, mode)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(reply)
+}
+
+// put is shared by Put and PutStream.
+func (svr *Server) put(
+	ctx context.Context,
+	payload []byte,
+	mode commitments.CommitmentMode,
+) (*pb.PutReply, error) {
+	if mode == commitments.OptimismKeccakCommitmentMode {
+		// Unlike the REST route, clients don't send the commitment along with the payload,
+		// since the commitment is fully determined by the payload.
+		commitment := commitments.NewOPKeccak256Commitment(payload)
+		err := svr.keccakMgr.PutOPKeccakPairInS3(ctx, commitment, payload)
+		if err != nil {
+			return nil, fmt.Errorf("keccak put request failed for commitment %x: %w", []byte(commitment), err)
+		}
+		return &pb.PutReply{Commitment: commitment.Encode()}, nil
+	}
+
+	versionedCert, err := svr.certMgr.Put(ctx, payload, coretypes.CertSerializationRLP)
+	if err != nil {
+		return nil, fmt.Errorf("put request failed: %w", err)
+	}
+	setCertVersion(ctx, string(versionedCert.Version))
+
+	commitment, err := commitments.EncodeCommitment(versionedCert, mode)
+	if err != nil {
+		// This should never happen if the mode was parsed correctly.
+		return nil, fmt.Errorf("failed to encode commitment %v (commitment mode %v): %w",
+			versionedCert, mode, err)
+	}
+
+	svr.log.Info("Processed request", "method", pb.Proxy_Put_FullMethodName, "commitmentMode", mode,
+		"certVersion", versionedCert.Version, "cert", versionedCert.SerializedCert, "payloadLen", len(payload))
+	return &pb.PutReply{Commitment: commitment}, nil
+}
+
+// Get retrieves the payload committed to by a commitment.
+func (svr *Server) Get(ctx context.Context, request *pb.GetRequest) (*pb.GetReply, error) {
+	mode, err := parseCommitmentMode(request.GetCommitmentMode())
+	if err != nil {
+		return nil, err
+	}
+	setCommitmentMode(ctx, mode)
+
+	if mode == commitments.OptimismKeccakCommitmentMode {
+		return svr.getOPKeccak(ctx, request)
+	}
+
+	versionedCert, err := commitments.DecodeCommitment(request.GetCommitment(), mode)
+	if err != nil {
+		return nil, proxyerrors.NewParsingError(fmt.Errorf("decoding commitment: %w", err))
+	}
+	setCertVersion(ctx, string(versionedCert.Version))
+
+	payloadOrEncodedPayload, err := svr.certMgr.Get(
+		ctx,
+		versionedCert,
+		coretypes.CertSerializationRLP,
+		common.GETOpts{
+			L1InclusionBlockNum:  request.GetL1InclusionBlockNumber(),
+			ReturnEncodedPayload: request.GetReturnEncodedPayload(),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get request failed with serializedCert (version %v) %x: %w",
+			versionedCert.Version, versionedCert.SerializedCert, err)
+	}
+
+	svr.log.Info("Processed request", "method", pb.Proxy_Get_FullMethodName,
+		"returnEncodedPayload", request.GetReturnEncodedPayload(),
+		"certVersion", versionedCert.Version, "serializedCert", versionedCert.SerializedCert)
+	return &pb.GetReply{Payload: payloadOrEncodedPayload}, nil
+}
+
+// getOPKeccak retrieves the payload committed to by an op keccak commitment from S3.
+func (svr *Server) getOPKeccak(ctx context.Context, request *pb.GetRequest) (*pb.GetReply, error) {
+	if request.GetReturnEncodedPayload() {
+		return nil, proxyerrors.NewParsingError(
+			errors.New("return_encoded_payload is not supported for op keccak commitments"))
+	}
+
+	// [0x00 | keccak256(payload)]
+	commitment := request.GetCommitment()
+	if len(commitment) != 33 || commitment[0] != byte(commitments.OPKeccak256CommitmentByte) {
+		return nil, proxyerrors.NewParsingError(
+			fmt.Errorf("op keccak commitment must be 0x00 followed by a 32 byte hash, got %x", commitment))
+	}
+
+	payload, err := svr.keccakMgr.GetOPKeccakValueFromS3(ctx, commitment[1:])
+	if err != nil {
+		return nil, fmt.Errorf("get keccakCommitment %x: %w", commitment[1:], err)
+	}
+	return &pb.GetReply{Payload: payload}, nil
+}
+
+// GetCompatibilityConfig returns the same values as the REST server's /config route.
+func (svr *Server) GetCompatibilityConfig(
+	context.Context,
+	*pb.GetCompatibilityConfigRequest,
+) (*pb.GetCompatibilityConfigReply, error) {
+	cfg := svr.config.CompatibilityCfg
+	supportedVersions := make([]uint32, 0, len(cfg.SupportedPayloadEncodingVersions))
+	for _, version := range cfg.SupportedPayloadEncodingVersions {
+		supportedVersions = append(supportedVersions, uint32(version))
+	}
+
+	return &pb.GetCompatibilityConfigReply{
+		Version:                          cfg.Version,
+		ChainId:                          cfg.ChainID,
+		DirectoryAddress:                 cfg.DirectoryAddress,
+		CertVerifierAddress:              cfg.CertVerifierAddress,
+		MaxPayloadSizeBytes:              cfg.MaxPayloadSizeBytes,
+		ApisEnabled:                      cfg.APIsEnabled,
+		ReadOnlyMode:                     cfg.ReadOnlyMode,
+		PayloadEncodingVersion:           uint32(cfg.PayloadEncodingVersion),
+		SupportedPayloadEncodingVersions: supportedVersions,
+	}, nil
+}
+
+// parseCommitmentMode converts a protobuf commitment mode into the proxy's commitment mode.
+func parseCommitmentMode(mode pb.CommitmentMode) (commitments.CommitmentMode, error) {
+	switch mode {
+	case pb.CommitmentMode_COMMITMENT_MODE_STANDARD:
+		return commitments.StandardCommitmentMode, nil
+	case pb.CommitmentMode_COMMITMENT_MODE_OP_GENERIC:
+		return commitments.OptimismGenericCommitmentMode, nil
+	case pb.CommitmentMode_COMMITMENT_MODE_OP_KECCAK:
+		return commitments.OptimismKeccakCommitmentMode, nil
+	default:
+		return "", proxyerrors.NewParsingError(fmt.Errorf("unsupported commitment mode: %v", mode))
+	}
+}
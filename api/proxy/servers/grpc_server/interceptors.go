@@ -0,0 +1,118 @@
+package grpc_server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type requestInfoKey struct{}
+
+// requestInfo is filled in by the handlers once they have parsed the request, so that the interceptors can record
+// the commitment mode and cert version of a request in metrics and logs.
+// It plays the same role as the REST server's middleware.RequestContext.
+type requestInfo struct {
+	commitmentMode string
+	certVersion    string
+	// set by the auth interceptors, empty if authentication is disabled
+	clientID string
+}
+
+func withRequestInfo(ctx context.Context) (context.Context, *requestInfo) {
+	info := &requestInfo{commitmentMode: "unknown", certVersion: "unknown"}
+	return context.WithValue(ctx, requestInfoKey{}, info), info
+}
+
+func getRequestInfo(ctx context.Context) *requestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(*requestInfo)
+	return info
+}
+
+func setCommitmentMode(ctx context.Context, mode commitments.CommitmentMode) {
+	if info := getRequestInfo(ctx); info != nil {
+		info.commitmentMode = string(mode)
+	}
+}
+
+func setCertVersion(ctx context.Context, version string) {
+	if info := getRequestInfo(ctx); info != nil {
+		info.certVersion = version
+	}
+}
+
+func setClientID(ctx context.Context, clientID string) {
+	if info := getRequestInfo(ctx); info != nil {
+		info.clientID = clientID
+	}
+}
+
+// unaryInterceptor converts errors returned by the handlers into gRPC statuses, and records metrics and logs for
+// each request. It is the gRPC equivalent of the REST server's middlewares.
+func (svr *Server) unaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, reqInfo := withRequestInfo(ctx)
+	done := svr.startRequest(info.FullMethod)
+	resp, err := handler(ctx, req)
+	err = errorToStatus(err)
+	done(reqInfo, err)
+	return resp, err
+}
+
+// streamInterceptor is the streaming equivalent of unaryInterceptor.
+func (svr *Server) streamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, reqInfo := withRequestInfo(ss.Context())
+	done := svr.startRequest(info.FullMethod)
+	err := errorToStatus(handler(srv, &serverStreamWithContext{ServerStream: ss, ctx: ctx}))
+	done(reqInfo, err)
+	return err
+}
+
+// startRequest starts recording a request, and returns a function to call with the outcome of the request.
+func (svr *Server) startRequest(method string) func(reqInfo *requestInfo, err error) {
+	start := time.Now()
+	recordDur := svr.metrics.RecordRPCServerRequest(method)
+	return func(reqInfo *requestInfo, err error) {
+		code := status.Code(err)
+		recordDur(code.String(), reqInfo.commitmentMode, reqInfo.certVersion)
+
+		args := []any{
+			"method", method,
+			"commitment_mode", reqInfo.commitmentMode, "cert_version", reqInfo.certVersion,
+			"status", code.String(), "duration", time.Since(start),
+		}
+		if reqInfo.clientID != "" {
+			args = append(args, "client", reqInfo.clientID)
+		}
+		switch {
+		case err == nil:
+			svr.log.Info("request completed", args...)
+		case isClientError(code):
+			svr.log.Warn("request completed with client error", append(args, "error", err.Error())...)
+		default:
+			svr.log.Error("request completed with error", append(args, "error", err.Error())...)
+		}
+	}
+}
+
+// serverStreamWithContext overrides the context of a grpc.ServerStream, since streams don't have a way
+// to pass a modified context to handlers.
+type serverStreamWithContext struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStreamWithContext) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,125 @@
+package grpc_server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/proxy"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// grpcMessageOverheadBytes is the room left in gRPC messages for everything besides the payload
+const grpcMessageOverheadBytes = 64 * 1024
+
+type Config struct {
+	Host             string
+	Port             int
+	CompatibilityCfg common.CompatibilityConfig
+}
+
+// Server is the gRPC ALT DA server of the proxy. It offers the same functionality as the REST server,
+// see the Proxy service in api/proto/proxy/proxy.proto.
+type Server struct {
+	pb.UnimplementedProxyServer
+
+	config    Config
+	log       logging.Logger
+	certMgr   store.IEigenDAManager
+	keccakMgr store.IKeccakManager
+	metrics   metrics.Metricer
+	// nil if authentication is disabled
+	auth *middleware.Authenticator
+
+	grpcServer *grpc.Server
+	listener   net.Listener
+}
+
+var _ pb.ProxyServer = &Server{}
+
+// NewServer constructs the gRPC server, and starts listening on the configured address.
+// Requests are only served once Start is called.
+//
+// auth authenticates the Put, PutStream and Get methods, and should be shared with the REST server so that each client
+// has a single quota across both servers. It is nil if authentication is disabled.
+func NewServer(
+	config Config,
+	certMgr store.IEigenDAManager,
+	keccakMgr store.IKeccakManager,
+	auth *middleware.Authenticator,
+	log logging.Logger,
+	m metrics.Metricer,
+) (*Server, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", config.Host, config.Port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on tcp: %w", err)
+	}
+
+	svr := &Server{
+		config:    config,
+		log:       log,
+		certMgr:   certMgr,
+		keccakMgr: keccakMgr,
+		metrics:   m,
+		auth:      auth,
+		listener:  listener,
+	}
+
+	// Put requests and Get replies carry a whole payload, so messages need to be able to hold the largest payload.
+	maxMessageSize := int(svr.maxPayloadSize() + grpcMessageOverheadBytes)
+	svr.grpcServer = grpc.NewServer(
+		grpc.MaxRecvMsgSize(maxMessageSize),
+		grpc.MaxSendMsgSize(maxMessageSize),
+		// the auth interceptors come second, so that their errors are converted to statuses and logged
+		grpc.ChainUnaryInterceptor(svr.unaryInterceptor, svr.authUnaryInterceptor),
+		grpc.ChainStreamInterceptor(svr.streamInterceptor, svr.authStreamInterceptor),
+	)
+	pb.RegisterProxyServer(svr.grpcServer, svr)
+	reflection.Register(svr.grpcServer)
+	healthcheck.RegisterHealthServer(pb.Proxy_ServiceDesc.ServiceName, svr.grpcServer)
+
+	return svr, nil
+}
+
+// Start serves the gRPC server on an independent go routine
+func (svr *Server) Start() error {
+	go func() {
+		if err := svr.grpcServer.Serve(svr.listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			svr.log.Error("gRPC server's Serve method returned an error", "err", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully stops the server, waiting for in-flight requests to complete.
+func (svr *Server) Stop() error {
+	svr.grpcServer.GracefulStop()
+	return nil
+}
+
+// Port returns the port that the server is listening on.
+// Useful in case Config.Port was set to 0 to let the OS assign a random port.
+func (svr *Server) Port() int {
+	_, portStr, _ := net.SplitHostPort(svr.listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	return port
+}
+
+// Addr returns the address that the server is listening on.
+func (svr *Server) Addr() string {
+	return svr.listener.Addr().String()
+}
+
+// maxPayloadSize returns the size of the largest payload accepted by Put and PutStream.
+func (svr *Server) maxPayloadSize() int64 {
+	return common.MaxPayloadPOSTRequestBodySize(svr.config.CompatibilityCfg.MaxPayloadSizeBytes)
+}
@@ -0,0 +1,303 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/proxy"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+var testCompatibilityConfig = common.CompatibilityConfig{
+	Version:                          "1.2.3",
+	ChainID:                          "17000",
+	MaxPayloadSizeBytes:              1024,
+	APIsEnabled:                      []string{"grpc"},
+	SupportedPayloadEncodingVersions: []int{0, 1},
+}
+
+// startTestServer starts a server backed by mock managers on a random port, and returns a client connected to it.
+func startTestServer(
+	t *testing.T,
+) (pb.ProxyClient, *mocks.MockIEigenDAManager, *mocks.MockIKeccakManager) {
+	return startTestServerWithAuth(t, nil)
+}
+
+// startTestServerWithAuth is like startTestServer, but with the given authenticator.
+func startTestServerWithAuth(
+	t *testing.T,
+	auth *middleware.Authenticator,
+) (pb.ProxyClient, *mocks.MockIEigenDAManager, *mocks.MockIKeccakManager) {
+	ctrl := gomock.NewController(t)
+	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+	mockKeccakManager := mocks.NewMockIKeccakManager(ctrl)
+
+	cfg := Config{Host: "localhost", Port: 0, CompatibilityCfg: testCompatibilityConfig}
+	server, err := NewServer(cfg, mockEigenDAManager, mockKeccakManager, auth, testLogger,
+		metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() { _ = server.Stop() })
+
+	conn, err := grpc.NewClient(server.Addr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewProxyClient(conn), mockEigenDAManager, mockKeccakManager
+}
+
+func TestPutGet(t *testing.T) {
+	modes := []struct {
+		pbMode pb.CommitmentMode
+		mode   commitments.CommitmentMode
+	}{
+		{pb.CommitmentMode_COMMITMENT_MODE_STANDARD, commitments.StandardCommitmentMode},
+		{pb.CommitmentMode_COMMITMENT_MODE_OP_GENERIC, commitments.OptimismGenericCommitmentMode},
+	}
+
+	for _, tc := range modes {
+		t.Run(string(tc.mode), func(t *testing.T) {
+			ctx := context.Background()
+			client, mockEigenDAManager, _ := startTestServer(t)
+
+			payload := []byte("some payload")
+			versionedCert := certs.NewVersionedCert([]byte("cert"), certs.V2VersionByte)
+			expectedCommitment, err := commitments.EncodeCommitment(versionedCert, tc.mode)
+			require.NoError(t, err)
+
+			mockEigenDAManager.EXPECT().Put(gomock.Any(), payload, coretypes.CertSerializationRLP).
+				Return(versionedCert, nil).Times(2)
+			mockEigenDAManager.EXPECT().Get(gomock.Any(), versionedCert, coretypes.CertSerializationRLP,
+				common.GETOpts{L1InclusionBlockNum: 100}).Return(payload, nil)
+
+			putReply, err := client.Put(ctx, &pb.PutRequest{CommitmentMode: tc.pbMode, Payload: payload})
+			require.NoError(t, err)
+			require.Equal(t, expectedCommitment, putReply.GetCommitment())
+
+			// the payload is reassembled from the chunks of the stream
+			stream, err := client.PutStream(ctx)
+			require.NoError(t, err)
+			require.NoError(t, stream.Send(&pb.PutStreamRequest{CommitmentMode: tc.pbMode, PayloadChunk: payload[:4]}))
+			require.NoError(t, stream.Send(&pb.PutStreamRequest{PayloadChunk: payload[4:]}))
+			putReply, err = stream.CloseAndRecv()
+			require.NoError(t, err)
+			require.Equal(t, expectedCommitment, putReply.GetCommitment())
+
+			getReply, err := client.Get(ctx, &pb.GetRequest{
+				CommitmentMode:         tc.pbMode,
+				Commitment:             expectedCommitment,
+				L1InclusionBlockNumber: 100,
+			})
+			require.NoError(t, err)
+			require.Equal(t, payload, getReply.GetPayload())
+		})
+	}
+}
+
+func TestPutGetOPKeccak(t *testing.T) {
+	ctx := context.Background()
+	client, _, mockKeccakManager := startTestServer(t)
+
+	payload := []byte("some payload")
+	keccak := crypto.Keccak256(payload)
+	mockKeccakManager.EXPECT().PutOPKeccakPairInS3(gomock.Any(), keccak, payload).Return(nil)
+	mockKeccakManager.EXPECT().GetOPKeccakValueFromS3(gomock.Any(), keccak).Return(payload, nil)
+
+	putReply, err := client.Put(ctx, &pb.PutRequest{
+		CommitmentMode: pb.CommitmentMode_COMMITMENT_MODE_OP_KECCAK,
+		Payload:        payload,
+	})
+	require.NoError(t, err)
+	require.Equal(t, append([]byte{0x00}, keccak...), putReply.GetCommitment())
+
+	getReply, err := client.Get(ctx, &pb.GetRequest{
+		CommitmentMode: pb.CommitmentMode_COMMITMENT_MODE_OP_KECCAK,
+		Commitment:     putReply.GetCommitment(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, payload, getReply.GetPayload())
+}
+
+func TestInvalidRequests(t *testing.T) {
+	ctx := context.Background()
+	// the managers must not be called for invalid requests
+	client, _, _ := startTestServer(t)
+
+	_, err := client.Put(ctx, &pb.PutRequest{Payload: []byte("payload")})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "unspecified commitment mode")
+
+	_, err = client.Put(ctx, &pb.PutRequest{
+		CommitmentMode: pb.CommitmentMode_COMMITMENT_MODE_STANDARD,
+		Payload:        make([]byte, common.MaxServerPOSTRequestBodySize+1),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "oversized payload")
+
+	stream, err := client.PutStream(ctx)
+	require.NoError(t, err)
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "empty stream")
+
+	_, err = client.Get(ctx, &pb.GetRequest{
+		CommitmentMode: pb.CommitmentMode_COMMITMENT_MODE_OP_GENERIC,
+		Commitment:     []byte{0x00, 0x00, 0x02, 0xaa},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "malformed commitment")
+
+	_, err = client.Get(ctx, &pb.GetRequest{
+		CommitmentMode: pb.CommitmentMode_COMMITMENT_MODE_OP_KECCAK,
+		Commitment:     []byte{0x00, 0xaa},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "malformed keccak commitment")
+}
+
+func TestAuth(t *testing.T) {
+	client, mockEigenDAManager, _ := startTestServerWithAuth(t, middleware.NewAuthenticator(middleware.AuthConfig{
+		Clients: []middleware.ClientConfig{
+			{Name: "rollup-a", APIKey: "key-a", BytesPerSecond: 1},
+		},
+		QuotaBurstDuration: time.Second,
+	}))
+	authedCtx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "key-a")
+
+	payload := []byte("some payload")
+	versionedCert := certs.NewVersionedCert([]byte("cert"), certs.V2VersionByte)
+	mockEigenDAManager.EXPECT().Put(gomock.Any(), payload, coretypes.CertSerializationRLP).
+		Return(versionedCert, nil).Times(1)
+
+	putRequest := &pb.PutRequest{CommitmentMode: pb.CommitmentMode_COMMITMENT_MODE_STANDARD, Payload: payload}
+	_, err := client.Put(context.Background(), putRequest)
+	require.Equal(t, codes.Unauthenticated, status.Code(err), "missing api key")
+	wrongKeyCtx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "key-b")
+	_, err = client.Put(wrongKeyCtx, putRequest)
+	require.Equal(t, codes.Unauthenticated, status.Code(err), "unknown api key")
+
+	stream, err := client.PutStream(context.Background())
+	require.NoError(t, err)
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.Unauthenticated, status.Code(err), "unauthenticated stream")
+
+	_, err = client.Get(context.Background(), &pb.GetRequest{
+		CommitmentMode: pb.CommitmentMode_COMMITMENT_MODE_STANDARD,
+		Commitment:     []byte{byte(certs.V2VersionByte), 0x01},
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err), "unauthenticated get")
+
+	// the compatibility config doesn't require authentication, same as the REST /config route
+	_, err = client.GetCompatibilityConfig(context.Background(), &pb.GetCompatibilityConfigRequest{})
+	require.NoError(t, err)
+
+	_, err = client.Put(authedCtx, putRequest)
+	require.NoError(t, err)
+	// the first payload used up the byte quota
+	_, err = client.Put(authedCtx, putRequest)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err), "byte quota exceeded")
+}
+
+func TestAuthQuotaSharedWithRESTServer(t *testing.T) {
+	auth := middleware.NewAuthenticator(middleware.AuthConfig{
+		Clients: []middleware.ClientConfig{
+			{Name: "rollup-a", APIKey: "key-a", BytesPerSecond: 1},
+		},
+		QuotaBurstDuration: time.Second,
+	})
+	client, _, _ := startTestServerWithAuth(t, auth)
+
+	// the REST server charges the same authenticator for a payload, which uses up the byte quota
+	payload := []byte("some payload")
+	restClient, err := auth.Authenticate("key-a", "")
+	require.NoError(t, err)
+	require.NoError(t, auth.Charge(restClient, 1, int64(len(payload))))
+
+	authedCtx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "key-a")
+	_, err = client.Put(authedCtx, &pb.PutRequest{
+		CommitmentMode: pb.CommitmentMode_COMMITMENT_MODE_STANDARD,
+		Payload:        payload,
+	})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err), "byte quota used up by the REST server")
+}
+
+func TestGetCompatibilityConfig(t *testing.T) {
+	client, _, _ := startTestServer(t)
+
+	reply, err := client.GetCompatibilityConfig(context.Background(), &pb.GetCompatibilityConfigRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3", reply.GetVersion())
+	require.Equal(t, "17000", reply.GetChainId())
+	require.Equal(t, uint32(1024), reply.GetMaxPayloadSizeBytes())
+	require.Equal(t, []string{"grpc"}, reply.GetApisEnabled())
+	require.Equal(t, []uint32{0, 1}, reply.GetSupportedPayloadEncodingVersions())
+}
+
+func TestErrorToStatus(t *testing.T) {
+	derivationErr := coretypes.ErrInvalidCertDerivationError.WithMessage("invalid cert")
+
+	tests := []struct {
+		name            string
+		err             error
+		expectedCode    codes.Code
+		expectedMessage string
+	}{
+		{
+			name:         "parsing error",
+			err:          proxyerrors.NewParsingError(errors.New("bad request")),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:            "derivation error",
+			err:             fmt.Errorf("get: %w", derivationErr),
+			expectedCode:    codes.FailedPrecondition,
+			expectedMessage: derivationErr.MarshalToTeapotBody(),
+		},
+		{
+			name:         "failover",
+			err:          fmt.Errorf("put: %w", &api.ErrorFailover{}),
+			expectedCode: codes.Unavailable,
+		},
+		{
+			name:         "disperser rate limit",
+			err:          fmt.Errorf("put: %w", status.Error(codes.ResourceExhausted, "slow down")),
+			expectedCode: codes.ResourceExhausted,
+		},
+		{
+			name:         "internal error",
+			err:          errors.New("oops"),
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(errorToStatus(tt.err))
+			require.True(t, ok)
+			require.Equal(t, tt.expectedCode, st.Code())
+			if tt.expectedMessage != "" {
+				require.Equal(t, tt.expectedMessage, st.Message())
+			}
+		})
+	}
+
+	require.NoError(t, errorToStatus(nil))
+}
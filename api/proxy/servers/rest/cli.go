@@ -1,10 +1,17 @@
 package rest
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/proxy/common"
 	"github.com/Layr-Labs/eigenda/api/proxy/config/enablement"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/dispersaljobs"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 )
 
@@ -12,6 +19,10 @@ const (
 	ListenAddrFlagName = "addr"
 	PortFlagName       = "port"
 
+	AuthClientsFileFlagName        = "auth.clients-file"
+	AuthJWTSecretFlagName          = "auth.jwt-secret"
+	AuthQuotaBurstDurationFlagName = "auth.quota-burst-duration"
+
 	DeprecatedAPIsEnabledFlagName = "api-enabled"
 	DeprecatedAdminAPIType        = "admin"
 )
@@ -55,12 +66,38 @@ func CLIFlags(envPrefix string, category string) []cli.Flag {
 			EnvVars:  withEnvPrefix(envPrefix, "PORT"),
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name: AuthClientsFileFlagName,
+			Usage: "Path to a JSON file listing the clients allowed to use the cert routes and the gRPC server, " +
+				"as [{\"name\", \"api_key\", \"requests_per_second\", \"bytes_per_second\"}]. " +
+				"Setting this flag or --" + AuthJWTSecretFlagName + " enables authentication.",
+			EnvVars:  withEnvPrefix(envPrefix, "AUTH_CLIENTS_FILE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: AuthJWTSecretFlagName,
+			Usage: "Path to a hex encoded 32 byte HS256 secret used to verify JWT bearer tokens. " +
+				"The sub claim of a token must match the name of a client in --" + AuthClientsFileFlagName + ".",
+			EnvVars:  withEnvPrefix(envPrefix, "AUTH_JWT_SECRET"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     AuthQuotaBurstDurationFlagName,
+			Usage:    "How long a client can burst at its full quota. Quota bucket capacities are rate * duration.",
+			Value:    10 * time.Second,
+			EnvVars:  withEnvPrefix(envPrefix, "AUTH_QUOTA_BURST_DURATION"),
+			Category: category,
+		},
 	}
 
 	return flags
 }
 
-func ReadConfig(ctx *cli.Context, apisEnabled *enablement.RestApisEnabled) Config {
+func ReadConfig(ctx *cli.Context, apisEnabled *enablement.RestApisEnabled) (Config, error) {
+	authCfg, err := readAuthConfig(ctx)
+	if err != nil {
+		return Config{}, fmt.Errorf("read auth config: %w", err)
+	}
 	return Config{
 		Host:        ctx.String(ListenAddrFlagName),
 		Port:        ctx.Int(PortFlagName),
@@ -68,5 +105,37 @@ func ReadConfig(ctx *cli.Context, apisEnabled *enablement.RestApisEnabled) Confi
 		// We can't set compatibility values until after configs have been read as
 		// ChainID requires an ethClient connection.
 		CompatibilityCfg: common.CompatibilityConfig{},
+		Auth:             authCfg,
+		AsyncDispersal:   dispersaljobs.ReadConfig(ctx),
+	}, nil
+}
+
+func readAuthConfig(ctx *cli.Context) (middleware.AuthConfig, error) {
+	cfg := middleware.AuthConfig{
+		QuotaBurstDuration: ctx.Duration(AuthQuotaBurstDurationFlagName),
+	}
+
+	if path := ctx.String(AuthClientsFileFlagName); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return middleware.AuthConfig{}, fmt.Errorf("read clients file %s: %w", path, err)
+		}
+		if err := json.Unmarshal(data, &cfg.Clients); err != nil {
+			return middleware.AuthConfig{}, fmt.Errorf("unmarshal clients file %s: %w", path, err)
+		}
+	}
+
+	if path := ctx.String(AuthJWTSecretFlagName); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return middleware.AuthConfig{}, fmt.Errorf("read JWT secret %s: %w", path, err)
+		}
+		secret := gethcommon.FromHex(strings.TrimSpace(string(data)))
+		if len(secret) != 32 {
+			return middleware.AuthConfig{}, fmt.Errorf("invalid JWT secret length, expected 32 bytes but got %d", len(secret))
+		}
+		cfg.JWTSecret = secret
 	}
+
+	return cfg, nil
 }
@@ -0,0 +1,101 @@
+package dispersaljobs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	EnabledFlagName         = withFlagPrefix("enabled")
+	PersistencePathFlagName = withFlagPrefix("persistence-path")
+	RetentionFlagName       = withFlagPrefix("retention")
+	MaxInFlightFlagName     = withFlagPrefix("max-in-flight")
+)
+
+// DispersalJournalDirectory is the directory, under the persistence path, that holds the dispersal journal when
+// no journal path is configured explicitly.
+const DispersalJournalDirectory = "dispersal-journal"
+
+func withFlagPrefix(s string) string {
+	return "async-dispersal." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_ASYNC_DISPERSAL_" + s}
+}
+
+// Config ... configures asynchronous dispersal jobs
+type Config struct {
+	// Enabled turns on the POST /put?async=true and GET /put/status/{id} routes.
+	Enabled bool
+	// Directory where jobs are persisted, so that in-flight jobs survive a restart. If empty, jobs are only kept
+	// in memory.
+	PersistencePath string
+	// How long the result of a finished job can be queried for.
+	Retention time.Duration
+	// Maximum number of jobs dispersing at the same time. Further submissions are rejected with a 429.
+	MaxInFlight int
+}
+
+// Check ... checks that the config is valid
+func (c Config) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Retention <= 0 {
+		return fmt.Errorf("%s must be > 0, got %s", RetentionFlagName, c.Retention)
+	}
+	if c.MaxInFlight <= 0 {
+		return fmt.Errorf("%s must be > 0, got %d", MaxInFlightFlagName, c.MaxInFlight)
+	}
+	return nil
+}
+
+// CLIFlags ... used for asynchronous dispersal configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name: EnabledFlagName,
+			Usage: "Enable asynchronous dispersal. POST /put?async=true then returns a job ID immediately, " +
+				"whose progress and commitment are queried with GET /put/status/{id}.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "ENABLED"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: PersistencePathFlagName,
+			Usage: "Directory where asynchronous dispersal jobs are persisted, so that in-flight jobs are restarted " +
+				"after a proxy restart. If empty, jobs are only kept in memory. Unless --eigenda.v2.dispersal-journal-path " +
+				"is set, the dispersal journal is also kept in this directory, so that restarted jobs resume their blob " +
+				"instead of dispersing it again.",
+			EnvVars:  withEnvPrefix(envPrefix, "PERSISTENCE_PATH"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     RetentionFlagName,
+			Usage:    "Duration that the result of a finished asynchronous dispersal job can be queried for.",
+			Value:    24 * time.Hour,
+			EnvVars:  withEnvPrefix(envPrefix, "RETENTION"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     MaxInFlightFlagName,
+			Usage:    "Maximum number of asynchronous dispersal jobs in flight. Further submissions get a 429.",
+			Value:    100,
+			EnvVars:  withEnvPrefix(envPrefix, "MAX_IN_FLIGHT"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Enabled:         ctx.Bool(EnabledFlagName),
+		PersistencePath: ctx.String(PersistencePathFlagName),
+		Retention:       ctx.Duration(RetentionFlagName),
+		MaxInFlight:     ctx.Int(MaxInFlightFlagName),
+	}
+}
@@ -0,0 +1,68 @@
+package dispersaljobs
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/dispersal"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Status is the progress of an asynchronous dispersal job.
+type Status string
+
+const (
+	// The job has been accepted. The payload is being dispersed, or is waiting in the disperser's queue.
+	StatusQueued Status = Status(dispersal.DispersalProgressQueued)
+	// The disperser has encoded the blob, and is gathering signatures from validators.
+	StatusEncoded Status = Status(dispersal.DispersalProgressEncoded)
+	// The blob has been signed by enough validators. The cert is being built and verified.
+	StatusSigned Status = Status(dispersal.DispersalProgressSigned)
+	// The job succeeded, and its commitment is available.
+	StatusCertReady Status = "cert_ready"
+	// The job failed. Its error describes why.
+	StatusFailed Status = "failed"
+)
+
+// IsTerminal returns true if the job will not make any further progress.
+func (s Status) IsTerminal() bool {
+	return s == StatusCertReady || s == StatusFailed
+}
+
+// rank orders statuses by progress, so that late progress reports can't move a job backwards.
+func (s Status) rank() int {
+	switch s {
+	case StatusQueued:
+		return 0
+	case StatusEncoded:
+		return 1
+	case StatusSigned:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Job describes an asynchronous dispersal. It is also the JSON body returned by GET /put/status/{id}.
+type Job struct {
+	ID             string                     `json:"job_id"`
+	Status         Status                     `json:"status"`
+	CommitmentMode commitments.CommitmentMode `json:"commitment_mode"`
+	// Owner is the name of the authenticated client that submitted the job, or empty if authentication is disabled.
+	// Only the owner can query the job.
+	Owner string `json:"owner,omitempty"`
+	// Commitment is only set once the job reaches StatusCertReady. It is encoded the same way as the body returned
+	// by the synchronous POST route for the same commitment mode.
+	Commitment hexutil.Bytes `json:"commitment,omitempty"`
+	// Error is only set once the job reaches StatusFailed.
+	Error     *JobError `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// JobError describes why a job failed. StatusCode and Message are the status code and body that the synchronous
+// POST route would have returned for the same failure.
+type JobError struct {
+	StatusCode int    `json:"status_code"`
+	Message    string `json:"message"`
+}
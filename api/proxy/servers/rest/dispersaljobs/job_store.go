@@ -0,0 +1,195 @@
+package dispersaljobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// directory, under the persistence path, that holds the LittDB instance
+	littDirectory = "jobs"
+	// name of the LittDB table that holds the persisted jobs
+	littTableName = "dispersal_jobs"
+
+	// LittDB doesn't support overwriting keys, so each job is persisted as two records:
+	// one written when the job is submitted, and one written when the job reaches a terminal status.
+	submittedRecordSuffix = "/submitted"
+	finishedRecordSuffix  = "/finished"
+)
+
+// submittedRecord is everything needed to restart a job that was in flight when the proxy stopped.
+type submittedRecord struct {
+	CommitmentMode commitments.CommitmentMode `json:"commitment_mode"`
+	Owner          string                     `json:"owner,omitempty"`
+	Payload        []byte                     `json:"payload"`
+	CreatedAt      time.Time                  `json:"created_at"`
+}
+
+// loadedJob is a job read back from the store. Payload is only set for jobs that were still in flight.
+type loadedJob struct {
+	job     Job
+	payload []byte
+}
+
+// jobStore persists jobs to disk using LittDB, so that in-flight jobs survive a restart.
+//
+// Records don't expire on their own, since a job can stay in flight for longer than the retention period. The submitted
+// record of a job is deleted once the job finishes, and the finished record is deleted by the manager once the
+// retention period has elapsed.
+type jobStore struct {
+	db    litt.DB
+	table litt.Table
+}
+
+// newJobStore opens (or creates) the job store under the given persistence path.
+func newJobStore(log logging.Logger, path string) (*jobStore, error) {
+	config, err := litt.DefaultConfig(filepath.Join(path, littDirectory))
+	if err != nil {
+		return nil, fmt.Errorf("default LittDB config: %w", err)
+	}
+	config.Logger = log
+
+	db, err := littbuilder.NewDB(config)
+	if err != nil {
+		return nil, fmt.Errorf("new LittDB: %w", err)
+	}
+
+	table, err := db.GetTable(littTableName)
+	if err != nil {
+		closeErr := db.Close()
+		if closeErr != nil {
+			log.Error("failed to close LittDB", "err", closeErr)
+		}
+		return nil, fmt.Errorf("open LittDB table %s: %w", littTableName, err)
+	}
+
+	return &jobStore{db: db, table: table}, nil
+}
+
+// load reads every job in the store.
+func (s *jobStore) load() ([]loadedJob, error) {
+	iterator, err := s.table.Iterator()
+	if err != nil {
+		return nil, fmt.Errorf("create LittDB iterator: %w", err)
+	}
+	defer func() {
+		_ = iterator.Close()
+	}()
+
+	submitted := make(map[string]submittedRecord)
+	finished := make(map[string]Job)
+	for iterator.Next() {
+		key := string(iterator.Key())
+		value, err := iterator.Value()
+		if err != nil {
+			return nil, fmt.Errorf("read record %s: %w", key, err)
+		}
+
+		if id, ok := strings.CutSuffix(key, submittedRecordSuffix); ok {
+			var record submittedRecord
+			if err := json.Unmarshal(value, &record); err != nil {
+				return nil, fmt.Errorf("unmarshal record %s: %w", key, err)
+			}
+			submitted[id] = record
+		} else if id, ok := strings.CutSuffix(key, finishedRecordSuffix); ok {
+			var job Job
+			if err := json.Unmarshal(value, &job); err != nil {
+				return nil, fmt.Errorf("unmarshal record %s: %w", key, err)
+			}
+			finished[id] = job
+		} else {
+			return nil, fmt.Errorf("unknown record %s", key)
+		}
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf("iterate over LittDB table: %w", err)
+	}
+
+	jobs := make([]loadedJob, 0, len(submitted)+len(finished))
+	for _, job := range finished {
+		jobs = append(jobs, loadedJob{job: job})
+	}
+	for id, record := range submitted {
+		if _, ok := finished[id]; ok {
+			// the proxy stopped between writing the finished record and deleting the submitted one
+			continue
+		}
+		jobs = append(jobs, loadedJob{
+			job: Job{
+				ID:             id,
+				Status:         StatusQueued,
+				CommitmentMode: record.CommitmentMode,
+				Owner:          record.Owner,
+				CreatedAt:      record.CreatedAt,
+				UpdatedAt:      record.CreatedAt,
+			},
+			payload: record.Payload,
+		})
+	}
+	return jobs, nil
+}
+
+// putSubmitted persists a newly submitted job, along with its payload.
+func (s *jobStore) putSubmitted(job Job, payload []byte) error {
+	value, err := json.Marshal(submittedRecord{
+		CommitmentMode: job.CommitmentMode,
+		Owner:          job.Owner,
+		Payload:        payload,
+		CreatedAt:      job.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal submitted record: %w", err)
+	}
+	return s.putAndFlush(job.ID+submittedRecordSuffix, value)
+}
+
+// putFinished persists the terminal state of a job, and deletes its payload which is no longer needed.
+func (s *jobStore) putFinished(job Job) error {
+	value, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("marshal finished record: %w", err)
+	}
+	err = s.putAndFlush(job.ID+finishedRecordSuffix, value)
+	if err != nil {
+		return err
+	}
+	err = s.table.Delete([]byte(job.ID + submittedRecordSuffix))
+	if err != nil {
+		return fmt.Errorf("LittDB delete: %w", err)
+	}
+	return nil
+}
+
+// deleteFinished deletes the terminal state of a job whose retention period has elapsed.
+func (s *jobStore) deleteFinished(id string) error {
+	err := s.table.Delete([]byte(id + finishedRecordSuffix))
+	if err != nil {
+		return fmt.Errorf("LittDB delete: %w", err)
+	}
+	return nil
+}
+
+func (s *jobStore) putAndFlush(key string, value []byte) error {
+	err := s.table.Put([]byte(key), value)
+	if err != nil {
+		return fmt.Errorf("LittDB put: %w", err)
+	}
+	// flush so that a job ID returned to the caller is still valid after a crash
+	err = s.table.Flush()
+	if err != nil {
+		return fmt.Errorf("LittDB flush: %w", err)
+	}
+	return nil
+}
+
+func (s *jobStore) close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,311 @@
+// Package dispersaljobs lets clients disperse payloads asynchronously. Instead of blocking until the cert is ready,
+// a POST /put?async=true request returns a job ID immediately, and the client polls GET /put/status/{id} for
+// progress and for the final commitment.
+package dispersaljobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/dispersal"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
+	"github.com/Layr-Labs/eigenda/api/proxy/store"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/google/uuid"
+)
+
+// how often finished jobs older than the retention period are removed from memory and from the store
+const pruneInterval = time.Minute
+
+// Manager runs asynchronous dispersal jobs, and keeps track of their status.
+//
+// This struct is goroutine safe.
+type Manager struct {
+	ctx     context.Context
+	log     logging.Logger
+	certMgr store.IEigenDAManager
+	cfg     Config
+
+	// nil if jobs are only kept in memory
+	store *jobStore
+	// tracks running jobs and the prune loop, so that the store is only closed once they have all returned
+	running sync.WaitGroup
+	// closed once ctx is cancelled and every running job has returned and the store is closed
+	closed chan struct{}
+
+	lock     sync.Mutex
+	jobs     map[string]*Job
+	inFlight int
+}
+
+// NewManager creates a Manager, and restarts any jobs that were in flight when the proxy last stopped.
+// Jobs are stopped, and the underlying store is closed, when ctx is cancelled.
+//
+// A restarted job sends its payload to certMgr again. To avoid dispersing (and paying for) the payload a second time
+// when the proxy stopped after the disperser had accepted the blob, certMgr should be backed by a PayloadDisperser
+// with a dispersal journal, which then resumes the journaled blob instead. See [dispersal.DispersalJournal].
+func NewManager(
+	ctx context.Context,
+	log logging.Logger,
+	certMgr store.IEigenDAManager,
+	cfg Config,
+) (*Manager, error) {
+	m := &Manager{
+		ctx:     ctx,
+		log:     log.With("component", "DispersalJobManager"),
+		certMgr: certMgr,
+		cfg:     cfg,
+		jobs:    make(map[string]*Job),
+		closed:  make(chan struct{}),
+	}
+
+	var restartedJobs []loadedJob
+	if cfg.PersistencePath != "" {
+		jobStore, err := newJobStore(log, cfg.PersistencePath)
+		if err != nil {
+			return nil, fmt.Errorf("new job store: %w", err)
+		}
+		loadedJobs, err := jobStore.load()
+		if err != nil {
+			closeErr := jobStore.close()
+			if closeErr != nil {
+				log.Error("failed to close dispersal job store", "err", closeErr)
+			}
+			return nil, fmt.Errorf("load jobs: %w", err)
+		}
+		m.store = jobStore
+
+		for _, loaded := range loadedJobs {
+			job := loaded.job
+			m.jobs[job.ID] = &job
+			if !job.Status.IsTerminal() {
+				restartedJobs = append(restartedJobs, loaded)
+			}
+		}
+	}
+
+	// finished jobs whose retention period elapsed while the proxy was stopped
+	m.prune(time.Now())
+
+	for _, loaded := range restartedJobs {
+		m.log.Info("Restarting dispersal job", "jobID", loaded.job.ID)
+		m.start(loaded.job.ID, loaded.payload, loaded.job.CommitmentMode)
+	}
+
+	m.running.Add(1)
+	go m.pruneLoop()
+	go func() {
+		defer close(m.closed)
+		<-ctx.Done()
+		m.running.Wait()
+		if m.store == nil {
+			return
+		}
+		err := m.store.close()
+		if err != nil {
+			m.log.Error("failed to close dispersal job store", "err", err)
+		}
+	}()
+
+	return m, nil
+}
+
+// Submit starts dispersing a payload in the background on behalf of owner, and returns the new job.
+// Returns [proxyerrors.ErrAsyncDispersalQueueFull] if too many jobs are already in flight.
+func (m *Manager) Submit(payload []byte, mode commitments.CommitmentMode, owner string) (Job, error) {
+	if m.ctx.Err() != nil {
+		return Job{}, fmt.Errorf("dispersal job manager is shutting down: %w", m.ctx.Err())
+	}
+
+	now := time.Now()
+	job := &Job{
+		ID:             uuid.New().String(),
+		Status:         StatusQueued,
+		CommitmentMode: mode,
+		Owner:          owner,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	m.lock.Lock()
+	if m.inFlight >= m.cfg.MaxInFlight {
+		m.lock.Unlock()
+		return Job{}, proxyerrors.ErrAsyncDispersalQueueFull
+	}
+	// reserve the slot before releasing the lock, so that concurrent submissions can't exceed the limit
+	m.inFlight++
+	m.lock.Unlock()
+
+	if m.store != nil {
+		err := m.store.putSubmitted(*job, payload)
+		if err != nil {
+			m.lock.Lock()
+			m.inFlight--
+			m.lock.Unlock()
+			return Job{}, fmt.Errorf("persist job: %w", err)
+		}
+	}
+
+	m.lock.Lock()
+	m.jobs[job.ID] = job
+	m.lock.Unlock()
+
+	m.run(job.ID, payload, mode)
+	return *job, nil
+}
+
+// Closed returns a channel that is closed once the manager has shut down after its context was cancelled,
+// i.e. once every running job has returned and the store has been closed.
+func (m *Manager) Closed() <-chan struct{} {
+	return m.closed
+}
+
+// Get returns the job with the given ID. Returns false if the job doesn't exist, or if it finished more than
+// the retention period ago.
+func (m *Manager) Get(id string) (Job, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// start registers an in-flight job, and disperses its payload in the background.
+func (m *Manager) start(id string, payload []byte, mode commitments.CommitmentMode) {
+	m.lock.Lock()
+	m.inFlight++
+	m.lock.Unlock()
+
+	m.run(id, payload, mode)
+}
+
+// run disperses the payload of a job in the background. The job must already be counted as in flight.
+func (m *Manager) run(id string, payload []byte, mode commitments.CommitmentMode) {
+	m.running.Add(1)
+	go func() {
+		defer m.running.Done()
+
+		ctx := dispersal.WithDispersalProgressListener(m.ctx, func(progress dispersal.DispersalProgress) {
+			m.updateStatus(id, Status(progress))
+		})
+		commitment, err := m.disperse(ctx, payload, mode)
+
+		if m.ctx.Err() != nil {
+			// The proxy is shutting down. The job is left in flight in the store, so that it is restarted
+			// the next time the proxy starts.
+			m.log.Info("Dispersal job interrupted by shutdown", "jobID", id)
+			return
+		}
+
+		m.finish(id, commitment, err)
+	}()
+}
+
+// disperse disperses the payload, and returns the commitment the synchronous POST route would have returned.
+func (m *Manager) disperse(ctx context.Context, payload []byte, mode commitments.CommitmentMode) ([]byte, error) {
+	versionedCert, err := m.certMgr.Put(ctx, payload, coretypes.CertSerializationRLP)
+	if err != nil {
+		return nil, fmt.Errorf("post request failed: %w", err)
+	}
+	commitment, err := commitments.EncodeCommitment(versionedCert, mode)
+	if err != nil {
+		// This error is only possible if we have a bug in the code.
+		return nil, fmt.Errorf("failed to encode DA Commitment %v: %w", versionedCert.SerializedCert, err)
+	}
+	return commitment, nil
+}
+
+// updateStatus records the progress of an in-flight job. Progress is only ever moved forward.
+func (m *Manager) updateStatus(id string, status Status) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok || job.Status.IsTerminal() || status.rank() <= job.Status.rank() {
+		return
+	}
+	job.Status = status
+	job.UpdatedAt = time.Now()
+}
+
+// finish moves a job to its terminal status, and persists it.
+func (m *Manager) finish(id string, commitment []byte, dispersalErr error) {
+	m.lock.Lock()
+	job := m.jobs[id]
+	job.UpdatedAt = time.Now()
+	if dispersalErr != nil {
+		statusCode, message := middleware.ErrorToHTTPStatus(dispersalErr)
+		job.Status = StatusFailed
+		job.Error = &JobError{StatusCode: statusCode, Message: message}
+	} else {
+		job.Status = StatusCertReady
+		job.Commitment = commitment
+	}
+	m.inFlight--
+	finished := *job
+	m.lock.Unlock()
+
+	if dispersalErr != nil {
+		m.log.Warn("Dispersal job failed", "jobID", id, "err", dispersalErr)
+	} else {
+		m.log.Info("Dispersal job finished", "jobID", id, "commitmentMode", finished.CommitmentMode)
+	}
+
+	if m.store != nil {
+		err := m.store.putFinished(finished)
+		if err != nil {
+			// The result is still available from memory until the proxy restarts, after which the job is re-run.
+			m.log.Error("failed to persist finished dispersal job", "jobID", id, "err", err)
+		}
+	}
+}
+
+// pruneLoop periodically removes finished jobs that are older than the retention period.
+func (m *Manager) pruneLoop() {
+	defer m.running.Done()
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.prune(now)
+		}
+	}
+}
+
+// prune removes the finished jobs that are older than the retention period. The retention period only starts once a
+// job finishes, so in-flight jobs are never removed, no matter how long they take.
+func (m *Manager) prune(now time.Time) {
+	var expired []string
+	m.lock.Lock()
+	for id, job := range m.jobs {
+		if job.Status.IsTerminal() && now.Sub(job.UpdatedAt) > m.cfg.Retention {
+			delete(m.jobs, id)
+			expired = append(expired, id)
+		}
+	}
+	m.lock.Unlock()
+
+	if m.store == nil {
+		return
+	}
+	for _, id := range expired {
+		err := m.store.deleteFinished(id)
+		if err != nil {
+			// the job is pruned again the next time the proxy starts
+			m.log.Error("failed to delete expired dispersal job", "jobID", id, "err", err)
+		}
+	}
+}
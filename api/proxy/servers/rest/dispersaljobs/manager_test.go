@@ -0,0 +1,243 @@
+package dispersaljobs
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+func testConfig(persistencePath string) Config {
+	return Config{
+		Enabled:         true,
+		PersistencePath: persistencePath,
+		Retention:       time.Hour,
+		MaxInFlight:     2,
+	}
+}
+
+// waitForTerminalJob waits for the job to reach a terminal status, and returns it.
+func waitForTerminalJob(t *testing.T, m *Manager, id string) Job {
+	t.Helper()
+
+	var job Job
+	require.Eventually(t, func() bool {
+		var ok bool
+		job, ok = m.Get(id)
+		require.True(t, ok)
+		return job.Status.IsTerminal()
+	}, 10*time.Second, 10*time.Millisecond)
+	return job
+}
+
+// newTestManager creates a manager that is shut down, and waited for, when the test ends.
+func newTestManager(t *testing.T, certMgr *mocks.MockIEigenDAManager, persistencePath string) *Manager {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m, err := NewManager(ctx, testLogger, certMgr, testConfig(persistencePath))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		cancel()
+		<-m.Closed()
+	})
+	return m
+}
+
+func TestSubmitCertReady(t *testing.T) {
+	t.Parallel()
+
+	certMgr := mocks.NewMockIEigenDAManager(gomock.NewController(t))
+	certMgr.EXPECT().Put(gomock.Any(), []byte("payload"), coretypes.CertSerializationRLP).
+		Return(certs.NewVersionedCert([]byte("cert"), certs.V2VersionByte), nil)
+
+	m := newTestManager(t, certMgr, "")
+
+	job, err := m.Submit([]byte("payload"), commitments.StandardCommitmentMode, "")
+	require.NoError(t, err)
+	require.Equal(t, StatusQueued, job.Status)
+
+	job = waitForTerminalJob(t, m, job.ID)
+	require.Equal(t, StatusCertReady, job.Status)
+	require.Nil(t, job.Error)
+	expected, err := commitments.EncodeCommitment(
+		certs.NewVersionedCert([]byte("cert"), certs.V2VersionByte), commitments.StandardCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, expected, []byte(job.Commitment))
+
+	_, ok := m.Get("unknown")
+	require.False(t, ok)
+}
+
+func TestSubmitFailed(t *testing.T) {
+	t.Parallel()
+
+	certMgr := mocks.NewMockIEigenDAManager(gomock.NewController(t))
+	certMgr.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.ResourceExhausted, "out of funds"))
+
+	m := newTestManager(t, certMgr, "")
+
+	job, err := m.Submit([]byte("payload"), commitments.OptimismGenericCommitmentMode, "")
+	require.NoError(t, err)
+
+	job = waitForTerminalJob(t, m, job.ID)
+	require.Equal(t, StatusFailed, job.Status)
+	require.Nil(t, job.Commitment)
+	require.NotNil(t, job.Error)
+	require.Equal(t, http.StatusTooManyRequests, job.Error.StatusCode)
+}
+
+func TestSubmitMaxInFlight(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	certMgr := mocks.NewMockIEigenDAManager(gomock.NewController(t))
+	certMgr.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, []byte, coretypes.CertSerializationType) (*certs.VersionedCert, error) {
+			<-release
+			return certs.NewVersionedCert([]byte("cert"), certs.V2VersionByte), nil
+		}).Times(3)
+
+	m := newTestManager(t, certMgr, "")
+
+	first, err := m.Submit([]byte("payload"), commitments.StandardCommitmentMode, "")
+	require.NoError(t, err)
+	_, err = m.Submit([]byte("payload"), commitments.StandardCommitmentMode, "")
+	require.NoError(t, err)
+	_, err = m.Submit([]byte("payload"), commitments.StandardCommitmentMode, "")
+	require.ErrorIs(t, err, proxyerrors.ErrAsyncDispersalQueueFull)
+	require.True(t, proxyerrors.Is429(err))
+
+	// once a job finishes, its slot is freed up
+	close(release)
+	waitForTerminalJob(t, m, first.ID)
+	last, err := m.Submit([]byte("payload"), commitments.StandardCommitmentMode, "")
+	require.NoError(t, err)
+	waitForTerminalJob(t, m, last.ID)
+}
+
+func TestJobsSurviveRestart(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+	ctrl := gomock.NewController(t)
+
+	// The first dispersal only returns once the manager is shut down, leaving its job in flight.
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+	gomock.InOrder(
+		certMgr.EXPECT().Put(gomock.Any(), []byte("finished"), gomock.Any()).
+			Return(certs.NewVersionedCert([]byte("cert-1"), certs.V2VersionByte), nil),
+		certMgr.EXPECT().Put(gomock.Any(), []byte("in-flight"), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ []byte, _ coretypes.CertSerializationType) (*certs.VersionedCert, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}),
+		// after the restart, the in-flight job is dispersed again
+		certMgr.EXPECT().Put(gomock.Any(), []byte("in-flight"), gomock.Any()).
+			Return(certs.NewVersionedCert([]byte("cert-2"), certs.V2VersionByte), nil),
+	)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	m, err := NewManager(ctx, testLogger, certMgr, testConfig(path))
+	require.NoError(t, err)
+
+	finishedJob, err := m.Submit([]byte("finished"), commitments.StandardCommitmentMode, "")
+	require.NoError(t, err)
+	finishedJob = waitForTerminalJob(t, m, finishedJob.ID)
+	require.Equal(t, StatusCertReady, finishedJob.Status)
+
+	inFlightJob, err := m.Submit([]byte("in-flight"), commitments.OptimismGenericCommitmentMode, "rollup-a")
+	require.NoError(t, err)
+
+	// restart the manager
+	cancel()
+	<-m.Closed()
+	m = newTestManager(t, certMgr, path)
+
+	job, ok := m.Get(finishedJob.ID)
+	require.True(t, ok)
+	require.Equal(t, StatusCertReady, job.Status)
+	require.Equal(t, finishedJob.Commitment, job.Commitment)
+
+	job = waitForTerminalJob(t, m, inFlightJob.ID)
+	require.Equal(t, StatusCertReady, job.Status)
+	require.Equal(t, commitments.OptimismGenericCommitmentMode, job.CommitmentMode)
+	require.Equal(t, "rollup-a", job.Owner)
+	expected, err := commitments.EncodeCommitment(
+		certs.NewVersionedCert([]byte("cert-2"), certs.V2VersionByte), commitments.OptimismGenericCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, expected, []byte(job.Commitment))
+}
+
+func TestStatusOnlyMovesForward(t *testing.T) {
+	t.Parallel()
+
+	m := &Manager{jobs: map[string]*Job{"id": {ID: "id", Status: StatusQueued}}}
+
+	m.updateStatus("id", StatusSigned)
+	job, _ := m.Get("id")
+	require.Equal(t, StatusSigned, job.Status)
+
+	m.updateStatus("id", StatusEncoded)
+	job, _ = m.Get("id")
+	require.Equal(t, StatusSigned, job.Status)
+}
+
+func TestPruneOnlyExpiresFinishedJobs(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+	ctrl := gomock.NewController(t)
+
+	// The in-flight dispersal only returns once the manager is shut down.
+	certMgr := mocks.NewMockIEigenDAManager(ctrl)
+	certMgr.EXPECT().Put(gomock.Any(), []byte("finished"), gomock.Any()).
+		Return(certs.NewVersionedCert([]byte("cert-1"), certs.V2VersionByte), nil)
+	certMgr.EXPECT().Put(gomock.Any(), []byte("in-flight"), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ []byte, _ coretypes.CertSerializationType) (*certs.VersionedCert, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).Times(2)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	m, err := NewManager(ctx, testLogger, certMgr, testConfig(path))
+	require.NoError(t, err)
+
+	finishedJob, err := m.Submit([]byte("finished"), commitments.StandardCommitmentMode, "")
+	require.NoError(t, err)
+	waitForTerminalJob(t, m, finishedJob.ID)
+	inFlightJob, err := m.Submit([]byte("in-flight"), commitments.StandardCommitmentMode, "")
+	require.NoError(t, err)
+
+	// both jobs were created more than the retention period ago, but only the finished one expires
+	m.prune(time.Now().Add(2 * testConfig(path).Retention))
+	_, ok := m.Get(finishedJob.ID)
+	require.False(t, ok)
+	job, ok := m.Get(inFlightJob.ID)
+	require.True(t, ok)
+	require.Equal(t, StatusQueued, job.Status)
+
+	// the expired job is also deleted from the store, while the in-flight job is restarted
+	cancel()
+	<-m.Closed()
+	m = newTestManager(t, certMgr, path)
+	_, ok = m.Get(finishedJob.ID)
+	require.False(t, ok)
+	_, ok = m.Get(inFlightJob.ID)
+	require.True(t, ok)
+}
@@ -0,0 +1,205 @@
+// handlers_admin_cache.go contains the admin handlers to inspect and repair the entries stored for a commitment
+// in the secondary storage backends (caches and fallbacks). They are only registered when the admin API is enabled.
+//
+// Like the handlers in handlers_misc.go, they are not wrapped in the cert middlewares,
+// so they do their own logging and error handling.
+package rest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+)
+
+const routingVarNameCommitmentHex = "commitment_hex"
+
+// CacheEntriesResponse is the JSON body returned by GET /admin/cache/{commitment}.
+type CacheEntriesResponse struct {
+	// Entries has one entry per cache and fallback, caches first. A chunked payload manifest has one entry
+	// per chunk and backend.
+	Entries []CacheEntryJSON `json:"entries"`
+}
+
+// CacheEntryJSON describes the entry stored for a commitment in a single secondary storage backend.
+type CacheEntryJSON struct {
+	Backend string `json:"backend"`
+	// Role is either "cache" or "fallback".
+	Role string `json:"role"`
+	// Key is the key of the entry in the backend, i.e. the keccak256 hash of the serialized cert.
+	Key       hexutil.Bytes `json:"key"`
+	Present   bool          `json:"present"`
+	SizeBytes int           `json:"size_bytes,omitempty"`
+	// Error is set if the backend could not be read from.
+	Error string `json:"error,omitempty"`
+}
+
+// WarmCacheRequest is the JSON body of a POST /admin/cache/warmup request.
+type WarmCacheRequest struct {
+	// Commitments are encoded the same way as the commitments accepted by the single-payload GET route for the
+	// commitment mode of the request.
+	Commitments []hexutil.Bytes `json:"commitments"`
+}
+
+// WarmCacheResponse is the JSON body returned by a POST /admin/cache/warmup request.
+type WarmCacheResponse struct {
+	// Results has exactly one entry per commitment in the request, in the same order.
+	Results []WarmCacheResult `json:"results"`
+}
+
+// WarmCacheResult is the result of warming the entries of a single commitment. Error is nil on success.
+type WarmCacheResult struct {
+	Error *BatchItemError `json:"error,omitempty"`
+}
+
+// handleGetCacheEntry reports whether each cache and fallback holds the payload of a commitment.
+func (svr *Server) handleGetCacheEntry(w http.ResponseWriter, r *http.Request) {
+	versionedCert, err := parseAdminCommitmentVar(r)
+	if err != nil {
+		svr.writeAdminError(w, r, err)
+		return
+	}
+
+	statuses, err := svr.certMgr.InspectCacheEntry(r.Context(), versionedCert)
+	if err != nil {
+		svr.writeAdminError(w, r, err)
+		return
+	}
+
+	response := CacheEntriesResponse{Entries: make([]CacheEntryJSON, 0, len(statuses))}
+	for _, status := range statuses {
+		response.Entries = append(response.Entries, newCacheEntryJSON(status))
+	}
+	svr.writeJSON(w, r, response)
+}
+
+// handleDeleteCacheEntry purges the payload of a commitment from all caches and fallbacks.
+func (svr *Server) handleDeleteCacheEntry(w http.ResponseWriter, r *http.Request) {
+	versionedCert, err := parseAdminCommitmentVar(r)
+	if err != nil {
+		svr.writeAdminError(w, r, err)
+		return
+	}
+
+	err = svr.certMgr.PurgeCacheEntry(r.Context(), versionedCert)
+	if err != nil {
+		svr.writeAdminError(w, r, err)
+		return
+	}
+
+	svr.log.Info("Purged cache entry", "method", r.Method, "path", r.URL.Path)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRefetchCacheEntry retrieves the payload of a commitment from EigenDA, and replaces the entries of all
+// caches and fallbacks with it.
+func (svr *Server) handleRefetchCacheEntry(w http.ResponseWriter, r *http.Request) {
+	versionedCert, err := parseAdminCommitmentVar(r)
+	if err != nil {
+		svr.writeAdminError(w, r, err)
+		return
+	}
+
+	err = svr.certMgr.RefetchCacheEntry(r.Context(), versionedCert, coretypes.CertSerializationRLP)
+	if err != nil {
+		svr.writeAdminError(w, r, err)
+		return
+	}
+
+	svr.log.Info("Refetched cache entry", "method", r.Method, "path", r.URL.Path)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleWarmCacheEntries fills the missing cache and fallback entries of a list of commitments.
+// Like the batch routes, every commitment is processed in parallel and its result is reported in the response body.
+func (svr *Server) handleWarmCacheEntries(w http.ResponseWriter, r *http.Request) {
+	var request WarmCacheRequest
+	err := readBatchRequest(w, r, &request)
+	if err == nil {
+		err = checkBatchSize(len(request.Commitments))
+	}
+	if err != nil {
+		svr.writeAdminError(w, r, err)
+		return
+	}
+
+	results := make([]WarmCacheResult, len(request.Commitments))
+	var wg sync.WaitGroup
+	for i, commitment := range request.Commitments {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			versionedCert, err := decodeAdminCommitment(r, commitment)
+			if err == nil {
+				err = svr.certMgr.WarmCacheEntry(r.Context(), versionedCert, coretypes.CertSerializationRLP)
+			}
+			if err != nil {
+				svr.log.Warn("Cache warm-up failed", "method", r.Method, "path", r.URL.Path, "index", i, "err", err)
+				results[i].Error = newBatchItemError(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	svr.log.Info("Warmed cache entries", "method", r.Method, "path", r.URL.Path, "count", len(request.Commitments))
+	err = writeBatchResponse(w, WarmCacheResponse{Results: results})
+	if err != nil {
+		svr.log.Error("failed to write response", "method", r.Method, "path", r.URL.Path, "error", err)
+	}
+}
+
+// writeAdminError logs err and writes the status code and body the cert routes would return for it.
+func (svr *Server) writeAdminError(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode, message := middleware.ErrorToHTTPStatus(err)
+	svr.log.Error("admin cache request failed", "method", r.Method, "path", r.URL.Path,
+		"status", statusCode, "error", err)
+	http.Error(w, message, statusCode)
+}
+
+// parseAdminCommitmentVar decodes the hex commitment of the request path, see decodeAdminCommitment.
+func parseAdminCommitmentVar(r *http.Request) (*certs.VersionedCert, error) {
+	commitmentHex := mux.Vars(r)[routingVarNameCommitmentHex]
+	commitment, err := hex.DecodeString(commitmentHex)
+	if err != nil {
+		return nil, proxyerrors.NewCertHexDecodingError(commitmentHex, err)
+	}
+	return decodeAdminCommitment(r, commitment)
+}
+
+// decodeAdminCommitment decodes a commitment in the mode given by the commitment_mode query param:
+// standard commitments if it is "standard", op generic commitments otherwise (same as the GET routes).
+func decodeAdminCommitment(r *http.Request, commitment []byte) (*certs.VersionedCert, error) {
+	mode := commitments.OptimismGenericCommitmentMode
+	if !notCommitmentModeStandard(r, nil) {
+		mode = commitments.StandardCommitmentMode
+	}
+
+	versionedCert, err := commitments.DecodeCommitment(commitment, mode)
+	if err != nil {
+		return nil, proxyerrors.NewParsingError(fmt.Errorf("decoding commitment: %w", err))
+	}
+	return versionedCert, nil
+}
+
+func newCacheEntryJSON(status secondary.EntryStatus) CacheEntryJSON {
+	entry := CacheEntryJSON{
+		Backend:   status.Backend.String(),
+		Role:      string(status.Role),
+		Key:       status.Key,
+		Present:   status.Present,
+		SizeBytes: status.SizeBytes,
+	}
+	if status.Err != nil {
+		entry.Error = status.Err.Error()
+	}
+	return entry
+}
@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/config/enablement"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdminCacheEndpoints(t *testing.T) {
+	// op generic commitment of a v1 cert, and the std commitment of the same cert
+	opGenericURL := "/admin/cache/0x010001" + testCommitStr
+	stdURL := "/admin/cache/01" + testCommitStr + "?commitment_mode=standard"
+	expectedCert := certs.NewVersionedCert(hexutil.MustDecode("0x"+testCommitStr), certs.V1VersionByte)
+
+	newRouter := func(t *testing.T, cfg Config) (*mux.Router, *mocks.MockIEigenDAManager) {
+		ctrl := gomock.NewController(t)
+		mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+		server := NewServer(cfg, mockEigenDAManager, mocks.NewMockIKeccakManager(ctrl), nil,
+			middleware.NewAuthenticator(cfg.Auth), testLogger, metrics.NoopMetrics)
+		r := mux.NewRouter()
+		server.RegisterRoutes(r)
+		return r, mockEigenDAManager
+	}
+
+	serve := func(r *mux.Router, method string, url string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("inspect", func(t *testing.T) {
+		r, mockEigenDAManager := newRouter(t, testCfg)
+		mockEigenDAManager.EXPECT().InspectCacheEntry(gomock.Any(), expectedCert).Return([]secondary.EntryStatus{
+			{Backend: common.FilesystemBackendType, Role: secondary.CacheRole, Key: []byte{1}, Present: true, SizeBytes: 3},
+			{Backend: common.S3BackendType, Role: secondary.FallbackRole, Key: []byte{1}, Err: errors.New("oops")},
+		}, nil).Times(2)
+
+		for _, url := range []string{opGenericURL, stdURL} {
+			rec := serve(r, http.MethodGet, url, nil)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var response CacheEntriesResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, []CacheEntryJSON{
+				{Backend: "Filesystem", Role: "cache", Key: []byte{1}, Present: true, SizeBytes: 3},
+				{Backend: "S3", Role: "fallback", Key: []byte{1}, Error: "oops"},
+			}, response.Entries)
+		}
+	})
+
+	t.Run("inspect without secondary storage", func(t *testing.T) {
+		r, mockEigenDAManager := newRouter(t, testCfg)
+		mockEigenDAManager.EXPECT().InspectCacheEntry(gomock.Any(), expectedCert).
+			Return(nil, proxyerrors.ErrNoSecondaryStorage)
+
+		rec := serve(r, http.MethodGet, opGenericURL, nil)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid commitment", func(t *testing.T) {
+		r, _ := newRouter(t, testCfg)
+
+		// odd number of hex chars
+		rec := serve(r, http.MethodGet, "/admin/cache/0x0100010", nil)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		// op keccak commitments have no cert
+		rec = serve(r, http.MethodGet, "/admin/cache/0x00"+testCommitStr, nil)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		r, mockEigenDAManager := newRouter(t, testCfg)
+		mockEigenDAManager.EXPECT().PurgeCacheEntry(gomock.Any(), expectedCert).Return(nil)
+
+		rec := serve(r, http.MethodDelete, opGenericURL, nil)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("refetch", func(t *testing.T) {
+		r, mockEigenDAManager := newRouter(t, testCfg)
+		mockEigenDAManager.EXPECT().RefetchCacheEntry(gomock.Any(), expectedCert, coretypes.CertSerializationRLP).
+			Return(nil)
+		rec := serve(r, http.MethodPost, opGenericURL+"/refetch", nil)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+
+		mockEigenDAManager.EXPECT().RefetchCacheEntry(gomock.Any(), expectedCert, coretypes.CertSerializationRLP).
+			Return(errors.New("EigenDA unavailable"))
+		rec = serve(r, http.MethodPost, opGenericURL+"/refetch", nil)
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("warmup", func(t *testing.T) {
+		r, mockEigenDAManager := newRouter(t, testCfg)
+		mockEigenDAManager.EXPECT().WarmCacheEntry(gomock.Any(), expectedCert, coretypes.CertSerializationRLP).
+			Return(nil)
+
+		body, err := json.Marshal(WarmCacheRequest{Commitments: []hexutil.Bytes{
+			hexutil.MustDecode("0x010001" + testCommitStr),
+			{0xfe, 0xfe, 0xaa},
+		}})
+		require.NoError(t, err)
+		rec := serve(r, http.MethodPost, "/admin/cache/warmup", body)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var response WarmCacheResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Results, 2)
+		require.Nil(t, response.Results[0].Error)
+		require.Equal(t, http.StatusBadRequest, response.Results[1].Error.StatusCode)
+
+		rec = serve(r, http.MethodPost, "/admin/cache/warmup", []byte(`{"commitments":[]}`))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("authentication required when configured", func(t *testing.T) {
+		cfg := testCfg
+		cfg.Auth = middleware.AuthConfig{
+			Clients:            []middleware.ClientConfig{{Name: "admin", APIKey: "admin-key"}},
+			QuotaBurstDuration: time.Second,
+		}
+		r, mockEigenDAManager := newRouter(t, cfg)
+		mockEigenDAManager.EXPECT().PurgeCacheEntry(gomock.Any(), expectedCert).Return(nil)
+
+		serveWithKey := func(method string, url string, apiKey string) int {
+			req := httptest.NewRequest(method, url, nil)
+			if apiKey != "" {
+				req.Header.Set(middleware.APIKeyHeader, apiKey)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		require.Equal(t, http.StatusUnauthorized, serveWithKey(http.MethodGet, opGenericURL, ""))
+		require.Equal(t, http.StatusUnauthorized, serveWithKey(http.MethodDelete, opGenericURL, "wrong-key"))
+		require.Equal(t, http.StatusUnauthorized, serveWithKey(http.MethodPost, opGenericURL+"/refetch", ""))
+		require.Equal(t, http.StatusUnauthorized, serveWithKey(http.MethodPost, "/admin/cache/warmup", ""))
+		require.Equal(t, http.StatusUnauthorized,
+			serveWithKey(http.MethodGet, "/admin/eigenda-dispersal-backend", ""))
+		require.Equal(t, http.StatusNoContent, serveWithKey(http.MethodDelete, opGenericURL, "admin-key"))
+	})
+
+	t.Run("routes disabled without admin API", func(t *testing.T) {
+		r, _ := newRouter(t, Config{APIsEnabled: &enablement.RestApisEnabled{OpGenericCommitment: true}})
+
+		require.Equal(t, http.StatusNotFound, serve(r, http.MethodGet, opGenericURL, nil).Code)
+		require.Equal(t, http.StatusNotFound, serve(r, http.MethodDelete, opGenericURL, nil).Code)
+		require.Equal(t, http.StatusNotFound, serve(r, http.MethodPost, opGenericURL+"/refetch", nil).Code)
+	})
+}
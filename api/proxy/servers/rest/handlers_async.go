@@ -0,0 +1,68 @@
+// handlers_async.go contains the HTTP handlers for asynchronous dispersal. A POST /put?async=true request returns
+// a job ID as soon as the payload is read, and the job's progress and final commitment are polled with
+// GET /put/status/{id}.
+// Handlers in this file SHOULD be wrapped in middlewares.
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/dispersaljobs"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
+	"github.com/gorilla/mux"
+)
+
+// submitDispersalJob starts an asynchronous dispersal of the payload, and writes the new job as JSON
+// with a 202 status code.
+func (svr *Server) submitDispersalJob(
+	w http.ResponseWriter,
+	r *http.Request,
+	payload []byte,
+	mode commitments.CommitmentMode,
+) error {
+	if svr.jobMgr == nil {
+		w.WriteHeader(http.StatusForbidden)
+		return fmt.Errorf("async dispersal requested but async dispersal is not enabled")
+	}
+
+	job, err := svr.jobMgr.Submit(payload, mode, middleware.GetClientID(r))
+	if err != nil {
+		return fmt.Errorf("submit dispersal job: %w", err)
+	}
+
+	svr.log.Info("Submitted dispersal job", "method", r.Method, "url", r.URL.Path, "commitmentMode", mode,
+		"jobID", job.ID)
+	return writeDispersalJob(w, job, http.StatusAccepted)
+}
+
+// handleGetDispersalJobStatus handles GET /put/status/{id} requests.
+func (svr *Server) handleGetDispersalJobStatus(w http.ResponseWriter, r *http.Request) error {
+	if svr.jobMgr == nil {
+		w.WriteHeader(http.StatusForbidden)
+		return fmt.Errorf("dispersal job status requested but async dispersal is not enabled")
+	}
+
+	jobID := mux.Vars(r)[routingVarNameJobID]
+	job, ok := svr.jobMgr.Get(jobID)
+	// Jobs owned by another client are reported as missing, so that clients can't probe for each other's job IDs.
+	if !ok || job.Owner != middleware.GetClientID(r) {
+		w.WriteHeader(http.StatusNotFound)
+		return fmt.Errorf("dispersal job %s not found", jobID)
+	}
+	return writeDispersalJob(w, job, http.StatusOK)
+}
+
+func writeDispersalJob(w http.ResponseWriter, job dispersaljobs.Job, statusCode int) error {
+	w.Header().Set(headerContentType, contentTypeJSON)
+	w.WriteHeader(statusCode)
+	err := json.NewEncoder(w).Encode(job)
+	if err != nil {
+		// If the write fails, we will already have sent the header. But we still return an error
+		// here so that the logging middleware can log it.
+		return fmt.Errorf("failed to write dispersal job %s: %w", job.ID, err)
+	}
+	return nil
+}
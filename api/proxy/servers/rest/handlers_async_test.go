@@ -0,0 +1,167 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/dispersaljobs"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerPutAsync(t *testing.T) {
+	modes := []struct {
+		name           string
+		url            string
+		expectedMode   commitments.CommitmentMode
+		expectedPrefix string
+	}{
+		{
+			name:           "OP Mode Alt-DA",
+			url:            "/put?async=true",
+			expectedMode:   commitments.OptimismGenericCommitmentMode,
+			expectedPrefix: opGenericPrefixStr,
+		},
+		{
+			name:           "Standard Commitment Mode",
+			url:            "/put?commitment_mode=standard&async=true",
+			expectedMode:   commitments.StandardCommitmentMode,
+			expectedPrefix: stdCommitmentPrefix,
+		},
+	}
+
+	for _, mode := range modes {
+		t.Run(mode.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+			mockKeccakManager := mocks.NewMockIKeccakManager(ctrl)
+			mockEigenDAManager.EXPECT().Put(gomock.Any(), []byte("payload"), gomock.Any()).
+				Return(certs.NewVersionedCert([]byte(testCommitStr), certs.V0VersionByte), nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			jobMgr, err := dispersaljobs.NewManager(ctx, testLogger, mockEigenDAManager, dispersaljobs.Config{
+				Enabled:     true,
+				Retention:   time.Hour,
+				MaxInFlight: 10,
+			})
+			require.NoError(t, err)
+			defer func() {
+				cancel()
+				<-jobMgr.Closed()
+			}()
+
+			r := mux.NewRouter()
+			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, jobMgr, nil, testLogger, metrics.NoopMetrics)
+			server.RegisterRoutes(r)
+
+			req := httptest.NewRequest(http.MethodPost, mode.url, bytes.NewReader([]byte("payload")))
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusAccepted, rec.Code)
+
+			var job dispersaljobs.Job
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+			require.Equal(t, mode.expectedMode, job.CommitmentMode)
+
+			require.Eventually(t, func() bool {
+				req := httptest.NewRequest(http.MethodGet, "/put/status/"+job.ID, nil)
+				rec := httptest.NewRecorder()
+				r.ServeHTTP(rec, req)
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+				return job.Status == dispersaljobs.StatusCertReady
+			}, 10*time.Second, 10*time.Millisecond)
+			require.Equal(t, mode.expectedPrefix+testCommitStr, string(job.Commitment))
+
+			// unknown jobs
+			req = httptest.NewRequest(http.MethodGet, "/put/status/00000000-0000-0000-0000-000000000000", nil)
+			rec = httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusNotFound, rec.Code)
+		})
+	}
+}
+
+func TestHandlerPutAsyncDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+	mockKeccakManager := mocks.NewMockIKeccakManager(ctrl)
+
+	r := mux.NewRouter()
+	server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodPost, "/put?async=true", bytes.NewReader([]byte("payload")))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/put/status/00000000-0000-0000-0000-000000000000", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestHandlerPutAsyncStatusOwnership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+	mockKeccakManager := mocks.NewMockIKeccakManager(ctrl)
+	mockEigenDAManager.EXPECT().Put(gomock.Any(), []byte("payload"), gomock.Any()).
+		Return(certs.NewVersionedCert([]byte(testCommitStr), certs.V0VersionByte), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	jobMgr, err := dispersaljobs.NewManager(ctx, testLogger, mockEigenDAManager, dispersaljobs.Config{
+		Enabled:     true,
+		Retention:   time.Hour,
+		MaxInFlight: 10,
+	})
+	require.NoError(t, err)
+	defer func() {
+		cancel()
+		<-jobMgr.Closed()
+	}()
+
+	cfg := testCfg
+	cfg.Auth = middleware.AuthConfig{
+		Clients: []middleware.ClientConfig{
+			{Name: "rollup-a", APIKey: "key-a"},
+			{Name: "rollup-b", APIKey: "key-b"},
+		},
+		QuotaBurstDuration: time.Second,
+	}
+	r := mux.NewRouter()
+	server := NewServer(cfg, mockEigenDAManager, mockKeccakManager, jobMgr, middleware.NewAuthenticator(cfg.Auth),
+		testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodPost, "/put?async=true", bytes.NewReader([]byte("payload")))
+	req.Header.Set(middleware.APIKeyHeader, "key-a")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var job dispersaljobs.Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	require.Equal(t, "rollup-a", job.Owner)
+
+	getStatus := func(apiKey string) int {
+		req := httptest.NewRequest(http.MethodGet, "/put/status/"+job.ID, nil)
+		req.Header.Set(middleware.APIKeyHeader, apiKey)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	require.Equal(t, http.StatusOK, getStatus("key-a"))
+	// another client can't read the job, and can't tell it exists
+	require.Equal(t, http.StatusNotFound, getStatus("key-b"))
+}
@@ -0,0 +1,301 @@
+// handlers_batch.go contains the HTTP handlers for the batched POST (payloads->commitments) and
+// GET (commitments->payloads) routes. Each item of a batch is processed in parallel, and the result of every item
+// (commitment, payload, or error) is returned in a single JSON response.
+// Handlers in this file SHOULD be wrapped in middlewares.
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// PutBatchRequest is the JSON body of a POST /put/batch request.
+type PutBatchRequest struct {
+	// Payloads to disperse. Each payload is dispersed independently, and results are returned in the same order.
+	Payloads []hexutil.Bytes `json:"payloads"`
+}
+
+// PutBatchResponse is the JSON body returned by a POST /put/batch request.
+type PutBatchResponse struct {
+	// Results has exactly one entry per payload in the request, in the same order.
+	Results []PutBatchResult `json:"results"`
+}
+
+// PutBatchResult is the result of dispersing a single payload of a batch. Exactly one of Commitment or Error is set.
+type PutBatchResult struct {
+	// Commitment is encoded the same way as the body returned by the single-payload POST route for the same
+	// commitment mode.
+	Commitment hexutil.Bytes   `json:"commitment,omitempty"`
+	Error      *BatchItemError `json:"error,omitempty"`
+}
+
+// GetBatchRequest is the JSON body of a POST /get/batch request. A POST is used instead of a GET because
+// the commitments of a batch are too large to be passed in the URL.
+type GetBatchRequest struct {
+	Items []GetBatchItem `json:"items"`
+}
+
+// GetBatchItem identifies a single payload to retrieve as part of a batch.
+type GetBatchItem struct {
+	// Commitment is encoded the same way as the commitments accepted by the single-payload GET route for the same
+	// commitment mode.
+	Commitment hexutil.Bytes `json:"commitment"`
+	// L1InclusionBlockNumber has the same meaning as the l1_inclusion_block_number query param of the
+	// single-payload GET route. 0 skips the recency check.
+	L1InclusionBlockNumber uint64 `json:"l1_inclusion_block_number,omitempty"`
+}
+
+// GetBatchResponse is the JSON body returned by a POST /get/batch request.
+type GetBatchResponse struct {
+	// Results has exactly one entry per item in the request, in the same order.
+	Results []GetBatchResult `json:"results"`
+}
+
+// GetBatchResult is the result of retrieving a single payload of a batch. Exactly one of Payload or Error is set.
+type GetBatchResult struct {
+	// Payload is the payload, or the encoded payload if the request set the return_encoded_payload query param.
+	Payload hexutil.Bytes   `json:"payload,omitempty"`
+	Error   *BatchItemError `json:"error,omitempty"`
+}
+
+// BatchItemError describes why a single item of a batch failed. StatusCode and Message are the status code and body
+// that the equivalent single-payload route would have returned for the same failure.
+type BatchItemError struct {
+	StatusCode int    `json:"status_code"`
+	Message    string `json:"message"`
+}
+
+func newBatchItemError(err error) *BatchItemError {
+	statusCode, message := middleware.ErrorToHTTPStatus(err)
+	return &BatchItemError{
+		StatusCode: statusCode,
+		Message:    message,
+	}
+}
+
+// =================================================================================================
+// POST /put/batch
+// =================================================================================================
+
+// handlePostStdCommitmentBatch handles batched POST requests for std commitments.
+func (svr *Server) handlePostStdCommitmentBatch(w http.ResponseWriter, r *http.Request) error {
+	if !svr.config.APIsEnabled.StandardCommitment {
+		w.WriteHeader(http.StatusForbidden)
+		return fmt.Errorf("standard DA Commitment type detected but `standard` API is not enabled")
+	}
+
+	return svr.handlePostBatchShared(w, r, commitments.StandardCommitmentMode)
+}
+
+// handlePostOPGenericCommitmentBatch handles batched POST requests for optimism generic commitments.
+func (svr *Server) handlePostOPGenericCommitmentBatch(w http.ResponseWriter, r *http.Request) error {
+	if !svr.config.APIsEnabled.OpGenericCommitment {
+		w.WriteHeader(http.StatusForbidden)
+		return fmt.Errorf("op-generic DA Commitment type detected but `op-generic` API is not enabled")
+	}
+
+	return svr.handlePostBatchShared(w, r, commitments.OptimismGenericCommitmentMode)
+}
+
+func (svr *Server) handlePostBatchShared(
+	w http.ResponseWriter,
+	r *http.Request,
+	mode commitments.CommitmentMode,
+) error {
+	var request PutBatchRequest
+	err := readBatchRequest(w, r, &request)
+	if err != nil {
+		return err
+	}
+	err = checkBatchSize(len(request.Payloads))
+	if err != nil {
+		return err
+	}
+
+	results := make([]PutBatchResult, len(request.Payloads))
+	var wg sync.WaitGroup
+	for i, payload := range request.Payloads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			commitment, err := svr.putBatchItem(r, payload, mode)
+			if err != nil {
+				svr.log.Warn("Batch item failed", "method", r.Method, "url", r.URL.Path, "index", i, "err", err)
+				results[i].Error = newBatchItemError(err)
+				return
+			}
+			results[i].Commitment = commitment
+		}()
+	}
+	wg.Wait()
+
+	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path, "commitmentMode", mode,
+		"batchSize", len(request.Payloads))
+
+	return writeBatchResponse(w, PutBatchResponse{Results: results})
+}
+
+// putBatchItem disperses a single payload of a batch and returns its encoded commitment.
+func (svr *Server) putBatchItem(
+	r *http.Request,
+	payload []byte,
+	mode commitments.CommitmentMode,
+) ([]byte, error) {
+	versionedCert, err := svr.certMgr.Put(r.Context(), payload, coretypes.CertSerializationRLP)
+	if err != nil {
+		return nil, fmt.Errorf("post request failed: %w", err)
+	}
+
+	commitment, err := commitments.EncodeCommitment(versionedCert, mode)
+	if err != nil {
+		// This error is only possible if we have a bug in the code.
+		return nil, fmt.Errorf("failed to encode DA Commitment %v: %w", versionedCert.SerializedCert, err)
+	}
+	return commitment, nil
+}
+
+// =================================================================================================
+// POST /get/batch
+// =================================================================================================
+
+// handleGetStdCommitmentBatch handles batched GET requests for std commitments.
+func (svr *Server) handleGetStdCommitmentBatch(w http.ResponseWriter, r *http.Request) error {
+	if !svr.config.APIsEnabled.StandardCommitment {
+		w.WriteHeader(http.StatusForbidden)
+		return fmt.Errorf("standard DA Commitment type detected but `standard` API is not enabled")
+	}
+
+	return svr.handleGetBatchShared(w, r, commitments.StandardCommitmentMode)
+}
+
+// handleGetOPGenericCommitmentBatch handles batched GET requests for optimism generic commitments.
+func (svr *Server) handleGetOPGenericCommitmentBatch(w http.ResponseWriter, r *http.Request) error {
+	if !svr.config.APIsEnabled.OpGenericCommitment {
+		w.WriteHeader(http.StatusForbidden)
+		return fmt.Errorf("op-generic DA Commitment type detected but `op-generic` API is not enabled")
+	}
+
+	return svr.handleGetBatchShared(w, r, commitments.OptimismGenericCommitmentMode)
+}
+
+func (svr *Server) handleGetBatchShared(
+	w http.ResponseWriter,
+	r *http.Request,
+	mode commitments.CommitmentMode,
+) error {
+	var request GetBatchRequest
+	err := readBatchRequest(w, r, &request)
+	if err != nil {
+		return err
+	}
+	err = checkBatchSize(len(request.Items))
+	if err != nil {
+		return err
+	}
+
+	// Applies to every item of the batch, see the single-payload GET route.
+	returnEncodedPayload := parseReturnEncodedPayloadQueryParam(r)
+
+	results := make([]GetBatchResult, len(request.Items))
+	var wg sync.WaitGroup
+	for i, item := range request.Items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			payload, err := svr.getBatchItem(r, item, mode, returnEncodedPayload)
+			if err != nil {
+				svr.log.Warn("Batch item failed", "method", r.Method, "url", r.URL.Path, "index", i, "err", err)
+				results[i].Error = newBatchItemError(err)
+				return
+			}
+			results[i].Payload = payload
+		}()
+	}
+	wg.Wait()
+
+	svr.log.Info("Processed request", "method", r.Method, "url", r.URL.Path, "commitmentMode", mode,
+		"batchSize", len(request.Items), "returnEncodedPayload", returnEncodedPayload)
+
+	return writeBatchResponse(w, GetBatchResponse{Results: results})
+}
+
+// getBatchItem retrieves the payload (or encoded payload) of a single item of a batch.
+func (svr *Server) getBatchItem(
+	r *http.Request,
+	item GetBatchItem,
+	mode commitments.CommitmentMode,
+	returnEncodedPayload bool,
+) ([]byte, error) {
+	versionedCert, err := commitments.DecodeCommitment(item.Commitment, mode)
+	if err != nil {
+		return nil, proxyerrors.NewParsingError(fmt.Errorf("decoding commitment: %w", err))
+	}
+
+	payload, err := svr.certMgr.Get(
+		r.Context(),
+		versionedCert,
+		coretypes.CertSerializationRLP,
+		common.GETOpts{
+			L1InclusionBlockNum:  item.L1InclusionBlockNumber,
+			ReturnEncodedPayload: returnEncodedPayload,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get request failed with serializedCert (version %v) %x: %w",
+			versionedCert.Version, versionedCert.SerializedCert, err)
+	}
+	return payload, nil
+}
+
+// =================================================================================================
+// HELPERS
+// =================================================================================================
+
+// readBatchRequest reads and unmarshals the JSON body of a batch request into request.
+func readBatchRequest(w http.ResponseWriter, r *http.Request, request any) error {
+	body := http.MaxBytesReader(w, r.Body, common.MaxServerBatchPOSTRequestBodySize)
+	err := json.NewDecoder(body).Decode(request)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return proxyerrors.NewReadRequestBodyError(err, common.MaxServerBatchPOSTRequestBodySize)
+		}
+		return proxyerrors.NewUnmarshalJSONError(err)
+	}
+	return nil
+}
+
+// checkBatchSize returns an error if a batch is empty or has more than [common.MaxServerBatchSize] items.
+func checkBatchSize(size int) error {
+	if size == 0 {
+		return proxyerrors.NewParsingError(errors.New("batch is empty"))
+	}
+	if size > common.MaxServerBatchSize {
+		return proxyerrors.NewParsingError(
+			fmt.Errorf("batch has %d items, at most %d are allowed", size, common.MaxServerBatchSize))
+	}
+	return nil
+}
+
+// writeBatchResponse writes a batch response as JSON. Batch responses always have a 200 status code,
+// failures of individual items are reported in the response body.
+func writeBatchResponse(w http.ResponseWriter, response any) error {
+	w.Header().Set(headerContentType, contentTypeJSON)
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		// If the write fails, we will already have sent a 200 header. But we still return an error
+		// here so that the logging middleware can log it.
+		return fmt.Errorf("failed to write batch response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func serveBatchRequest(
+	t *testing.T,
+	mockEigenDAManager *mocks.MockIEigenDAManager,
+	url string,
+	request any,
+) *httptest.ResponseRecorder {
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	r := mux.NewRouter()
+	mockKeccakManager := mocks.NewMockIKeccakManager(gomock.NewController(t))
+	server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
+	server.RegisterRoutes(r)
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerPutBatch(t *testing.T) {
+	modes := []struct {
+		name           string
+		url            string
+		expectedPrefix string
+	}{
+		{
+			name:           "OP Mode Alt-DA",
+			url:            "/put/batch",
+			expectedPrefix: opGenericPrefixStr,
+		},
+		{
+			name:           "Standard Commitment Mode",
+			url:            "/put/batch?commitment_mode=standard",
+			expectedPrefix: stdCommitmentPrefix,
+		},
+	}
+
+	for _, mode := range modes {
+		t.Run(mode.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+
+			// Every payload is dispersed independently, the ones starting with "fail" return an error.
+			mockEigenDAManager.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Times(4).DoAndReturn(
+				func(_ any, payload []byte, _ any) (*certs.VersionedCert, error) {
+					switch string(payload) {
+					case "fail-400":
+						return nil, proxyerrors.ErrProxyOversizedBlob
+					case "fail-429":
+						return nil, status.Errorf(codes.ResourceExhausted, "rate limited")
+					}
+					return certs.NewVersionedCert([]byte("cert-"+string(payload)), certs.V0VersionByte), nil
+				})
+
+			rec := serveBatchRequest(t, mockEigenDAManager, mode.url, PutBatchRequest{
+				Payloads: []hexutil.Bytes{[]byte("a"), []byte("fail-400"), []byte("b"), []byte("fail-429")},
+			})
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, contentTypeJSON, rec.Header().Get(headerContentType))
+
+			var response PutBatchResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Results, 4)
+
+			require.Nil(t, response.Results[0].Error)
+			require.Equal(t, mode.expectedPrefix+"cert-a", string(response.Results[0].Commitment))
+			require.Nil(t, response.Results[1].Commitment)
+			require.Equal(t, http.StatusBadRequest, response.Results[1].Error.StatusCode)
+			require.Nil(t, response.Results[2].Error)
+			require.Equal(t, mode.expectedPrefix+"cert-b", string(response.Results[2].Commitment))
+			require.Nil(t, response.Results[3].Commitment)
+			require.Equal(t, http.StatusTooManyRequests, response.Results[3].Error.StatusCode)
+		})
+	}
+}
+
+func TestHandlerGetBatch(t *testing.T) {
+	modes := []struct {
+		name string
+		url  string
+		mode commitments.CommitmentMode
+	}{
+		{
+			name: "OP Mode Alt-DA",
+			url:  "/get/batch?return_encoded_payload=true",
+			mode: commitments.OptimismGenericCommitmentMode,
+		},
+		{
+			name: "Standard Commitment Mode",
+			url:  "/get/batch?commitment_mode=standard&return_encoded_payload=true",
+			mode: commitments.StandardCommitmentMode,
+		},
+	}
+
+	for _, mode := range modes {
+		t.Run(mode.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+
+			mockEigenDAManager.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+				func(_ any, versionedCert *certs.VersionedCert, _ any, opts common.GETOpts) ([]byte, error) {
+					if string(versionedCert.SerializedCert) == "cert-fail" {
+						return nil, fmt.Errorf("internal error")
+					}
+					require.Equal(t, certs.V2VersionByte, versionedCert.Version)
+					require.True(t, opts.ReturnEncodedPayload)
+					return []byte(fmt.Sprintf("payload-%s-%d", versionedCert.SerializedCert, opts.L1InclusionBlockNum)), nil
+				})
+
+			encode := func(cert string) hexutil.Bytes {
+				commitment, err := commitments.EncodeCommitment(
+					certs.NewVersionedCert([]byte(cert), certs.V2VersionByte), mode.mode)
+				require.NoError(t, err)
+				return commitment
+			}
+
+			rec := serveBatchRequest(t, mockEigenDAManager, mode.url, GetBatchRequest{
+				Items: []GetBatchItem{
+					{Commitment: encode("cert-a"), L1InclusionBlockNumber: 100},
+					{Commitment: encode("cert-fail")},
+					// malformed in both modes, rejected before reaching the manager
+					{Commitment: hexutil.Bytes{0xfe, 0xfe, 0xaa}},
+				},
+			})
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var response GetBatchResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Results, 3)
+
+			require.Nil(t, response.Results[0].Error)
+			require.Equal(t, "payload-cert-a-100", string(response.Results[0].Payload))
+			require.Nil(t, response.Results[1].Payload)
+			require.Equal(t, http.StatusInternalServerError, response.Results[1].Error.StatusCode)
+			require.Nil(t, response.Results[2].Payload)
+			require.Equal(t, http.StatusBadRequest, response.Results[2].Error.StatusCode)
+		})
+	}
+}
+
+func TestHandlerBatchRequestErrors(t *testing.T) {
+	tooManyPayloads := make([]hexutil.Bytes, common.MaxServerBatchSize+1)
+	for i := range tooManyPayloads {
+		tooManyPayloads[i] = []byte("payload")
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		request any
+	}{
+		{
+			name:    "empty put batch",
+			url:     "/put/batch",
+			request: PutBatchRequest{},
+		},
+		{
+			name:    "too many payloads",
+			url:     "/put/batch?commitment_mode=standard",
+			request: PutBatchRequest{Payloads: tooManyPayloads},
+		},
+		{
+			name:    "empty get batch",
+			url:     "/get/batch",
+			request: GetBatchRequest{},
+		},
+		{
+			name:    "malformed json",
+			url:     "/get/batch",
+			request: "not a batch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the manager must not be called for requests that are rejected as a whole
+			mockEigenDAManager := mocks.NewMockIEigenDAManager(gomock.NewController(t))
+			rec := serveBatchRequest(t, mockEigenDAManager, tt.url, tt.request)
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
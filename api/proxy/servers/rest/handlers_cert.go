@@ -195,9 +195,14 @@ func (svr *Server) handlePostShared(
 		return fmt.Errorf("standard DA Commitment type detected but `standard` API is not enabled")
 	}
 
-	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, common.MaxServerPOSTRequestBodySize))
+	maxBodySize := common.MaxPayloadPOSTRequestBodySize(svr.config.CompatibilityCfg.MaxPayloadSizeBytes)
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
 	if err != nil {
-		return proxyerrors.NewReadRequestBodyError(err, common.MaxServerPOSTRequestBodySize)
+		return proxyerrors.NewReadRequestBodyError(err, maxBodySize)
+	}
+
+	if parseAsyncQueryParam(r) {
+		return svr.submitDispersalJob(w, r, payload, mode)
 	}
 
 	versionedCert, err := svr.certMgr.Put(r.Context(), payload, coretypes.CertSerializationRLP)
@@ -57,7 +57,7 @@ func TestConfigEndpoint(t *testing.T) {
 		rec := httptest.NewRecorder()
 
 		r := mux.NewRouter()
-		server := NewServer(cfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+		server := NewServer(cfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 		server.RegisterRoutes(r)
 		r.ServeHTTP(rec, req)
 
@@ -104,7 +104,8 @@ func TestEigenDADispersalBackendEndpoints(t *testing.T) {
 		rec := httptest.NewRecorder()
 
 		r := mux.NewRouter()
-		server := NewServer(adminDisabledCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+		server := NewServer(adminDisabledCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger,
+			metrics.NoopMetrics)
 		server.RegisterRoutes(r)
 		r.ServeHTTP(rec, req)
 
@@ -123,7 +124,7 @@ func TestEigenDADispersalBackendEndpoints(t *testing.T) {
 			rec := httptest.NewRecorder()
 
 			r := mux.NewRouter()
-			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 			server.RegisterRoutes(r)
 			r.ServeHTTP(rec, req)
 
@@ -151,7 +152,7 @@ func TestEigenDADispersalBackendEndpoints(t *testing.T) {
 			rec := httptest.NewRecorder()
 
 			r := mux.NewRouter()
-			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 			server.RegisterRoutes(r)
 			r.ServeHTTP(rec, req)
 
@@ -175,7 +176,7 @@ func TestEigenDADispersalBackendEndpoints(t *testing.T) {
 			rec := httptest.NewRecorder()
 
 			r := mux.NewRouter()
-			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, testLogger, metrics.NoopMetrics)
+			server := NewServer(testCfg, mockEigenDAManager, mockKeccakManager, nil, nil, testLogger, metrics.NoopMetrics)
 			server.RegisterRoutes(r)
 			r.ServeHTTP(rec, req)
 
@@ -0,0 +1,64 @@
+// handlers_peering.go contains the handler serving the cache entries of this proxy to its peers,
+// see the peer package for the client side.
+//
+// Like the handlers in handlers_misc.go, it is not wrapped in the cert middlewares,
+// so it does its own authentication, logging and error handling.
+package rest
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/peer"
+	"github.com/gorilla/mux"
+)
+
+const routingVarNamePeeringCacheKeyHex = "cache_key_hex"
+
+// handleGetPeeringCacheEntry returns the payload stored under a cache key (keccak256 of a serialized cert)
+// in the caches of this proxy. Only the caches are read, never EigenDA nor the peers of this proxy,
+// such that peers can't trigger retrievals or forward requests to each other.
+// The payload is not verified here, peers verify it against the cert themselves.
+func (svr *Server) handleGetPeeringCacheEntry(w http.ResponseWriter, r *http.Request) {
+	if !svr.isAuthorizedPeer(r) {
+		svr.log.Warn("unauthorized peering request", "path", r.URL.Path, "remoteAddr", r.RemoteAddr)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	key, err := hex.DecodeString(mux.Vars(r)[routingVarNamePeeringCacheKeyHex])
+	if err != nil {
+		// can't happen since the route only matches 64 hex chars
+		http.Error(w, "invalid cache key", http.StatusBadRequest)
+		return
+	}
+
+	payload, err := svr.certMgr.GetCachedPayload(r.Context(), key)
+	if err != nil {
+		svr.log.Error("failed to read cache for peer", "key", hex.EncodeToString(key), "error", err)
+		http.Error(w, "failed to read cache", http.StatusInternalServerError)
+		return
+	}
+	if payload == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	svr.log.Debug("served cache entry to peer", "key", hex.EncodeToString(key), "payloadLen", len(payload))
+	w.Header().Set(headerContentType, "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(payload); err != nil {
+		svr.log.Error("failed to write cache entry to peer", "key", hex.EncodeToString(key), "error", err)
+	}
+}
+
+// isAuthorizedPeer checks, in constant time, that the request carries the peering shared secret.
+func (svr *Server) isAuthorizedPeer(r *http.Request) bool {
+	secret, ok := strings.CutPrefix(r.Header.Get("Authorization"), peer.AuthorizationHeaderPrefix)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(svr.config.PeeringSharedSecret)) == 1
+}
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/config/enablement"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/peer"
+	"github.com/Layr-Labs/eigenda/api/proxy/test/mocks"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleGetPeeringCacheEntry(t *testing.T) {
+	const secret = "shared secret"
+	key := crypto.Keccak256([]byte("cert"))
+	path := peer.CacheRoutePrefix + hex.EncodeToString(key)
+	payload := []byte("some payload")
+
+	newRouter := func(t *testing.T, peeringSecret string) (*mux.Router, *mocks.MockIEigenDAManager) {
+		ctrl := gomock.NewController(t)
+		mockEigenDAManager := mocks.NewMockIEigenDAManager(ctrl)
+		cfg := Config{
+			APIsEnabled:         &enablement.RestApisEnabled{},
+			PeeringSharedSecret: peeringSecret,
+		}
+		server := NewServer(cfg, mockEigenDAManager, mocks.NewMockIKeccakManager(ctrl), nil, nil, testLogger,
+			metrics.NoopMetrics)
+		r := mux.NewRouter()
+		server.RegisterRoutes(r)
+		return r, mockEigenDAManager
+	}
+
+	serve := func(r *mux.Router, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("cached payload", func(t *testing.T) {
+		r, mockEigenDAManager := newRouter(t, secret)
+		mockEigenDAManager.EXPECT().GetCachedPayload(gomock.Any(), key).Return(payload, nil)
+
+		rec := serve(r, peer.AuthorizationHeaderPrefix+secret)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, payload, rec.Body.Bytes())
+	})
+
+	t.Run("payload not cached", func(t *testing.T) {
+		r, mockEigenDAManager := newRouter(t, secret)
+		mockEigenDAManager.EXPECT().GetCachedPayload(gomock.Any(), key).Return(nil, nil)
+
+		rec := serve(r, peer.AuthorizationHeaderPrefix+secret)
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("cache error", func(t *testing.T) {
+		r, mockEigenDAManager := newRouter(t, secret)
+		mockEigenDAManager.EXPECT().GetCachedPayload(gomock.Any(), key).Return(nil, errors.New("oops"))
+
+		rec := serve(r, peer.AuthorizationHeaderPrefix+secret)
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("wrong or missing secret", func(t *testing.T) {
+		// the cache must not be read for unauthorized requests
+		r, _ := newRouter(t, secret)
+
+		require.Equal(t, http.StatusUnauthorized, serve(r, peer.AuthorizationHeaderPrefix+"wrong").Code)
+		require.Equal(t, http.StatusUnauthorized, serve(r, secret).Code)
+		require.Equal(t, http.StatusUnauthorized, serve(r, "").Code)
+	})
+
+	t.Run("route disabled without secret", func(t *testing.T) {
+		r, _ := newRouter(t, "")
+
+		rec := serve(r, peer.AuthorizationHeaderPrefix)
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
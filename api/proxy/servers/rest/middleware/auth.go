@@ -0,0 +1,325 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// APIKeyHeader is the header that clients authenticating with a static API key must set.
+	APIKeyHeader = "X-API-Key"
+
+	// UnauthenticatedClientID is the client label used in logs and metrics for requests that failed authentication.
+	UnauthenticatedClientID = "unauthenticated"
+)
+
+// ClientConfig describes a client that is allowed to use the proxy, and the quotas applied to it.
+type ClientConfig struct {
+	// Name identifies the client in logs and metrics. For JWT authentication, it is matched against the `sub` claim,
+	// and tokens whose `sub` doesn't match any configured client are rejected.
+	Name string `json:"name"`
+	// APIKey authenticates the client via the X-API-Key header. Can be left empty for clients that only use JWTs.
+	APIKey string `json:"api_key"`
+	// RequestsPerSecond is the sustained request rate allowed for the client. 0 means unlimited.
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	// BytesPerSecond is the sustained rate of POST body bytes allowed for the client. 0 means unlimited.
+	BytesPerSecond float64 `json:"bytes_per_second"`
+}
+
+// AuthConfig configures authentication and per-client quotas on the cert routes.
+// Authentication is disabled unless at least one client or a JWT secret is configured.
+type AuthConfig struct {
+	// Clients authenticated by API key, or by JWT with a matching `sub` claim.
+	Clients []ClientConfig
+	// HS256 secret used to verify JWT bearer tokens. JWT authentication is disabled if empty.
+	JWTSecret []byte
+	// QuotaBurstDuration * rate is the capacity of each quota bucket,
+	// i.e. how much a client is allowed to burst above its sustained rate.
+	QuotaBurstDuration time.Duration
+}
+
+// Enabled returns true if requests to the cert routes must be authenticated.
+func (c AuthConfig) Enabled() bool {
+	return len(c.Clients) > 0 || len(c.JWTSecret) > 0
+}
+
+// Check checks that the auth config is valid.
+func (c AuthConfig) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.QuotaBurstDuration <= 0 {
+		return fmt.Errorf("quota burst duration must be > 0, got %s", c.QuotaBurstDuration)
+	}
+	if len(c.Clients) == 0 {
+		// JWTs are only accepted for configured clients, so a JWT secret on its own would reject every request.
+		return errors.New("at least one client must be configured when JWT authentication is enabled")
+	}
+	names := make(map[string]struct{}, len(c.Clients))
+	keys := make(map[string]struct{}, len(c.Clients))
+	for i, client := range c.Clients {
+		if client.Name == "" {
+			return fmt.Errorf("client %d has no name", i)
+		}
+		if client.Name == UnauthenticatedClientID {
+			return fmt.Errorf("client name %s is reserved", UnauthenticatedClientID)
+		}
+		if _, ok := names[client.Name]; ok {
+			return fmt.Errorf("duplicate client name %s", client.Name)
+		}
+		names[client.Name] = struct{}{}
+		if client.APIKey == "" && len(c.JWTSecret) == 0 {
+			return fmt.Errorf("client %s has no api key, and JWT authentication is disabled", client.Name)
+		}
+		if client.APIKey != "" {
+			if _, ok := keys[client.APIKey]; ok {
+				return fmt.Errorf("client %s reuses the api key of another client", client.Name)
+			}
+			keys[client.APIKey] = struct{}{}
+		}
+		if client.RequestsPerSecond < 0 || client.BytesPerSecond < 0 {
+			return fmt.Errorf("client %s quotas must be >= 0, got %f requests/s and %f bytes/s",
+				client.Name, client.RequestsPerSecond, client.BytesPerSecond)
+		}
+	}
+	return nil
+}
+
+// Authenticator authenticates requests and enforces per-client quotas.
+// A nil *Authenticator disables authentication.
+//
+// The REST server uses it through its middlewares, and the gRPC server through interceptors.
+type Authenticator struct {
+	cfg AuthConfig
+	// clients by sha256 of their api key, so that lookups don't compare keys byte by byte
+	clientsByKeyHash map[[32]byte]ClientConfig
+	clientsByName    map[string]ClientConfig
+	jwtParser        *jwt.Parser
+
+	// Quotas are created lazily, the first time a client makes a request. Only configured clients can authenticate,
+	// so the map never holds more entries than there are clients in the config.
+	quotasLock sync.Mutex
+	quotas     map[string]*clientQuota
+}
+
+// NewAuthenticator creates an Authenticator from the config, or returns nil if authentication is disabled.
+// The config is expected to have been validated with [AuthConfig.Check].
+func NewAuthenticator(cfg AuthConfig) *Authenticator {
+	if !cfg.Enabled() {
+		return nil
+	}
+	a := &Authenticator{
+		cfg:              cfg,
+		clientsByKeyHash: make(map[[32]byte]ClientConfig),
+		clientsByName:    make(map[string]ClientConfig),
+		jwtParser:        jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()})),
+		quotas:           make(map[string]*clientQuota),
+	}
+	for _, client := range cfg.Clients {
+		a.clientsByName[client.Name] = client
+		if client.APIKey != "" {
+			a.clientsByKeyHash[sha256.Sum256([]byte(client.APIKey))] = client
+		}
+	}
+	return a
+}
+
+// authenticate returns the client that made the request.
+func (a *Authenticator) authenticate(r *http.Request) (ClientConfig, error) {
+	return a.Authenticate(r.Header.Get(APIKeyHeader), r.Header.Get("Authorization"))
+}
+
+// Authenticate returns the client identified by either the value of the X-API-Key header, or by the value of the
+// Authorization header (a JWT bearer token). The API key takes precedence if both are set.
+func (a *Authenticator) Authenticate(apiKey string, authHeader string) (ClientConfig, error) {
+	if apiKey != "" {
+		client, ok := a.clientsByKeyHash[sha256.Sum256([]byte(apiKey))]
+		if !ok {
+			return ClientConfig{}, errors.New("unknown api key")
+		}
+		return client, nil
+	}
+
+	if authHeader == "" {
+		return ClientConfig{}, fmt.Errorf("missing %s or Authorization header", APIKeyHeader)
+	}
+	token, found := strings.CutPrefix(authHeader, "Bearer ")
+	if !found {
+		return ClientConfig{}, errors.New("authorization header is not a bearer token")
+	}
+	if len(a.cfg.JWTSecret) == 0 {
+		return ClientConfig{}, errors.New("JWT authentication is disabled")
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	_, err := a.jwtParser.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return a.cfg.JWTSecret, nil
+	})
+	if err != nil {
+		return ClientConfig{}, fmt.Errorf("invalid JWT: %w", err)
+	}
+	// Tokens without an expiry would be valid forever, which we don't want for bearer credentials.
+	if claims.ExpiresAt == nil {
+		return ClientConfig{}, errors.New("JWT has no exp claim")
+	}
+	if claims.Subject == "" || claims.Subject == UnauthenticatedClientID {
+		return ClientConfig{}, fmt.Errorf("invalid JWT sub claim %q", claims.Subject)
+	}
+
+	// Unknown subjects are rejected rather than given some default quota, so that every client is metered, and so
+	// that a secret holder can't create an unbounded number of quotas by minting tokens with fresh subjects.
+	client, ok := a.clientsByName[claims.Subject]
+	if !ok {
+		return ClientConfig{}, fmt.Errorf("unknown JWT sub claim %q", claims.Subject)
+	}
+	return client, nil
+}
+
+// Charge charges the given number of requests and bytes against the client's quotas. It returns a
+// [proxyerrors.QuotaExceededError] if either quota is exhausted, in which case nothing is charged.
+func (a *Authenticator) Charge(client ClientConfig, requests int, size int64) error {
+	now := time.Now()
+	quota, err := a.getQuota(client, now)
+	if err != nil {
+		return err
+	}
+	return quota.consume(client.Name, requests, size, now)
+}
+
+// getQuota returns the quota of the client, creating it if this is the client's first request.
+func (a *Authenticator) getQuota(client ClientConfig, now time.Time) (*clientQuota, error) {
+	a.quotasLock.Lock()
+	defer a.quotasLock.Unlock()
+
+	if quota, ok := a.quotas[client.Name]; ok {
+		return quota, nil
+	}
+	quota, err := newClientQuota(client, a.cfg.QuotaBurstDuration, now)
+	if err != nil {
+		return nil, fmt.Errorf("new quota for client %s: %w", client.Name, err)
+	}
+	a.quotas[client.Name] = quota
+	return quota, nil
+}
+
+// clientQuota holds the leaky buckets metering a single client. A nil bucket means the quota is unlimited.
+type clientQuota struct {
+	lock     sync.Mutex
+	requests *ratelimit.LeakyBucket
+	bytes    *ratelimit.LeakyBucket
+}
+
+func newClientQuota(client ClientConfig, burstDuration time.Duration, now time.Time) (*clientQuota, error) {
+	quota := &clientQuota{}
+	var err error
+	if client.RequestsPerSecond > 0 {
+		quota.requests, err = ratelimit.NewLeakyBucket(
+			client.RequestsPerSecond, burstDuration, false, ratelimit.OverfillNotPermitted, now)
+		if err != nil {
+			return nil, fmt.Errorf("new requests bucket: %w", err)
+		}
+	}
+	if client.BytesPerSecond > 0 {
+		// A single payload can be larger than the bucket, so we let it through as long as there is any capacity left.
+		quota.bytes, err = ratelimit.NewLeakyBucket(
+			client.BytesPerSecond, burstDuration, false, ratelimit.OverfillOncePermitted, now)
+		if err != nil {
+			return nil, fmt.Errorf("new bytes bucket: %w", err)
+		}
+	}
+	return quota, nil
+}
+
+// consume charges requests and size bytes against the quota. It returns a [proxyerrors.QuotaExceededError]
+// if either quota is exhausted, in which case nothing is charged.
+func (q *clientQuota) consume(client string, requests int, size int64, now time.Time) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.requests != nil && requests > 0 {
+		ok, err := q.requests.Fill(now, float64(requests))
+		if err != nil {
+			return fmt.Errorf("fill requests bucket: %w", err)
+		}
+		if !ok {
+			return proxyerrors.NewQuotaExceededError(client, "requests")
+		}
+	}
+	if q.bytes != nil && size > 0 {
+		ok, err := q.bytes.Fill(now, float64(size))
+		if err != nil {
+			return fmt.Errorf("fill bytes bucket: %w", err)
+		}
+		if !ok {
+			if q.requests != nil && requests > 0 {
+				if err := q.requests.RevertFill(now, float64(requests)); err != nil {
+					return fmt.Errorf("revert requests bucket fill: %w", err)
+				}
+			}
+			return proxyerrors.NewQuotaExceededError(client, "bytes")
+		}
+	}
+	return nil
+}
+
+// withAuth is a middleware that authenticates the request and charges it against the client's quotas.
+// It sits inside the error handling middleware, so the errors it returns are converted to 401s and 429s.
+// If auth is nil, requests are passed through untouched.
+func withAuth(
+	handleFn func(http.ResponseWriter, *http.Request) error,
+	auth *Authenticator,
+) func(http.ResponseWriter, *http.Request) error {
+	if auth == nil {
+		return handleFn
+	}
+	return func(w http.ResponseWriter, r *http.Request) error {
+		client, err := auth.authenticate(r)
+		if err != nil {
+			setClientID(r, UnauthenticatedClientID)
+			return proxyerrors.NewUnauthorizedError(err)
+		}
+		setClientID(r, client.Name)
+
+		// Only POST bodies count towards the byte quota, since those are what get dispersed
+		// and paid for with the proxy's EigenDA payment account.
+		var size int64
+		if r.Method == http.MethodPost {
+			size, err = requestBodySize(w, r)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = auth.Charge(client, 1, size)
+		if err != nil {
+			return err
+		}
+		return handleFn(w, r)
+	}
+}
+
+// requestBodySize returns the size of the request body. When the client didn't send a Content-Length
+// (e.g. chunked encoding), the body is read into memory and replaced so that handlers can still read it.
+func requestBodySize(w http.ResponseWriter, r *http.Request) (int64, error) {
+	if r.ContentLength >= 0 {
+		return r.ContentLength, nil
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, common.MaxServerBatchPOSTRequestBodySize))
+	if err != nil {
+		return 0, proxyerrors.NewReadRequestBodyError(err, common.MaxServerBatchPOSTRequestBodySize)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return int64(len(body)), nil
+}
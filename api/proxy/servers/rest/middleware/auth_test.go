@@ -0,0 +1,231 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+)
+
+var testJWTSecret = bytes.Repeat([]byte{0x42}, 32)
+
+func newTestAuthMiddleware(t *testing.T, cfg AuthConfig) (http.HandlerFunc, *clientRecordingMetricer) {
+	t.Helper()
+	require.NoError(t, cfg.Check())
+
+	handler := func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	m := &clientRecordingMetricer{}
+	testLogger := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+	return WithCertMiddlewares(
+		handler,
+		testLogger,
+		m,
+		commitments.OptimismGenericCommitmentMode,
+		NewAuthenticator(cfg),
+	), m
+}
+
+func signTestJWT(t *testing.T, secret []byte, claims jwt.RegisteredClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuth_APIKey(t *testing.T) {
+	mw, m := newTestAuthMiddleware(t, AuthConfig{
+		Clients:            []ClientConfig{{Name: "batcher", APIKey: "secret-key"}},
+		QuotaBurstDuration: time.Second,
+	})
+
+	tests := []struct {
+		name           string
+		apiKey         string
+		expectedStatus int
+		expectedClient string
+	}{
+		{name: "valid key", apiKey: "secret-key", expectedStatus: http.StatusOK, expectedClient: "batcher"},
+		{name: "unknown key", apiKey: "wrong-key", expectedStatus: http.StatusUnauthorized,
+			expectedClient: UnauthenticatedClientID},
+		{name: "missing key", apiKey: "", expectedStatus: http.StatusUnauthorized,
+			expectedClient: UnauthenticatedClientID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/get/0x00", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			mw(rec, req)
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			require.Equal(t, tt.expectedClient, m.lastClient)
+		})
+	}
+}
+
+func TestAuth_JWT(t *testing.T) {
+	mw, m := newTestAuthMiddleware(t, AuthConfig{
+		Clients:            []ClientConfig{{Name: "rollup-a"}},
+		JWTSecret:          testJWTSecret,
+		QuotaBurstDuration: time.Second,
+	})
+	validExpiry := jwt.NewNumericDate(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+		expectedClient string
+	}{
+		{
+			name:           "valid token",
+			token:          signTestJWT(t, testJWTSecret, jwt.RegisteredClaims{Subject: "rollup-a", ExpiresAt: validExpiry}),
+			expectedStatus: http.StatusOK,
+			expectedClient: "rollup-a",
+		},
+		{
+			name: "expired token",
+			token: signTestJWT(t, testJWTSecret, jwt.RegisteredClaims{
+				Subject: "rollup-a", ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour))}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedClient: UnauthenticatedClientID,
+		},
+		{
+			name:           "token without expiry",
+			token:          signTestJWT(t, testJWTSecret, jwt.RegisteredClaims{Subject: "rollup-a"}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedClient: UnauthenticatedClientID,
+		},
+		{
+			name:           "token with unknown subject",
+			token:          signTestJWT(t, testJWTSecret, jwt.RegisteredClaims{Subject: "rollup-b", ExpiresAt: validExpiry}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedClient: UnauthenticatedClientID,
+		},
+		{
+			name:           "token without subject",
+			token:          signTestJWT(t, testJWTSecret, jwt.RegisteredClaims{ExpiresAt: validExpiry}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedClient: UnauthenticatedClientID,
+		},
+		{
+			name: "token signed with another secret",
+			token: signTestJWT(t, bytes.Repeat([]byte{0x43}, 32),
+				jwt.RegisteredClaims{Subject: "rollup-a", ExpiresAt: validExpiry}),
+			expectedStatus: http.StatusUnauthorized,
+			expectedClient: UnauthenticatedClientID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/get/0x00", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			mw(rec, req)
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			require.Equal(t, tt.expectedClient, m.lastClient)
+		})
+	}
+}
+
+func TestAuth_RequestQuota(t *testing.T) {
+	// bucket capacity is 1 request/s * 1s = 1 request, so the second request is rejected
+	mw, _ := newTestAuthMiddleware(t, AuthConfig{
+		Clients: []ClientConfig{
+			{Name: "limited", APIKey: "limited-key", RequestsPerSecond: 1},
+			{Name: "unlimited", APIKey: "unlimited-key"},
+		},
+		QuotaBurstDuration: time.Second,
+	})
+
+	send := func(apiKey string) int {
+		req := httptest.NewRequest(http.MethodGet, "/get/0x00", nil)
+		req.Header.Set(APIKeyHeader, apiKey)
+		rec := httptest.NewRecorder()
+		mw(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, send("limited-key"))
+	require.Equal(t, http.StatusTooManyRequests, send("limited-key"))
+	// quotas are per client
+	for range 10 {
+		require.Equal(t, http.StatusOK, send("unlimited-key"))
+	}
+}
+
+func TestAuth_ByteQuota(t *testing.T) {
+	// bucket capacity is 100 bytes/s * 1s = 100 bytes
+	mw, _ := newTestAuthMiddleware(t, AuthConfig{
+		Clients:            []ClientConfig{{Name: "rollup-a", BytesPerSecond: 100}},
+		JWTSecret:          testJWTSecret,
+		QuotaBurstDuration: time.Second,
+	})
+	token := signTestJWT(t, testJWTSecret, jwt.RegisteredClaims{
+		Subject: "rollup-a", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))})
+
+	send := func(method string, body []byte) int {
+		req := httptest.NewRequest(method, "/put", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		mw(rec, req)
+		return rec.Code
+	}
+
+	// a payload larger than the bucket is let through while there is capacity left...
+	require.Equal(t, http.StatusOK, send(http.MethodPost, make([]byte, 150)))
+	// ...but the next payload has to wait for the bucket to drain
+	require.Equal(t, http.StatusTooManyRequests, send(http.MethodPost, make([]byte, 10)))
+	// GETs don't count towards the byte quota
+	require.Equal(t, http.StatusOK, send(http.MethodGet, nil))
+}
+
+func TestAuthConfig_Check(t *testing.T) {
+	require.NoError(t, AuthConfig{}.Check(), "disabled auth config should always be valid")
+	require.Nil(t, NewAuthenticator(AuthConfig{}))
+
+	require.Error(t, AuthConfig{
+		Clients: []ClientConfig{{Name: "a", APIKey: "key"}},
+	}.Check(), "burst duration is required")
+	require.Error(t, AuthConfig{
+		Clients:            []ClientConfig{{Name: "a", APIKey: "key"}, {Name: "b", APIKey: "key"}},
+		QuotaBurstDuration: time.Second,
+	}.Check(), "api keys must be unique")
+	require.Error(t, AuthConfig{
+		Clients:            []ClientConfig{{Name: "a"}},
+		QuotaBurstDuration: time.Second,
+	}.Check(), "clients need an api key when JWTs are disabled")
+	require.Error(t, AuthConfig{
+		JWTSecret:          testJWTSecret,
+		QuotaBurstDuration: time.Second,
+	}.Check(), "JWTs are only accepted for configured clients")
+	require.Error(t, AuthConfig{
+		Clients:            []ClientConfig{{Name: "a", APIKey: "key", RequestsPerSecond: -1}},
+		QuotaBurstDuration: time.Second,
+	}.Check(), "quotas must be non-negative")
+}
+
+// clientRecordingMetricer records the client of the last request seen by the metrics middleware.
+type clientRecordingMetricer struct {
+	MockMetricer
+	lastClient string
+}
+
+func (m *clientRecordingMetricer) RecordRPCServerRequest(string) func(string, string, string) {
+	return func(string, string, string) {}
+}
+
+func (m *clientRecordingMetricer) RecordClientRequest(client string, _ string) {
+	m.lastClient = client
+}
@@ -23,31 +23,41 @@ func withErrorHandling(
 		// Or maybe we should just add a requestID to the error, and log the request-specific information
 		// in the logging middleware, so that we can correlate the error with the request?
 
-		var derivationErr coretypes.DerivationError
-		switch {
-		case proxyerrors.Is400(err):
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		// 418 TEAPOT errors don't follow the pattern proxyerrors.Is418(err),
-		// because we need to marshal the correct json body.
-		case errors.As(err, &derivationErr):
-			http.Error(w, derivationErr.MarshalToTeapotBody(), http.StatusTeapot)
-		case proxyerrors.Is429(err):
-			http.Error(w, err.Error(), http.StatusTooManyRequests)
-		case proxyerrors.Is503(err):
-			// this tells the caller (batcher) to failover to ethda b/c eigenda is temporarily down
-			http.Error(w, err.Error(), http.StatusServiceUnavailable)
-		default:
-			// Default to 500 for unexpected errors.
-			// Note that this includes grpc 4xx errors returned from the disperser server.
-			// because those are due to formatting bugs in proxy code, e.g. badly
-			// IFFT'ing or encoding the blob, so we shouldn't return a 400 to the client.
-			// See https://github.com/Layr-Labs/eigenda/blob/bee55ed9207f16153c3fd8ebf73c219e68685def/api/errors.go#L22
-			// for the 400s returned by the disperser server (currently only INVALID_ARGUMENT).
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
+		status, body := ErrorToHTTPStatus(err)
+		http.Error(w, body, status)
 
 		// forward error to the logging middleware (through the metrics middleware)
 		// so that the error is logged.
 		return err
 	}
 }
+
+// ErrorToHTTPStatus maps an internal error to the HTTP status code and body that are returned to clients.
+// It is exported so that handlers that report errors for individual items of a batch request
+// can report them the same way as the single-item routes.
+func ErrorToHTTPStatus(err error) (int, string) {
+	var derivationErr coretypes.DerivationError
+	switch {
+	case proxyerrors.Is400(err):
+		return http.StatusBadRequest, err.Error()
+	case proxyerrors.Is401(err):
+		return http.StatusUnauthorized, err.Error()
+	// 418 TEAPOT errors don't follow the pattern proxyerrors.Is418(err),
+	// because we need to marshal the correct json body.
+	case errors.As(err, &derivationErr):
+		return http.StatusTeapot, derivationErr.MarshalToTeapotBody()
+	case proxyerrors.Is429(err):
+		return http.StatusTooManyRequests, err.Error()
+	case proxyerrors.Is503(err):
+		// this tells the caller (batcher) to failover to ethda b/c eigenda is temporarily down
+		return http.StatusServiceUnavailable, err.Error()
+	default:
+		// Default to 500 for unexpected errors.
+		// Note that this includes grpc 4xx errors returned from the disperser server.
+		// because those are due to formatting bugs in proxy code, e.g. badly
+		// IFFT'ing or encoding the blob, so we shouldn't return a 400 to the client.
+		// See https://github.com/Layr-Labs/eigenda/blob/bee55ed9207f16153c3fd8ebf73c219e68685def/api/errors.go#L22
+		// for the 400s returned by the disperser server (currently only INVALID_ARGUMENT).
+		return http.StatusInternalServerError, err.Error()
+	}
+}
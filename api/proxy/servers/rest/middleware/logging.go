@@ -28,6 +28,9 @@ func withLogging(
 			"commitment_mode", mode, "cert_version", getCertVersion(r),
 			"status", scw.status, "duration", time.Since(start),
 		}
+		if clientID := GetClientID(r); clientID != "" {
+			args = append(args, "client", clientID)
+		}
 
 		if err != nil {
 			args = append(args, "error", err.Error())
@@ -24,6 +24,10 @@ func withMetrics(
 		certVersion := getCertVersion(r)
 		// Prob should use different metric for POST and GET errors.
 		recordDur(strconv.Itoa(scw.status), string(mode), certVersion)
+		// The client is only set when authentication is enabled.
+		if clientID := GetClientID(r); clientID != "" {
+			m.RecordClientRequest(clientID, strconv.Itoa(scw.status))
+		}
 
 		// Forward error to the logging middleware
 		return err
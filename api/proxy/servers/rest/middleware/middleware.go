@@ -9,7 +9,9 @@ import (
 )
 
 // Helper function to chain middlewares in the correct order
-// Context -> Logging -> Metrics -> Error Handling -> Handler
+// Context -> Logging -> Metrics -> Error Handling -> Auth -> Handler
+//
+// auth can be nil, in which case requests are not authenticated.
 //
 // This should only be used for cert POST and GET routes,
 // as the middlewares are currently not compatible with
@@ -21,11 +23,12 @@ func WithCertMiddlewares(
 	log logging.Logger,
 	m metrics.Metricer,
 	mode commitments.CommitmentMode,
+	auth *Authenticator,
 ) http.HandlerFunc {
 	return withRequestContext(
 		withLogging(
 			withMetrics(
-				withErrorHandling(handler),
+				withErrorHandling(withAuth(handler, auth)),
 				m,
 				mode,
 			),
@@ -34,3 +37,25 @@ func WithCertMiddlewares(
 		),
 	)
 }
+
+// WithAdminMiddlewares chains the same middlewares as WithCertMiddlewares around an admin route handler. Admin
+// handlers write their own error responses, so only errors returned by the auth middleware are handled here.
+//
+// auth can be nil, in which case requests are not authenticated.
+func WithAdminMiddlewares(
+	handler http.HandlerFunc,
+	log logging.Logger,
+	m metrics.Metricer,
+	auth *Authenticator,
+) http.HandlerFunc {
+	return WithCertMiddlewares(
+		func(w http.ResponseWriter, r *http.Request) error {
+			handler(w, r)
+			return nil
+		},
+		log,
+		m,
+		"",
+		auth,
+	)
+}
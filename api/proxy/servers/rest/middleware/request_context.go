@@ -30,6 +30,9 @@ func withRequestContext(
 // RequestContext holds request-specific data that middlewares need to share
 type RequestContext struct {
 	CertVersion string
+	// ClientID is the name of the client that the auth middleware authenticated the request as.
+	// It is empty when authentication is disabled.
+	ClientID string
 }
 
 // ContextKey is used to store CertVersion in the request context
@@ -61,3 +64,19 @@ func getCertVersion(r *http.Request) string {
 	}
 	return "unknown"
 }
+
+// setClientID is private because the client is only ever set by the auth middleware.
+func setClientID(r *http.Request, clientID string) {
+	if ctx := getRequestContext(r); ctx != nil {
+		ctx.ClientID = clientID
+	}
+}
+
+// GetClientID returns the name of the client that the auth middleware authenticated the request as.
+// It is public so that handlers can restrict access to resources created by another client.
+func GetClientID(r *http.Request) string {
+	if ctx := getRequestContext(r); ctx != nil {
+		return ctx.ClientID
+	}
+	return ""
+}
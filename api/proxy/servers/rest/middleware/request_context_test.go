@@ -33,6 +33,7 @@ func TestRequestContext_CertVersionCanBeReadFromMetricsMiddleware(t *testing.T)
 		testLogger,
 		mockMetrics,
 		commitments.OptimismGenericCommitmentMode,
+		nil,
 	)
 
 	req := httptest.NewRequest(http.MethodGet, "/test", nil)
@@ -61,6 +62,7 @@ func (m *MockMetricer) RecordRPCServerRequest(method string) func(status string,
 		m.recordDurCertVersion = ver // Capture the cert version
 	}
 }
+func (m *MockMetricer) RecordClientRequest(client string, status string) {}
 func (m *MockMetricer) RecordSecondaryRequest(bt string, method string) func(status string) {
 	return func(status string) {}
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/commitments"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/peer"
 	"github.com/gorilla/mux"
 )
 
@@ -18,6 +19,7 @@ const (
 	routingVarNamePayloadHex          = "payload_hex"
 	routingVarNameVersionByteHex      = "version_byte_hex"
 	routingVarNameCommitTypeByteHex   = "commit_type_byte_hex"
+	routingVarNameJobID               = "job_id"
 )
 
 func (svr *Server) RegisterRoutes(r *mux.Router) {
@@ -27,7 +29,7 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 		"{optional_prefix:(?:0x)?}"+ // commitments can be prefixed with 0x
 		"{"+routingVarNameVersionByteHex+":[0-9a-fA-F]{2}}"+ // should always be 0x00 for now but we let others through to return a 404
 		"{"+routingVarNamePayloadHex+":[0-9a-fA-F]*}",
-		middleware.WithCertMiddlewares(svr.handleGetStdCommitment, svr.log, svr.m, commitments.StandardCommitmentMode),
+		middleware.WithCertMiddlewares(svr.handleGetStdCommitment, svr.log, svr.m, commitments.StandardCommitmentMode, svr.auth),
 	).Queries("commitment_mode", "standard")
 	// op keccak256 commitments (write to S3)
 	subrouterGET.HandleFunc(
@@ -40,6 +42,7 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 			svr.log,
 			svr.m,
 			commitments.OptimismKeccakCommitmentMode,
+			svr.auth,
 		),
 	)
 	// op generic commitments (write to EigenDA)
@@ -55,6 +58,7 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 			svr.log,
 			svr.m,
 			commitments.OptimismGenericCommitmentMode,
+			svr.auth,
 		),
 	)
 	// unrecognized op commitment type (not 00 or 01)
@@ -72,10 +76,45 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 		},
 	).MatcherFunc(notCommitmentModeStandard)
 
+	// batched GETs are POSTs because the commitments don't fit in the URL.
+	// Only std and op generic commitments are supported.
+	r.HandleFunc("/get/batch",
+		middleware.WithCertMiddlewares(svr.handleGetStdCommitmentBatch, svr.log, svr.m, commitments.StandardCommitmentMode, svr.auth),
+	).Methods("POST").Queries("commitment_mode", "standard")
+	r.HandleFunc("/get/batch",
+		middleware.WithCertMiddlewares(
+			svr.handleGetOPGenericCommitmentBatch,
+			svr.log,
+			svr.m,
+			commitments.OptimismGenericCommitmentMode,
+			svr.auth,
+		),
+	).Methods("POST")
+
+	// async dispersal job status. Status requests aren't tied to a commitment mode,
+	// the mode of the job is returned in the response body instead.
+	r.HandleFunc("/put/status/{"+routingVarNameJobID+":[0-9a-fA-F-]{36}}",
+		middleware.WithCertMiddlewares(svr.handleGetDispersalJobStatus, svr.log, svr.m, "", svr.auth),
+	).Methods("GET")
+
 	subrouterPOST := r.Methods("POST").PathPrefix("/put").Subrouter()
+	// batched std commitments (for nitro)
+	subrouterPOST.HandleFunc("/batch",
+		middleware.WithCertMiddlewares(svr.handlePostStdCommitmentBatch, svr.log, svr.m, commitments.StandardCommitmentMode, svr.auth),
+	).Queries("commitment_mode", "standard")
+	// batched op generic commitments (write to EigenDA)
+	subrouterPOST.HandleFunc("/batch",
+		middleware.WithCertMiddlewares(
+			svr.handlePostOPGenericCommitmentBatch,
+			svr.log,
+			svr.m,
+			commitments.OptimismGenericCommitmentMode,
+			svr.auth,
+		),
+	)
 	// std commitments (for nitro)
 	subrouterPOST.HandleFunc("", // commitment is calculated by the server using the body data
-		middleware.WithCertMiddlewares(svr.handlePostStdCommitment, svr.log, svr.m, commitments.StandardCommitmentMode),
+		middleware.WithCertMiddlewares(svr.handlePostStdCommitment, svr.log, svr.m, commitments.StandardCommitmentMode, svr.auth),
 	).Queries("commitment_mode", "standard")
 	// op keccak256 commitments (write to S3)
 	subrouterPOST.HandleFunc(
@@ -88,6 +127,7 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 			svr.log,
 			svr.m,
 			commitments.OptimismKeccakCommitmentMode,
+			svr.auth,
 		),
 	)
 	// op generic commitments (write to EigenDA)
@@ -98,6 +138,7 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 			svr.log,
 			svr.m,
 			commitments.OptimismGenericCommitmentMode,
+			svr.auth,
 		),
 	)
 	subrouterPOST.HandleFunc(
@@ -107,6 +148,7 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 			svr.log,
 			svr.m,
 			commitments.OptimismGenericCommitmentMode,
+			svr.auth,
 		),
 	)
 
@@ -117,16 +159,34 @@ func (svr *Server) RegisterRoutes(r *mux.Router) {
 	// this is done to explicitly log capture potential redirect errors
 	r.HandleFunc("/put", svr.logDispersalGetError).Methods("GET")
 
-	// Only register admin endpoints if explicitly enabled in configuration
-	//
-	// Note: A common pattern for admin endpoints is to generate a random API key on startup for authentication.
-	// Since the proxy isn't meant to be exposed publicly, we haven't implemented this here, but it's something
-	// that might be done in the future.
+	// Only register admin endpoints if explicitly enabled in configuration.
+	// Admin endpoints go through the same middlewares as the cert routes, so they require authentication whenever it
+	// is configured.
 	if svr.config.APIsEnabled.Admin {
 		svr.log.Warn("Admin API endpoints are enabled")
+		admin := func(handler http.HandlerFunc) http.HandlerFunc {
+			return middleware.WithAdminMiddlewares(handler, svr.log, svr.m, svr.auth)
+		}
 		// Admin endpoints to check and set EigenDA backend used for dispersal
-		r.HandleFunc("/admin/eigenda-dispersal-backend", svr.handleGetEigenDADispersalBackend).Methods("GET")
-		r.HandleFunc("/admin/eigenda-dispersal-backend", svr.handleSetEigenDADispersalBackend).Methods("PUT")
+		r.HandleFunc("/admin/eigenda-dispersal-backend", admin(svr.handleGetEigenDADispersalBackend)).Methods("GET")
+		r.HandleFunc("/admin/eigenda-dispersal-backend", admin(svr.handleSetEigenDADispersalBackend)).Methods("PUT")
+		// Admin endpoints to inspect and repair the secondary storage entries of a commitment.
+		// Commitments are decoded according to the commitment_mode query param, like on the GET routes.
+		r.HandleFunc("/admin/cache/warmup", admin(svr.handleWarmCacheEntries)).Methods("POST")
+		adminCacheEntryRoute := "/admin/cache/" +
+			"{optional_prefix:(?:0x)?}" + // commitments can be prefixed with 0x
+			"{" + routingVarNameCommitmentHex + ":[0-9a-fA-F]+}"
+		r.HandleFunc(adminCacheEntryRoute, admin(svr.handleGetCacheEntry)).Methods("GET")
+		r.HandleFunc(adminCacheEntryRoute, admin(svr.handleDeleteCacheEntry)).Methods("DELETE")
+		r.HandleFunc(adminCacheEntryRoute+"/refetch", admin(svr.handleRefetchCacheEntry)).Methods("POST")
+	}
+
+	// Only serve the caches to peer proxies if a shared secret is configured to authenticate them
+	if svr.config.PeeringSharedSecret != "" {
+		r.HandleFunc(
+			peer.CacheRoutePrefix+"{"+routingVarNamePeeringCacheKeyHex+":[0-9a-fA-F]{64}}",
+			svr.handleGetPeeringCacheEntry,
+		).Methods("GET")
 	}
 
 	// proxy compatibility config endpoint
@@ -141,7 +201,7 @@ func notCommitmentModeStandard(r *http.Request, _ *mux.RouteMatch) bool {
 // ================== QUERY PARAMS PARSING FUNCTION ==================================================
 // These query params don't affect routing, but we keep them here so that everything related to query URLs is in one place,
 // and its easy to deduce what kind of queries are supported by the proxy server by just looking at this file.
-// The below 2 functions are used in both standard and optimism routes (see handlers_cert.go).
+// The below functions are used in both standard and optimism routes (see handlers_cert.go).
 
 // Parses the l1_inclusion_block_number query param from the request.
 // Happy path:
@@ -162,16 +222,28 @@ func parseCommitmentInclusionL1BlockNumQueryParam(r *http.Request) (uint64, erro
 	return 0, nil
 }
 
+// Parses the async query parameter from the request (use the first value if multiple are provided).
+// Returns true for: ?async, ?async=true, ?async=1
+// Anything else returns false, including if the parameter is not present.
+func parseAsyncQueryParam(r *http.Request) bool {
+	return parseBoolQueryParam(r, "async")
+}
+
 // Parses the return_encoded_payload query parameter from the request (use the first value if multiple are provided).
 // Returns true for: ?return_encoded_payload, ?return_encoded_payload=true, ?return_encoded_payload=1
 // Anything else returns false, including if the parameter is not present.
 func parseReturnEncodedPayloadQueryParam(r *http.Request) bool {
-	returnEncodedPayloadValues, exists := r.URL.Query()["return_encoded_payload"]
-	if !exists || len(returnEncodedPayloadValues) == 0 {
+	return parseBoolQueryParam(r, "return_encoded_payload")
+}
+
+// parseBoolQueryParam implements the boolean query param parsing shared by the functions above.
+func parseBoolQueryParam(r *http.Request, name string) bool {
+	values, exists := r.URL.Query()[name]
+	if !exists || len(values) == 0 {
 		return false
 	}
-	returnEncodedPayload := strings.ToLower(returnEncodedPayloadValues[0])
-	if returnEncodedPayload == "" || returnEncodedPayload == "true" || returnEncodedPayload == "1" {
+	value := strings.ToLower(values[0])
+	if value == "" || value == "true" || value == "1" {
 		return true
 	}
 	return false
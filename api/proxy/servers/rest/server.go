@@ -13,6 +13,8 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda/api/proxy/config/enablement"
 	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/dispersaljobs"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gorilla/mux"
@@ -24,23 +26,49 @@ type Config struct {
 	Port             int
 	APIsEnabled      *enablement.RestApisEnabled
 	CompatibilityCfg common.CompatibilityConfig
+	// Auth configures authentication and per-client quotas on the cert routes and the gRPC server. Disabled by default.
+	Auth middleware.AuthConfig
+	// AsyncDispersal configures the asynchronous dispersal routes. Disabled by default.
+	AsyncDispersal dispersaljobs.Config
+	// PeeringSharedSecret enables the route serving the cache entries of this proxy to peer proxies,
+	// which must authenticate with this secret. Disabled if empty.
+	PeeringSharedSecret string
+}
+
+// Check checks that the server config is valid.
+func (c Config) Check() error {
+	if err := c.Auth.Check(); err != nil {
+		return fmt.Errorf("check auth config: %w", err)
+	}
+	if err := c.AsyncDispersal.Check(); err != nil {
+		return fmt.Errorf("check async dispersal config: %w", err)
+	}
+	return nil
 }
 
 type Server struct {
-	log        logging.Logger
-	endpoint   string
-	certMgr    store.IEigenDAManager
-	keccakMgr  store.IKeccakManager
+	log       logging.Logger
+	endpoint  string
+	certMgr   store.IEigenDAManager
+	keccakMgr store.IKeccakManager
+	// nil if asynchronous dispersal is disabled
+	jobMgr     *dispersaljobs.Manager
 	m          metrics.Metricer
 	httpServer *http.Server
 	listener   net.Listener
 	config     Config
+	// nil if authentication is disabled
+	auth *middleware.Authenticator
 }
 
 func NewServer(
 	cfg Config,
 	certMgr store.IEigenDAManager,
 	keccakMgr store.IKeccakManager,
+	// nil if asynchronous dispersal is disabled
+	jobMgr *dispersaljobs.Manager,
+	// nil if authentication is disabled
+	auth *middleware.Authenticator,
 	log logging.Logger,
 	m metrics.Metricer,
 ) *Server {
@@ -51,7 +79,9 @@ func NewServer(
 		endpoint:  endpoint,
 		certMgr:   certMgr,
 		keccakMgr: keccakMgr,
+		jobMgr:    jobMgr,
 		config:    cfg,
+		auth:      auth,
 		httpServer: &http.Server{
 			Addr:              endpoint,
 			ReadHeaderTimeout: 10 * time.Second,
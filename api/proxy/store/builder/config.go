@@ -11,6 +11,9 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/store"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/filesystem"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/peer"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	"github.com/urfave/cli/v2"
 )
@@ -25,9 +28,17 @@ type Config struct {
 
 	MemstoreConfig  *memconfig.SafeConfig
 	MemstoreEnabled bool
+	// MemstorePersistencePath is the directory where memstore persists its blobs.
+	// If empty, blobs are only kept in memory and are lost on restart.
+	MemstorePersistencePath string
 
 	// secondary storage cfgs
-	S3Config s3.Config
+	S3Config         s3.Config
+	LittDBConfig     littdb.Config
+	FilesystemConfig filesystem.Config
+
+	// PeeringConfig configures reads from the caches of peer proxies
+	PeeringConfig peer.Config
 
 	// eth rpc retry count and delay
 	RetryCount int
@@ -73,14 +84,19 @@ func ReadConfig(ctx *cli.Context) (Config, error) {
 	}
 
 	cfg := Config{
-		StoreConfig:     storeConfig,
-		ClientConfigV2:  clientConfigV2,
-		MemstoreConfig:  memstoreConfig,
-		MemstoreEnabled: ctx.Bool(memstore.EnabledFlagName),
-		S3Config:        s3.ReadConfig(ctx),
-		RetryCount:      ctx.Int(eigendaflags_v2.EthRPCRetryCountFlagName),
-		RetryDelay:      ctx.Duration(eigendaflags_v2.EthRPCRetryDelayIncrementFlagName),
-		PutRetryDelay:   ctx.Duration(eigendaflags_v2.PutRetryDelayIncrementFlagName),
+		StoreConfig:             storeConfig,
+		ClientConfigV2:          clientConfigV2,
+		MemstoreConfig:          memstoreConfig,
+		MemstoreEnabled:         ctx.Bool(memstore.EnabledFlagName),
+		MemstorePersistencePath: ctx.String(memstore.PersistencePathFlagName),
+		S3Config:                s3.ReadConfig(ctx),
+		LittDBConfig:            littdb.ReadConfig(ctx),
+		FilesystemConfig:        filesystem.ReadConfig(ctx),
+		RetryCount:              ctx.Int(eigendaflags_v2.EthRPCRetryCountFlagName),
+		RetryDelay:              ctx.Duration(eigendaflags_v2.EthRPCRetryDelayIncrementFlagName),
+		PutRetryDelay:           ctx.Duration(eigendaflags_v2.PutRetryDelayIncrementFlagName),
+		// #nosec G115 - max blob size is at most 16MiB
+		PeeringConfig: peer.ReadConfig(ctx, int64(maxBlobSizeBytes)),
 	}
 
 	return cfg, nil
@@ -110,6 +126,28 @@ func (cfg *Config) Check() error {
 		}
 	}
 
+	secondaryTargets := append(slices.Clone(cfg.StoreConfig.CacheTargets), cfg.StoreConfig.FallbackTargets...)
+	for _, target := range secondaryTargets {
+		//nolint:exhaustive // only backends with required configuration are checked
+		switch common.StringToBackendType(target) {
+		case common.LittDBBackendType:
+			if len(cfg.LittDBConfig.Paths) == 0 {
+				return fmt.Errorf("littdb is used as a secondary target, but no littdb paths are set")
+			}
+		case common.FilesystemBackendType:
+			if cfg.FilesystemConfig.Path == "" {
+				return fmt.Errorf("filesystem is used as a secondary target, but no filesystem path is set")
+			}
+			if cfg.FilesystemConfig.TTL > 0 && cfg.FilesystemConfig.EvictionInterval <= 0 {
+				return fmt.Errorf("filesystem eviction interval must be positive when a TTL is set")
+			}
+		}
+	}
+
+	if err := cfg.PeeringConfig.Check(); err != nil {
+		return fmt.Errorf("check peering config: %w", err)
+	}
+
 	return cfg.StoreConfig.Check()
 }
 
@@ -125,6 +163,9 @@ func (cfg *Config) ToString() (string, error) {
 	if configCopy.S3Config.AccessKeyID != "" {
 		configCopy.S3Config.AccessKeyID = redacted
 	}
+	if configCopy.PeeringConfig.SharedSecret != "" {
+		configCopy.PeeringConfig.SharedSecret = redacted
+	}
 
 	configJSON, err := json.MarshalIndent(configCopy, "", "  ")
 	if err != nil {
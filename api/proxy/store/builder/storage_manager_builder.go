@@ -5,7 +5,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/big"
 	"math/rand"
 	"regexp"
 	"slices"
@@ -25,9 +24,11 @@ import (
 	memstore_v2 "github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/v2"
 	eigenda_v2 "github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/v2"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/filesystem"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/littdb"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/peer"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary/s3"
 	common_eigenda "github.com/Layr-Labs/eigenda/common"
-	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDACertVerifierRouter"
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -35,11 +36,8 @@ import (
 	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/eth/directory"
-	"github.com/Layr-Labs/eigenda/core/payments"
-	"github.com/Layr-Labs/eigenda/core/payments/clientledger"
-	"github.com/Layr-Labs/eigenda/core/payments/ondemand"
-	"github.com/Layr-Labs/eigenda/core/payments/reservation"
-	"github.com/Layr-Labs/eigenda/core/payments/vault"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/codec"
 	"github.com/Layr-Labs/eigenda/encoding/v2/kzg/committer"
 	kzgverifierv2 "github.com/Layr-Labs/eigenda/encoding/v2/kzg/verifier"
 	rsv2 "github.com/Layr-Labs/eigenda/encoding/v2/rs"
@@ -62,6 +60,8 @@ func BuildManagers(
 ) (*store.EigenDAManager, *store.KeccakManager, error) {
 	var err error
 	var s3Store *s3.Store
+	var littDBStore *littdb.Store
+	var filesystemStore *filesystem.Store
 	var eigenDAV2Store common.EigenDAV2Store
 
 	if config.S3Config.Bucket != "" {
@@ -72,6 +72,23 @@ func BuildManagers(
 		}
 	}
 
+	if len(config.LittDBConfig.Paths) > 0 {
+		log.Info("Using LittDB storage backend", "paths", config.LittDBConfig.Paths, "ttl", config.LittDBConfig.TTL)
+		littDBStore, err = littdb.NewStore(ctx, log, config.LittDBConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("new LittDB store: %w", err)
+		}
+	}
+
+	if config.FilesystemConfig.Path != "" {
+		log.Info("Using filesystem storage backend",
+			"path", config.FilesystemConfig.Path, "ttl", config.FilesystemConfig.TTL)
+		filesystemStore, err = filesystem.NewStore(ctx, log, config.FilesystemConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("new filesystem store: %w", err)
+		}
+	}
+
 	v1Enabled := slices.Contains(config.StoreConfig.BackendsToEnable, common.V1EigenDABackend)
 	v2Enabled := slices.Contains(config.StoreConfig.BackendsToEnable, common.V2EigenDABackend)
 
@@ -103,13 +120,18 @@ func BuildManagers(
 		}
 	}
 
-	fallbacks := buildSecondaries(config.StoreConfig.FallbackTargets, s3Store)
-	caches := buildSecondaries(config.StoreConfig.CacheTargets, s3Store)
+	fallbacks := buildSecondaries(config.StoreConfig.FallbackTargets, s3Store, littDBStore, filesystemStore)
+	caches := buildSecondaries(config.StoreConfig.CacheTargets, s3Store, littDBStore, filesystemStore)
+	peers, err := buildPeers(config.PeeringConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build peers: %w", err)
+	}
 	secondary := secondary.NewSecondaryManager(
 		log,
 		metrics,
 		caches,
 		fallbacks,
+		peers,
 		config.StoreConfig.WriteOnCacheMiss,
 		config.StoreConfig.ErrorOnSecondaryInsertFailure,
 	)
@@ -126,17 +148,26 @@ func BuildManagers(
 		"Created storage backends",
 		"eigenda_v2", eigenDAV2Store != nil,
 		"s3", s3Store != nil,
+		"littdb", littDBStore != nil,
+		"filesystem", filesystemStore != nil,
 		"read_fallback", len(fallbacks) > 0,
 		"caching", len(caches) > 0,
+		"peers", len(peers),
 		"async_secondary_writes", (secondary.Enabled() && config.StoreConfig.AsyncPutWorkers > 0),
 		"error_on_secondary_insert_failure", config.StoreConfig.ErrorOnSecondaryInsertFailure,
 	)
 
+	chunking, err := buildPayloadChunkingConfig(config.ClientConfigV2)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build payload chunking config: %w", err)
+	}
+
 	certMgr, err := store.NewEigenDAManager(
 		eigenDAV2Store,
 		log,
 		secondary,
 		config.StoreConfig.DispersalBackend,
+		chunking,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("new eigenda manager: %w", err)
@@ -154,7 +185,9 @@ func BuildManagers(
 // failover or caching
 func buildSecondaries(
 	targets []string,
-	s3Store common.SecondaryStore,
+	s3Store *s3.Store,
+	littDBStore *littdb.Store,
+	filesystemStore *filesystem.Store,
 ) []common.SecondaryStore {
 	stores := make([]common.SecondaryStore, len(targets))
 
@@ -167,6 +200,18 @@ func buildSecondaries(
 			}
 			stores[i] = s3Store
 
+		case common.LittDBBackendType:
+			if littDBStore == nil {
+				panic(fmt.Sprintf("LittDB backend not configured: %s", target))
+			}
+			stores[i] = littDBStore
+
+		case common.FilesystemBackendType:
+			if filesystemStore == nil {
+				panic(fmt.Sprintf("Filesystem backend not configured: %s", target))
+			}
+			stores[i] = filesystemStore
+
 		default:
 			panic(fmt.Sprintf("Invalid backend target: %s", target))
 		}
@@ -174,6 +219,20 @@ func buildSecondaries(
 	return stores
 }
 
+// buildPeers ... Creates a read-only secondary store for each peer proxy
+func buildPeers(cfg peer.Config) ([]common.SecondaryStore, error) {
+	peerStores, err := peer.NewStores(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("new peer stores: %w", err)
+	}
+
+	stores := make([]common.SecondaryStore, 0, len(peerStores))
+	for _, peerStore := range peerStores {
+		stores = append(stores, peerStore)
+	}
+	return stores, nil
+}
+
 // A regexp matching "execution reverted" errors returned from the parent chain RPC.
 var executionRevertedRegexp = regexp.MustCompile(`(?i)execution reverted|VM execution error\.?`)
 
@@ -209,7 +268,14 @@ func buildEigenDAV2Backend(
 	}
 
 	if config.MemstoreEnabled {
-		return memstore_v2.New(ctx, log, config.MemstoreConfig, kzgVerifier.G1SRS), nil
+		payloadEncodingVersion := config.ClientConfigV2.PayloadDisperserCfg.PayloadEncodingVersion
+		if config.MemstorePersistencePath != "" {
+			log.Info("Using persistent memstore", "path", config.MemstorePersistencePath)
+			return memstore_v2.NewPersistent(
+				ctx, log, config.MemstoreConfig, kzgVerifier.G1SRS, payloadEncodingVersion,
+				config.MemstorePersistencePath)
+		}
+		return memstore_v2.New(ctx, log, config.MemstoreConfig, kzgVerifier.G1SRS, payloadEncodingVersion), nil
 	}
 
 	routerOrImmutableVerifierAddr := geth_common.HexToAddress(config.ClientConfigV2.EigenDACertVerifierOrRouterAddress)
@@ -312,8 +378,14 @@ func buildEigenDAV2Backend(
 			if err != nil {
 				return nil, fmt.Errorf("get relay registry address: %w", err)
 			}
-			relayPayloadRetriever, err := buildRelayPayloadRetriever(
-				log, config.ClientConfigV2, ethClient, kzgVerifier.G1SRS, relayRegistryAddr, retrievalMetrics)
+			relayPayloadRetriever, err := payloadretrieval.BuildRelayPayloadRetriever(
+				log,
+				config.ClientConfigV2.RelayPayloadRetrieverCfg,
+				buildRelayClientConfig(config.ClientConfigV2),
+				ethClient,
+				relayRegistryAddr,
+				kzgVerifier.G1SRS,
+				retrievalMetrics)
 			if err != nil {
 				return nil, fmt.Errorf("build relay payload retriever: %w", err)
 			}
@@ -338,6 +410,13 @@ func buildEigenDAV2Backend(
 		return nil, fmt.Errorf("no payload retrievers enabled, please enable at least one retriever type")
 	}
 
+	if config.ClientConfigV2.HedgedRetrievalEnabled {
+		retrievers, err = buildHedgedPayloadRetriever(log, config.ClientConfigV2, retrievers, retrievalMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("build hedged payload retriever: %w", err)
+		}
+	}
+
 	var payloadDisperser *dispersal.PayloadDisperser
 
 	if secrets.SignerPaymentKey == "" {
@@ -368,6 +447,9 @@ func buildEigenDAV2Backend(
 		config.ClientConfigV2.PutTries,
 		config.PutRetryDelay,
 		certVerifier,
+		srs.GetG1SRS(),
+		config.ClientConfigV2.RelayPayloadRetrieverCfg.PayloadPolynomialForm,
+		config.ClientConfigV2.RelayPayloadRetrieverCfg.PayloadEncodingVersion,
 		retrievers,
 		// PayloadDisperserCfg.ContractCallTimeout is set by the --eigenda.v2.contract-call-timeout flag, the value
 		// is not read into any other configs. For simplicity the PayloadDisperserCfg value is reused here.
@@ -380,57 +462,48 @@ func buildEigenDAV2Backend(
 	return eigenDAV2Store, nil
 }
 
-func buildRelayPayloadRetriever(
+// buildHedgedPayloadRetriever combines the relay and validator retrievers into a single PayloadRetriever, which
+// hedges relay retrievals with validator retrievals.
+func buildHedgedPayloadRetriever(
 	log logging.Logger,
 	clientConfigV2 common.ClientConfigV2,
-	ethClient common_eigenda.EthClient,
-	g1Srs []bn254.G1Affine,
-	relayRegistryAddr geth_common.Address,
+	retrievers []clients_v2.PayloadRetriever,
 	metrics metrics_v2.RetrievalMetricer,
-) (*payloadretrieval.RelayPayloadRetriever, error) {
-	relayClient, err := buildRelayClient(log, clientConfigV2, ethClient, relayRegistryAddr)
-	if err != nil {
-		return nil, fmt.Errorf("build relay client: %w", err)
+) ([]clients_v2.PayloadRetriever, error) {
+	var relayRetriever, validatorRetriever clients_v2.PayloadRetriever
+	for _, retriever := range retrievers {
+		switch retriever.(type) {
+		case *payloadretrieval.RelayPayloadRetriever:
+			relayRetriever = retriever
+		case *payloadretrieval.ValidatorPayloadRetriever:
+			validatorRetriever = retriever
+		default:
+			return nil, fmt.Errorf("unexpected retriever type %T", retriever)
+		}
+	}
+	if relayRetriever == nil || validatorRetriever == nil {
+		return nil, fmt.Errorf("hedged retrieval requires both the relay and validator retrievers to be enabled")
 	}
 
-	relayPayloadRetriever, err := payloadretrieval.NewRelayPayloadRetriever(
-		log,
-		clientConfigV2.RelayPayloadRetrieverCfg,
-		relayClient,
-		g1Srs,
-		metrics)
+	log.Info("Hedging relay retrievals with validator retrievals",
+		"hedgeDelay", clientConfigV2.PayloadRetrieverCfg.HedgeDelay)
+	hedgedRetriever, err := payloadretrieval.NewPayloadRetriever(
+		log, clientConfigV2.PayloadRetrieverCfg, relayRetriever, validatorRetriever, metrics)
 	if err != nil {
-		return nil, fmt.Errorf("new relay payload retriever: %w", err)
+		return nil, fmt.Errorf("new payload retriever: %w", err)
 	}
 
-	return relayPayloadRetriever, nil
+	return []clients_v2.PayloadRetriever{hedgedRetriever}, nil
 }
 
-func buildRelayClient(
-	log logging.Logger,
-	clientConfigV2 common.ClientConfigV2,
-	ethClient common_eigenda.EthClient,
-	relayRegistryAddress geth_common.Address,
-) (relay.RelayClient, error) {
-	relayURLProvider, err := relay.NewRelayUrlProvider(ethClient, relayRegistryAddress)
-	if err != nil {
-		return nil, fmt.Errorf("new relay url provider: %w", err)
-	}
-
-	relayCfg := &relay.RelayClientConfig{
+func buildRelayClientConfig(clientConfigV2 common.ClientConfigV2) *relay.RelayClientConfig {
+	return &relay.RelayClientConfig{
 		UseSecureGrpcFlag: clientConfigV2.DisperserClientCfg.UseSecureGrpcFlag,
 		// we should never expect a message greater than our allowed max blob size.
 		// 10% of max blob size is added for additional safety
 		MaxGRPCMessageSize: uint(clientConfigV2.MaxBlobSizeBytes + (clientConfigV2.MaxBlobSizeBytes / 10)),
 		ConnectionPoolSize: clientConfigV2.RelayConnectionPoolSize,
 	}
-
-	relayClient, err := relay.NewRelayClient(relayCfg, log, relayURLProvider)
-	if err != nil {
-		return nil, fmt.Errorf("new relay client: %w", err)
-	}
-
-	return relayClient, nil
 }
 
 // buildValidatorPayloadRetriever constructs a ValidatorPayloadRetriever for retrieving
@@ -536,13 +609,14 @@ func buildPayloadDisperser(
 		return nil, fmt.Errorf("create disperser client multiplexer: %w", err)
 	}
 
-	clientLedger, err := buildClientLedger(
+	clientLedger, err := dispersal.BuildClientLedger(
 		ctx,
 		log,
-		clientConfigV2,
 		ethClient,
-		accountId,
 		contractDirectory,
+		accountId,
+		clientConfigV2.ClientLedgerMode,
+		clientConfigV2.VaultMonitorInterval,
 		accountantMetrics,
 		disperserClientMultiplexer,
 	)
@@ -582,180 +656,22 @@ func buildPayloadDisperser(
 	return payloadDisperser, nil
 }
 
-// buildReservationLedger creates a reservation ledger for a given account
-func buildReservationLedger(
-	ctx context.Context,
-	paymentVault payments.PaymentVault,
-	accountID geth_common.Address,
-	minNumSymbols uint32,
-) (*reservation.ReservationLedger, error) {
-	reservationData, err := paymentVault.GetReservation(ctx, accountID)
-	if err != nil {
-		return nil, fmt.Errorf("get reservation: %w", err)
-	}
-	if reservationData == nil {
-		return nil, fmt.Errorf("no reservation found for account %s", accountID.Hex())
-	}
-
-	clientReservation, err := reservation.NewReservation(
-		reservationData.SymbolsPerSecond,
-		time.Unix(int64(reservationData.StartTimestamp), 0),
-		time.Unix(int64(reservationData.EndTimestamp), 0),
-		reservationData.QuorumNumbers,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("new reservation: %w", err)
-	}
-
-	reservationConfig, err := reservation.NewReservationLedgerConfig(
-		*clientReservation,
-		minNumSymbols,
-		// start full since reservation usage isn't persisted: assume the worst case (heavy usage before startup)
-		true,
-		// this is a parameter for flexibility, but there aren't plans to operate with anything other than this value
-		ratelimit.OverfillOncePermitted,
-		// TODO(litt3): once the checkpointed onchain config registry is ready, that should be used
-		// instead of hardcoding. At that point, this field will be removed from the config struct
-		// entirely, and the value will be fetched dynamically at runtime.
-		60*time.Second,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("new reservation ledger config: %w", err)
+// buildPayloadChunkingConfig derives the chunk size from the max blob size, such that each chunk of an oversized
+// payload fits in a single blob. No headroom is needed for compressed payloads, since compression falls back to the
+// uncompressed encoding whenever it would make a chunk larger (see coretypes.Payload.ToEncodedPayloadWithVersion).
+func buildPayloadChunkingConfig(clientConfigV2 common.ClientConfigV2) (store.PayloadChunkingConfig, error) {
+	if clientConfigV2.MaxPayloadChunks <= 1 {
+		return store.PayloadChunkingConfig{}, nil
 	}
 
-	reservationLedger, err := reservation.NewReservationLedger(*reservationConfig, time.Now)
+	maxChunkSize, err := codec.BlobSymbolsToMaxPayloadSize(
+		uint32(clientConfigV2.MaxBlobSizeBytes / encoding.BYTES_PER_SYMBOL))
 	if err != nil {
-		return nil, fmt.Errorf("new reservation ledger: %w", err)
+		return store.PayloadChunkingConfig{}, fmt.Errorf("calculate max payload size: %w", err)
 	}
 
-	return reservationLedger, nil
-}
-
-// buildOnDemandLedger creates an on-demand ledger for a given account
-func buildOnDemandLedger(
-	ctx context.Context,
-	paymentVault payments.PaymentVault,
-	accountID geth_common.Address,
-	minNumSymbols uint32,
-	cumulativePayment *big.Int,
-) (*ondemand.OnDemandLedger, error) {
-	pricePerSymbol, err := paymentVault.GetPricePerSymbol(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("get price per symbol: %w", err)
-	}
-
-	totalDeposits, err := paymentVault.GetTotalDeposit(ctx, accountID)
-	if err != nil {
-		return nil, fmt.Errorf("get total deposit from vault: %w", err)
-	}
-
-	onDemandLedger, err := ondemand.OnDemandLedgerFromValue(
-		totalDeposits,
-		new(big.Int).SetUint64(pricePerSymbol),
-		minNumSymbols,
-		cumulativePayment,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("new on-demand ledger: %w", err)
-	}
-
-	return onDemandLedger, nil
-}
-
-func getCumulativePayment(
-	ctx context.Context,
-	disperserClientMultiplexer *dispersal.DisperserClientMultiplexer,
-) (*big.Int, error) {
-	disperserClient, err := disperserClientMultiplexer.GetDisperserClient(ctx, time.Now(), true)
-	if err != nil {
-		return nil, fmt.Errorf("get disperser client: %w", err)
-	}
-
-	paymentState, err := disperserClient.GetPaymentState(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("get payment state: %w", err)
-	}
-
-	if paymentState.GetCumulativePayment() == nil {
-		return big.NewInt(0), nil
-	}
-	return new(big.Int).SetBytes(paymentState.GetCumulativePayment()), nil
-}
-
-// buildClientLedger creates a ClientLedger for managing payment state
-func buildClientLedger(
-	ctx context.Context,
-	log logging.Logger,
-	config common.ClientConfigV2,
-	ethClient common_eigenda.EthClient,
-	accountID geth_common.Address,
-	contractDirectory *directory.ContractDirectory,
-	accountantMetrics metrics_v2.AccountantMetricer,
-	disperserClientMultiplexer *dispersal.DisperserClientMultiplexer,
-) (*clientledger.ClientLedger, error) {
-	paymentVaultAddr, err := contractDirectory.GetContractAddress(ctx, directory.PaymentVault)
-	if err != nil {
-		return nil, fmt.Errorf("get PaymentVault address: %w", err)
-	}
-
-	paymentVault, err := vault.NewPaymentVault(log, ethClient, paymentVaultAddr)
-	if err != nil {
-		return nil, fmt.Errorf("new payment vault: %w", err)
-	}
-
-	minNumSymbols, err := paymentVault.GetMinNumSymbols(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("get min num symbols: %w", err)
-	}
-
-	var reservationLedger *reservation.ReservationLedger
-	var onDemandLedger *ondemand.OnDemandLedger
-	switch config.ClientLedgerMode {
-	case clientledger.ClientLedgerModeReservationOnly:
-		reservationLedger, err = buildReservationLedger(ctx, paymentVault, accountID, minNumSymbols)
-		if err != nil {
-			return nil, fmt.Errorf("build reservation ledger: %w", err)
-		}
-	case clientledger.ClientLedgerModeOnDemandOnly:
-		cumulativePayment, err := getCumulativePayment(ctx, disperserClientMultiplexer)
-		if err != nil {
-			return nil, fmt.Errorf("get cumulative payment: %w", err)
-		}
-		onDemandLedger, err = buildOnDemandLedger(ctx, paymentVault, accountID, minNumSymbols, cumulativePayment)
-		if err != nil {
-			return nil, fmt.Errorf("build on-demand ledger: %w", err)
-		}
-
-	case clientledger.ClientLedgerModeReservationAndOnDemand:
-		reservationLedger, err = buildReservationLedger(ctx, paymentVault, accountID, minNumSymbols)
-		if err != nil {
-			return nil, fmt.Errorf("build reservation ledger: %w", err)
-		}
-		cumulativePayment, err := getCumulativePayment(ctx, disperserClientMultiplexer)
-		if err != nil {
-			return nil, fmt.Errorf("get cumulative payment: %w", err)
-		}
-		onDemandLedger, err = buildOnDemandLedger(ctx, paymentVault, accountID, minNumSymbols, cumulativePayment)
-		if err != nil {
-			return nil, fmt.Errorf("build on-demand ledger: %w", err)
-		}
-
-	default:
-		return nil, fmt.Errorf("unexpected client ledger mode: %s", config.ClientLedgerMode)
-	}
-
-	ledger := clientledger.NewClientLedger(
-		ctx,
-		log,
-		accountantMetrics,
-		accountID,
-		config.ClientLedgerMode,
-		reservationLedger,
-		onDemandLedger,
-		time.Now,
-		paymentVault,
-		config.VaultMonitorInterval,
-	)
-
-	return ledger, nil
+	return store.PayloadChunkingConfig{
+		MaxChunkSizeBytes: maxChunkSize,
+		MaxChunks:         clientConfigV2.MaxPayloadChunks,
+	}, nil
 }
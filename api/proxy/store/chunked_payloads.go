@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxConcurrentChunkOps bounds the number of chunks of a single payload that are dispersed or retrieved at once.
+const maxConcurrentChunkOps = 8
+
+// PayloadChunkingConfig configures how payloads too large for a single blob are split across several blobs.
+type PayloadChunkingConfig struct {
+	// Largest payload that fits in a single blob.
+	MaxChunkSizeBytes uint32
+	// Maximum number of blobs a payload can be split into. Values <= 1 disable chunking.
+	MaxChunks int
+}
+
+// Enabled returns true if oversized payloads should be split across several blobs.
+func (c PayloadChunkingConfig) Enabled() bool {
+	return c.MaxChunks > 1 && c.MaxChunkSizeBytes > 0
+}
+
+// putChunked splits the value into chunks of at most MaxChunkSizeBytes, disperses each chunk as its own blob,
+// and returns a VersionedCert holding the [certs.ChunkManifest] that references them.
+//
+// Each chunk is backed up to secondary storage under its own cert, so that GETs of a manifest can make use of the
+// cache and fallback targets the same way as GETs of a single cert.
+func (m *EigenDAManager) putChunked(
+	ctx context.Context, value []byte, serializationType coretypes.CertSerializationType,
+) (*certs.VersionedCert, error) {
+	chunkSize := int(m.chunking.MaxChunkSizeBytes)
+	chunkCount := (len(value) + chunkSize - 1) / chunkSize
+	if chunkCount > m.chunking.MaxChunks {
+		return nil, fmt.Errorf("%w: payload of %d bytes would need %d chunks of %d bytes, max is %d chunks",
+			proxyerrors.ErrProxyOversizedBlob, len(value), chunkCount, chunkSize, m.chunking.MaxChunks)
+	}
+
+	m.log.Debug("Splitting payload across multiple blobs", "payloadLen", len(value), "chunks", chunkCount)
+	chunks := make([]certs.ManifestChunk, chunkCount)
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(maxConcurrentChunkOps)
+	for i := range chunkCount {
+		chunk := value[i*chunkSize : min((i+1)*chunkSize, len(value))]
+		group.Go(func() error {
+			versionedCert, err := m.putSingle(groupCtx, chunk, serializationType)
+			if err != nil {
+				return fmt.Errorf("put chunk %d of %d: %w", i, chunkCount, err)
+			}
+			chunks[i] = certs.ManifestChunk{PayloadLength: uint32(len(chunk)), Cert: *versionedCert}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return certs.NewChunkManifest(value, chunks).VersionedCert(), nil
+}
+
+// getChunked retrieves every chunk referenced by a [certs.ChunkManifest], and reassembles them into the payload.
+//
+// Each chunk cert is verified the same way as a standalone cert. A manifest that can't be parsed is treated as an
+// invalid cert, and a reassembled payload that doesn't match the manifest is treated as undecodable, so that
+// derivation pipelines drop it deterministically.
+func (m *EigenDAManager) getChunked(
+	ctx context.Context,
+	versionedCert *certs.VersionedCert,
+	serializationType coretypes.CertSerializationType,
+	opts common.GETOpts,
+) ([]byte, error) {
+	if opts.ReturnEncodedPayload {
+		return nil, proxyerrors.ErrChunkedEncodedPayloadUnsupported
+	}
+
+	manifest, err := certs.DeserializeChunkManifest(versionedCert.SerializedCert)
+	if err != nil {
+		return nil, coretypes.NewCertParsingFailedError(
+			fmt.Sprintf("%x", versionedCert.SerializedCert), fmt.Sprintf("deserialize chunk manifest: %v", err))
+	}
+
+	chunkPayloads := make([][]byte, len(manifest.Chunks))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(maxConcurrentChunkOps)
+	for i, chunk := range manifest.Chunks {
+		group.Go(func() error {
+			payload, err := m.getEigenDAV2(groupCtx, &chunk.Cert, serializationType, opts)
+			if err != nil {
+				return fmt.Errorf("get chunk %d of %d: %w", i, len(manifest.Chunks), err)
+			}
+			if uint64(len(payload)) != uint64(chunk.PayloadLength) {
+				return coretypes.ErrBlobDecodingFailedDerivationError.WithMessage(
+					fmt.Sprintf("chunk %d is %d bytes, but manifest expects %d bytes",
+						i, len(payload), chunk.PayloadLength))
+			}
+			chunkPayloads[i] = payload
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, 0, manifest.PayloadLength)
+	for _, chunkPayload := range chunkPayloads {
+		payload = append(payload, chunkPayload...)
+	}
+	if err := manifest.VerifyPayload(payload); err != nil {
+		return nil, coretypes.ErrBlobDecodingFailedDerivationError.WithMessage(err.Error())
+	}
+	return payload, nil
+}
@@ -0,0 +1,172 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+
+// fakeEigenDAV2Store is an in-memory EigenDAV2Store which hands out sequential certs.
+type fakeEigenDAV2Store struct {
+	mu       sync.Mutex
+	payloads map[string][]byte
+	// number of calls to Get
+	gets int
+}
+
+var _ common.EigenDAV2Store = &fakeEigenDAV2Store{}
+
+func newFakeEigenDAV2Store() *fakeEigenDAV2Store {
+	return &fakeEigenDAV2Store{payloads: make(map[string][]byte)}
+}
+
+func (s *fakeEigenDAV2Store) BackendType() common.BackendType {
+	return common.MemstoreV2BackendType
+}
+
+func (s *fakeEigenDAV2Store) Put(
+	_ context.Context, payload []byte, _ coretypes.CertSerializationType,
+) (*certs.VersionedCert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cert := fmt.Sprintf("cert-%d", len(s.payloads))
+	s.payloads[cert] = bytes.Clone(payload)
+	return certs.NewVersionedCert([]byte(cert), certs.V2VersionByte), nil
+}
+
+func (s *fakeEigenDAV2Store) Get(
+	_ context.Context, versionedCert *certs.VersionedCert, _ coretypes.CertSerializationType, _ bool,
+) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gets++
+	payload, ok := s.payloads[string(versionedCert.SerializedCert)]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return payload, nil
+}
+
+func (s *fakeEigenDAV2Store) VerifyCert(
+	_ context.Context, versionedCert *certs.VersionedCert, _ coretypes.CertSerializationType, _ uint64,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.payloads[string(versionedCert.SerializedCert)]; !ok {
+		return coretypes.ErrInvalidCertDerivationError
+	}
+	return nil
+}
+
+// VerifyPayload compares the payload with the one that was Put under the cert.
+func (s *fakeEigenDAV2Store) VerifyPayload(
+	_ context.Context, versionedCert *certs.VersionedCert, _ coretypes.CertSerializationType, payload []byte,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !bytes.Equal(s.payloads[string(versionedCert.SerializedCert)], payload) {
+		return errors.New("payload does not match cert")
+	}
+	return nil
+}
+
+func newTestChunkingManager(t *testing.T, chunking PayloadChunkingConfig) (*EigenDAManager, *fakeEigenDAV2Store) {
+	v2Store := newFakeEigenDAV2Store()
+	secondaryMgr := secondary.NewSecondaryManager(testLogger, metrics.NoopMetrics, nil, nil, nil, false, false)
+	manager, err := NewEigenDAManager(v2Store, testLogger, secondaryMgr, common.V2EigenDABackend, chunking)
+	require.NoError(t, err)
+	return manager, v2Store
+}
+
+func requireDerivationError(t *testing.T, err error, expected coretypes.DerivationError) {
+	var derivationErr coretypes.DerivationError
+	require.ErrorAs(t, err, &derivationErr)
+	require.Equal(t, expected.StatusCode, derivationErr.StatusCode)
+}
+
+func TestChunkedPutGet(t *testing.T) {
+	ctx := context.Background()
+	manager, v2Store := newTestChunkingManager(t, PayloadChunkingConfig{MaxChunkSizeBytes: 10, MaxChunks: 4})
+
+	t.Run("payload fitting in a single blob", func(t *testing.T) {
+		payload := []byte("0123456789")
+		versionedCert, err := manager.Put(ctx, payload, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		require.Equal(t, certs.V2VersionByte, versionedCert.Version)
+
+		retrieved, err := manager.Get(ctx, versionedCert, coretypes.CertSerializationRLP, common.GETOpts{})
+		require.NoError(t, err)
+		require.Equal(t, payload, retrieved)
+	})
+
+	t.Run("payload split across blobs", func(t *testing.T) {
+		payload := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+		versionedCert, err := manager.Put(ctx, payload, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		require.Equal(t, certs.ManifestVersionByte, versionedCert.Version)
+
+		manifest, err := certs.DeserializeChunkManifest(versionedCert.SerializedCert)
+		require.NoError(t, err)
+		require.Len(t, manifest.Chunks, 4)
+		require.Equal(t, uint32(6), manifest.Chunks[3].PayloadLength)
+
+		retrieved, err := manager.Get(ctx, versionedCert, coretypes.CertSerializationRLP, common.GETOpts{})
+		require.NoError(t, err)
+		require.Equal(t, payload, retrieved)
+
+		_, err = manager.Get(ctx, versionedCert, coretypes.CertSerializationRLP,
+			common.GETOpts{ReturnEncodedPayload: true})
+		require.ErrorIs(t, err, proxyerrors.ErrChunkedEncodedPayloadUnsupported)
+
+		// a chunk whose contents don't match the manifest hash makes the whole manifest undecodable
+		v2Store.payloads[string(manifest.Chunks[1].Cert.SerializedCert)] = []byte("ABCDEFGHIJ")
+		_, err = manager.Get(ctx, versionedCert, coretypes.CertSerializationRLP, common.GETOpts{})
+		requireDerivationError(t, err, coretypes.ErrBlobDecodingFailedDerivationError)
+	})
+
+	t.Run("payload exceeding max chunks", func(t *testing.T) {
+		_, err := manager.Put(ctx, make([]byte, 41), coretypes.CertSerializationRLP)
+		require.ErrorIs(t, err, proxyerrors.ErrProxyOversizedBlob)
+	})
+
+	t.Run("malformed manifest", func(t *testing.T) {
+		versionedCert := certs.NewVersionedCert([]byte{0x00, 0x01}, certs.ManifestVersionByte)
+		_, err := manager.Get(ctx, versionedCert, coretypes.CertSerializationRLP, common.GETOpts{})
+		requireDerivationError(t, err, coretypes.ErrCertParsingFailedDerivationError)
+	})
+
+	t.Run("manifest referencing an invalid cert", func(t *testing.T) {
+		payload := []byte("0123456789abcdefghij")
+		versionedCert := certs.NewChunkManifest(payload, []certs.ManifestChunk{
+			{PayloadLength: 10, Cert: *certs.NewVersionedCert([]byte("cert-0"), certs.V2VersionByte)},
+			{PayloadLength: 10, Cert: *certs.NewVersionedCert([]byte("unknown"), certs.V2VersionByte)},
+		}).VersionedCert()
+		_, err := manager.Get(ctx, versionedCert, coretypes.CertSerializationRLP, common.GETOpts{})
+		requireDerivationError(t, err, coretypes.ErrInvalidCertDerivationError)
+	})
+}
+
+func TestChunkingDisabled(t *testing.T) {
+	ctx := context.Background()
+	manager, _ := newTestChunkingManager(t, PayloadChunkingConfig{MaxChunkSizeBytes: 10, MaxChunks: 1})
+
+	payload := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	versionedCert, err := manager.Put(ctx, payload, coretypes.CertSerializationRLP)
+	require.NoError(t, err)
+	require.Equal(t, certs.V2VersionByte, versionedCert.Version)
+}
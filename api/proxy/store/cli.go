@@ -47,15 +47,17 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Value:    "V2",
 		},
 		&cli.StringSliceFlag{
-			Name:     FallbackTargetsFlagName,
-			Usage:    "List of read fallback targets to rollover to if cert can't be read from EigenDA.",
+			Name: FallbackTargetsFlagName,
+			Usage: "List of read fallback targets to rollover to if cert can't be read from EigenDA. " +
+				"Options are [s3, littdb, filesystem].",
 			Value:    cli.NewStringSlice(),
 			EnvVars:  withEnvPrefix(envPrefix, "FALLBACK_TARGETS"),
 			Category: category,
 		},
 		&cli.StringSliceFlag{
-			Name:     CacheTargetsFlagName,
-			Usage:    "List of caching targets to use fast reads from EigenDA.",
+			Name: CacheTargetsFlagName,
+			Usage: "List of caching targets to use fast reads from EigenDA. " +
+				"Options are [s3, littdb, filesystem].",
 			Value:    cli.NewStringSlice(),
 			EnvVars:  withEnvPrefix(envPrefix, "CACHE_TARGETS"),
 			Category: category,
@@ -41,6 +41,15 @@ func TestConfigVerification(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("LocalTargets", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.CacheTargets = []string{"littdb"}
+		cfg.FallbackTargets = []string{"s3", "filesystem"}
+
+		err := cfg.Check()
+		require.NoError(t, err)
+	})
+
 	t.Run("DuplicateCacheTargets", func(t *testing.T) {
 		cfg := validCfg()
 		cfg.CacheTargets = []string{"s3", "s3"}
@@ -41,6 +41,20 @@ type IEigenDAManager interface {
 	SetDispersalBackend(backend common.EigenDABackend)
 	// See [EigenDAManager.GetDispersalBackend]
 	GetDispersalBackend() common.EigenDABackend
+	// See [EigenDAManager.GetCachedPayload]
+	GetCachedPayload(ctx context.Context, key []byte) ([]byte, error)
+	// See [EigenDAManager.InspectCacheEntry]
+	InspectCacheEntry(ctx context.Context, versionedCert *certs.VersionedCert) ([]secondary.EntryStatus, error)
+	// See [EigenDAManager.PurgeCacheEntry]
+	PurgeCacheEntry(ctx context.Context, versionedCert *certs.VersionedCert) error
+	// See [EigenDAManager.RefetchCacheEntry]
+	RefetchCacheEntry(
+		ctx context.Context, versionedCert *certs.VersionedCert, serializationType coretypes.CertSerializationType,
+	) error
+	// See [EigenDAManager.WarmCacheEntry]
+	WarmCacheEntry(
+		ctx context.Context, versionedCert *certs.VersionedCert, serializationType coretypes.CertSerializationType,
+	) error
 }
 
 // EigenDAManager handles EigenDA certificate operations
@@ -52,6 +66,9 @@ type EigenDAManager struct {
 
 	// secondary storage backends (caching and fallbacks)
 	secondary secondary.ISecondary
+
+	// splitting of payloads that are too large for a single blob
+	chunking PayloadChunkingConfig
 }
 
 var _ IEigenDAManager = &EigenDAManager{}
@@ -62,6 +79,7 @@ func NewEigenDAManager(
 	l logging.Logger,
 	secondary secondary.ISecondary,
 	dispersalBackend common.EigenDABackend,
+	chunking PayloadChunkingConfig,
 ) (*EigenDAManager, error) {
 	// Enforce invariants
 	if dispersalBackend == common.V2EigenDABackend && eigenDAV2 == nil {
@@ -76,6 +94,7 @@ func NewEigenDAManager(
 		log:       l,
 		eigendaV2: eigenDAV2,
 		secondary: secondary,
+		chunking:  chunking,
 	}
 	manager.dispersalBackend.Store(dispersalBackend)
 	return manager, nil
@@ -113,13 +132,28 @@ func (m *EigenDAManager) Get(ctx context.Context,
 			return nil, errors.New("received EigenDAV2 cert but EigenDA V2 client is not initialized")
 		}
 		return m.getEigenDAV2(ctx, versionedCert, serializationType, opts)
+	case certs.ManifestVersionByte:
+		if m.eigendaV2 == nil {
+			return nil, errors.New("received chunked payload manifest but EigenDA V2 client is not initialized")
+		}
+		return m.getChunked(ctx, versionedCert, serializationType, opts)
 	default:
 		return nil, fmt.Errorf("cert version unknown: %b", versionedCert.Version)
 	}
 }
 
+// GetCachedPayload returns the payload stored under key in the caches, or nil if it isn't cached.
+// key is the keccak256 hash of a serialized cert. It serves the reads of peer proxies, which verify the payload
+// against the cert themselves, so the payload is returned without verification.
+func (m *EigenDAManager) GetCachedPayload(ctx context.Context, key []byte) ([]byte, error) {
+	if !m.secondary.CachingEnabled() {
+		return nil, nil
+	}
+	return m.secondary.CacheReadByKey(ctx, key)
+}
+
 // getEigenDAV2 will attempt to retrieve a blob for the given versionedCert
-// from cache, EigenDA V2 relays, EigenDA V2 validators, and fallback storage.
+// from cache, peer proxies, EigenDA V2 relays, EigenDA V2 validators, and fallback storage.
 func (m *EigenDAManager) getEigenDAV2(
 	ctx context.Context,
 	versionedCert *certs.VersionedCert,
@@ -138,10 +172,18 @@ func (m *EigenDAManager) getEigenDAV2(
 	verifyFnForSecondary := func(ctx context.Context, cert []byte, payload []byte) error {
 		// This was previously using the VerifyCert function, which is pointless because it is now verified above,
 		// and the cert only needs to be verified once.
-		// TODO: implement a verify blob function, the same way it is implemented in [payloadretrieval.RelayPayloadRetriever]
+		// Our own secondary storages are trusted, so payloads read from them aren't verified against the cert.
+		// TODO: consider verifying them with [common.EigenDAV2Store.VerifyPayload] as well,
+		// which costs a kzg commitment computation per read.
 		return nil
 	}
 
+	// Unlike our own secondary storages, peer proxies are not trusted, so payloads read from them
+	// are verified against the blob commitment of the cert.
+	verifyFnForPeers := func(ctx context.Context, _ []byte, payload []byte) error {
+		return m.eigendaV2.VerifyPayload(ctx, versionedCert, serializationType, payload)
+	}
+
 	var readErrors []error
 	// 1 - read payload from cache if enabled
 	// Secondary storages (cache and fallback) store payloads instead of blobs.
@@ -161,7 +203,24 @@ func (m *EigenDAManager) getEigenDAV2(
 		readErrors = append(readErrors, fmt.Errorf("read from cache targets: %w", err))
 	}
 
-	// 2 - read payloadOrEncodedPayload from EigenDA
+	// 2 - read payload from the caches of peer proxies if enabled
+	if m.secondary.PeeringEnabled() && !opts.ReturnEncodedPayload {
+		m.log.Debug("Retrieving payload from peer proxies")
+		payload, err := m.secondary.PeerRead(ctx, versionedCert.SerializedCert, verifyFnForPeers)
+		if err == nil {
+			if m.secondary.WriteOnCacheMissEnabled() {
+				err = m.backupToSecondary(ctx, versionedCert.SerializedCert, payload)
+				if err != nil {
+					return nil, fmt.Errorf("backup to secondary on cache miss: %w", err)
+				}
+			}
+			return payload, nil
+		}
+		m.log.Warn("Failed to read payload from peer proxies", "err", err)
+		readErrors = append(readErrors, fmt.Errorf("read from peers: %w", err))
+	}
+
+	// 3 - read payloadOrEncodedPayload from EigenDA
 	m.log.Debug("Reading blob from EigenDAV2 backend", "returnEncodedPayload", opts.ReturnEncodedPayload)
 	payloadOrEncodedPayload, err := m.eigendaV2.Get(ctx, versionedCert, serializationType, opts.ReturnEncodedPayload)
 	if err == nil {
@@ -178,7 +237,7 @@ func (m *EigenDAManager) getEigenDAV2(
 	}
 	readErrors = append(readErrors, fmt.Errorf("read from EigenDA backend: %w", err))
 
-	// 3 - read blob from fallbacks if enabled and data is non-retrievable from EigenDA
+	// 4 - read blob from fallbacks if enabled and data is non-retrievable from EigenDA
 	// Only use fallbacks if we're not requesting encoded payload
 	if m.secondary.FallbackEnabled() && !opts.ReturnEncodedPayload {
 		payloadOrEncodedPayload, err = m.secondary.MultiSourceRead(ctx,
@@ -192,10 +251,22 @@ func (m *EigenDAManager) getEigenDAV2(
 	return nil, fmt.Errorf("failed to read from all storage backends: %w", errors.Join(readErrors...))
 }
 
-// Put ... inserts a value into a storage backend based on the commitment mode
+// Put ... inserts a value into a storage backend based on the commitment mode.
+// Values too large for a single blob are split into several blobs when chunking is enabled,
+// in which case the returned VersionedCert holds a [certs.ChunkManifest].
 func (m *EigenDAManager) Put(
 	ctx context.Context, value []byte, serializationType coretypes.CertSerializationType,
 ) (*certs.VersionedCert, error) {
+	if m.chunking.Enabled() && len(value) > int(m.chunking.MaxChunkSizeBytes) {
+		return m.putChunked(ctx, value, serializationType)
+	}
+	return m.putSingle(ctx, value, serializationType)
+}
+
+// putSingle ... disperses a value as a single blob, and backs it up to secondary storage
+func (m *EigenDAManager) putSingle(
+	ctx context.Context, value []byte, serializationType coretypes.CertSerializationType,
+) (*certs.VersionedCert, error) {
 
 	// 1 - Put blob into primary storage backend and obtain serialized DA Cert
 	versionedCert, err := m.putToCorrectEigenDABackend(ctx, value, serializationType)
@@ -15,6 +15,7 @@ var (
 	PutLatencyFlagName              = withFlagPrefix("put-latency")
 	GetLatencyFlagName              = withFlagPrefix("get-latency")
 	PutReturnsFailoverErrorFlagName = withFlagPrefix("put-returns-failover-error")
+	PersistencePathFlagName         = withFlagPrefix("persistence-path")
 )
 
 func withFlagPrefix(s string) string {
@@ -93,6 +94,13 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  []string{withEnvPrefix(envPrefix, "PUT_RETURNS_FAILOVER_ERROR")},
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name: PersistencePathFlagName,
+			Usage: "Directory where memstore persists blobs, so that certs issued before a restart remain " +
+				"retrievable. If empty, blobs are only kept in memory.",
+			EnvVars:  []string{withEnvPrefix(envPrefix, "PERSISTENCE_PATH")},
+			Category: category,
+		},
 	}
 }
 
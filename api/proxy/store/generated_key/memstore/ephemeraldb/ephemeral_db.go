@@ -24,8 +24,38 @@ type payloadWithDerivationError struct {
 	derivationError error // the underlying type is [coretypes.DerivationError]
 }
 
+// entryStore ... holds the entries of a DB. Implementations don't need to be thread safe,
+// since all access is serialized by the DB's lock.
+type entryStore interface {
+	// get ... returns the entry stored under key, and whether it exists
+	get(key string) (payloadWithDerivationError, bool, error)
+	// put ... stores an entry that was inserted at the given time
+	put(key string, entry payloadWithDerivationError, insertedAt time.Time) error
+	// delete ... removes the entry stored under key
+	delete(key string) error
+}
+
+// memEntryStore ... an entryStore that keeps all entries in memory
+type memEntryStore map[string]payloadWithDerivationError
+
+func (s memEntryStore) get(key string) (payloadWithDerivationError, bool, error) {
+	entry, exists := s[key]
+	return entry, exists, nil
+}
+
+func (s memEntryStore) put(key string, entry payloadWithDerivationError, _ time.Time) error {
+	s[key] = entry
+	return nil
+}
+
+func (s memEntryStore) delete(key string) error {
+	delete(s, key)
+	return nil
+}
+
 // DB ... An ephemeral && simple in-memory database used to emulate
 // an EigenDA network for dispersal/retrieval operations.
+// Entries can optionally be persisted to disk (see NewPersistent), in which case they survive restarts.
 type DB struct {
 	// knobs used to express artificial conditions for testing
 	config *memconfig.SafeConfig
@@ -33,16 +63,45 @@ type DB struct {
 
 	// mu guards the below fields
 	mu        sync.RWMutex
-	keyStarts map[string]time.Time                  // used for managing expiration
-	store     map[string]payloadWithDerivationError // db
+	keyStarts map[string]time.Time // used for managing expiration
+	store     entryStore           // db
 }
 
 // New ... constructor
 func New(ctx context.Context, cfg *memconfig.SafeConfig, log logging.Logger) *DB {
+	return newDB(ctx, cfg, log, make(memEntryStore), make(map[string]time.Time))
+}
+
+// NewPersistent ... constructor for a DB that persists its entries to the given directory.
+// Entries written by a previous DB using the same directory (e.g. before a restart) are loaded, and their
+// expiration is computed relative to when they were originally inserted. The directory is released
+// when ctx is cancelled.
+func NewPersistent(ctx context.Context, cfg *memconfig.SafeConfig, log logging.Logger, path string) (*DB, error) {
+	store, insertionTimes, err := newLittEntryStore(ctx, log, path)
+	if err != nil {
+		return nil, fmt.Errorf("new persistent entry store: %w", err)
+	}
+
+	keyStarts := make(map[string]time.Time)
+	if cfg.BlobExpiration() > 0 {
+		keyStarts = insertionTimes
+	}
+	log.Info("loaded persisted ephemeral db entries", "path", path, "count", len(insertionTimes))
+
+	return newDB(ctx, cfg, log, store, keyStarts), nil
+}
+
+func newDB(
+	ctx context.Context,
+	cfg *memconfig.SafeConfig,
+	log logging.Logger,
+	store entryStore,
+	keyStarts map[string]time.Time,
+) *DB {
 	db := &DB{
 		config:    cfg,
-		keyStarts: make(map[string]time.Time),
-		store:     make(map[string]payloadWithDerivationError),
+		keyStarts: keyStarts,
+		store:     store,
 		log:       log,
 	}
 
@@ -77,20 +136,27 @@ func (db *DB) InsertEntry(key []byte, value []byte) error {
 	derivationError := db.config.OverwritePutWithDerivationError()
 
 	// disallow any overwrite
-	_, exists := db.store[strKey]
+	_, exists, err := db.store.get(strKey)
+	if err != nil {
+		return fmt.Errorf("get entry: %w", err)
+	}
 	if exists {
 		return fmt.Errorf("payload key already exists in ephemeral db: %s", strKey)
 	}
 
-	if derivationError == nil {
-		db.store[strKey] = payloadWithDerivationError{payload: value}
-	} else {
-		db.store[strKey] = payloadWithDerivationError{derivationError: derivationError}
+	entry := payloadWithDerivationError{payload: value}
+	if derivationError != nil {
+		entry = payloadWithDerivationError{derivationError: derivationError}
+	}
+	insertedAt := time.Now()
+	err = db.store.put(strKey, entry, insertedAt)
+	if err != nil {
+		return fmt.Errorf("put entry: %w", err)
 	}
 
 	// add expiration if applicable
 	if db.config.BlobExpiration() > 0 {
-		db.keyStarts[strKey] = time.Now()
+		db.keyStarts[strKey] = insertedAt
 	}
 
 	return nil
@@ -102,7 +168,10 @@ func (db *DB) FetchEntry(key []byte) ([]byte, error) {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
-	payloadWithDerivationError, exists := db.store[string(key)]
+	payloadWithDerivationError, exists, err := db.store.get(string(key))
+	if err != nil {
+		return nil, fmt.Errorf("get entry: %w", err)
+	}
 	if !exists {
 		return nil, fmt.Errorf("payload not found for key: %s", hex.EncodeToString(key))
 	}
@@ -136,8 +205,13 @@ func (db *DB) pruneExpired() {
 
 	for commit, dur := range db.keyStarts {
 		if time.Since(dur) >= db.config.BlobExpiration() {
+			err := db.store.delete(commit)
+			if err != nil {
+				// leave the entry in keyStarts so that pruning is retried on the next iteration
+				db.log.Warn("failed to prune blob", "commit", commit, "err", err)
+				continue
+			}
 			delete(db.keyStarts, commit)
-			delete(db.store, commit)
 
 			db.log.Debug("blob pruned", "commit", commit)
 		}
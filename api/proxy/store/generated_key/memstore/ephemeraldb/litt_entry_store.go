@@ -0,0 +1,202 @@
+package ephemeraldb
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// name of the LittDB table that holds the persisted entries
+	littTableName = "memstore"
+
+	// entry kinds, stored as the first byte of a serialized entry
+	payloadEntryKind         byte = 0
+	derivationErrorEntryKind byte = 1
+
+	// size of the header of a serialized entry: the entry kind followed by the insertion time in unix nanoseconds
+	entryHeaderSize = 1 + 8
+)
+
+// littEntryStore ... an entryStore that persists entries to disk using LittDB
+type littEntryStore struct {
+	db    litt.DB
+	table litt.Table
+}
+
+var _ entryStore = (*littEntryStore)(nil)
+
+// newLittEntryStore ... opens (or creates) the entry store at the given path, and returns the insertion times of all
+// entries already present in it. The underlying LittDB is closed when ctx is cancelled.
+func newLittEntryStore(
+	ctx context.Context,
+	log logging.Logger,
+	path string,
+) (*littEntryStore, map[string]time.Time, error) {
+	config, err := litt.DefaultConfig(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("default LittDB config: %w", err)
+	}
+	config.Logger = log
+
+	db, err := littbuilder.NewDB(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new LittDB: %w", err)
+	}
+
+	store := &littEntryStore{db: db}
+	insertionTimes, err := store.load()
+	if err != nil {
+		closeErr := db.Close()
+		if closeErr != nil {
+			log.Error("failed to close LittDB", "err", closeErr)
+		}
+		return nil, nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		err := db.Close()
+		if err != nil {
+			log.Error("failed to close ephemeral db LittDB", "err", err)
+		}
+	}()
+
+	return store, insertionTimes, nil
+}
+
+// load ... opens the entry table and reads the insertion time of every entry in it
+func (s *littEntryStore) load() (map[string]time.Time, error) {
+	table, err := s.db.GetTable(littTableName)
+	if err != nil {
+		return nil, fmt.Errorf("get LittDB table %s: %w", littTableName, err)
+	}
+	s.table = table
+
+	iterator, err := table.Iterator()
+	if err != nil {
+		return nil, fmt.Errorf("create LittDB iterator: %w", err)
+	}
+	defer func() {
+		_ = iterator.Close()
+	}()
+
+	insertionTimes := make(map[string]time.Time)
+	for iterator.Next() {
+		value, err := iterator.Value()
+		if err != nil {
+			return nil, fmt.Errorf("read entry %x: %w", iterator.Key(), err)
+		}
+		_, insertedAt, err := deserializeEntry(value)
+		if err != nil {
+			return nil, fmt.Errorf("deserialize entry %x: %w", iterator.Key(), err)
+		}
+		insertionTimes[string(iterator.Key())] = insertedAt
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, fmt.Errorf("iterate over LittDB table: %w", err)
+	}
+
+	return insertionTimes, nil
+}
+
+func (s *littEntryStore) get(key string) (payloadWithDerivationError, bool, error) {
+	value, exists, err := s.table.Get([]byte(key))
+	if err != nil {
+		return payloadWithDerivationError{}, false, fmt.Errorf("LittDB get: %w", err)
+	}
+	if !exists {
+		return payloadWithDerivationError{}, false, nil
+	}
+
+	entry, _, err := deserializeEntry(value)
+	if err != nil {
+		return payloadWithDerivationError{}, false, fmt.Errorf("deserialize entry: %w", err)
+	}
+	return entry, true, nil
+}
+
+func (s *littEntryStore) put(key string, entry payloadWithDerivationError, insertedAt time.Time) error {
+	value, err := serializeEntry(entry, insertedAt)
+	if err != nil {
+		return fmt.Errorf("serialize entry: %w", err)
+	}
+
+	err = s.table.Put([]byte(key), value)
+	if err != nil {
+		return fmt.Errorf("LittDB put: %w", err)
+	}
+	// flush so that a cert returned to the caller is still retrievable after a crash
+	err = s.table.Flush()
+	if err != nil {
+		return fmt.Errorf("LittDB flush: %w", err)
+	}
+	return nil
+}
+
+func (s *littEntryStore) delete(key string) error {
+	err := s.table.Delete([]byte(key))
+	if err != nil {
+		return fmt.Errorf("LittDB delete: %w", err)
+	}
+	return nil
+}
+
+// serializeEntry ... encodes an entry along with the time it was inserted
+func serializeEntry(entry payloadWithDerivationError, insertedAt time.Time) ([]byte, error) {
+	kind := payloadEntryKind
+	body := entry.payload
+	if entry.derivationError != nil {
+		var derivationError coretypes.DerivationError
+		if !errors.As(entry.derivationError, &derivationError) {
+			return nil, fmt.Errorf("unable to cast error into a DerivationError: %w", entry.derivationError)
+		}
+		var err error
+		body, err = json.Marshal(derivationError)
+		if err != nil {
+			return nil, fmt.Errorf("marshal derivation error: %w", err)
+		}
+		kind = derivationErrorEntryKind
+	}
+
+	serialized := make([]byte, entryHeaderSize+len(body))
+	serialized[0] = kind
+	// #nosec G115 - insertion times are always after the unix epoch
+	binary.BigEndian.PutUint64(serialized[1:entryHeaderSize], uint64(insertedAt.UnixNano()))
+	copy(serialized[entryHeaderSize:], body)
+	return serialized, nil
+}
+
+// deserializeEntry ... decodes an entry encoded by serializeEntry, returning the entry and its insertion time
+func deserializeEntry(serialized []byte) (payloadWithDerivationError, time.Time, error) {
+	if len(serialized) < entryHeaderSize {
+		return payloadWithDerivationError{}, time.Time{},
+			fmt.Errorf("entry is %d bytes, shorter than the %d byte header", len(serialized), entryHeaderSize)
+	}
+
+	// #nosec G115 - insertion times are always after the unix epoch
+	insertedAt := time.Unix(0, int64(binary.BigEndian.Uint64(serialized[1:entryHeaderSize])))
+	body := serialized[entryHeaderSize:]
+
+	switch serialized[0] {
+	case payloadEntryKind:
+		return payloadWithDerivationError{payload: body}, insertedAt, nil
+	case derivationErrorEntryKind:
+		var derivationError coretypes.DerivationError
+		err := json.Unmarshal(body, &derivationError)
+		if err != nil {
+			return payloadWithDerivationError{}, time.Time{}, fmt.Errorf("unmarshal derivation error: %w", err)
+		}
+		return payloadWithDerivationError{derivationError: derivationError}, insertedAt, nil
+	default:
+		return payloadWithDerivationError{}, time.Time{}, fmt.Errorf("unknown entry kind %d", serialized[0])
+	}
+}
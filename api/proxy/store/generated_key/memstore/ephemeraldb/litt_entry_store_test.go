@@ -0,0 +1,118 @@
+package ephemeraldb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/stretchr/testify/require"
+)
+
+// reopen ... waits for the DB at path to be released by a cancelled DB, and then opens it again
+func reopen(t *testing.T, ctx context.Context, cfg *memconfig.SafeConfig, path string) *DB {
+	t.Helper()
+
+	var db *DB
+	require.Eventually(t, func() bool {
+		var err error
+		db, err = NewPersistent(ctx, cfg, testLogger, path)
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+	return db
+}
+
+func TestPersistentGetSetAcrossRestart(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+	cfg := testConfig()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	db, err := NewPersistent(ctx, cfg, testLogger, path)
+	require.NoError(t, err)
+
+	testKey := []byte("bland")
+	expected := []byte(testPreimage)
+	err = db.InsertEntry(testKey, expected)
+	require.NoError(t, err)
+
+	err = cfg.SetOverwritePutWithDerivationError(coretypes.ErrInvalidCertDerivationError)
+	require.NoError(t, err)
+	derivationErrorKey := []byte("derivation-error")
+	err = db.InsertEntry(derivationErrorKey, []byte("some-value"))
+	require.NoError(t, err)
+	err = cfg.SetOverwritePutWithDerivationError(nil)
+	require.NoError(t, err)
+
+	// Restart the DB. Entries written before the restart should still be retrievable.
+	cancel()
+	db = reopen(t, t.Context(), cfg, path)
+
+	actual, err := db.FetchEntry(testKey)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	_, err = db.FetchEntry(derivationErrorKey)
+	require.ErrorIs(t, err, coretypes.ErrInvalidCertDerivationError)
+
+	// Keys written before the restart still can't be overwritten.
+	err = db.InsertEntry(testKey, []byte("another-value"))
+	require.ErrorContains(t, err, "key already exists")
+
+	_, err = db.FetchEntry([]byte("missing"))
+	require.Error(t, err)
+}
+
+func TestPersistentExpirationAcrossRestart(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+	cfg := testConfig()
+	cfg.SetBlobExpiration(time.Hour)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	db, err := NewPersistent(ctx, cfg, testLogger, path)
+	require.NoError(t, err)
+
+	testKey := []byte("bland")
+	err = db.InsertEntry(testKey, []byte(testPreimage))
+	require.NoError(t, err)
+
+	// Expiration is computed from the original insertion time, not from when the entry was reloaded.
+	cancel()
+	cfg.SetBlobExpiration(10 * time.Millisecond)
+	db = reopen(t, t.Context(), cfg, path)
+
+	require.Eventually(t, func() bool {
+		_, err := db.FetchEntry(testKey)
+		return err != nil
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestSerializeEntry(t *testing.T) {
+	t.Parallel()
+
+	insertedAt := time.Unix(0, time.Now().UnixNano())
+
+	serialized, err := serializeEntry(payloadWithDerivationError{payload: []byte(testPreimage)}, insertedAt)
+	require.NoError(t, err)
+	entry, actualInsertedAt, err := deserializeEntry(serialized)
+	require.NoError(t, err)
+	require.Equal(t, []byte(testPreimage), entry.payload)
+	require.NoError(t, entry.derivationError)
+	require.True(t, insertedAt.Equal(actualInsertedAt))
+
+	derivationError := coretypes.ErrRecencyCheckFailedDerivationError.WithMessage("too old")
+	serialized, err = serializeEntry(payloadWithDerivationError{derivationError: derivationError}, insertedAt)
+	require.NoError(t, err)
+	entry, actualInsertedAt, err = deserializeEntry(serialized)
+	require.NoError(t, err)
+	require.Nil(t, entry.payload)
+	require.Equal(t, derivationError, entry.derivationError)
+	require.True(t, insertedAt.Equal(actualInsertedAt))
+
+	_, _, err = deserializeEntry([]byte{payloadEntryKind})
+	require.Error(t, err)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/ephemeraldb"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/utils"
 	cert_types_binding "github.com/Layr-Labs/eigenda/contracts/bindings/IEigenDACertTypeBindings"
 	"github.com/Layr-Labs/eigenda/encoding/v2/rs"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -61,6 +62,8 @@ type MemStore struct {
 	g1SRS []bn254.G1Affine
 
 	polyForm codecs.PolynomialForm
+	// encoding applied to payloads on Put. Get decodes any supported version.
+	payloadEncodingVersion codecs.PayloadEncodingVersion
 
 	config *memconfig.SafeConfig
 }
@@ -70,17 +73,39 @@ var _ common.EigenDAV2Store = (*MemStore)(nil)
 // New ... constructor
 func New(
 	ctx context.Context, log logging.Logger, config *memconfig.SafeConfig,
-	g1SRS []bn254.G1Affine,
+	g1SRS []bn254.G1Affine, payloadEncodingVersion codecs.PayloadEncodingVersion,
 ) *MemStore {
 	return &MemStore{
-		DB:       ephemeraldb.New(ctx, config, log),
-		log:      log,
-		g1SRS:    g1SRS,
-		polyForm: codecs.PolynomialFormEval,
-		config:   config,
+		DB:                     ephemeraldb.New(ctx, config, log),
+		log:                    log,
+		g1SRS:                  g1SRS,
+		polyForm:               codecs.PolynomialFormEval,
+		payloadEncodingVersion: payloadEncodingVersion,
+		config:                 config,
 	}
 }
 
+// NewPersistent ... constructor for a MemStore that persists its blobs to the given directory,
+// so that certs issued before a restart remain retrievable afterwards.
+func NewPersistent(
+	ctx context.Context, log logging.Logger, config *memconfig.SafeConfig,
+	g1SRS []bn254.G1Affine, payloadEncodingVersion codecs.PayloadEncodingVersion, path string,
+) (*MemStore, error) {
+	db, err := ephemeraldb.NewPersistent(ctx, config, log, path)
+	if err != nil {
+		return nil, fmt.Errorf("new persistent ephemeral db: %w", err)
+	}
+
+	return &MemStore{
+		DB:                     db,
+		log:                    log,
+		g1SRS:                  g1SRS,
+		polyForm:               codecs.PolynomialFormEval,
+		payloadEncodingVersion: payloadEncodingVersion,
+		config:                 config,
+	}, nil
+}
+
 // generateRandomV4Cert ... generates a pseudo random EigenDA V4 certificate with a offchain derivation version of 0
 func (e *MemStore) generateRandomV4Cert(blobContents []byte) (*coretypes.EigenDACertV4, error) {
 	v3Cert, err := e.generateRandomV3Cert(blobContents)
@@ -256,7 +281,7 @@ func (e *MemStore) Get(
 		return encodedPayload.Serialize(), nil
 	}
 
-	payload, err := blob.ToPayload(e.polyForm)
+	payload, err := blob.ToEncodedPayloadUnchecked(e.polyForm).DecodeAllowingVersion(e.payloadEncodingVersion)
 	if err != nil {
 		return nil, fmt.Errorf("convert blob to payload: %w", err)
 	}
@@ -272,7 +297,11 @@ func (e *MemStore) Put(
 ) (*certs.VersionedCert, error) {
 	payload := coretypes.Payload(value)
 
-	blob, err := payload.ToBlob(e.polyForm)
+	encodedPayload, err := payload.ToEncodedPayloadWithVersion(e.payloadEncodingVersion)
+	if err != nil {
+		return nil, fmt.Errorf("encoding payload: %w", err)
+	}
+	blob, err := encodedPayload.ToBlob(e.polyForm)
 	if err != nil {
 		return nil, fmt.Errorf("generating blob: %w", err)
 	}
@@ -328,6 +357,19 @@ func (e *MemStore) VerifyCert(
 	return nil
 }
 
+// VerifyPayload verifies that the payload matches the blob commitment of the cert.
+// Unlike certs, the blob commitments of memstore certs are real, so payloads are verified the same way as in
+// the EigenDA V2 store.
+func (e *MemStore) VerifyPayload(
+	_ context.Context,
+	versionedCert *certs.VersionedCert,
+	serializationType coretypes.CertSerializationType,
+	payload []byte,
+) error {
+	return utils.VerifyPayloadAgainstCert(
+		e.g1SRS, e.polyForm, e.payloadEncodingVersion, versionedCert, serializationType, payload)
+}
+
 func (e *MemStore) BackendType() common.BackendType {
 	return common.MemstoreV2BackendType
 }
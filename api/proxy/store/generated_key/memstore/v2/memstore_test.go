@@ -1,11 +1,14 @@
 package memstore
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/utils"
 	"github.com/Layr-Labs/eigenda/encoding/v2/kzg"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/stretchr/testify/require"
@@ -39,6 +42,7 @@ func TestGetSet(t *testing.T) {
 		testLogger,
 		getDefaultMemStoreTestConfig(),
 		g1Srs,
+		codecs.PayloadEncodingVersion0,
 	)
 
 	expected := []byte(testPreimage)
@@ -69,6 +73,7 @@ func TestGetSetV3Cert(t *testing.T) {
 		testLogger,
 		config,
 		g1Srs,
+		codecs.PayloadEncodingVersion0,
 	)
 
 	expected := []byte(testPreimage)
@@ -102,6 +107,7 @@ func TestGetSetV4Cert(t *testing.T) {
 		testLogger,
 		config,
 		g1Srs,
+		codecs.PayloadEncodingVersion0,
 	)
 
 	expected := []byte(testPreimage)
@@ -131,6 +137,7 @@ func TestSwitchCertVersion(t *testing.T) {
 		testLogger,
 		config,
 		g1Srs,
+		codecs.PayloadEncodingVersion0,
 	)
 
 	expected := []byte(testPreimage)
@@ -158,3 +165,57 @@ func TestSwitchCertVersion(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, actualV3)
 }
+
+func TestGetSetCompressedPayload(t *testing.T) {
+	g1Srs, err := kzg.ReadG1Points("../../../../resources/g1.point", 3000, 2)
+	require.NoError(t, err)
+
+	ms := New(
+		t.Context(),
+		testLogger,
+		getDefaultMemStoreTestConfig(),
+		g1Srs,
+		codecs.PayloadEncodingVersion1,
+	)
+
+	expected := bytes.Repeat([]byte(testPreimage), 100)
+	versionedCert, err := ms.Put(t.Context(), expected, coretypes.CertSerializationRLP)
+	require.NoError(t, err)
+
+	actual, err := ms.Get(t.Context(), versionedCert, coretypes.CertSerializationRLP, false)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	// the encoded payload records the encoding version in its header
+	encodedPayload, err := ms.Get(t.Context(), versionedCert, coretypes.CertSerializationRLP, true)
+	require.NoError(t, err)
+	require.Equal(t, byte(codecs.PayloadEncodingVersion1), encodedPayload[1])
+	require.Less(t, len(encodedPayload), len(expected))
+}
+
+func TestVerifyPayload(t *testing.T) {
+	g1Srs, err := kzg.ReadG1Points("../../../../resources/g1.point", 3000, 2)
+	require.NoError(t, err)
+
+	for _, encodingVersion := range codecs.SupportedPayloadEncodingVersions {
+		ms := New(
+			t.Context(),
+			testLogger,
+			getDefaultMemStoreTestConfig(),
+			g1Srs,
+			encodingVersion,
+		)
+
+		payload := bytes.Repeat([]byte(testPreimage), 10)
+		versionedCert, err := ms.Put(t.Context(), payload, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+
+		err = ms.VerifyPayload(t.Context(), versionedCert, coretypes.CertSerializationRLP, payload)
+		require.NoError(t, err)
+
+		tampered := bytes.Clone(payload)
+		tampered[0] ^= 0xff
+		err = ms.VerifyPayload(t.Context(), versionedCert, coretypes.CertSerializationRLP, tampered)
+		require.ErrorIs(t, err, utils.ErrPayloadCommitmentMismatch)
+	}
+}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// ErrPayloadCommitmentMismatch is returned by [VerifyPayloadAgainstCert] when a payload doesn't match the blob
+// commitment of a cert.
+var ErrPayloadCommitmentMismatch = errors.New("payload does not match the blob commitment of the cert")
+
+// VerifyPayloadAgainstCert checks that a payload is the one committed to by the cert, by encoding it into a blob and
+// comparing the kzg commitment of that blob with the commitment in the blob header of the cert.
+// This is the same check that [payloadretrieval.RelayPayloadRetriever] does on the blobs it receives, and is used to
+// verify payloads that are read from untrusted sources such as secondary storages or peer proxies.
+//
+// The payload encoding version used at dispersal isn't part of the cert, so the payload is encoded with each of the
+// [codecs.DecodablePayloadEncodingVersions] of the configured encodingVersion until one of them matches.
+func VerifyPayloadAgainstCert(
+	g1SRS []bn254.G1Affine,
+	polyForm codecs.PolynomialForm,
+	encodingVersion codecs.PayloadEncodingVersion,
+	versionedCert *certs.VersionedCert,
+	serializationType coretypes.CertSerializationType,
+	payload []byte,
+) error {
+	claimedCommitment, err := certBlobCommitment(versionedCert, serializationType)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range codecs.DecodablePayloadEncodingVersions(encodingVersion) {
+		encodedPayload, err := coretypes.Payload(payload).ToEncodedPayloadWithVersion(version)
+		if err != nil {
+			// the payload can't be encoded with this version (e.g. too large to be compressed),
+			// so it can't have been dispersed with it either.
+			continue
+		}
+		blob, err := encodedPayload.ToBlob(polyForm)
+		if err != nil {
+			return fmt.Errorf("payload to blob (encoding version %d): %w", version, err)
+		}
+		valid, err := verification.GenerateAndCompareBlobCommitment(g1SRS, blob, claimedCommitment)
+		if err != nil {
+			return fmt.Errorf("generate and compare blob commitment (encoding version %d): %w", version, err)
+		}
+		if valid {
+			return nil
+		}
+	}
+
+	return ErrPayloadCommitmentMismatch
+}
+
+// certBlobCommitment returns the kzg commitment to the blob, read from the blob header of the cert.
+//
+// Only the G1 commitment is read, instead of using [coretypes.EigenDACert.Commitments],
+// since the length commitment and proof aren't needed to verify a payload.
+func certBlobCommitment(
+	versionedCert *certs.VersionedCert,
+	serializationType coretypes.CertSerializationType,
+) (*encoding.G1Commitment, error) {
+	certVersion, err := versionedCert.Version.IntoCertVersion()
+	if err != nil {
+		return nil, fmt.Errorf("casting to cert type version: %w", err)
+	}
+	cert, err := coretypes.DeserializeEigenDACert(versionedCert.SerializedCert, certVersion, serializationType)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize cert: %w", err)
+	}
+
+	var x, y *big.Int
+	switch cert := cert.(type) {
+	case *coretypes.EigenDACertV2:
+		x = cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.X
+		y = cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.Y
+	case *coretypes.EigenDACertV3:
+		x = cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.X
+		y = cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.Y
+	case *coretypes.EigenDACertV4:
+		x = cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.X
+		y = cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment.Y
+	default:
+		return nil, fmt.Errorf("unsupported cert type: %T", cert)
+	}
+	if x == nil || y == nil {
+		return nil, errors.New("cert has no blob commitment")
+	}
+
+	var commitment encoding.G1Commitment
+	commitment.X.SetBigInt(x)
+	commitment.Y.SetBigInt(y)
+	return &commitment, nil
+}
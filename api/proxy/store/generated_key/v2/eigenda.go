@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/dispersal"
@@ -18,6 +19,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/utils"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/avast/retry-go/v4"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -39,6 +41,11 @@ type Store struct {
 
 	// Verification related fields.
 	certVerifier *verification.CertVerifier
+	// g1SRS, polyForm and payloadEncodingVersion are used to verify payloads against the blob commitment of certs,
+	// see VerifyPayload.
+	g1SRS                  []bn254.G1Affine
+	polyForm               codecs.PolynomialForm
+	payloadEncodingVersion codecs.PayloadEncodingVersion
 
 	// Retrieval related fields.
 	retrievers []clients.PayloadRetriever
@@ -59,6 +66,9 @@ func NewStore(
 	putTries int,
 	retryDelay time.Duration,
 	certVerifier *verification.CertVerifier,
+	g1SRS []bn254.G1Affine,
+	polyForm codecs.PolynomialForm,
+	payloadEncodingVersion codecs.PayloadEncodingVersion,
 	retrievers []clients.PayloadRetriever,
 	contractCallTimeout time.Duration,
 ) (*Store, error) {
@@ -74,14 +84,17 @@ func NewStore(
 	}
 
 	return &Store{
-		log:                   log,
-		putTries:              putTries,
-		retryDelay:            retryDelay,
-		disperser:             disperser,
-		retrievers:            retrievers,
-		certVerifier:          certVerifier,
-		contractCallTimeout:   contractCallTimeout,
-		offchainDerivationMap: offchainDerivationMap,
+		log:                    log,
+		putTries:               putTries,
+		retryDelay:             retryDelay,
+		disperser:              disperser,
+		retrievers:             retrievers,
+		certVerifier:           certVerifier,
+		g1SRS:                  g1SRS,
+		polyForm:               polyForm,
+		payloadEncodingVersion: payloadEncodingVersion,
+		contractCallTimeout:    contractCallTimeout,
+		offchainDerivationMap:  offchainDerivationMap,
 	}, nil
 }
 
@@ -246,6 +259,20 @@ func (e Store) Put(
 	}
 }
 
+// VerifyPayload verifies that the payload matches the blob commitment of the cert.
+//
+// Payloads retrieved by Get are already verified by the retrievers, so this is only needed for payloads read
+// from other sources. The cert itself is not verified, VerifyCert should be called for that.
+func (e Store) VerifyPayload(
+	_ context.Context,
+	versionedCert *certs.VersionedCert,
+	serializationType coretypes.CertSerializationType,
+	payload []byte,
+) error {
+	return utils.VerifyPayloadAgainstCert(
+		e.g1SRS, e.polyForm, e.payloadEncodingVersion, versionedCert, serializationType, payload)
+}
+
 // BackendType returns the backend type for EigenDA Store
 func (e Store) BackendType() common.BackendType {
 	return common.EigenDAV2BackendType
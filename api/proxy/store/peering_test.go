@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSecondaryStore is an in-memory SecondaryStore, standing in for both caches and peers.
+type fakeSecondaryStore struct {
+	backendType common.BackendType
+	values      map[string][]byte
+}
+
+var _ common.SecondaryStore = &fakeSecondaryStore{}
+
+func newFakeSecondaryStore(backendType common.BackendType) *fakeSecondaryStore {
+	return &fakeSecondaryStore{backendType: backendType, values: make(map[string][]byte)}
+}
+
+func (s *fakeSecondaryStore) BackendType() common.BackendType {
+	return s.backendType
+}
+
+func (s *fakeSecondaryStore) Put(_ context.Context, key []byte, value []byte) error {
+	s.values[string(key)] = value
+	return nil
+}
+
+func (s *fakeSecondaryStore) Get(_ context.Context, key []byte) ([]byte, error) {
+	return s.values[string(key)], nil
+}
+
+func (s *fakeSecondaryStore) Verify(context.Context, []byte, []byte) error {
+	return nil
+}
+
+func (s *fakeSecondaryStore) Delete(_ context.Context, key []byte) error {
+	delete(s.values, string(key))
+	return nil
+}
+
+func TestGetFromPeers(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte("some payload")
+
+	setup := func(t *testing.T, writeOnCacheMiss bool) (
+		*EigenDAManager, *fakeEigenDAV2Store, *fakeSecondaryStore, *fakeSecondaryStore,
+	) {
+		v2Store := newFakeEigenDAV2Store()
+		cache := newFakeSecondaryStore(common.FilesystemBackendType)
+		peer := newFakeSecondaryStore(common.PeerBackendType)
+		secondaryMgr := secondary.NewSecondaryManager(testLogger, metrics.NoopMetrics,
+			[]common.SecondaryStore{cache}, nil, []common.SecondaryStore{peer}, writeOnCacheMiss, false)
+		manager, err := NewEigenDAManager(
+			v2Store, testLogger, secondaryMgr, common.V2EigenDABackend, PayloadChunkingConfig{})
+		require.NoError(t, err)
+		return manager, v2Store, cache, peer
+	}
+
+	t.Run("payload read from peer", func(t *testing.T) {
+		manager, v2Store, cache, peer := setup(t, true)
+		versionedCert, err := v2Store.Put(ctx, payload, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		key := crypto.Keccak256(versionedCert.SerializedCert)
+		peer.values[string(key)] = payload
+
+		retrieved, err := manager.Get(ctx, versionedCert, coretypes.CertSerializationRLP, common.GETOpts{})
+		require.NoError(t, err)
+		require.Equal(t, payload, retrieved)
+		require.Zero(t, v2Store.gets, "EigenDA should not be read from")
+		require.Equal(t, payload, cache.values[string(key)], "payload read from peer should be cached")
+
+		// the payload is served to other peers from the cache
+		cached, err := manager.GetCachedPayload(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, payload, cached)
+	})
+
+	t.Run("invalid payload from peer is ignored", func(t *testing.T) {
+		manager, v2Store, _, peer := setup(t, false)
+		versionedCert, err := v2Store.Put(ctx, payload, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		peer.values[string(crypto.Keccak256(versionedCert.SerializedCert))] = []byte("tampered payload")
+
+		retrieved, err := manager.Get(ctx, versionedCert, coretypes.CertSerializationRLP, common.GETOpts{})
+		require.NoError(t, err)
+		require.Equal(t, payload, retrieved)
+		require.Equal(t, 1, v2Store.gets, "EigenDA should be read from")
+	})
+
+	t.Run("peers are not read for encoded payloads", func(t *testing.T) {
+		manager, v2Store, _, peer := setup(t, false)
+		versionedCert, err := v2Store.Put(ctx, payload, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		peer.values[string(crypto.Keccak256(versionedCert.SerializedCert))] = payload
+
+		_, err = manager.Get(ctx, versionedCert, coretypes.CertSerializationRLP,
+			common.GETOpts{ReturnEncodedPayload: true})
+		require.NoError(t, err)
+		require.Equal(t, 1, v2Store.gets)
+	})
+
+	t.Run("peers are never served from peers", func(t *testing.T) {
+		manager, _, _, peer := setup(t, false)
+		key := crypto.Keccak256([]byte("cert"))
+		peer.values[string(key)] = payload
+
+		cached, err := manager.GetCachedPayload(ctx, key)
+		require.NoError(t, err)
+		require.Nil(t, cached)
+	})
+}
@@ -0,0 +1,56 @@
+package filesystem
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	PathFlagName             = withFlagPrefix("path")
+	TTLFlagName              = withFlagPrefix("ttl")
+	EvictionIntervalFlagName = withFlagPrefix("eviction-interval")
+)
+
+func withFlagPrefix(s string) string {
+	return "filesystem." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_FILESYSTEM_" + s}
+}
+
+// CLIFlags ... used for filesystem backend configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     PathFlagName,
+			Usage:    "Directory where values are stored. Must be set to use filesystem as a cache or fallback target.",
+			EnvVars:  withEnvPrefix(envPrefix, "PATH"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     TTLFlagName,
+			Usage:    "Duration that an entry is retained after being written. Setting to (0) disables eviction.",
+			Value:    14 * 24 * time.Hour,
+			EnvVars:  withEnvPrefix(envPrefix, "TTL"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     EvictionIntervalFlagName,
+			Usage:    "Interval between scans for expired entries.",
+			Value:    10 * time.Minute,
+			EnvVars:  withEnvPrefix(envPrefix, "EVICTION_INTERVAL"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Path:             ctx.String(PathFlagName),
+		TTL:              ctx.Duration(TTLFlagName),
+		EvictionInterval: ctx.Duration(EvictionIntervalFlagName),
+	}
+}
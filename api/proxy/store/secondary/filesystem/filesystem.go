@@ -0,0 +1,221 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// tempFilePrefix is the prefix of files that are in the process of being written. Values are written to a temporary
+// file and then atomically renamed, so that readers never observe a partially written value.
+const tempFilePrefix = ".tmp-"
+
+var _ common.SecondaryStore = (*Store)(nil)
+
+type Config struct {
+	// Path is the directory where values are stored, one file per key.
+	Path string
+	// TTL is the amount of time that an entry is retained after being written. A TTL of 0 disables eviction.
+	TTL time.Duration
+	// EvictionInterval is the period between scans of the directory for expired entries.
+	EvictionInterval time.Duration
+}
+
+// Store is a secondary store that keeps each value in its own file on the local filesystem. Values expire
+// once they are older than the configured TTL. Expired values are never returned by Get, and are deleted from disk
+// by a background goroutine. Methods on this struct are goroutine safe.
+type Store struct {
+	log logging.Logger
+	cfg Config
+	// now returns the current time. Overridden in tests.
+	now func() time.Time
+}
+
+// NewStore creates a filesystem backed secondary store, creating the directory if it does not exist.
+// If a TTL is configured, expired entries are evicted in the background until ctx is cancelled.
+func NewStore(ctx context.Context, log logging.Logger, cfg Config) (*Store, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("filesystem store path must be set")
+	}
+	if cfg.TTL > 0 && cfg.EvictionInterval <= 0 {
+		return nil, fmt.Errorf("filesystem store eviction interval must be positive, got %s", cfg.EvictionInterval)
+	}
+
+	err := os.MkdirAll(cfg.Path, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("create filesystem store directory %s: %w", cfg.Path, err)
+	}
+
+	store := &Store{
+		log: log,
+		cfg: cfg,
+		now: time.Now,
+	}
+
+	if cfg.TTL > 0 {
+		go store.evictionLoop(ctx)
+	}
+
+	return store, nil
+}
+
+// Get returns the value stored under the given key, or nil if the key is not present or has expired.
+func (s *Store) Get(_ context.Context, key []byte) ([]byte, error) {
+	filePath := s.filePath(key)
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("stat %s: %w", filePath, err)
+	}
+	if s.isExpired(info) {
+		return nil, nil
+	}
+
+	value, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// The file was evicted between the stat and the read.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", filePath, err)
+	}
+	return value, nil
+}
+
+// Put durably writes the given key-value pair. If the key is already present, its value is replaced and its
+// expiry is reset.
+func (s *Store) Put(_ context.Context, key []byte, value []byte) error {
+	tempFile, err := os.CreateTemp(s.cfg.Path, tempFilePrefix)
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+
+	_, err = tempFile.Write(value)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	closeErr := tempFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("write temp file %s: %w", tempPath, err)
+	}
+
+	filePath := s.filePath(key)
+	err = os.Rename(tempPath, filePath)
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("rename %s to %s: %w", tempPath, filePath, err)
+	}
+	return nil
+}
+
+// Delete removes the file holding the value of the given key, if any.
+func (s *Store) Delete(_ context.Context, key []byte) error {
+	filePath := s.filePath(key)
+	err := os.Remove(filePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// Verify checks that key=keccak(value).
+func (s *Store) Verify(_ context.Context, key []byte, value []byte) error {
+	keccakedValue := crypto.Keccak256Hash(value)
+	if !bytes.Equal(key, keccakedValue[:]) {
+		return fmt.Errorf("key!=keccak(value): key=%s keccak(value)=%s",
+			hex.EncodeToString(key), keccakedValue.Hex())
+	}
+	return nil
+}
+
+func (s *Store) BackendType() common.BackendType {
+	return common.FilesystemBackendType
+}
+
+// filePath returns the path of the file that holds the value for the given key.
+func (s *Store) filePath(key []byte) string {
+	return filepath.Join(s.cfg.Path, hex.EncodeToString(key))
+}
+
+// isExpired returns true if the file described by info is older than the TTL.
+func (s *Store) isExpired(info fs.FileInfo) bool {
+	return s.cfg.TTL > 0 && s.now().Sub(info.ModTime()) > s.cfg.TTL
+}
+
+// evictionLoop periodically deletes expired entries until ctx is cancelled.
+func (s *Store) evictionLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.EvictionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			evicted, err := s.evictExpired()
+			if err != nil {
+				s.log.Warn("Failed to evict expired entries from filesystem store", "err", err)
+			}
+			if evicted > 0 {
+				s.log.Debug("Evicted expired entries from filesystem store", "count", evicted)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// evictExpired deletes all expired entries, returning the number of entries deleted. Temporary files left behind
+// by interrupted writes are deleted once they are older than the TTL.
+func (s *Store) evictExpired() (int, error) {
+	entries, err := os.ReadDir(s.cfg.Path)
+	if err != nil {
+		return 0, fmt.Errorf("read directory %s: %w", s.cfg.Path, err)
+	}
+
+	evicted := 0
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("stat %s: %w", entry.Name(), err))
+			}
+			continue
+		}
+		if !s.isExpired(info) {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(s.cfg.Path, entry.Name()))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("remove %s: %w", entry.Name(), err))
+			continue
+		}
+		if !strings.HasPrefix(entry.Name(), tempFilePrefix) {
+			evicted++
+		}
+	}
+
+	return evicted, errors.Join(errs...)
+}
@@ -0,0 +1,148 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+)
+
+const (
+	testPreimage = "Four score and seven years ago"
+)
+
+func TestGetPut(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{Path: filepath.Join(t.TempDir(), "store")}
+	store, err := NewStore(t.Context(), testLogger, cfg)
+	require.NoError(t, err)
+
+	key := crypto.Keccak256([]byte("bland"))
+	value := []byte(testPreimage)
+
+	actual, err := store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Nil(t, actual)
+
+	err = store.Put(t.Context(), key, value)
+	require.NoError(t, err)
+
+	actual, err = store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, value, actual)
+
+	// Data should be visible to a new store using the same directory.
+	reopened, err := NewStore(t.Context(), testLogger, cfg)
+	require.NoError(t, err)
+	actual, err = reopened.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, value, actual)
+
+	// No temporary files should be left behind.
+	entries, err := os.ReadDir(cfg.Path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewStore(t.Context(), testLogger, Config{Path: t.TempDir()})
+	require.NoError(t, err)
+
+	key := crypto.Keccak256([]byte("bland"))
+	err = store.Put(t.Context(), key, []byte(testPreimage))
+	require.NoError(t, err)
+
+	err = store.Delete(t.Context(), key)
+	require.NoError(t, err)
+	actual, err := store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Nil(t, actual)
+
+	// Deleting a missing key is a no-op.
+	err = store.Delete(t.Context(), key)
+	require.NoError(t, err)
+}
+
+func TestExpiration(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{Path: t.TempDir(), TTL: time.Hour, EvictionInterval: time.Hour}
+	store, err := NewStore(t.Context(), testLogger, cfg)
+	require.NoError(t, err)
+
+	now := time.Now()
+	store.now = func() time.Time {
+		return now
+	}
+
+	key := crypto.Keccak256([]byte("bland"))
+	value := []byte(testPreimage)
+	err = store.Put(t.Context(), key, value)
+	require.NoError(t, err)
+
+	// A leftover temporary file from an interrupted write.
+	tempPath := filepath.Join(cfg.Path, tempFilePrefix+"leftover")
+	err = os.WriteFile(tempPath, value, 0o600)
+	require.NoError(t, err)
+
+	evicted, err := store.evictExpired()
+	require.NoError(t, err)
+	require.Equal(t, 0, evicted)
+
+	actual, err := store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, value, actual)
+
+	// Once the TTL has passed, entries are no longer returned, even before they are evicted.
+	now = now.Add(2 * time.Hour)
+	actual, err = store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Nil(t, actual)
+
+	evicted, err = store.evictExpired()
+	require.NoError(t, err)
+	require.Equal(t, 1, evicted)
+
+	entries, err := os.ReadDir(cfg.Path)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestEvictionLoop(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{Path: t.TempDir(), TTL: 10 * time.Millisecond, EvictionInterval: time.Millisecond}
+	store, err := NewStore(t.Context(), testLogger, cfg)
+	require.NoError(t, err)
+
+	key := crypto.Keccak256([]byte("bland"))
+	err = store.Put(t.Context(), key, []byte(testPreimage))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		entries, err := os.ReadDir(cfg.Path)
+		require.NoError(t, err)
+		return len(entries) == 0
+	}, 10*time.Second, time.Millisecond)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewStore(t.Context(), testLogger, Config{})
+	require.Error(t, err)
+
+	_, err = NewStore(t.Context(), testLogger, Config{Path: t.TempDir(), TTL: time.Hour})
+	require.Error(t, err)
+}
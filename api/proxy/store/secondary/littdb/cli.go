@@ -0,0 +1,56 @@
+package littdb
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	PathsFlagName = withFlagPrefix("paths")
+	TTLFlagName   = withFlagPrefix("ttl")
+)
+
+func withFlagPrefix(s string) string {
+	return "littdb." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_LITTDB_" + s}
+}
+
+// CLIFlags ... used for LittDB backend configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: PathsFlagName,
+			Usage: "Directories where LittDB stores its data. Data is spread across all provided directories. " +
+				"Must be set to use littdb as a cache or fallback target.",
+			EnvVars:  withEnvPrefix(envPrefix, "PATHS"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     TTLFlagName,
+			Usage:    "Minimum duration that an entry is retained before being evicted. Setting to (0) disables eviction.",
+			Value:    14 * 24 * time.Hour,
+			EnvVars:  withEnvPrefix(envPrefix, "TTL"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	// Filter out empty strings, which urfave/cli adds when the env var is set to an empty value.
+	paths := make([]string, 0)
+	for _, path := range ctx.StringSlice(PathsFlagName) {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	return Config{
+		Paths: paths,
+		TTL:   ctx.Duration(TTLFlagName),
+	}
+}
@@ -0,0 +1,190 @@
+package littdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/docker/go-units"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// tableName is the name of the LittDB table used to store secondary entries.
+const tableName = "secondary"
+
+// targetSegmentFileSize is the size at which LittDB segments are sealed. LittDB evicts data a segment at a time, and
+// only once a segment has been sealed. The LittDB default is tuned for much higher write volumes than a proxy sees,
+// and would delay eviction well past the TTL.
+const targetSegmentFileSize = 64 * units.MiB
+
+var _ common.SecondaryStore = (*Store)(nil)
+
+type Config struct {
+	// Paths are the directories where LittDB stores its data. Data is spread across all paths.
+	Paths []string
+	// TTL is the minimum amount of time that an entry is retained. Entries older than this are evicted
+	// by LittDB's garbage collector. A TTL of 0 disables eviction.
+	TTL time.Duration
+}
+
+// littConfig builds the LittDB configuration for the store.
+func (c Config) littConfig(log logging.Logger) (*litt.Config, error) {
+	littConfig, err := litt.DefaultConfig(c.Paths...)
+	if err != nil {
+		return nil, fmt.Errorf("default LittDB config: %w", err)
+	}
+	littConfig.Logger = log
+	littConfig.TTL = c.TTL
+	littConfig.TargetSegmentFileSize = targetSegmentFileSize
+	return littConfig, nil
+}
+
+// Store is a secondary store backed by a local LittDB instance. It allows payloads to be cached on local disk,
+// so that they remain readable across proxy restarts without depending on external object storage.
+// Methods on this struct are goroutine safe.
+type Store struct {
+	log   logging.Logger
+	db    litt.DB
+	table litt.Table
+
+	// LittDB does not permit a key to be written more than once. Since keys are derived from commitments,
+	// rewriting a key is always a no-op, but checking for the key and writing it must be done atomically.
+	putLock sync.Mutex
+}
+
+// NewStore opens (or creates) a LittDB backed secondary store. The database is closed when ctx is cancelled.
+func NewStore(ctx context.Context, log logging.Logger, cfg Config) (*Store, error) {
+	littConfig, err := cfg.littConfig(log)
+	if err != nil {
+		return nil, err
+	}
+	return newStore(ctx, log, littConfig)
+}
+
+func newStore(ctx context.Context, log logging.Logger, littConfig *litt.Config) (*Store, error) {
+	db, err := littbuilder.NewDB(littConfig)
+	if err != nil {
+		return nil, fmt.Errorf("new LittDB: %w", err)
+	}
+
+	table, err := db.GetTable(tableName)
+	if err != nil {
+		closeErr := db.Close()
+		if closeErr != nil {
+			log.Error("Failed to close LittDB", "err", closeErr)
+		}
+		return nil, fmt.Errorf("get LittDB table %s: %w", tableName, err)
+	}
+
+	// The TTL is reapplied each time the store is opened, since the TTL of an existing table is persisted on disk.
+	err = table.SetTTL(littConfig.TTL)
+	if err != nil {
+		closeErr := db.Close()
+		if closeErr != nil {
+			log.Error("Failed to close LittDB", "err", closeErr)
+		}
+		return nil, fmt.Errorf("set LittDB TTL: %w", err)
+	}
+
+	store := &Store{
+		log:   log,
+		db:    db,
+		table: table,
+	}
+
+	go func() {
+		<-ctx.Done()
+		err := store.Close()
+		if err != nil {
+			log.Error("Failed to close LittDB secondary store", "err", err)
+		}
+	}()
+
+	return store, nil
+}
+
+// Get returns the value stored under the given key, or nil if the key is not present (or has been evicted).
+func (s *Store) Get(_ context.Context, key []byte) ([]byte, error) {
+	value, exists, err := s.table.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("LittDB Get %s: %w", hex.EncodeToString(key), err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	// LittDB values must not be mutated, and callers of a SecondaryStore are free to do so.
+	return bytes.Clone(value), nil
+}
+
+// Put durably writes the given key-value pair. Writing a key that is already present is a no-op.
+func (s *Store) Put(_ context.Context, key []byte, value []byte) error {
+	s.putLock.Lock()
+	defer s.putLock.Unlock()
+
+	exists, err := s.table.Exists(key)
+	if err != nil {
+		return fmt.Errorf("LittDB Exists %s: %w", hex.EncodeToString(key), err)
+	}
+	if exists {
+		return nil
+	}
+
+	// LittDB holds onto the byte slices passed to it, so they must not be modified by the caller afterwards.
+	err = s.table.Put(bytes.Clone(key), bytes.Clone(value))
+	if err != nil {
+		return fmt.Errorf("LittDB Put %s: %w", hex.EncodeToString(key), err)
+	}
+	err = s.table.Flush()
+	if err != nil {
+		return fmt.Errorf("LittDB Flush: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the given key. The deletion is flushed before returning, such that the key can be written again
+// by a later Put (LittDB drops keys that are written and deleted within the same flush).
+func (s *Store) Delete(_ context.Context, key []byte) error {
+	s.putLock.Lock()
+	defer s.putLock.Unlock()
+
+	err := s.table.Delete(bytes.Clone(key))
+	if err != nil {
+		return fmt.Errorf("LittDB Delete %s: %w", hex.EncodeToString(key), err)
+	}
+	err = s.table.Flush()
+	if err != nil {
+		return fmt.Errorf("LittDB Flush: %w", err)
+	}
+	return nil
+}
+
+// Verify checks that key=keccak(value).
+func (s *Store) Verify(_ context.Context, key []byte, value []byte) error {
+	keccakedValue := crypto.Keccak256Hash(value)
+	if !bytes.Equal(key, keccakedValue[:]) {
+		return fmt.Errorf("key!=keccak(value): key=%s keccak(value)=%s",
+			hex.EncodeToString(key), keccakedValue.Hex())
+	}
+	return nil
+}
+
+func (s *Store) BackendType() common.BackendType {
+	return common.LittDBBackendType
+}
+
+// Close flushes and closes the underlying LittDB instance. It is safe to call Close more than once.
+func (s *Store) Close() error {
+	err := s.db.Close()
+	if err != nil {
+		return fmt.Errorf("close LittDB: %w", err)
+	}
+	return nil
+}
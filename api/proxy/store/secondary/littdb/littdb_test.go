@@ -0,0 +1,167 @@
+package littdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testLogger = logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{})
+)
+
+const (
+	testPreimage = "Four score and seven years ago"
+)
+
+func testStore(t *testing.T, ctx context.Context, cfg Config) *Store {
+	t.Helper()
+
+	littConfig, err := cfg.littConfig(testLogger)
+	require.NoError(t, err)
+	littConfig.Fsync = false // fsync is too slow for unit tests
+	littConfig.GCPeriod = time.Millisecond
+	littConfig.TargetSegmentFileSize = 100
+
+	store, err := newStore(ctx, testLogger, littConfig)
+	require.NoError(t, err)
+	return store
+}
+
+func TestGetPut(t *testing.T) {
+	t.Parallel()
+
+	store := testStore(t, t.Context(), Config{Paths: []string{t.TempDir()}})
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	key := crypto.Keccak256([]byte("bland"))
+	value := []byte(testPreimage)
+
+	actual, err := store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Nil(t, actual)
+
+	err = store.Put(t.Context(), key, value)
+	require.NoError(t, err)
+
+	actual, err = store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, value, actual)
+
+	// Writing the same key a second time is a no-op.
+	err = store.Put(t.Context(), key, value)
+	require.NoError(t, err)
+
+	actual, err = store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, value, actual)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	store := testStore(t, t.Context(), Config{Paths: []string{t.TempDir()}})
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	key := crypto.Keccak256([]byte("bland"))
+	value := []byte(testPreimage)
+	err := store.Put(t.Context(), key, value)
+	require.NoError(t, err)
+
+	err = store.Delete(t.Context(), key)
+	require.NoError(t, err)
+	actual, err := store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Nil(t, actual)
+
+	// Deleting a missing key is a no-op.
+	err = store.Delete(t.Context(), key)
+	require.NoError(t, err)
+
+	// A deleted key can be written again.
+	err = store.Put(t.Context(), key, value)
+	require.NoError(t, err)
+	actual, err = store.Get(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, value, actual)
+}
+
+func TestPersistence(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{Paths: []string{filepath.Join(t.TempDir(), "a"), filepath.Join(t.TempDir(), "b")}}
+	key := crypto.Keccak256([]byte("bland"))
+	value := []byte(testPreimage)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	store := testStore(t, ctx, cfg)
+	err := store.Put(t.Context(), key, value)
+	require.NoError(t, err)
+
+	// Cancelling the context closes the store, after which it can be reopened.
+	cancel()
+	require.Eventually(t, func() bool {
+		reopened, err := NewStore(t.Context(), testLogger, cfg)
+		if err != nil {
+			return false
+		}
+		defer func() {
+			require.NoError(t, reopened.Close())
+		}()
+
+		actual, err := reopened.Get(t.Context(), key)
+		require.NoError(t, err)
+		require.Equal(t, value, actual)
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestEviction(t *testing.T) {
+	t.Parallel()
+
+	store := testStore(t, t.Context(), Config{Paths: []string{t.TempDir()}, TTL: 10 * time.Millisecond})
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	key := crypto.Keccak256([]byte("bland"))
+	err := store.Put(t.Context(), key, []byte(testPreimage))
+	require.NoError(t, err)
+
+	// LittDB only garbage collects sealed segments, so keep writing values until the segment holding the first
+	// value is sealed and collected.
+	i := 0
+	require.Eventually(t, func() bool {
+		i++
+		err := store.Put(t.Context(), crypto.Keccak256([]byte(fmt.Sprintf("other-%d", i))), []byte(testPreimage))
+		require.NoError(t, err)
+
+		actual, err := store.Get(t.Context(), key)
+		require.NoError(t, err)
+		return actual == nil
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	store := testStore(t, t.Context(), Config{Paths: []string{t.TempDir()}})
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	value := []byte(testPreimage)
+	require.NoError(t, store.Verify(t.Context(), crypto.Keccak256(value), value))
+	require.Error(t, store.Verify(t.Context(), crypto.Keccak256([]byte("bland")), value))
+}
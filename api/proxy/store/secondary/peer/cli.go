@@ -0,0 +1,70 @@
+package peer
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	URLsFlagName           = withFlagPrefix("urls")
+	SharedSecretFlagName   = withFlagPrefix("shared-secret")
+	RequestTimeoutFlagName = withFlagPrefix("request-timeout")
+)
+
+func withFlagPrefix(s string) string {
+	return "peering." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_PEERING_" + s}
+}
+
+// CLIFlags ... used for proxy peering configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: URLsFlagName,
+			Usage: "Comma separated list of REST server URLs of peer proxies serving the same rollup. " +
+				"On a cache miss, the caches of the peers are read before retrieving the payload from EigenDA. " +
+				"Payloads read from peers are verified against the cert.",
+			Value:    cli.NewStringSlice(),
+			EnvVars:  withEnvPrefix(envPrefix, "URLS"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name: SharedSecretFlagName,
+			Usage: "Secret shared by all peers, used to authenticate requests between them. " +
+				"Setting it enables the route serving the cache entries of this proxy to its peers.",
+			EnvVars:  withEnvPrefix(envPrefix, "SHARED_SECRET"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     RequestTimeoutFlagName,
+			Usage:    "Timeout of a single read from a peer.",
+			Value:    2 * time.Second,
+			EnvVars:  withEnvPrefix(envPrefix, "REQUEST_TIMEOUT"),
+			Category: category,
+		},
+	}
+}
+
+// ReadConfig reads the peering config from the flags. maxValueSizeBytes is the size of the largest value
+// accepted from a peer.
+func ReadConfig(ctx *cli.Context, maxValueSizeBytes int64) Config {
+	// filter out empty strings, see the comment in store.ReadConfig
+	urls := make([]string, 0, len(ctx.StringSlice(URLsFlagName)))
+	for _, peerURL := range ctx.StringSlice(URLsFlagName) {
+		if peerURL != "" {
+			urls = append(urls, peerURL)
+		}
+	}
+
+	return Config{
+		URLs:              urls,
+		SharedSecret:      ctx.String(SharedSecretFlagName),
+		RequestTimeout:    ctx.Duration(RequestTimeoutFlagName),
+		MaxValueSizeBytes: maxValueSizeBytes,
+	}
+}
@@ -0,0 +1,151 @@
+package peer
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+)
+
+const (
+	// CacheRoutePrefix is the path prefix of the REST route that serves the cache entries of a proxy to its peers.
+	// The route is CacheRoutePrefix followed by the hex encoded cache key.
+	CacheRoutePrefix = "/peering/cache/"
+	// AuthorizationHeaderPrefix prefixes the shared secret in the Authorization header of requests to peers.
+	AuthorizationHeaderPrefix = "Bearer "
+)
+
+// ErrReadOnly is returned when writing to a peer. Peers fill their own caches, they are never written to.
+var ErrReadOnly = errors.New("peer stores are read-only")
+
+var _ common.SecondaryStore = (*Store)(nil)
+
+type Config struct {
+	// URLs are the base URLs of the REST servers of the peer proxies. Peering is disabled if empty.
+	URLs []string
+	// SharedSecret authenticates requests between peers. It must be the same on all peers.
+	// Setting it also enables the route serving this proxy's cache entries to its peers.
+	SharedSecret string
+	// RequestTimeout is the timeout of a single read from a peer.
+	RequestTimeout time.Duration
+	// MaxValueSizeBytes is the size of the largest value accepted from a peer.
+	MaxValueSizeBytes int64
+}
+
+// Enabled returns true if peers are configured.
+func (c Config) Enabled() bool {
+	return len(c.URLs) > 0
+}
+
+// Check verifies that the config is valid.
+func (c Config) Check() error {
+	for _, peerURL := range c.URLs {
+		parsed, err := url.Parse(peerURL)
+		if err != nil {
+			return fmt.Errorf("parse peer url %s: %w", peerURL, err)
+		}
+		if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("peer url %s must be an absolute http(s) url", peerURL)
+		}
+	}
+	if c.Enabled() {
+		if c.SharedSecret == "" {
+			return errors.New("a shared secret is required when peers are configured")
+		}
+		if c.RequestTimeout <= 0 {
+			return fmt.Errorf("peer request timeout must be positive, got %s", c.RequestTimeout)
+		}
+		if c.MaxValueSizeBytes <= 0 {
+			return fmt.Errorf("max value size must be positive, got %d", c.MaxValueSizeBytes)
+		}
+	}
+	return nil
+}
+
+// Store is a read-only secondary store that reads values from the caches of another proxy, through the route
+// served under CacheRoutePrefix. Values read from a peer are not trusted, and must be verified by the caller.
+// Methods on this struct are goroutine safe.
+type Store struct {
+	baseURL    string
+	cfg        Config
+	httpClient *http.Client
+}
+
+// NewStores creates one Store per peer URL in the config.
+func NewStores(cfg Config) ([]*Store, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("check peer config: %w", err)
+	}
+
+	httpClient := &http.Client{Timeout: cfg.RequestTimeout}
+	stores := make([]*Store, 0, len(cfg.URLs))
+	for _, peerURL := range cfg.URLs {
+		stores = append(stores, &Store{
+			baseURL:    strings.TrimSuffix(peerURL, "/"),
+			cfg:        cfg,
+			httpClient: httpClient,
+		})
+	}
+	return stores, nil
+}
+
+// Get reads the value stored under key in the caches of the peer, or nil if the peer doesn't have it.
+func (s *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
+	request, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, s.baseURL+CacheRoutePrefix+hex.EncodeToString(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+	request.Header.Set("Authorization", AuthorizationHeaderPrefix+s.cfg.SharedSecret)
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("request peer %s: %w", s.baseURL, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("peer %s returned status %d", s.baseURL, response.StatusCode)
+	}
+
+	// read one more byte than allowed to detect oversized values
+	value, err := io.ReadAll(io.LimitReader(response.Body, s.cfg.MaxValueSizeBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read response from peer %s: %w", s.baseURL, err)
+	}
+	if int64(len(value)) > s.cfg.MaxValueSizeBytes {
+		return nil, fmt.Errorf("peer %s returned a value larger than %d bytes", s.baseURL, s.cfg.MaxValueSizeBytes)
+	}
+	return value, nil
+}
+
+// Put always fails, see ErrReadOnly.
+func (s *Store) Put(context.Context, []byte, []byte) error {
+	return ErrReadOnly
+}
+
+// Delete always fails, see ErrReadOnly.
+func (s *Store) Delete(context.Context, []byte) error {
+	return ErrReadOnly
+}
+
+// Verify is a no-op. Keys are hashes of certs, so values read from peers are verified against the cert by the
+// caller instead.
+func (s *Store) Verify(context.Context, []byte, []byte) error {
+	return nil
+}
+
+func (s *Store) BackendType() common.BackendType {
+	return common.PeerBackendType
+}
@@ -0,0 +1,114 @@
+package peer
+
+import (
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPreimage = "Four score and seven years ago"
+	testSecret   = "shared secret"
+)
+
+// newTestPeer starts a peer serving values from the given map, and returns a store reading from it.
+func newTestPeer(t *testing.T, values map[string][]byte, status int) *Store {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != AuthorizationHeaderPrefix+testSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		value, ok := values[strings.TrimPrefix(r.URL.Path, CacheRoutePrefix)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(value)
+	}))
+	t.Cleanup(server.Close)
+
+	stores, err := NewStores(Config{
+		URLs:              []string{server.URL + "/"},
+		SharedSecret:      testSecret,
+		RequestTimeout:    time.Second,
+		MaxValueSizeBytes: int64(len(testPreimage)),
+	})
+	require.NoError(t, err)
+	require.Len(t, stores, 1)
+	return stores[0]
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	key := crypto.Keccak256([]byte("bland"))
+	values := map[string][]byte{hex.EncodeToString(key): []byte(testPreimage)}
+
+	t.Run("hit", func(t *testing.T) {
+		value, err := newTestPeer(t, values, http.StatusOK).Get(t.Context(), key)
+		require.NoError(t, err)
+		require.Equal(t, []byte(testPreimage), value)
+	})
+
+	t.Run("miss", func(t *testing.T) {
+		value, err := newTestPeer(t, values, http.StatusOK).Get(t.Context(), crypto.Keccak256([]byte("other")))
+		require.NoError(t, err)
+		require.Nil(t, value)
+	})
+
+	t.Run("peer error", func(t *testing.T) {
+		_, err := newTestPeer(t, values, http.StatusUnauthorized).Get(t.Context(), key)
+		require.ErrorContains(t, err, "401")
+	})
+
+	t.Run("oversized value", func(t *testing.T) {
+		oversized := map[string][]byte{hex.EncodeToString(key): []byte(testPreimage + "!")}
+		_, err := newTestPeer(t, oversized, http.StatusOK).Get(t.Context(), key)
+		require.ErrorContains(t, err, "larger than")
+	})
+}
+
+func TestWritesAreUnsupported(t *testing.T) {
+	t.Parallel()
+
+	store := newTestPeer(t, nil, http.StatusOK)
+	err := store.Put(t.Context(), crypto.Keccak256([]byte("bland")), []byte(testPreimage))
+	require.ErrorIs(t, err, ErrReadOnly)
+	err = store.Delete(t.Context(), crypto.Keccak256([]byte("bland")))
+	require.ErrorIs(t, err, ErrReadOnly)
+}
+
+func TestConfigCheck(t *testing.T) {
+	t.Parallel()
+
+	valid := Config{
+		URLs:              []string{"http://proxy-1:3100", "https://proxy-2"},
+		SharedSecret:      testSecret,
+		RequestTimeout:    time.Second,
+		MaxValueSizeBytes: 1024,
+	}
+	require.NoError(t, valid.Check())
+	require.NoError(t, Config{}.Check(), "peering disabled")
+
+	noSecret := valid
+	noSecret.SharedSecret = ""
+	require.Error(t, noSecret.Check())
+
+	relativeURL := valid
+	relativeURL.URLs = []string{"proxy-1:3100"}
+	require.Error(t, relativeURL.Check())
+
+	noTimeout := valid
+	noTimeout.RequestTimeout = 0
+	require.Error(t, noTimeout.Check())
+}
@@ -138,6 +138,20 @@ func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
 	return nil
 }
 
+// Delete removes the object of the given key. S3 doesn't return an error when deleting a missing object.
+func (s *Store) Delete(ctx context.Context, key []byte) error {
+	err := s.client.RemoveObject(
+		ctx,
+		s.cfg.Bucket,
+		path.Join(s.cfg.Path, hex.EncodeToString(key)),
+		minio.RemoveObjectOptions{},
+	)
+	if err != nil {
+		return fmt.Errorf("S3 Delete: %w", err)
+	}
+	return nil
+}
+
 // TODO: this should probably live elsewhere, it's related to op keccak commitments, not to S3.
 func (s *Store) Verify(_ context.Context, key []byte, value []byte) error {
 	keccakedValue := crypto.Keccak256Hash(value)
@@ -2,6 +2,7 @@ package secondary
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
@@ -28,12 +29,25 @@ type ISecondary interface {
 	Topic() chan<- PutNotify
 	CachingEnabled() bool
 	FallbackEnabled() bool
+	PeeringEnabled() bool
 	HandleRedundantWrites(ctx context.Context, commitment []byte, value []byte) error
 	// verify fn signature has to match that of common/store.go's GeneratedKeyStore.Verify fn.
 	MultiSourceRead(
 		ctx context.Context, commitment []byte, fallback bool,
 		verifyPayload func(context.Context, []byte, []byte) error,
 	) ([]byte, error)
+	// PeerRead is the same as MultiSourceRead, but reads from the caches of peer proxies.
+	PeerRead(
+		ctx context.Context, commitment []byte,
+		verifyPayload func(context.Context, []byte, []byte) error,
+	) ([]byte, error)
+	// CacheReadByKey reads the value stored under a key in the caches, without verifying it.
+	// It serves reads from peer proxies, which verify the value themselves.
+	CacheReadByKey(ctx context.Context, key []byte) ([]byte, error)
+	// InspectEntry reports whether each cache and fallback holds the value stored for a commitment.
+	InspectEntry(ctx context.Context, commitment []byte) []EntryStatus
+	// PurgeEntry deletes the value stored for a commitment from all caches and fallbacks.
+	PurgeEntry(ctx context.Context, commitment []byte) error
 	WriteSubscriptionLoop(ctx context.Context)
 	WriteOnCacheMissEnabled() bool
 	ErrorOnInsertFailure() bool
@@ -46,6 +60,28 @@ type PutNotify struct {
 	Value      []byte
 }
 
+// Role is the role of a secondary storage backend, which determines when it is read from.
+type Role string
+
+const (
+	CacheRole    Role = "cache"
+	FallbackRole Role = "fallback"
+)
+
+// EntryStatus describes the entry stored for a commitment in a single secondary storage backend.
+type EntryStatus struct {
+	Backend common.BackendType
+	Role    Role
+	// Key is the key of the entry, i.e. the keccak256 hash of the commitment.
+	Key []byte
+	// Present is true if the backend holds a value for Key.
+	Present bool
+	// SizeBytes is the size of the value, if present.
+	SizeBytes int
+	// Err is set if the backend could not be read from, in which case Present is false.
+	Err error
+}
+
 // SecondaryManager ... routing abstraction for secondary storage backends
 type SecondaryManager struct {
 	log logging.Logger
@@ -53,6 +89,9 @@ type SecondaryManager struct {
 
 	caches    []common.SecondaryStore
 	fallbacks []common.SecondaryStore
+	// peers are read-only stores backed by the caches of other proxies, see the peer package.
+	// They are read after the caches, but are never written to.
+	peers []common.SecondaryStore
 
 	verifyLock           sync.RWMutex
 	topic                chan PutNotify
@@ -67,6 +106,7 @@ func NewSecondaryManager(
 	m metrics.Metricer,
 	caches []common.SecondaryStore,
 	fallbacks []common.SecondaryStore,
+	peers []common.SecondaryStore,
 	writeOnCacheMiss bool,
 	errorOnInsertFailure bool,
 ) ISecondary {
@@ -78,6 +118,7 @@ func NewSecondaryManager(
 		m:                    m,
 		caches:               caches,
 		fallbacks:            fallbacks,
+		peers:                peers,
 		verifyLock:           sync.RWMutex{},
 		writeOnCacheMiss:     writeOnCacheMiss,
 		errorOnInsertFailure: errorOnInsertFailure,
@@ -101,6 +142,10 @@ func (sm *SecondaryManager) FallbackEnabled() bool {
 	return len(sm.fallbacks) > 0
 }
 
+func (sm *SecondaryManager) PeeringEnabled() bool {
+	return len(sm.peers) > 0
+}
+
 func (sm *SecondaryManager) WriteOnCacheMissEnabled() bool {
 	return sm.CachingEnabled() && sm.writeOnCacheMiss
 }
@@ -198,13 +243,102 @@ func (sm *SecondaryManager) MultiSourceRead(
 	fallback bool,
 	verifyPayload func(context.Context, []byte, []byte) error,
 ) ([]byte, error) {
-	var sources []common.SecondaryStore
 	if fallback {
-		sources = sm.fallbacks
-	} else {
-		sources = sm.caches
+		return sm.multiSourceRead(ctx, sm.fallbacks, commitment, verifyPayload)
+	}
+	return sm.multiSourceRead(ctx, sm.caches, commitment, verifyPayload)
+}
+
+// PeerRead ... reads from the caches of peer proxies and returns the first successfully read and verified blob.
+// Peers are not trusted, so verifyPayload must check the payload against the cert.
+func (sm *SecondaryManager) PeerRead(
+	ctx context.Context,
+	commitment []byte,
+	verifyPayload func(context.Context, []byte, []byte) error,
+) ([]byte, error) {
+	return sm.multiSourceRead(ctx, sm.peers, commitment, verifyPayload)
+}
+
+// CacheReadByKey ... returns the first value found under key in the caches, or nil if no cache holds it.
+// Peers are never read from, such that a read from a peer can't be forwarded to yet another proxy.
+func (sm *SecondaryManager) CacheReadByKey(ctx context.Context, key []byte) ([]byte, error) {
+	var errs []error
+	for _, src := range sm.caches {
+		cb := sm.m.RecordSecondaryRequest(src.BackendType().String(), http.MethodGet)
+		data, err := src.Get(ctx, key)
+		if err != nil {
+			cb(Failed)
+			errs = append(errs, fmt.Errorf("read from %s: %w", src.BackendType(), err))
+			continue
+		}
+		if data == nil {
+			cb(Miss)
+			continue
+		}
+		cb(Success)
+		return data, nil
+	}
+
+	if len(errs) > 0 && len(errs) == len(sm.caches) {
+		return nil, fmt.Errorf("failed to read from all caches: %w", errors.Join(errs...))
 	}
+	return nil, nil
+}
 
+// InspectEntry ... reads the entry of the commitment from every cache and fallback, in that order.
+// Values are not verified, and peers are not inspected.
+func (sm *SecondaryManager) InspectEntry(ctx context.Context, commitment []byte) []EntryStatus {
+	key := crypto.Keccak256(commitment)
+	statuses := make([]EntryStatus, 0, len(sm.caches)+len(sm.fallbacks))
+	inspect := func(sources []common.SecondaryStore, role Role) {
+		for _, src := range sources {
+			status := EntryStatus{Backend: src.BackendType(), Role: role, Key: key}
+			data, err := src.Get(ctx, key)
+			switch {
+			case err != nil:
+				status.Err = err
+			case data != nil:
+				status.Present = true
+				status.SizeBytes = len(data)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	inspect(sm.caches, CacheRole)
+	inspect(sm.fallbacks, FallbackRole)
+	return statuses
+}
+
+// PurgeEntry ... deletes the entry of the commitment from every cache and fallback.
+// All backends are attempted, and the errors of those that failed are joined.
+func (sm *SecondaryManager) PurgeEntry(ctx context.Context, commitment []byte) error {
+	sources := append([]common.SecondaryStore{}, sm.caches...)
+	sources = append(sources, sm.fallbacks...)
+
+	key := crypto.Keccak256(commitment)
+	var errs []error
+	for _, src := range sources {
+		cb := sm.m.RecordSecondaryRequest(src.BackendType().String(), http.MethodDelete)
+		err := src.Delete(ctx, key)
+		if err != nil {
+			cb(Failed)
+			sm.log.Warn("Failed to purge entry from redundant target", "backend", src.BackendType(), "err", err)
+			errs = append(errs, fmt.Errorf("delete from %s: %w", src.BackendType(), err))
+			continue
+		}
+		cb(Success)
+		sm.log.Info("Purged entry from redundant target", "backend", src.BackendType(), "key", hex.EncodeToString(key))
+	}
+	return errors.Join(errs...)
+}
+
+// multiSourceRead implements MultiSourceRead and PeerRead over the given sources.
+func (sm *SecondaryManager) multiSourceRead(
+	ctx context.Context,
+	sources []common.SecondaryStore,
+	commitment []byte,
+	verifyPayload func(context.Context, []byte, []byte) error,
+) ([]byte, error) {
 	key := crypto.Keccak256(commitment)
 	for _, src := range sources {
 		cb := sm.m.RecordSecondaryRequest(src.BackendType().String(), http.MethodGet)
@@ -0,0 +1,158 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+)
+
+// This file contains the operations backing the admin cache routes, which let operators inspect and repair
+// the entries stored for a cert in the secondary storage backends (caches and fallbacks).
+//
+// Secondary storages hold one entry per blob. The entries of a chunked payload manifest are thus the entries of
+// its chunks, and every operation below applies to each of them.
+
+// InspectCacheEntry reports whether each cache and fallback holds the payload of the cert.
+func (m *EigenDAManager) InspectCacheEntry(
+	ctx context.Context, versionedCert *certs.VersionedCert,
+) ([]secondary.EntryStatus, error) {
+	entryCerts, err := m.secondaryEntryCerts(versionedCert)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []secondary.EntryStatus
+	for _, entryCert := range entryCerts {
+		statuses = append(statuses, m.secondary.InspectEntry(ctx, entryCert.SerializedCert)...)
+	}
+	return statuses, nil
+}
+
+// PurgeCacheEntry deletes the payload of the cert from all caches and fallbacks.
+func (m *EigenDAManager) PurgeCacheEntry(ctx context.Context, versionedCert *certs.VersionedCert) error {
+	entryCerts, err := m.secondaryEntryCerts(versionedCert)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, entryCert := range entryCerts {
+		err := m.secondary.PurgeEntry(ctx, entryCert.SerializedCert)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("purge secondary entries: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// RefetchCacheEntry retrieves the payload of the cert from EigenDA, bypassing the secondary storages and peers,
+// and replaces the entries of all caches and fallbacks with it. This repairs entries holding a bad payload.
+func (m *EigenDAManager) RefetchCacheEntry(
+	ctx context.Context, versionedCert *certs.VersionedCert, serializationType coretypes.CertSerializationType,
+) error {
+	entryCerts, err := m.secondaryEntryCerts(versionedCert)
+	if err != nil {
+		return err
+	}
+
+	for _, entryCert := range entryCerts {
+		err := m.eigendaV2.VerifyCert(ctx, entryCert, serializationType, 0)
+		if err != nil {
+			return fmt.Errorf("verify EigenDACert: %w", err)
+		}
+		payload, err := m.eigendaV2.Get(ctx, entryCert, serializationType, false)
+		if err != nil {
+			return fmt.Errorf("read from EigenDA backend: %w", err)
+		}
+
+		// Entries are purged first since some backends (e.g. LittDB) don't overwrite existing entries.
+		err = m.secondary.PurgeEntry(ctx, entryCert.SerializedCert)
+		if err != nil {
+			return fmt.Errorf("purge secondary entries: %w", err)
+		}
+		err = m.secondary.HandleRedundantWrites(ctx, entryCert.SerializedCert, payload)
+		if err != nil {
+			return fmt.Errorf("write secondary entries: %w", err)
+		}
+	}
+	return nil
+}
+
+// WarmCacheEntry makes sure that all caches and fallbacks hold the payload of the cert. Entries that are missing
+// are filled with the payload read through the regular GET path (caches, peers, EigenDA, then fallbacks).
+func (m *EigenDAManager) WarmCacheEntry(
+	ctx context.Context, versionedCert *certs.VersionedCert, serializationType coretypes.CertSerializationType,
+) error {
+	entryCerts, err := m.secondaryEntryCerts(versionedCert)
+	if err != nil {
+		return err
+	}
+
+	for _, entryCert := range entryCerts {
+		if allPresent(m.secondary.InspectEntry(ctx, entryCert.SerializedCert)) {
+			continue
+		}
+
+		payload, err := m.getEigenDAV2(ctx, entryCert, serializationType, common.GETOpts{})
+		if err != nil {
+			return err
+		}
+		err = m.secondary.HandleRedundantWrites(ctx, entryCert.SerializedCert, payload)
+		if err != nil {
+			return fmt.Errorf("write secondary entries: %w", err)
+		}
+	}
+	return nil
+}
+
+// secondaryEntryCerts returns the certs under which the payload of versionedCert is stored in secondary storages.
+func (m *EigenDAManager) secondaryEntryCerts(versionedCert *certs.VersionedCert) ([]*certs.VersionedCert, error) {
+	if !m.secondary.Enabled() {
+		return nil, proxyerrors.ErrNoSecondaryStorage
+	}
+
+	switch versionedCert.Version {
+	case certs.V0VersionByte:
+		return nil, errors.New("V1 backend has been removed, V0 certs are no longer supported")
+	case certs.V1VersionByte, certs.V2VersionByte, certs.V3VersionByte:
+		if m.eigendaV2 == nil {
+			return nil, errors.New("received EigenDAV2 cert but EigenDA V2 client is not initialized")
+		}
+		return []*certs.VersionedCert{versionedCert}, nil
+	case certs.ManifestVersionByte:
+		if m.eigendaV2 == nil {
+			return nil, errors.New("received chunked payload manifest but EigenDA V2 client is not initialized")
+		}
+		manifest, err := certs.DeserializeChunkManifest(versionedCert.SerializedCert)
+		if err != nil {
+			return nil, coretypes.NewCertParsingFailedError(
+				fmt.Sprintf("%x", versionedCert.SerializedCert), fmt.Sprintf("deserialize chunk manifest: %v", err))
+		}
+		entryCerts := make([]*certs.VersionedCert, 0, len(manifest.Chunks))
+		for i := range manifest.Chunks {
+			entryCerts = append(entryCerts, &manifest.Chunks[i].Cert)
+		}
+		return entryCerts, nil
+	default:
+		return nil, fmt.Errorf("cert version unknown: %b", versionedCert.Version)
+	}
+}
+
+// allPresent returns true if every backend holds the entry.
+func allPresent(statuses []secondary.EntryStatus) bool {
+	for _, status := range statuses {
+		if !status.Present {
+			return false
+		}
+	}
+	return true
+}
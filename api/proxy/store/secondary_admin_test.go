@@ -0,0 +1,120 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/proxyerrors"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/api/proxy/metrics"
+	"github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheEntryAdmin(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte("some payload")
+
+	setup := func(t *testing.T, chunking PayloadChunkingConfig) (
+		*EigenDAManager, *fakeEigenDAV2Store, *fakeSecondaryStore, *fakeSecondaryStore,
+	) {
+		v2Store := newFakeEigenDAV2Store()
+		cache := newFakeSecondaryStore(common.FilesystemBackendType)
+		fallback := newFakeSecondaryStore(common.S3BackendType)
+		secondaryMgr := secondary.NewSecondaryManager(testLogger, metrics.NoopMetrics,
+			[]common.SecondaryStore{cache}, []common.SecondaryStore{fallback}, nil, false, false)
+		manager, err := NewEigenDAManager(v2Store, testLogger, secondaryMgr, common.V2EigenDABackend, chunking)
+		require.NoError(t, err)
+		return manager, v2Store, cache, fallback
+	}
+
+	t.Run("inspect and purge", func(t *testing.T) {
+		manager, _, cache, fallback := setup(t, PayloadChunkingConfig{})
+		versionedCert, err := manager.Put(ctx, payload, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		key := crypto.Keccak256(versionedCert.SerializedCert)
+
+		statuses, err := manager.InspectCacheEntry(ctx, versionedCert)
+		require.NoError(t, err)
+		require.Equal(t, []secondary.EntryStatus{
+			{Backend: common.FilesystemBackendType, Role: secondary.CacheRole, Key: key, Present: true,
+				SizeBytes: len(payload)},
+			{Backend: common.S3BackendType, Role: secondary.FallbackRole, Key: key, Present: true,
+				SizeBytes: len(payload)},
+		}, statuses)
+
+		err = manager.PurgeCacheEntry(ctx, versionedCert)
+		require.NoError(t, err)
+		require.Empty(t, cache.values)
+		require.Empty(t, fallback.values)
+
+		statuses, err = manager.InspectCacheEntry(ctx, versionedCert)
+		require.NoError(t, err)
+		require.False(t, statuses[0].Present)
+		require.False(t, statuses[1].Present)
+	})
+
+	t.Run("refetch replaces bad entries", func(t *testing.T) {
+		manager, v2Store, cache, fallback := setup(t, PayloadChunkingConfig{})
+		versionedCert, err := manager.Put(ctx, payload, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		key := string(crypto.Keccak256(versionedCert.SerializedCert))
+		cache.values[key] = []byte("bad payload")
+
+		err = manager.RefetchCacheEntry(ctx, versionedCert, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		require.Equal(t, 1, v2Store.gets, "EigenDA should be read from")
+		require.Equal(t, payload, cache.values[key])
+		require.Equal(t, payload, fallback.values[key])
+	})
+
+	t.Run("warm up fills missing entries", func(t *testing.T) {
+		manager, v2Store, cache, fallback := setup(t, PayloadChunkingConfig{})
+		versionedCert, err := manager.Put(ctx, payload, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		key := string(crypto.Keccak256(versionedCert.SerializedCert))
+
+		// all entries present, nothing to do
+		err = manager.WarmCacheEntry(ctx, versionedCert, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		require.Zero(t, v2Store.gets)
+
+		// the cache entry is missing, and is filled from EigenDA
+		delete(cache.values, key)
+		err = manager.WarmCacheEntry(ctx, versionedCert, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		require.Equal(t, 1, v2Store.gets)
+		require.Equal(t, payload, cache.values[key])
+		require.Equal(t, payload, fallback.values[key])
+	})
+
+	t.Run("chunked payloads operate on their chunks", func(t *testing.T) {
+		manager, _, cache, _ := setup(t, PayloadChunkingConfig{MaxChunkSizeBytes: 5, MaxChunks: 4})
+		versionedCert, err := manager.Put(ctx, payload, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+		require.Equal(t, certs.ManifestVersionByte, versionedCert.Version)
+
+		statuses, err := manager.InspectCacheEntry(ctx, versionedCert)
+		require.NoError(t, err)
+		require.Len(t, statuses, 3*2, "one entry per chunk and backend")
+		require.True(t, allPresent(statuses))
+
+		err = manager.PurgeCacheEntry(ctx, versionedCert)
+		require.NoError(t, err)
+		require.Empty(t, cache.values)
+	})
+
+	t.Run("no secondary storage", func(t *testing.T) {
+		manager, v2Store := newTestChunkingManager(t, PayloadChunkingConfig{})
+		versionedCert, err := v2Store.Put(ctx, payload, coretypes.CertSerializationRLP)
+		require.NoError(t, err)
+
+		_, err = manager.InspectCacheEntry(ctx, versionedCert)
+		require.ErrorIs(t, err, proxyerrors.ErrNoSecondaryStorage)
+		err = manager.RefetchCacheEntry(ctx, versionedCert, coretypes.CertSerializationRLP)
+		require.ErrorIs(t, err, proxyerrors.ErrNoSecondaryStorage)
+	})
+}
@@ -16,6 +16,7 @@ import (
 	coretypes "github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	common "github.com/Layr-Labs/eigenda/api/proxy/common"
 	certs "github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	secondary "github.com/Layr-Labs/eigenda/api/proxy/store/secondary"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -58,6 +59,21 @@ func (mr *MockIEigenDAManagerMockRecorder) Get(ctx, versionedCert, serialization
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIEigenDAManager)(nil).Get), ctx, versionedCert, serializationType, opts)
 }
 
+// GetCachedPayload mocks base method.
+func (m *MockIEigenDAManager) GetCachedPayload(ctx context.Context, key []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedPayload", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedPayload indicates an expected call of GetCachedPayload.
+func (mr *MockIEigenDAManagerMockRecorder) GetCachedPayload(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedPayload", reflect.TypeOf((*MockIEigenDAManager)(nil).GetCachedPayload), ctx, key)
+}
+
 // GetDispersalBackend mocks base method.
 func (m *MockIEigenDAManager) GetDispersalBackend() common.EigenDABackend {
 	m.ctrl.T.Helper()
@@ -72,6 +88,35 @@ func (mr *MockIEigenDAManagerMockRecorder) GetDispersalBackend() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispersalBackend", reflect.TypeOf((*MockIEigenDAManager)(nil).GetDispersalBackend))
 }
 
+// InspectCacheEntry mocks base method.
+func (m *MockIEigenDAManager) InspectCacheEntry(ctx context.Context, versionedCert *certs.VersionedCert) ([]secondary.EntryStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectCacheEntry", ctx, versionedCert)
+	ret0, _ := ret[0].([]secondary.EntryStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectCacheEntry indicates an expected call of InspectCacheEntry.
+func (mr *MockIEigenDAManagerMockRecorder) InspectCacheEntry(ctx, versionedCert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectCacheEntry", reflect.TypeOf((*MockIEigenDAManager)(nil).InspectCacheEntry), ctx, versionedCert)
+}
+
+// PurgeCacheEntry mocks base method.
+func (m *MockIEigenDAManager) PurgeCacheEntry(ctx context.Context, versionedCert *certs.VersionedCert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeCacheEntry", ctx, versionedCert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeCacheEntry indicates an expected call of PurgeCacheEntry.
+func (mr *MockIEigenDAManagerMockRecorder) PurgeCacheEntry(ctx, versionedCert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeCacheEntry", reflect.TypeOf((*MockIEigenDAManager)(nil).PurgeCacheEntry), ctx, versionedCert)
+}
+
 // Put mocks base method.
 func (m *MockIEigenDAManager) Put(ctx context.Context, value []byte, serializationType coretypes.CertSerializationType) (*certs.VersionedCert, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +132,20 @@ func (mr *MockIEigenDAManagerMockRecorder) Put(ctx, value, serializationType any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockIEigenDAManager)(nil).Put), ctx, value, serializationType)
 }
 
+// RefetchCacheEntry mocks base method.
+func (m *MockIEigenDAManager) RefetchCacheEntry(ctx context.Context, versionedCert *certs.VersionedCert, serializationType coretypes.CertSerializationType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefetchCacheEntry", ctx, versionedCert, serializationType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefetchCacheEntry indicates an expected call of RefetchCacheEntry.
+func (mr *MockIEigenDAManagerMockRecorder) RefetchCacheEntry(ctx, versionedCert, serializationType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefetchCacheEntry", reflect.TypeOf((*MockIEigenDAManager)(nil).RefetchCacheEntry), ctx, versionedCert, serializationType)
+}
+
 // SetDispersalBackend mocks base method.
 func (m *MockIEigenDAManager) SetDispersalBackend(backend common.EigenDABackend) {
 	m.ctrl.T.Helper()
@@ -98,3 +157,17 @@ func (mr *MockIEigenDAManagerMockRecorder) SetDispersalBackend(backend any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDispersalBackend", reflect.TypeOf((*MockIEigenDAManager)(nil).SetDispersalBackend), backend)
 }
+
+// WarmCacheEntry mocks base method.
+func (m *MockIEigenDAManager) WarmCacheEntry(ctx context.Context, versionedCert *certs.VersionedCert, serializationType coretypes.CertSerializationType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WarmCacheEntry", ctx, versionedCert, serializationType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WarmCacheEntry indicates an expected call of WarmCacheEntry.
+func (mr *MockIEigenDAManagerMockRecorder) WarmCacheEntry(ctx, versionedCert, serializationType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WarmCacheEntry", reflect.TypeOf((*MockIEigenDAManager)(nil).WarmCacheEntry), ctx, versionedCert, serializationType)
+}
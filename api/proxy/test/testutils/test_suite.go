@@ -10,6 +10,8 @@ import (
 	proxy_metrics "github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/arbitrum_altda"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/dispersaljobs"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/generated_key/memstore/memconfig"
 	common_eigenda "github.com/Layr-Labs/eigenda/common"
@@ -127,7 +129,15 @@ func CreateTestSuite(
 	//       & simplify where possible.
 	if appConfig.EnabledServersConfig.RestAPIConfig.DAEndpointEnabled() {
 		appConfig.RestSvrCfg.CompatibilityCfg = compatibilityCfg
-		restServer = rest.NewServer(appConfig.RestSvrCfg, certMgr, keccakMgr, logger, metrics)
+		var jobMgr *dispersaljobs.Manager
+		if appConfig.RestSvrCfg.AsyncDispersal.Enabled {
+			jobMgr, err = dispersaljobs.NewManager(ctx, logger, certMgr, appConfig.RestSvrCfg.AsyncDispersal)
+			if err != nil {
+				panic(fmt.Sprintf("new dispersal job manager: %v", err.Error()))
+			}
+		}
+		restServer = rest.NewServer(appConfig.RestSvrCfg, certMgr, keccakMgr, jobMgr,
+			middleware.NewAuthenticator(appConfig.RestSvrCfg.Auth), logger, metrics)
 		router := mux.NewRouter()
 		restServer.RegisterRoutes(router)
 		if appConfig.StoreBuilderConfig.MemstoreEnabled {
@@ -18,6 +18,11 @@ type FIFOCache[K comparable, V any] struct {
 	data          map[K]V
 	evictionQueue *structures.Queue[*insertionRecord]
 	metrics       *CacheMetrics
+
+	// The insertion record of each key currently in the cache.
+	records map[K]*insertionRecord
+	// The number of records in the eviction queue whose keys have been removed from the cache.
+	staleRecords int
 }
 
 // insertionRecord is a record of when a key was inserted into the cache, and is used to decide when it should be
@@ -27,6 +32,8 @@ type insertionRecord struct {
 	key any
 	// The time at which the key was added to the cache.
 	timestamp time.Time
+	// Set to true if the key was removed from the cache. Stale records are skipped during eviction.
+	stale bool
 }
 
 // NewFIFOCache creates a new FIFOCache. If the calculator is nil, the weight of each key-value pair will be 1.
@@ -45,6 +52,7 @@ func NewFIFOCache[K comparable, V any](
 		weightCalculator: calculator,
 		evictionQueue:    structures.NewQueue[*insertionRecord](1024),
 		metrics:          metrics,
+		records:          make(map[K]*insertionRecord),
 	}
 }
 
@@ -67,10 +75,12 @@ func (f *FIFOCache[K, V]) Put(key K, value V) {
 		oldWeight := f.weightCalculator(key, old)
 		f.currentWeight -= oldWeight
 	} else {
-		f.evictionQueue.Push(&insertionRecord{
+		record := &insertionRecord{
 			key:       key,
 			timestamp: time.Now(),
-		})
+		}
+		f.records[key] = record
+		f.evictionQueue.Push(record)
 	}
 
 	if f.currentWeight > f.maxWeight {
@@ -81,14 +91,55 @@ func (f *FIFOCache[K, V]) Put(key K, value V) {
 	f.metrics.reportCurrentSize(len(f.data), f.currentWeight)
 }
 
+func (f *FIFOCache[K, V]) Remove(key K) {
+	old, ok := f.data[key]
+	if !ok {
+		return
+	}
+
+	delete(f.data, key)
+	f.currentWeight -= f.weightCalculator(key, old)
+
+	// The insertion record is left in the eviction queue and skipped when it is popped. If the key is inserted
+	// again, it gets a new record, so the stale record can't evict the new entry early.
+	f.records[key].stale = true
+	delete(f.records, key)
+	f.staleRecords++
+	if f.staleRecords > len(f.data) {
+		f.compactEvictionQueue()
+	}
+
+	f.metrics.reportCurrentSize(len(f.data), f.currentWeight)
+}
+
+// compactEvictionQueue drops stale records from the eviction queue, so that the queue does not grow without bound
+// when keys are repeatedly inserted and removed.
+func (f *FIFOCache[K, V]) compactEvictionQueue() {
+	compacted := structures.NewQueue[*insertionRecord](uint64(max(len(f.data), 1024)))
+	for _, record := range f.evictionQueue.Iterator() {
+		if !record.stale {
+			compacted.Push(record)
+		}
+	}
+	f.evictionQueue = compacted
+	f.staleRecords = 0
+}
+
 func (f *FIFOCache[K, V]) evict() {
 	now := time.Now()
 
 	for f.currentWeight > f.maxWeight {
 		next := f.evictionQueue.Pop()
+		if next.stale {
+			// This key was removed from the cache after it was inserted.
+			f.staleRecords--
+			continue
+		}
 		keyToEvict := next.key.(K)
-		weightToEvict := f.weightCalculator(keyToEvict, f.data[keyToEvict])
+		valueToEvict := f.data[keyToEvict]
+		weightToEvict := f.weightCalculator(keyToEvict, valueToEvict)
 		delete(f.data, keyToEvict)
+		delete(f.records, keyToEvict)
 		f.currentWeight -= weightToEvict
 		f.metrics.reportEviction(now.Sub(next.timestamp))
 	}
@@ -135,3 +135,98 @@ func TestWeightedValues(t *testing.T) {
 		require.Equal(t, v, value)
 	}
 }
+
+func TestRemove(t *testing.T) {
+	random.InitializeRandom()
+
+	maxWeight := uint64(10 + rand.Intn(10))
+	c := NewFIFOCache[int, int](maxWeight, nil, nil)
+
+	// Fill up the cache.
+	for i := 1; i <= int(maxWeight); i++ {
+		c.Put(i, i)
+	}
+	require.Equal(t, maxWeight, c.Weight())
+
+	// Removing a key that is not present should have no effect.
+	c.Remove(-1)
+	require.Equal(t, maxWeight, c.Weight())
+	require.Equal(t, int(maxWeight), c.Size())
+
+	// Remove the oldest key.
+	c.Remove(1)
+	_, ok := c.Get(1)
+	require.False(t, ok)
+	require.Equal(t, maxWeight-1, c.Weight())
+	require.Equal(t, int(maxWeight)-1, c.Size())
+
+	// There is room for one more value without evicting anything.
+	c.Put(-1, -1)
+	require.Equal(t, maxWeight, c.Weight())
+	for i := 2; i <= int(maxWeight); i++ {
+		value, ok := c.Get(i)
+		require.True(t, ok)
+		require.Equal(t, i, value)
+	}
+
+	// The next insertion should evict key 2. The insertion record for the removed key is skipped.
+	c.Put(-2, -2)
+	_, ok = c.Get(2)
+	require.False(t, ok)
+	require.Equal(t, maxWeight, c.Weight())
+	require.Equal(t, int(maxWeight), c.Size())
+}
+
+func TestRemoveAndReinsert(t *testing.T) {
+	random.InitializeRandom()
+
+	maxWeight := uint64(10 + rand.Intn(10))
+	c := NewFIFOCache[int, int](maxWeight, nil, nil)
+
+	// Fill up the cache.
+	for i := 1; i <= int(maxWeight); i++ {
+		c.Put(i, i)
+	}
+
+	// Remove the oldest key and insert it again. It is now the newest key in the cache.
+	c.Remove(1)
+	c.Put(1, 1)
+	require.Equal(t, maxWeight, c.Weight())
+	require.Equal(t, int(maxWeight), c.Size())
+
+	// The next insertion should evict key 2, not the re-inserted key 1.
+	c.Put(-1, -1)
+	_, ok := c.Get(2)
+	require.False(t, ok)
+	value, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	// Evict every key inserted before key 1. Key 1 should be the next key to be evicted.
+	for i := 2; i < int(maxWeight); i++ {
+		c.Put(-1-i, -1-i)
+	}
+	_, ok = c.Get(int(maxWeight))
+	require.False(t, ok)
+	_, ok = c.Get(1)
+	require.True(t, ok)
+	c.Put(-1-int(maxWeight), -1-int(maxWeight))
+	_, ok = c.Get(1)
+	require.False(t, ok)
+	require.Equal(t, maxWeight, c.Weight())
+	require.Equal(t, int(maxWeight), c.Size())
+}
+
+func TestRemoveChurnDoesNotGrowEvictionQueue(t *testing.T) {
+	random.InitializeRandom()
+
+	maxWeight := uint64(10 + rand.Intn(10))
+	c := NewFIFOCache[int, int](maxWeight, nil, nil)
+
+	for i := 0; i < 10_000; i++ {
+		c.Put(i, i)
+		c.Remove(i)
+	}
+	require.Equal(t, 0, c.Size())
+	require.LessOrEqual(t, c.(*FIFOCache[int, int]).evictionQueue.Size(), uint64(1))
+}
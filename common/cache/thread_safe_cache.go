@@ -29,6 +29,12 @@ func (t *threadSafeCache[K, V]) Put(key K, value V) {
 	t.cache.Put(key, value)
 }
 
+func (t *threadSafeCache[K, V]) Remove(key K) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.cache.Remove(key)
+}
+
 func (t *threadSafeCache[K, V]) Size() int {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
@@ -248,6 +248,17 @@ func (c *BlobCertificate) Hash() ([32]byte, error) {
 		return [32]byte{}, fmt.Errorf("blob header is nil")
 	}
 
+	blobKey, err := c.BlobHeader.BlobKey()
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return ComputeBlobCertificateHash(blobKey, c.Signature, c.RelayKeys)
+}
+
+// ComputeBlobCertificateHash computes the hash of a blob certificate from the key of its blob header, its signature
+// and its relay keys. This matches hashBlobCertificate in EigenDACertVerificationLib.sol.
+func ComputeBlobCertificateHash(blobKey BlobKey, signature []byte, relayKeys []RelayKey) ([32]byte, error) {
 	blobKeyType, err := abi.NewType("bytes32", "", nil)
 	if err != nil {
 		return [32]byte{}, err
@@ -275,12 +286,7 @@ func (c *BlobCertificate) Hash() ([32]byte, error) {
 		},
 	}
 
-	blobKey, err := c.BlobHeader.BlobKey()
-	if err != nil {
-		return [32]byte{}, err
-	}
-
-	bytes, err := arguments.Pack(blobKey, c.Signature, c.RelayKeys)
+	bytes, err := arguments.Pack(blobKey, signature, relayKeys)
 	if err != nil {
 		return [32]byte{}, err
 	}
@@ -34,8 +34,23 @@ func (s *DispersalServerV2) getBlobStatus(
 		s.metrics.reportGetBlobStatusLatency(time.Since(start))
 	}()
 
+	blobKey, st := parseBlobKey(req)
+	if st != nil {
+		return nil, st
+	}
+
+	metadata, st := s.getBlobMetadata(ctx, blobKey)
+	if st != nil {
+		return nil, st
+	}
+
+	return s.buildBlobStatusReply(ctx, blobKey, metadata)
+}
+
+// parseBlobKey validates and parses the blob key of a BlobStatusRequest.
+func parseBlobKey(req *pb.BlobStatusRequest) (corev2.BlobKey, *status.Status) {
 	if req.GetBlobKey() == nil || len(req.GetBlobKey()) != 32 {
-		return nil, status.New(
+		return corev2.BlobKey{}, status.New(
 			codes.InvalidArgument,
 			fmt.Sprintf("blob key must be 32 bytes, got %d bytes", len(req.GetBlobKey())),
 		)
@@ -43,9 +58,16 @@ func (s *DispersalServerV2) getBlobStatus(
 
 	blobKey, err := corev2.BytesToBlobKey(req.GetBlobKey())
 	if err != nil {
-		return nil, status.Newf(codes.InvalidArgument, "invalid blob key: %s", req.GetBlobKey())
+		return corev2.BlobKey{}, status.Newf(codes.InvalidArgument, "invalid blob key: %s", req.GetBlobKey())
 	}
+	return blobKey, nil
+}
 
+// getBlobMetadata fetches the metadata of a blob from the metadata store.
+func (s *DispersalServerV2) getBlobMetadata(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+) (*dispv2.BlobMetadata, *status.Status) {
 	metadata, err := s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
 	if err != nil {
 		if errors.Is(err, blobstore.ErrMetadataNotFound) {
@@ -55,7 +77,16 @@ func (s *DispersalServerV2) getBlobStatus(
 		s.logger.Warn("failed to get blob metadata", "err", err, "blobKey", blobKey.Hex())
 		return nil, status.Newf(codes.Internal, "failed to get blob metadata: %v", err)
 	}
+	return metadata, nil
+}
 
+// buildBlobStatusReply builds the BlobStatusReply of a blob from its metadata. For blobs that are gathering signatures
+// or complete, the signed batch and the blob inclusion info are fetched from the metadata store.
+func (s *DispersalServerV2) buildBlobStatusReply(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	metadata *dispv2.BlobMetadata,
+) (*pb.BlobStatusReply, *status.Status) {
 	// If the blob is not complete or gathering signatures, return the status without the signed batch
 	if metadata.BlobStatus != dispv2.Complete && metadata.BlobStatus != dispv2.GatheringSignatures {
 		return &pb.BlobStatusReply{
@@ -100,6 +100,13 @@ type DispersalServerV2 struct {
 	// a deprecation error. This endpoint is deprecated and will be removed in a future release.
 	disableGetBlobCommitment bool
 
+	// The interval at which SubscribeBlobStatus streams check the metadata store for blob status transitions.
+	blobStatusSubscriptionPollInterval time.Duration
+	// The maximum duration of a SubscribeBlobStatus stream.
+	blobStatusSubscriptionMaxDuration time.Duration
+	// Holds a token for each SubscribeBlobStatus stream being served, and bounds how many are served at once.
+	blobStatusSubscriptions chan struct{}
+
 	// Tracks signing rates for validators. This data is mirrored from the controller's signing rate tracker,
 	// so that external requests can be serviced without involving the controller.
 	signingRateTracker signingrate.SigningRateTracker
@@ -174,6 +181,19 @@ func NewDispersalServerV2(
 
 	logger := _logger.With("component", "DispersalServerV2")
 
+	blobStatusSubscriptionPollInterval := serverConfig.BlobStatusSubscriptionPollInterval
+	if blobStatusSubscriptionPollInterval <= 0 {
+		blobStatusSubscriptionPollInterval = defaultBlobStatusSubscriptionPollInterval
+	}
+	blobStatusSubscriptionMaxDuration := serverConfig.BlobStatusSubscriptionMaxDuration
+	if blobStatusSubscriptionMaxDuration <= 0 {
+		blobStatusSubscriptionMaxDuration = defaultBlobStatusSubscriptionMaxDuration
+	}
+	maxConcurrentBlobStatusSubscriptions := serverConfig.MaxConcurrentBlobStatusSubscriptions
+	if maxConcurrentBlobStatusSubscriptions == 0 {
+		maxConcurrentBlobStatusSubscriptions = defaultMaxConcurrentBlobStatusSubscriptions
+	}
+
 	if controllerClient == nil {
 		return nil, errors.New("controller client is required")
 	}
@@ -205,6 +225,10 @@ func NewDispersalServerV2(
 		listener:                 listener,
 		disableGetBlobCommitment: serverConfig.DisableGetBlobCommitment,
 		signingRateTracker:       signingRateTracker,
+
+		blobStatusSubscriptionPollInterval: blobStatusSubscriptionPollInterval,
+		blobStatusSubscriptionMaxDuration:  blobStatusSubscriptionMaxDuration,
+		blobStatusSubscriptions:            make(chan struct{}, maxConcurrentBlobStatusSubscriptions),
 	}, nil
 }
 
@@ -230,6 +254,9 @@ func (s *DispersalServerV2) Start(ctx context.Context) error {
 	s.grpcServer = grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			s.metrics.grpcMetrics.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			s.metrics.grpcMetrics.StreamServerInterceptor(),
 		), opt, keepAliveConfig)
 	reflection.Register(s.grpcServer)
 	pb.RegisterDisperserServer(s.grpcServer, s)
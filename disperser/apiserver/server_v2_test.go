@@ -44,7 +44,10 @@ import (
 	tmock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -511,6 +514,227 @@ func TestV2GetBlobStatus(t *testing.T) {
 	require.Equal(t, attestationProto, reply.GetSignedBatch().GetAttestation())
 }
 
+// blobStatusStream is a SubscribeBlobStatus server stream that hands the replies sent to it to the test.
+type blobStatusStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies chan *pbv2.BlobStatusReply
+}
+
+func (s *blobStatusStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *blobStatusStream) Send(reply *pbv2.BlobStatusReply) error {
+	s.replies <- reply
+	return nil
+}
+
+func TestV2SubscribeBlobStatus(t *testing.T) {
+	ctx := t.Context()
+	c := newTestServerV2(t)
+	ctx = peer.NewContext(ctx, c.Peer)
+
+	testData := codec.ConvertByPaddingEmptyByte([]byte("test data for blob status subscription"))
+	commitments, err := c.Committer.GetCommitmentsForPaddedLength(testData)
+	require.NoError(t, err)
+
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: commitments,
+		QuorumNumbers:   []core.QuorumID{0},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         gethcommon.HexToAddress("0x1234"),
+			Timestamp:         0,
+			CumulativePayment: big.NewInt(533),
+		},
+	}
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+
+	// unknown blob
+	err = c.DispersalServerV2.SubscribeBlobStatus(
+		&pbv2.BlobStatusRequest{BlobKey: blobKey[:]},
+		&blobStatusStream{ctx: ctx, replies: make(chan *pbv2.BlobStatusReply, 1)})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// invalid blob key
+	err = c.DispersalServerV2.SubscribeBlobStatus(
+		&pbv2.BlobStatusRequest{BlobKey: blobKey[:31]},
+		&blobStatusStream{ctx: ctx, replies: make(chan *pbv2.BlobStatusReply, 1)})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	now := time.Now()
+	metadata := &dispv2.BlobMetadata{
+		BlobHeader: blobHeader,
+		BlobStatus: dispv2.Queued,
+		Expiry:     uint64(now.Add(time.Hour).Unix()),
+		NumRetries: 0,
+		UpdatedAt:  uint64(now.UnixNano()),
+	}
+	err = c.BlobMetadataStore.PutBlobMetadata(ctx, metadata)
+	require.NoError(t, err)
+	blobCert := &corev2.BlobCertificate{
+		BlobHeader: blobHeader,
+		RelayKeys:  []corev2.RelayKey{0, 1, 2},
+	}
+	err = c.BlobMetadataStore.PutBlobCertificate(ctx, blobCert, nil)
+	require.NoError(t, err)
+
+	stream := &blobStatusStream{ctx: ctx, replies: make(chan *pbv2.BlobStatusReply)}
+	subscriptionErr := make(chan error, 1)
+	go func() {
+		subscriptionErr <- c.DispersalServerV2.SubscribeBlobStatus(
+			&pbv2.BlobStatusRequest{BlobKey: blobKey[:]}, stream)
+	}()
+	nextReply := func() *pbv2.BlobStatusReply {
+		select {
+		case reply := <-stream.replies:
+			return reply
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "timed out waiting for blob status reply")
+			return nil
+		}
+	}
+
+	require.Equal(t, pbv2.BlobStatus_QUEUED, nextReply().GetStatus())
+
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Encoded)
+	require.NoError(t, err)
+	require.Equal(t, pbv2.BlobStatus_ENCODED, nextReply().GetStatus())
+
+	// the reply for GatheringSignatures is only sent once the signed batch has been stored
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.GatheringSignatures)
+	require.NoError(t, err)
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{4, 5, 6},
+		ReferenceBlockNumber: 100,
+	}
+	err = c.BlobMetadataStore.PutBatchHeader(ctx, batchHeader)
+	require.NoError(t, err)
+	err = c.BlobMetadataStore.PutBlobInclusionInfo(ctx, &corev2.BlobInclusionInfo{
+		BatchHeader:    batchHeader,
+		BlobKey:        blobKey,
+		BlobIndex:      7,
+		InclusionProof: []byte("inclusion proof"),
+	})
+	require.NoError(t, err)
+	attestation := &corev2.Attestation{
+		BatchHeader: batchHeader,
+		NonSignerPubKeys: []*core.G1Point{
+			core.NewG1Point(big.NewInt(1), big.NewInt(2)),
+			core.NewG1Point(big.NewInt(3), big.NewInt(4)),
+		},
+		APKG2: &core.G2Point{
+			G2Affine: &bn254.G2Affine{
+				X: commitments.LengthCommitment.X,
+				Y: commitments.LengthCommitment.Y,
+			},
+		},
+		Sigma: &core.Signature{
+			G1Point: core.NewG1Point(big.NewInt(5), big.NewInt(6)),
+		},
+	}
+	err = c.BlobMetadataStore.PutAttestation(ctx, attestation)
+	require.NoError(t, err)
+
+	reply := nextReply()
+	require.Equal(t, pbv2.BlobStatus_GATHERING_SIGNATURES, reply.GetStatus())
+	require.Equal(t, uint32(7), reply.GetBlobInclusionInfo().GetBlobIndex())
+	require.Len(t, reply.GetSignedBatch().GetAttestation().GetNonSignerPubkeys(), 2)
+
+	// attestation updates are streamed without a status transition
+	attestation.NonSignerPubKeys = attestation.NonSignerPubKeys[:1]
+	err = c.BlobMetadataStore.PutAttestation(ctx, attestation)
+	require.NoError(t, err)
+	reply = nextReply()
+	require.Equal(t, pbv2.BlobStatus_GATHERING_SIGNATURES, reply.GetStatus())
+	require.Len(t, reply.GetSignedBatch().GetAttestation().GetNonSignerPubkeys(), 1)
+
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Complete)
+	require.NoError(t, err)
+	require.Equal(t, pbv2.BlobStatus_COMPLETE, nextReply().GetStatus())
+
+	// the stream ends after the terminal status
+	select {
+	case err := <-subscriptionErr:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "subscription did not end after the terminal status")
+	}
+}
+
+func TestV2SubscribeBlobStatusLimits(t *testing.T) {
+	ctx := t.Context()
+	c := newTestServerV2WithServerConfig(t, func(serverConfig *disperser.ServerConfig) {
+		serverConfig.BlobStatusSubscriptionMaxDuration = time.Second
+		serverConfig.MaxConcurrentBlobStatusSubscriptions = 1
+	})
+	ctx = peer.NewContext(ctx, c.Peer)
+
+	testData := codec.ConvertByPaddingEmptyByte([]byte("test data for blob status subscription limits"))
+	commitments, err := c.Committer.GetCommitmentsForPaddedLength(testData)
+	require.NoError(t, err)
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: commitments,
+		QuorumNumbers:   []core.QuorumID{0},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         gethcommon.HexToAddress("0x1234"),
+			Timestamp:         0,
+			CumulativePayment: big.NewInt(533),
+		},
+	}
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	now := time.Now()
+	err = c.BlobMetadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+		BlobHeader: blobHeader,
+		BlobStatus: dispv2.Queued,
+		Expiry:     uint64(now.Add(time.Hour).Unix()),
+		UpdatedAt:  uint64(now.UnixNano()),
+	})
+	require.NoError(t, err)
+	err = c.BlobMetadataStore.PutBlobCertificate(ctx, &corev2.BlobCertificate{
+		BlobHeader: blobHeader,
+		RelayKeys:  []corev2.RelayKey{0},
+	}, nil)
+	require.NoError(t, err)
+
+	stream := &blobStatusStream{ctx: ctx, replies: make(chan *pbv2.BlobStatusReply, 1)}
+	subscriptionErr := make(chan error, 1)
+	go func() {
+		subscriptionErr <- c.DispersalServerV2.SubscribeBlobStatus(
+			&pbv2.BlobStatusRequest{BlobKey: blobKey[:]}, stream)
+	}()
+	select {
+	case reply := <-stream.replies:
+		require.Equal(t, pbv2.BlobStatus_QUEUED, reply.GetStatus())
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "timed out waiting for blob status reply")
+	}
+
+	// the first subscription uses up the concurrency limit
+	err = c.DispersalServerV2.SubscribeBlobStatus(
+		&pbv2.BlobStatusRequest{BlobKey: blobKey[:]},
+		&blobStatusStream{ctx: ctx, replies: make(chan *pbv2.BlobStatusReply, 1)})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// the blob never reaches a terminal status, so the subscription ends at its maximum duration
+	select {
+	case err := <-subscriptionErr:
+		require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "subscription did not end at its maximum duration")
+	}
+
+	// the subscription released its slot
+	err = c.DispersalServerV2.SubscribeBlobStatus(
+		&pbv2.BlobStatusRequest{BlobKey: blobKey[:]},
+		&blobStatusStream{ctx: ctx, replies: make(chan *pbv2.BlobStatusReply, 1)})
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
 func TestV2GetBlobCommitment(t *testing.T) {
 	ctx := t.Context()
 	c := newTestServerV2(t)
@@ -559,6 +783,14 @@ func newTestServerV2(t *testing.T) *testComponents {
 }
 
 func newTestServerV2WithDeprecationFlag(t *testing.T, disableGetBlobCommitment bool) *testComponents {
+	return newTestServerV2WithServerConfig(t, func(serverConfig *disperser.ServerConfig) {
+		serverConfig.DisableGetBlobCommitment = disableGetBlobCommitment
+	})
+}
+
+// newTestServerV2WithServerConfig creates a test server whose config is modified by configure before the server is
+// constructed.
+func newTestServerV2WithServerConfig(t *testing.T, configure func(*disperser.ServerConfig)) *testComponents {
 	t.Helper()
 
 	ctx := t.Context()
@@ -664,15 +896,17 @@ func newTestServerV2WithDeprecationFlag(t *testing.T, disableGetBlobCommitment b
 		Return(&controller.AuthorizePaymentResponse{}, nil).
 		AnyTimes()
 
+	serverConfig := disperser.ServerConfig{
+		GrpcPort:                           "51002",
+		GrpcTimeout:                        1 * time.Second,
+		BlobStatusSubscriptionPollInterval: 10 * time.Millisecond,
+		DisperserId:                        0,
+		TolerateMissingAnchorSignature:     false,
+		DisableAnchorSignatureVerification: false,
+	}
+	configure(&serverConfig)
 	s, err := apiserver.NewDispersalServerV2(
-		disperser.ServerConfig{
-			GrpcPort:                           "51002",
-			GrpcTimeout:                        1 * time.Second,
-			DisableGetBlobCommitment:           disableGetBlobCommitment,
-			DisperserId:                        0,
-			TolerateMissingAnchorSignature:     false,
-			DisableAnchorSignatureVerification: false,
-		},
+		serverConfig,
 		time.Now,
 		big.NewInt(31337),
 		blobStore,
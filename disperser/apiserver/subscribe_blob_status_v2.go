@@ -0,0 +1,125 @@
+package apiserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultBlobStatusSubscriptionPollInterval is used when ServerConfig.BlobStatusSubscriptionPollInterval is not
+	// set. It matches the interval at which clients poll GetBlobStatus, so that a subscription doesn't read the
+	// metadata store more often than the polling it replaces.
+	defaultBlobStatusSubscriptionPollInterval = time.Second
+	// defaultBlobStatusSubscriptionMaxDuration is used when ServerConfig.BlobStatusSubscriptionMaxDuration is not set.
+	defaultBlobStatusSubscriptionMaxDuration = 5 * time.Minute
+	// defaultMaxConcurrentBlobStatusSubscriptions is used when ServerConfig.MaxConcurrentBlobStatusSubscriptions is
+	// not set.
+	defaultMaxConcurrentBlobStatusSubscriptions = 1000
+)
+
+// SubscribeBlobStatus streams the status of a blob to the client. The current status is sent immediately, and a new
+// reply is sent whenever the status of the blob transitions in the metadata store, or when its attestation is updated
+// while it is gathering signatures. The stream ends once a terminal status has been sent.
+//
+// The stream ends with a DEADLINE_EXCEEDED error if the blob hasn't reached a terminal status within the maximum
+// subscription duration. Subscriptions over the concurrency limit are rejected with a RESOURCE_EXHAUSTED error.
+func (s *DispersalServerV2) SubscribeBlobStatus(
+	req *pb.BlobStatusRequest,
+	stream pb.Disperser_SubscribeBlobStatusServer,
+) error {
+	st := s.subscribeBlobStatus(req, stream)
+	api.LogResponseStatus(s.logger, st)
+	if st != nil {
+		// nolint:wrapcheck
+		return st.Err()
+	}
+	return nil
+}
+
+func (s *DispersalServerV2) subscribeBlobStatus(
+	req *pb.BlobStatusRequest,
+	stream pb.Disperser_SubscribeBlobStatusServer,
+) *status.Status {
+	blobKey, st := parseBlobKey(req)
+	if st != nil {
+		return st
+	}
+
+	select {
+	case s.blobStatusSubscriptions <- struct{}{}:
+		defer func() { <-s.blobStatusSubscriptions }()
+	default:
+		return status.Newf(codes.ResourceExhausted,
+			"too many blob status subscriptions, at most %d are served at once", cap(s.blobStatusSubscriptions))
+	}
+
+	ctx, cancel := context.WithTimeout(stream.Context(), s.blobStatusSubscriptionMaxDuration)
+	defer cancel()
+	ticker := time.NewTicker(s.blobStatusSubscriptionPollInterval)
+	defer ticker.Stop()
+
+	var previousMetadata *dispv2.BlobMetadata
+	var previousReply *pb.BlobStatusReply
+	for {
+		metadata, st := s.getBlobMetadata(ctx, blobKey)
+		if st != nil && st.Code() == codes.NotFound {
+			return st
+		}
+
+		// The attestation of a blob that is gathering signatures is updated without a status transition, so it
+		// has to be checked on every tick.
+		needsReply := st == nil && (previousMetadata == nil ||
+			metadata.BlobStatus != previousMetadata.BlobStatus ||
+			metadata.BlobStatus == dispv2.GatheringSignatures)
+
+		if needsReply {
+			reply, st := s.buildBlobStatusReply(ctx, blobKey, metadata)
+			if st != nil {
+				// The signed batch of a blob may not be written yet right after its status transitions, so failures
+				// to build the reply are retried on the next tick.
+				s.logger.Debug("failed to build blob status reply", "err", st.Err(), "blobKey", blobKey.Hex())
+			} else {
+				previousMetadata = metadata
+				if !proto.Equal(reply, previousReply) {
+					if err := stream.Send(reply); err != nil {
+						if ctx.Err() != nil {
+							return status.FromContextError(ctx.Err())
+						}
+						return status.Newf(codes.Unavailable, "failed to send blob status: %v", err)
+					}
+					previousReply = reply
+				}
+				if isTerminalBlobStatus(reply.GetStatus()) {
+					return status.New(codes.OK, "")
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if stream.Context().Err() == nil {
+				return status.Newf(codes.DeadlineExceeded,
+					"blob status subscription exceeded its maximum duration of %v", s.blobStatusSubscriptionMaxDuration)
+			}
+			return status.FromContextError(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// isTerminalBlobStatus returns true if the status of a blob will not be updated anymore.
+func isTerminalBlobStatus(blobStatus pb.BlobStatus) bool {
+	switch blobStatus {
+	case pb.BlobStatus_QUEUED, pb.BlobStatus_ENCODED, pb.BlobStatus_GATHERING_SIGNATURES:
+		return false
+	default:
+		return true
+	}
+}
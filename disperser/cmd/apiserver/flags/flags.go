@@ -274,6 +274,28 @@ var (
 		Value:    time.Minute,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SIGNING_RATE_POLL_INTERVAL"),
 	}
+	BlobStatusSubscriptionPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-status-subscription-poll-interval"),
+		Usage:    "The interval at which SubscribeBlobStatus streams check the metadata store for blob status transitions",
+		Required: false,
+		Value:    time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_STATUS_SUBSCRIPTION_POLL_INTERVAL"),
+	}
+	BlobStatusSubscriptionMaxDurationFlag = cli.DurationFlag{
+		Name: common.PrefixFlag(FlagPrefix, "blob-status-subscription-max-duration"),
+		Usage: "The maximum duration of a SubscribeBlobStatus stream, after which clients fall back to polling " +
+			"GetBlobStatus",
+		Required: false,
+		Value:    5 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_STATUS_SUBSCRIPTION_MAX_DURATION"),
+	}
+	MaxConcurrentBlobStatusSubscriptionsFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-concurrent-blob-status-subscriptions"),
+		Usage:    "The maximum number of SubscribeBlobStatus streams served at the same time",
+		Required: false,
+		Value:    1000,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_CONCURRENT_BLOB_STATUS_SUBSCRIPTIONS"),
+	}
 	DisperserIdFlag = cli.Uint64Flag{
 		Name:     common.PrefixFlag(FlagPrefix, "disperser-id"),
 		Usage:    "Unique identifier for this disperser instance",
@@ -363,6 +385,9 @@ var optionalFlags = []cli.Flag{
 	DisablePerAccountMetricsFlag,
 	SigningRateRetentionPeriodFlag,
 	SigningRatePollIntervalFlag,
+	BlobStatusSubscriptionPollIntervalFlag,
+	BlobStatusSubscriptionMaxDurationFlag,
+	MaxConcurrentBlobStatusSubscriptionsFlag,
 	TolerateMissingAnchorSignatureFlag,
 	DisableAnchorSignatureVerificationFlag,
 	OperatorStateRetrieverFlag,
@@ -83,6 +83,10 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			DisableGetBlobCommitment:           ctx.GlobalBool(flags.DisableGetBlobCommitment.Name),
 			SigningRateRetentionPeriod:         ctx.GlobalDuration(flags.SigningRateRetentionPeriodFlag.Name),
 			SigningRatePollInterval:            ctx.GlobalDuration(flags.SigningRatePollIntervalFlag.Name),
+			BlobStatusSubscriptionPollInterval: ctx.GlobalDuration(flags.BlobStatusSubscriptionPollIntervalFlag.Name),
+			BlobStatusSubscriptionMaxDuration:  ctx.GlobalDuration(flags.BlobStatusSubscriptionMaxDurationFlag.Name),
+			MaxConcurrentBlobStatusSubscriptions: uint32(
+				ctx.GlobalUint(flags.MaxConcurrentBlobStatusSubscriptionsFlag.Name)),
 			DisperserId:                        uint32(ctx.GlobalUint64(flags.DisperserIdFlag.Name)),
 			TolerateMissingAnchorSignature:     ctx.GlobalBool(flags.TolerateMissingAnchorSignatureFlag.Name),
 			DisableAnchorSignatureVerification: ctx.GlobalBool(flags.DisableAnchorSignatureVerificationFlag.Name),
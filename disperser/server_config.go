@@ -35,6 +35,19 @@ type ServerConfig struct {
 	// The interval at which to poll for signing rate data from the controller.
 	SigningRatePollInterval time.Duration
 
+	// The interval at which SubscribeBlobStatus streams check the metadata store for blob status transitions.
+	// If zero, a default interval is used.
+	BlobStatusSubscriptionPollInterval time.Duration
+
+	// The maximum duration of a SubscribeBlobStatus stream. Streams of blobs that haven't reached a terminal status by
+	// then end with a DEADLINE_EXCEEDED error, after which clients fall back to polling GetBlobStatus.
+	// If zero, a default duration is used.
+	BlobStatusSubscriptionMaxDuration time.Duration
+
+	// The maximum number of SubscribeBlobStatus streams served at the same time. Further subscriptions are rejected
+	// with a RESOURCE_EXHAUSTED error. If zero, a default limit is used.
+	MaxConcurrentBlobStatusSubscriptions uint32
+
 	// Unique identifier for this disperser instance.
 	DisperserId uint32
 
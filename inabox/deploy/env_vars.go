@@ -79,6 +79,12 @@ type DisperserVars struct {
 
 	DISPERSER_SERVER_SIGNING_RATE_POLL_INTERVAL string
 
+	DISPERSER_SERVER_BLOB_STATUS_SUBSCRIPTION_POLL_INTERVAL string
+
+	DISPERSER_SERVER_BLOB_STATUS_SUBSCRIPTION_MAX_DURATION string
+
+	DISPERSER_SERVER_MAX_CONCURRENT_BLOB_STATUS_SUBSCRIPTIONS string
+
 	DISPERSER_SERVER_TOLERATE_MISSING_ANCHOR_SIGNATURE string
 
 	DISPERSER_SERVER_DISABLE_ANCHOR_SIGNATURE_VERIFICATION string
@@ -1155,6 +1161,10 @@ type ProxyVars struct {
 
 	EIGENDA_PROXY_EIGENDA_V2_BLOB_STATUS_POLL_INTERVAL string
 
+	EIGENDA_PROXY_EIGENDA_V2_DISPERSAL_JOURNAL_PATH string
+
+	EIGENDA_PROXY_EIGENDA_V2_DISPERSAL_JOURNAL_RETENTION string
+
 	EIGENDA_PROXY_EIGENDA_V2_BLOB_PARAMS_VERSION string
 
 	EIGENDA_PROXY_EIGENDA_V2_MAX_BLOB_LENGTH string
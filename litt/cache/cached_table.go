@@ -61,6 +61,15 @@ func (c *cachedTable) Put(key []byte, value []byte) error {
 	return nil
 }
 
+func (c *cachedTable) PutWithTTL(key []byte, value []byte, ttl time.Duration) error {
+	err := c.base.PutWithTTL(key, value, ttl)
+	if err != nil {
+		return fmt.Errorf("failed to put entry into base table: %w", err)
+	}
+	c.writeCache.Put(string(key), value)
+	return nil
+}
+
 func (c *cachedTable) PutBatch(batch []*types.KVPair) error {
 	err := c.base.PutBatch(batch)
 	if err != nil {
@@ -72,6 +81,17 @@ func (c *cachedTable) PutBatch(batch []*types.KVPair) error {
 	return nil
 }
 
+func (c *cachedTable) PutBatchAtomic(batch []*types.KVPair) error {
+	err := c.base.PutBatchAtomic(batch)
+	if err != nil {
+		return err
+	}
+	for _, kv := range batch {
+		c.writeCache.Put(util.UnsafeBytesToString(kv.Key), kv.Value)
+	}
+	return nil
+}
+
 func (c *cachedTable) Get(key []byte) (value []byte, exists bool, err error) {
 	value, exists, _, err = c.CacheAwareGet(key, false)
 	return value, exists, err
@@ -170,6 +190,24 @@ func (c *cachedTable) Exists(key []byte) (exists bool, err error) {
 	return c.base.Exists(key)
 }
 
+func (c *cachedTable) Delete(key []byte) error {
+	err := c.base.Delete(key)
+	if err != nil {
+		return fmt.Errorf("failed to delete entry from base table: %w", err)
+	}
+	c.writeCache.Remove(util.UnsafeBytesToString(key))
+	c.readCache.Remove(util.UnsafeBytesToString(key))
+	return nil
+}
+
+func (c *cachedTable) Iterator() (litt.Iterator, error) {
+	return c.base.Iterator()
+}
+
+func (c *cachedTable) PrefixIterator(prefix []byte) (litt.Iterator, error) {
+	return c.base.PrefixIterator(prefix)
+}
+
 func (c *cachedTable) Flush() error {
 	return c.base.Flush()
 }
@@ -208,6 +246,14 @@ func (c *cachedTable) SetShardingFactor(shardingFactor uint32) error {
 	return c.base.SetShardingFactor(shardingFactor)
 }
 
+func (c *cachedTable) SetCompression(compression types.CompressionType) error {
+	return c.base.SetCompression(compression)
+}
+
+func (c *cachedTable) CompressionRatio() float64 {
+	return c.base.CompressionRatio()
+}
+
 func (c *cachedTable) RunGC() error {
 	return c.base.RunGC()
 }
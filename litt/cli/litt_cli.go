@@ -152,6 +152,34 @@ func buildCLIParser(logger logging.Logger) *cli.App {
 				},
 				Action: pruneCommand,
 			},
+			{
+				Name: "verify",
+				Usage: "Scrub a LittDB database/snapshot for corrupt values. " +
+					"If the DB is spread across multiple paths, all paths must be provided.",
+				ArgsUsage: "--src <path1> ... --src <pathN> [--table <table1> ... --table <tableN>] " +
+					"[--threads <threadCount>] [--quarantine]",
+				Flags: []cli.Flag{
+					srcFlag,
+					&cli.StringSliceFlag{
+						Name:    "table",
+						Aliases: []string{"t"},
+						Usage:   "Verify this table. If not specified, all tables will be verified.",
+					},
+					&cli.Uint64Flag{
+						Name:    "threads",
+						Aliases: []string{"T"},
+						Usage:   "Number of segments to verify in parallel.",
+						Value:   8,
+					},
+					&cli.BoolFlag{
+						Name:    "quarantine",
+						Aliases: []string{"q"},
+						Usage: "If enabled, remove corrupt values from the DB. " +
+							"Corrupt values are reported but left in place otherwise.",
+					},
+				},
+				Action: verifyCommand,
+			},
 			{
 				Name:  "push",
 				Usage: "Push data to a remote location using ssh and rsync.",
@@ -291,6 +319,46 @@ func buildCLIParser(logger logging.Logger) *cli.App {
 				},
 				Action: syncCommand,
 			},
+			{
+				Name: "restore",
+				Usage: "Reconstruct a LittDB database from a snapshot or a backup created by 'litt push'. " +
+					"Refuses to write to a database that is currently in use.",
+				ArgsUsage: "--src <source-path1> ... --src <source-pathN> " +
+					"--dst <destination-path1> ... --dst <destination-pathN> " +
+					"[--table <table1> ... --table <tableN>] [--max-segment <segmentIndex>] " +
+					"[--time <unixTimestampInSeconds>] [--force]",
+				Flags: []cli.Flag{
+					srcFlag,
+					&cli.StringSliceFlag{
+						Name:     "dst",
+						Aliases:  []string{"d"},
+						Usage:    "Destination paths for the restored LittDB, at least one is required.",
+						Required: true,
+					},
+					&cli.StringSliceFlag{
+						Name:    "table",
+						Aliases: []string{"t"},
+						Usage:   "Restore this table. If not specified, all tables will be restored.",
+					},
+					&cli.Uint64Flag{
+						Name:    "max-segment",
+						Aliases: []string{"m"},
+						Usage: "Restore segments up to and including this segment index. " +
+							"Requires exactly one --table.",
+					},
+					&cli.Uint64Flag{
+						Name:    "time",
+						Aliases: []string{"T"},
+						Usage:   "Restore only segments sealed at or before this unix timestamp, in seconds.",
+					},
+					&cli.BoolFlag{
+						Name:    "force",
+						Aliases: []string{"f"},
+						Usage:   "Overwrite tables that already exist at the destination.",
+					},
+				},
+				Action: restoreCommand,
+			},
 			{
 				Name:      "unlock",
 				Usage:     "Manually delete LittDB lock files. Dangerous if used improperly, use with caution.",
@@ -0,0 +1,426 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// restoreCommand reconstructs a LittDB database from a snapshot or a backup.
+func restoreCommand(ctx *cli.Context) error {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	sources := ctx.StringSlice("src")
+	if len(sources) == 0 {
+		return fmt.Errorf("no sources provided")
+	}
+	for i, src := range sources {
+		var err error
+		sources[i], err = util.SanitizePath(src)
+		if err != nil {
+			return fmt.Errorf("invalid source path: %s", src)
+		}
+	}
+
+	destinations := ctx.StringSlice("dst")
+	if len(destinations) == 0 {
+		return fmt.Errorf("no destinations provided")
+	}
+	for i, dest := range destinations {
+		var err error
+		destinations[i], err = util.SanitizePath(dest)
+		if err != nil {
+			return fmt.Errorf("invalid destination path: %s", dest)
+		}
+	}
+
+	tables := ctx.StringSlice("table")
+
+	boundary := &restoreBoundary{}
+	if ctx.IsSet("max-segment") {
+		if len(tables) != 1 {
+			return fmt.Errorf("--max-segment requires exactly one --table, since segment indices are per table")
+		}
+		maxSegment := ctx.Uint64("max-segment")
+		if maxSegment > uint64(^uint32(0)) {
+			return fmt.Errorf("--max-segment %d is out of range", maxSegment)
+		}
+		boundary.maxSegmentIndex = uint32(maxSegment)
+		boundary.hasMaxSegmentIndex = true
+	}
+	if ctx.IsSet("time") {
+		boundary.maxSealTime = time.Unix(int64(ctx.Uint64("time")), 0)
+	}
+
+	return restore(logger, sources, destinations, tables, boundary, ctx.Bool("force"), true)
+}
+
+// restoreBoundary describes the point in time that a database is restored to. Since segments are the unit of
+// backup, the boundary always falls between two segments.
+type restoreBoundary struct {
+	// If hasMaxSegmentIndex is true, then no segment with an index greater than maxSegmentIndex is restored.
+	maxSegmentIndex    uint32
+	hasMaxSegmentIndex bool
+
+	// If non-zero, then no segment sealed after this time is restored.
+	maxSealTime time.Time
+}
+
+// restore reconstructs a LittDB database from a snapshot or a backup (e.g. a directory populated by 'litt push').
+// Segment files are copied from the sources to the destinations, up to the requested boundary. The keymap is not
+// copied, the DB rebuilds it from the restored segment files the next time it is started. Once the files are copied,
+// the restored segments are checked to ensure that every live key resolves to a readable value.
+//
+// The sources and the destinations are locked for the duration of the restore. This prevents a restore from
+// overwriting a database that is currently in use.
+func restore(
+	logger logging.Logger,
+	sources []string,
+	destinations []string,
+	allowedTables []string,
+	boundary *restoreBoundary,
+	force bool,
+	fsync bool) error {
+
+	sourceSet := make(map[string]struct{}, len(sources))
+	for _, src := range sources {
+		sourceSet[src] = struct{}{}
+	}
+	for _, dest := range destinations {
+		if _, ok := sourceSet[dest]; ok {
+			return fmt.Errorf("path %s is both a source and a destination", dest)
+		}
+	}
+
+	allowedTablesSet := make(map[string]struct{})
+	for _, table := range allowedTables {
+		allowedTablesSet[table] = struct{}{}
+	}
+
+	// Forbid touching data in active use.
+	releaseSourceLocks, err := util.LockDirectories(logger, sources, util.LockfileName, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to acquire locks on source paths %v: %w", sources, err)
+	}
+	defer releaseSourceLocks()
+
+	for _, dest := range destinations {
+		err = util.EnsureDirectoryExists(dest, fsync)
+		if err != nil {
+			return fmt.Errorf("failed to ensure destination path %s exists: %w", dest, err)
+		}
+	}
+	releaseDestinationLocks, err := util.LockDirectories(logger, destinations, util.LockfileName, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to acquire locks on destination paths %v "+
+			"(refusing to restore over a database that is in use): %w", destinations, err)
+	}
+	defer releaseDestinationLocks()
+
+	// Determine which tables to restore.
+	var tables []string
+	foundTables, err := lsPaths(logger, sources, false, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to list tables in paths %v: %w", sources, err)
+	}
+	if len(allowedTables) == 0 {
+		tables = foundTables
+	} else {
+		for _, table := range foundTables {
+			if _, ok := allowedTablesSet[table]; ok {
+				tables = append(tables, table)
+			}
+		}
+		if len(tables) != len(allowedTablesSet) {
+			return fmt.Errorf("not all requested tables %v were found in paths %v", allowedTables, sources)
+		}
+	}
+	if len(tables) == 0 {
+		return fmt.Errorf("no tables found in paths %v", sources)
+	}
+
+	// Check for existing data before copying anything, so that a restore is not abandoned part of the way through.
+	for _, table := range tables {
+		err = prepareRestoreDestination(destinations, table, force)
+		if err != nil {
+			return fmt.Errorf("failed to prepare destination for table %s: %w", table, err)
+		}
+	}
+
+	for _, table := range tables {
+		err = restoreTable(logger, sources, destinations, table, boundary, fsync)
+		if err != nil {
+			return fmt.Errorf("failed to restore table %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// prepareRestoreDestination ensures that there is no existing data for a table at the destinations. If force is true,
+// existing data is deleted. Otherwise, an error is returned if existing data is found.
+func prepareRestoreDestination(destinations []string, tableName string, force bool) error {
+	for _, dest := range destinations {
+		tableDirectory := path.Join(dest, tableName)
+		exists, err := util.Exists(tableDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to check if table directory %s exists: %w", tableDirectory, err)
+		}
+		if !exists {
+			continue
+		}
+		if !force {
+			return fmt.Errorf("table directory %s already exists, use --force to overwrite it", tableDirectory)
+		}
+		err = os.RemoveAll(tableDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to remove table directory %s: %w", tableDirectory, err)
+		}
+	}
+	return nil
+}
+
+// restoreTable copies the segments of a single table from the sources to the destinations, up to the requested
+// boundary, and then validates the restored table.
+func restoreTable(
+	logger logging.Logger,
+	sources []string,
+	destinations []string,
+	tableName string,
+	boundary *restoreBoundary,
+	fsync bool) error {
+
+	errorMonitor := util.NewErrorMonitor(context.Background(), logger, nil)
+
+	segmentPaths, err := segment.BuildSegmentPaths(sources, "", tableName)
+	if err != nil {
+		return fmt.Errorf("failed to build segment paths for table %s at paths %v: %w", tableName, sources, err)
+	}
+
+	lowestSegmentIndex, highestSegmentIndex, segments, err := segment.GatherSegmentFiles(
+		logger,
+		errorMonitor,
+		segmentPaths,
+		false,
+		time.Now(),
+		false,
+		fsync)
+	if err != nil {
+		return fmt.Errorf("failed to gather segment files for table %s at paths %v: %w",
+			tableName, sources, err)
+	}
+	if len(segments) == 0 {
+		return fmt.Errorf("no segments found for table %s at paths %v", tableName, sources)
+	}
+
+	// If we are restoring from a snapshot, only segments below the snapshot's upper bound are complete.
+	isSnapshot, err := segments[lowestSegmentIndex].IsSnapshot()
+	if err != nil {
+		return fmt.Errorf("failed to check if segment %d is a snapshot: %w", lowestSegmentIndex, err)
+	}
+	if isSnapshot {
+		if len(sources) > 1 {
+			return fmt.Errorf("this is a symlinked snapshot directory, " +
+				"snapshot directory cannot be spread across multiple sources")
+		}
+		upperBoundFile, err := disktable.LoadBoundaryFile(disktable.UpperBound, path.Join(sources[0], tableName))
+		if err != nil {
+			return fmt.Errorf("failed to load boundary file for table %s at path %s: %w",
+				tableName, sources[0], err)
+		}
+		if upperBoundFile.IsDefined() {
+			highestSegmentIndex = min(highestSegmentIndex, upperBoundFile.BoundaryIndex())
+		}
+	}
+
+	// Apply the requested boundary.
+	if boundary.hasMaxSegmentIndex {
+		if boundary.maxSegmentIndex < lowestSegmentIndex {
+			return fmt.Errorf("requested segment %d is older than the oldest segment %d in the backup",
+				boundary.maxSegmentIndex, lowestSegmentIndex)
+		}
+		highestSegmentIndex = min(highestSegmentIndex, boundary.maxSegmentIndex)
+	}
+	if !boundary.maxSealTime.IsZero() {
+		if segments[lowestSegmentIndex].GetSealTime().After(boundary.maxSealTime) {
+			return fmt.Errorf("the oldest segment in the backup was sealed at %v, after the requested time %v",
+				segments[lowestSegmentIndex].GetSealTime(), boundary.maxSealTime)
+		}
+		for index := lowestSegmentIndex + 1; index <= highestSegmentIndex; index++ {
+			if segments[index].GetSealTime().After(boundary.maxSealTime) {
+				highestSegmentIndex = index - 1
+				break
+			}
+		}
+	}
+
+	// Copy the table metadata, if present. Snapshots do not contain table metadata, in which case the restored
+	// table uses the configuration of the DB that opens it.
+	err = restoreTableMetadata(sources, destinations, tableName, fsync)
+	if err != nil {
+		return fmt.Errorf("failed to restore table metadata: %w", err)
+	}
+
+	for _, dest := range destinations {
+		err = util.EnsureDirectoryExists(path.Join(dest, tableName, segment.SegmentDirectory), fsync)
+		if err != nil {
+			return fmt.Errorf("failed to create segment directory at %s: %w", dest, err)
+		}
+	}
+
+	// Copy the segment files. Copying (as opposed to hard linking) ensures that the restored DB shares no files
+	// with the backup, and that symlinked snapshot files are resolved to their contents.
+	for index := lowestSegmentIndex; index <= highestSegmentIndex; index++ {
+		for _, filePath := range segments[index].GetFilePaths() {
+			fileName := path.Base(filePath)
+			destination, err := determineDestination(fileName, destinations)
+			if err != nil {
+				return fmt.Errorf("failed to determine destination for file %s: %w", fileName, err)
+			}
+
+			targetPath := path.Join(destination, tableName, segment.SegmentDirectory, fileName)
+			err = util.CopyRegularFile(filePath, targetPath, fsync)
+			if err != nil {
+				return fmt.Errorf("failed to copy segment file %s to %s: %w", filePath, targetPath, err)
+			}
+		}
+	}
+
+	if ok, err := errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("error monitor reports errors: %w", err)
+	}
+
+	liveKeyCount, err := validateRestoredTable(logger, destinations, tableName, fsync)
+	if err != nil {
+		return fmt.Errorf("restored table failed validation: %w", err)
+	}
+
+	logger.Infof("Table '%s': restored segments %d through %d (last sealed at %v), %d live key(s).",
+		tableName, lowestSegmentIndex, highestSegmentIndex, segments[highestSegmentIndex].GetSealTime(),
+		liveKeyCount)
+
+	return nil
+}
+
+// restoreTableMetadata copies the table metadata file from the sources to the destinations, if it is present.
+func restoreTableMetadata(sources []string, destinations []string, tableName string, fsync bool) error {
+	for _, source := range sources {
+		sourcePath := path.Join(source, tableName, disktable.TableMetadataFileName)
+		exists, err := util.Exists(sourcePath)
+		if err != nil {
+			return fmt.Errorf("failed to check if table metadata file %s exists: %w", sourcePath, err)
+		}
+		if !exists {
+			continue
+		}
+
+		destination, err := determineDestination(tableName, destinations)
+		if err != nil {
+			return fmt.Errorf("failed to determine destination for table metadata %s: %w", sourcePath, err)
+		}
+		destinationPath := path.Join(destination, tableName, disktable.TableMetadataFileName)
+		err = util.CopyRegularFile(sourcePath, destinationPath, fsync)
+		if err != nil {
+			return fmt.Errorf("failed to copy table metadata from %s to %s: %w", sourcePath, destinationPath, err)
+		}
+		return nil
+	}
+	return nil
+}
+
+// validateRestoredTable checks that the restored segments of a table are consistent with the keymap that the DB will
+// build from them. The key-to-address mapping is reconstructed from the key files in the same way that the DB
+// reconstructs its keymap (later segments take precedence, and tombstones remove the values they refer to), and every
+// live key is checked to resolve to a readable value. Returns the number of live keys.
+func validateRestoredTable(
+	logger logging.Logger,
+	destinations []string,
+	tableName string,
+	fsync bool) (uint64, error) {
+
+	errorMonitor := util.NewErrorMonitor(context.Background(), logger, nil)
+
+	// The keymap is rebuilt from the segment files the next time the DB is started. A keymap left over in the
+	// destination would not match the restored segments.
+	for _, dest := range destinations {
+		keymapPath := path.Join(dest, tableName, keymap.KeymapDirectoryName)
+		err := os.RemoveAll(keymapPath)
+		if err != nil {
+			return 0, fmt.Errorf("failed to remove keymap %s: %w", keymapPath, err)
+		}
+	}
+
+	segmentPaths, err := segment.BuildSegmentPaths(destinations, "", tableName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build segment paths for table %s at paths %v: %w",
+			tableName, destinations, err)
+	}
+
+	// Missing or partial segments cause this to fail.
+	lowestSegmentIndex, highestSegmentIndex, segments, err := segment.GatherSegmentFiles(
+		logger,
+		errorMonitor,
+		segmentPaths,
+		false,
+		time.Now(),
+		false,
+		fsync)
+	if err != nil {
+		return 0, fmt.Errorf("failed to gather restored segment files: %w", err)
+	}
+
+	liveKeys := make(map[string]types.Address)
+	for index := lowestSegmentIndex; index <= highestSegmentIndex; index++ {
+		seg := segments[index]
+
+		keys, err := seg.GetKeys()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get keys for segment %d: %w", index, err)
+		}
+		for _, key := range keys {
+			liveKeys[string(key.Key)] = key.Address
+		}
+
+		tombstones, err := seg.GetTombstones()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get tombstones for segment %d: %w", index, err)
+		}
+		for _, tombstone := range tombstones {
+			if address, ok := liveKeys[string(tombstone.Key)]; ok && address == tombstone.Address {
+				delete(liveKeys, string(tombstone.Key))
+			}
+		}
+	}
+
+	for key, address := range liveKeys {
+		seg, ok := segments[address.Index()]
+		if !ok {
+			return 0, fmt.Errorf("key %x refers to missing segment %d", key, address.Index())
+		}
+		_, err = seg.Read([]byte(key), address)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read value for key %x at segment %d, offset %d: %w",
+				key, address.Index(), address.Offset(), err)
+		}
+	}
+
+	if ok, err := errorMonitor.IsOk(); !ok {
+		return 0, fmt.Errorf("error monitor reports errors: %w", err)
+	}
+
+	return uint64(len(liveKeys)), nil
+}
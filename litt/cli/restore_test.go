@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyRestoredData opens the DB at the given paths and checks that it contains exactly the expected data.
+func verifyRestoredData(
+	t *testing.T,
+	roots []string,
+	expectedData map[string]map[string][]byte,
+	absentData map[string]map[string]struct{}) {
+
+	config, err := litt.DefaultConfig(roots...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	for tableName, expected := range expectedData {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		for key, expectedValue := range expected {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok, "key %s missing from table %s", key, tableName)
+			require.Equal(t, expectedValue, value)
+		}
+		for key := range absentData[tableName] {
+			ok, err := table.Exists([]byte(key))
+			require.NoError(t, err)
+			require.False(t, ok, "key %s unexpectedly present in table %s", key, tableName)
+		}
+		require.Equal(t, uint64(len(expected)), table.KeyCount())
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestRestoreFromSnapshot(t *testing.T) {
+	t.Parallel()
+	logger := test.GetLogger()
+	rand := random.NewTestRandom()
+	testDirectory := t.TempDir()
+
+	sourceRoots := []string{path.Join(testDirectory, "source-0"), path.Join(testDirectory, "source-1")}
+	snapshotDir := path.Join(testDirectory, "snapshot")
+	destinationRoots := []string{path.Join(testDirectory, "dest-0"), path.Join(testDirectory, "dest-1")}
+
+	config, err := litt.DefaultConfig(sourceRoots...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	config.TargetSegmentFileSize = 100
+	config.SnapshotDirectory = snapshotDir
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	tableNames := []string{"table-0", "table-1", "table-2"}
+	expectedData := make(map[string]map[string][]byte)
+	deletedData := make(map[string]map[string]struct{})
+	for _, tableName := range tableNames {
+		expectedData[tableName] = make(map[string][]byte)
+		deletedData[tableName] = make(map[string]struct{})
+	}
+
+	for i := 0; i < 500; i++ {
+		tableName := tableNames[rand.Intn(len(tableNames))]
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+
+		key := rand.String(32)
+		value := rand.PrintableVariableBytes(1, 100)
+		err = table.Put([]byte(key), value)
+		require.NoError(t, err)
+		expectedData[tableName][key] = value
+
+		if rand.BoolWithProbability(0.1) {
+			for keyToDelete := range expectedData[tableName] {
+				err = table.Delete([]byte(keyToDelete))
+				require.NoError(t, err)
+				delete(expectedData[tableName], keyToDelete)
+				deletedData[tableName][keyToDelete] = struct{}{}
+				break
+			}
+		}
+	}
+
+	// Power cycle the DB twice. The last segment only makes it into the snapshot once the DB restarts and seals it.
+	err = db.Close()
+	require.NoError(t, err)
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	for _, tableName := range tableNames {
+		_, err = db.GetTable(tableName)
+		require.NoError(t, err)
+	}
+	err = db.Close()
+	require.NoError(t, err)
+
+	// A restore may not write to a DB that is in use.
+	liveConfig, err := litt.DefaultConfig(destinationRoots...)
+	require.NoError(t, err)
+	liveConfig.Fsync = false
+	liveDB, err := littbuilder.NewDB(liveConfig)
+	require.NoError(t, err)
+	err = restore(logger, []string{snapshotDir}, destinationRoots, nil, &restoreBoundary{}, true, false)
+	require.Error(t, err)
+	err = liveDB.Close()
+	require.NoError(t, err)
+
+	err = restore(logger, []string{snapshotDir}, destinationRoots, nil, &restoreBoundary{}, false, false)
+	require.NoError(t, err)
+	verifyRestoredData(t, destinationRoots, expectedData, deletedData)
+
+	// The restore should not have left any locks behind.
+	for _, root := range append([]string{snapshotDir}, destinationRoots...) {
+		exists, err := util.Exists(path.Join(root, util.LockfileName))
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+
+	// Existing tables are only overwritten if forced.
+	err = restore(logger, []string{snapshotDir}, destinationRoots, nil, &restoreBoundary{}, false, false)
+	require.Error(t, err)
+	err = restore(logger, []string{snapshotDir}, destinationRoots, nil, &restoreBoundary{}, true, false)
+	require.NoError(t, err)
+	verifyRestoredData(t, destinationRoots, expectedData, deletedData)
+
+	// A path may not be both a source and a destination.
+	err = restore(logger, sourceRoots, sourceRoots, nil, &restoreBoundary{}, true, false)
+	require.Error(t, err)
+}
+
+func TestRestoreToSegmentBoundary(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	logger := test.GetLogger()
+	rand := random.NewTestRandom()
+	testDirectory := t.TempDir()
+
+	backupRoots := make([]string, rand.Uint64Range(1, 4))
+	for i := range backupRoots {
+		backupRoots[i] = path.Join(testDirectory, fmt.Sprintf("backup-%d", i))
+	}
+	destinationRoots := []string{path.Join(testDirectory, "dest")}
+
+	config, err := litt.DefaultConfig(backupRoots...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	config.TargetSegmentFileSize = 100
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	tableName := "table"
+	table, err := db.GetTable(tableName)
+	require.NoError(t, err)
+	otherTable, err := db.GetTable("other-table")
+	require.NoError(t, err)
+
+	values := make(map[string][]byte)
+	for i := 0; i < 200; i++ {
+		key := rand.String(32)
+		value := rand.PrintableVariableBytes(1, 100)
+		err = table.Put([]byte(key), value)
+		require.NoError(t, err)
+		values[key] = value
+
+		err = otherTable.Put([]byte(rand.String(32)), value)
+		require.NoError(t, err)
+	}
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Pick a segment in the middle of the table and figure out which keys are at or before it.
+	segmentPaths, err := segment.BuildSegmentPaths(backupRoots, "", tableName)
+	require.NoError(t, err)
+	lowestSegmentIndex, highestSegmentIndex, segments, err := segment.GatherSegmentFiles(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		segmentPaths,
+		false,
+		time.Now(),
+		false,
+		false)
+	require.NoError(t, err)
+	require.Greater(t, highestSegmentIndex, lowestSegmentIndex+1)
+	boundaryIndex := lowestSegmentIndex + (highestSegmentIndex-lowestSegmentIndex)/2
+
+	expectedData := map[string]map[string][]byte{tableName: {}}
+	absentData := map[string]map[string]struct{}{tableName: {}}
+	for index := lowestSegmentIndex; index <= highestSegmentIndex; index++ {
+		keys, err := segments[index].GetKeys()
+		require.NoError(t, err)
+		for _, key := range keys {
+			if index <= boundaryIndex {
+				expectedData[tableName][string(key.Key)] = values[string(key.Key)]
+			} else {
+				absentData[tableName][string(key.Key)] = struct{}{}
+			}
+		}
+	}
+	require.NotEmpty(t, expectedData[tableName])
+	require.NotEmpty(t, absentData[tableName])
+
+	boundary := &restoreBoundary{
+		maxSegmentIndex:    boundaryIndex,
+		hasMaxSegmentIndex: true,
+	}
+
+	// Requesting a table that does not exist is an error.
+	err = restore(logger, backupRoots, destinationRoots, []string{"missing-table"}, boundary, false, false)
+	require.Error(t, err)
+
+	err = restore(logger, backupRoots, destinationRoots, []string{tableName}, boundary, false, false)
+	require.NoError(t, err)
+	verifyRestoredData(t, destinationRoots, expectedData, absentData)
+
+	// Only the requested table is restored.
+	exists, err := util.Exists(path.Join(destinationRoots[0], "other-table"))
+	require.NoError(t, err)
+	require.False(t, exists)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/Layr-Labs/eigenda/litt/disktable"
 	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
 	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/urfave/cli/v2"
@@ -22,6 +23,12 @@ type TableInfo struct {
 	KeyCount uint64
 	// The size of the table in bytes.
 	Size uint64
+	// The size the table would have in bytes if its values were not compressed.
+	UncompressedSize uint64
+	// The ratio between the uncompressed size and the on-disk size of the table.
+	CompressionRatio float64
+	// The compression type used by the newest segment in the table.
+	Compression types.CompressionType
 	// If true, the table at the specified path is a snapshot of another table.
 	IsSnapshot bool
 	// The time when the oldest segment was sealed.
@@ -76,6 +83,9 @@ func tableInfoCommand(ctx *cli.Context) error {
 	logger.Infof("Table:                       %s", tableName)
 	logger.Infof("Key count:                   %s", common.CommaOMatic(info.KeyCount))
 	logger.Infof("Size:                        %s", common.PrettyPrintBytes(info.Size))
+	logger.Infof("Uncompressed size:           %s", common.PrettyPrintBytes(info.UncompressedSize))
+	logger.Infof("Compression ratio:           %.2f", info.CompressionRatio)
+	logger.Infof("Compression:                 %s", info.Compression)
 	logger.Infof("Is snapshot:                 %t", info.IsSnapshot)
 	logger.Infof("Oldest segment age:          %s", common.PrettyPrintTime(oldestSegmentAge))
 	logger.Infof("Oldest segment seal time:    %s", info.OldestSegmentSealTime.Format(time.RFC3339))
@@ -165,6 +175,7 @@ func tableInfo(logger logging.Logger, tableName string, paths []string, fsync bo
 
 	keyCount := uint64(0)
 	size := uint64(0)
+	uncompressedSize := uint64(0)
 	for _, seg := range segments {
 		if seg.SegmentIndex() > highestSegmentIndex {
 			// Do not attempt to read segments outside the limit set by the boundary file.
@@ -173,6 +184,12 @@ func tableInfo(logger logging.Logger, tableName string, paths []string, fsync bo
 
 		keyCount += uint64(seg.KeyCount())
 		size += seg.Size()
+		uncompressedSize += seg.UncompressedSize()
+	}
+
+	compressionRatio := 1.0
+	if size > 0 {
+		compressionRatio = float64(uncompressedSize) / float64(size)
 	}
 
 	_, _, keymapTypeFile, err := littbuilder.FindKeymapLocation(paths, tableName)
@@ -189,6 +206,9 @@ func tableInfo(logger logging.Logger, tableName string, paths []string, fsync bo
 	return &TableInfo{
 		KeyCount:              keyCount,
 		Size:                  size,
+		UncompressedSize:      uncompressedSize,
+		CompressionRatio:      compressionRatio,
+		Compression:           segments[highestSegmentIndex].Compression(),
 		IsSnapshot:            isSnapshot,
 		OldestSegmentSealTime: segments[lowestSegmentIndex].GetSealTime(),
 		NewestSegmentSealTime: segments[highestSegmentIndex].GetSealTime(),
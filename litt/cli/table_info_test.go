@@ -6,6 +6,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/litt"
 	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/test"
 	"github.com/Layr-Labs/eigenda/test/random"
 	"github.com/stretchr/testify/require"
@@ -119,6 +120,9 @@ func TestTableInfo(t *testing.T) {
 		require.Greater(t, info.Size, uint64(0))
 		require.Equal(t, info.KeyCount, uint64(100))
 		require.Equal(t, "LevelDBKeymap", info.KeymapType)
+		require.Equal(t, types.NoCompression, info.Compression)
+		require.Equal(t, info.Size, info.UncompressedSize)
+		require.Equal(t, 1.0, info.CompressionRatio)
 	}
 
 	// A non-existent table should return an error for the core directories as well.
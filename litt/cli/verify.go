@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli/v2"
+)
+
+// verifyCommand scrubs the data in a LittDB instance/snapshot, looking for corrupt values.
+func verifyCommand(ctx *cli.Context) error {
+	logger, err := common.NewLogger(common.DefaultConsoleLoggerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	sources := ctx.StringSlice("src")
+	if len(sources) == 0 {
+		return fmt.Errorf("no sources provided")
+	}
+	for i, src := range sources {
+		var err error
+		sources[i], err = util.SanitizePath(src)
+		if err != nil {
+			return fmt.Errorf("invalid source path: %s", src)
+		}
+	}
+
+	tables := ctx.StringSlice("table")
+	threads := ctx.Uint64("threads")
+	quarantine := ctx.Bool("quarantine")
+
+	reports, err := verify(logger, sources, tables, threads, quarantine, true)
+	if err != nil {
+		return err
+	}
+
+	corruptCount := 0
+	for _, report := range reports {
+		corruptCount += len(report.corruptValues)
+	}
+	if corruptCount > 0 && !quarantine {
+		return fmt.Errorf("found %d corrupt value(s)", corruptCount)
+	}
+
+	return nil
+}
+
+// tableVerificationReport describes the results of verifying a single table.
+type tableVerificationReport struct {
+	// The name of the table.
+	tableName string
+
+	// The number of segments that were scanned.
+	segmentCount uint64
+
+	// The number of segments that were written without checksums. Values in these segments can only be checked
+	// for structural integrity, not for bit rot.
+	segmentsWithoutChecksums uint64
+
+	// The number of values that were scanned.
+	valueCount uint64
+
+	// Values that failed verification.
+	corruptValues []*segment.CorruptValue
+
+	// True if the corrupt values were quarantined.
+	quarantined bool
+}
+
+// verify scrubs the data in a LittDB instance/snapshot. Each segment is read in full, and every value is checked for
+// corruption. If quarantine is true, then corrupt values are removed from their segments' key files, and the keymap
+// is deleted so that it will be rebuilt without the corrupt keys the next time the DB is started.
+func verify(
+	logger logging.Logger,
+	sources []string,
+	allowedTables []string,
+	threads uint64,
+	quarantine bool,
+	fsync bool) ([]*tableVerificationReport, error) {
+
+	if threads == 0 {
+		return nil, fmt.Errorf("threads must be greater than 0")
+	}
+
+	allowedTablesSet := make(map[string]struct{})
+	for _, table := range allowedTables {
+		allowedTablesSet[table] = struct{}{}
+	}
+
+	// Forbid touching tables in active use.
+	releaseLocks, err := util.LockDirectories(logger, sources, util.LockfileName, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire locks on paths %v: %w", sources, err)
+	}
+	defer releaseLocks()
+
+	// Determine which tables to verify.
+	var tables []string
+	foundTables, err := lsPaths(logger, sources, false, fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables in paths %v: %w", sources, err)
+	}
+	if len(allowedTables) == 0 {
+		tables = foundTables
+	} else {
+		for _, table := range foundTables {
+			if _, ok := allowedTablesSet[table]; ok {
+				tables = append(tables, table)
+			}
+		}
+	}
+
+	reports := make([]*tableVerificationReport, 0, len(tables))
+	for _, table := range tables {
+		report, err := verifyTable(logger, sources, table, threads, quarantine, fsync)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify table %s in paths %v: %w", table, sources, err)
+		}
+		reports = append(reports, report)
+
+		for _, corruptValue := range report.corruptValues {
+			logger.Errorf("Table '%s': corrupt value for key %x at segment %d, offset %d: %v",
+				table, corruptValue.Key.Key, corruptValue.Key.Address.Index(),
+				corruptValue.Key.Address.Offset(), corruptValue.Err)
+		}
+
+		if report.segmentsWithoutChecksums > 0 {
+			logger.Warnf("Table '%s': %d of %d segment(s) were written without checksums, "+
+				"only structural integrity of these segments was verified.",
+				table, report.segmentsWithoutChecksums, report.segmentCount)
+		}
+
+		if len(report.corruptValues) == 0 {
+			logger.Infof("Table '%s': verified %d value(s) in %d segment(s), no corruption detected.",
+				table, report.valueCount, report.segmentCount)
+		} else if report.quarantined {
+			logger.Warnf("Table '%s': verified %d value(s) in %d segment(s), quarantined %d corrupt value(s).",
+				table, report.valueCount, report.segmentCount, len(report.corruptValues))
+		} else {
+			logger.Errorf("Table '%s': verified %d value(s) in %d segment(s), found %d corrupt value(s).",
+				table, report.valueCount, report.segmentCount, len(report.corruptValues))
+		}
+	}
+
+	return reports, nil
+}
+
+// verifyTable scrubs the data in a single table. Segments are verified in parallel in background goroutines.
+func verifyTable(
+	logger logging.Logger,
+	sources []string,
+	tableName string,
+	threads uint64,
+	quarantine bool,
+	fsync bool) (*tableVerificationReport, error) {
+
+	errorMonitor := util.NewErrorMonitor(context.Background(), logger, nil)
+
+	segmentPaths, err := segment.BuildSegmentPaths(sources, "", tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build segment paths for table %s at paths %v: %w",
+			tableName, sources, err)
+	}
+
+	lowestSegmentIndex, highestSegmentIndex, segments, err := segment.GatherSegmentFiles(
+		logger,
+		errorMonitor,
+		segmentPaths,
+		false,
+		time.Now(),
+		false,
+		fsync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather segment files for table %s at paths %v: %w",
+			tableName, sources, err)
+	}
+
+	report := &tableVerificationReport{
+		tableName:     tableName,
+		corruptValues: make([]*segment.CorruptValue, 0),
+	}
+
+	if len(segments) == 0 {
+		return report, nil
+	}
+
+	isSnapshot, err := segments[lowestSegmentIndex].IsSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if segment %d is a snapshot: %w", lowestSegmentIndex, err)
+	}
+	if quarantine && isSnapshot {
+		return nil, fmt.Errorf("cannot quarantine values in a snapshot, verify the source DB instead")
+	}
+
+	// Used to limit verification concurrency.
+	limiter := make(chan struct{}, threads)
+	wg := sync.WaitGroup{}
+	lock := sync.Mutex{}
+	corruptBySegment := make(map[uint32][]*segment.CorruptValue)
+
+	for i := lowestSegmentIndex; i <= highestSegmentIndex; i++ {
+		seg := segments[i]
+
+		report.segmentCount++
+		report.valueCount += uint64(seg.KeyCount())
+		if !seg.HasChecksums() {
+			report.segmentsWithoutChecksums++
+		}
+
+		limiter <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+
+			corruptValues, err := seg.Verify()
+			if err != nil {
+				errorMonitor.Panic(fmt.Errorf("failed to verify segment %d: %w", seg.SegmentIndex(), err))
+				return
+			}
+			if len(corruptValues) > 0 {
+				lock.Lock()
+				corruptBySegment[seg.SegmentIndex()] = corruptValues
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ok, err := errorMonitor.IsOk(); !ok {
+		return nil, fmt.Errorf("error detected during verification: %w", err)
+	}
+
+	segmentIndices := make([]uint32, 0, len(corruptBySegment))
+	for segmentIndex := range corruptBySegment {
+		segmentIndices = append(segmentIndices, segmentIndex)
+	}
+	sort.Slice(segmentIndices, func(i, j int) bool {
+		return segmentIndices[i] < segmentIndices[j]
+	})
+	for _, segmentIndex := range segmentIndices {
+		report.corruptValues = append(report.corruptValues, corruptBySegment[segmentIndex]...)
+	}
+
+	if !quarantine || len(report.corruptValues) == 0 {
+		return report, nil
+	}
+
+	// Remove corrupt keys from their segments.
+	for _, segmentIndex := range segmentIndices {
+		corruptKeys := make([]*types.ScopedKey, 0, len(corruptBySegment[segmentIndex]))
+		for _, corruptValue := range corruptBySegment[segmentIndex] {
+			corruptKeys = append(corruptKeys, corruptValue.Key)
+		}
+
+		err = segments[segmentIndex].DropKeys(corruptKeys)
+		if err != nil {
+			return nil, fmt.Errorf("failed to quarantine corrupt values in segment %d: %w", segmentIndex, err)
+		}
+	}
+
+	// The keymap still references the quarantined keys, and the hard linked snapshot files still reference the
+	// old key files. The DB will automatically rebuild the snapshots directory & keymap on the next startup.
+	err = deleteSnapshots(sources, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete snapshots/keymap for table %s at paths %v: %w",
+			tableName, sources, err)
+	}
+	report.quarantined = true
+
+	return report, nil
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	logger := test.GetLogger()
+	rand := random.NewTestRandom()
+	testDirectory := t.TempDir()
+
+	errorMonitor := util.NewErrorMonitor(ctx, logger, nil)
+
+	rootPathCount := rand.Uint64Range(2, 5)
+	rootPaths := make([]string, rootPathCount)
+	for i := uint64(0); i < rootPathCount; i++ {
+		rootPaths[i] = path.Join(testDirectory, fmt.Sprintf("root-%d", i))
+	}
+
+	// Use a standard test configuration for LittDB.
+	config, err := litt.DefaultConfig(rootPaths...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	config.Checksums = true
+	config.ShardingFactor = uint32(rand.Uint64Range(rootPathCount, 2*rootPathCount))
+	config.TargetSegmentFileSize = 100
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	tableCount := rand.Uint64Range(2, 5)
+	tables := make(map[string]litt.Table, tableCount)
+	for i := uint64(0); i < tableCount; i++ {
+		tableName := fmt.Sprintf("table-%d", i)
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		tables[tableName] = table
+	}
+
+	// map from table name to keys to values
+	expectedData := make(map[string]map[string][]byte)
+	for _, table := range tables {
+		expectedData[table.Name()] = make(map[string][]byte)
+	}
+
+	// Write some data into the DB.
+	for i := 0; i < 1000; i++ {
+		tableIndex := rand.Uint64Range(0, tableCount)
+		tableName := fmt.Sprintf("table-%d", tableIndex)
+		table := tables[tableName]
+
+		key := rand.String(32)
+		value := rand.PrintableVariableBytes(1, 100)
+
+		err = table.Put([]byte(key), value)
+		require.NoError(t, err)
+
+		expectedData[tableName][key] = value
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// An uncorrupted DB should pass verification.
+	reports, err := verify(logger, rootPaths, []string{}, 4, false, false)
+	require.NoError(t, err)
+	require.Len(t, reports, int(tableCount))
+	for _, report := range reports {
+		require.Empty(t, report.corruptValues)
+		require.Zero(t, report.segmentsWithoutChecksums)
+		require.Equal(t, uint64(len(expectedData[report.tableName])), report.valueCount)
+	}
+
+	// Corrupt a few values in the first table.
+	corruptTable := "table-0"
+	corruptKeys := make(map[string]struct{})
+
+	segmentPaths, err := segment.BuildSegmentPaths(rootPaths, "", corruptTable)
+	require.NoError(t, err)
+	lowSegmentIndex, highSegmentIndex, segments, err := segment.GatherSegmentFiles(
+		logger,
+		errorMonitor,
+		segmentPaths,
+		false,
+		time.Now(),
+		false,
+		false)
+	require.NoError(t, err)
+
+	for i := lowSegmentIndex; i <= highSegmentIndex; i++ {
+		if !rand.BoolWithProbability(0.25) {
+			continue
+		}
+		seg := segments[i]
+		keys, err := seg.GetKeys()
+		require.NoError(t, err)
+		if len(keys) == 0 {
+			continue
+		}
+
+		target := keys[rand.Intn(len(keys))]
+		corruptKeys[string(target.Key)] = struct{}{}
+		corruptValue(t, seg, target)
+	}
+
+	// Verify without quarantine. Corrupt values should be reported but left in place.
+	reports, err = verify(logger, rootPaths, []string{corruptTable}, 4, false, false)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, corruptTable, reports[0].tableName)
+	require.False(t, reports[0].quarantined)
+	require.Len(t, reports[0].corruptValues, len(corruptKeys))
+	for _, corrupt := range reports[0].corruptValues {
+		_, ok := corruptKeys[string(corrupt.Key.Key)]
+		require.True(t, ok)
+		require.True(t, errors.Is(corrupt.Err, segment.ErrChecksumMismatch))
+	}
+
+	// Verify with quarantine. Corrupt values should be removed.
+	reports, err = verify(logger, rootPaths, []string{}, 4, true, false)
+	require.NoError(t, err)
+	for _, report := range reports {
+		if report.tableName == corruptTable {
+			require.Len(t, report.corruptValues, len(corruptKeys))
+		} else {
+			require.Empty(t, report.corruptValues)
+		}
+	}
+
+	// A second pass should find nothing.
+	reports, err = verify(logger, rootPaths, []string{}, 4, false, false)
+	require.NoError(t, err)
+	for _, report := range reports {
+		require.Empty(t, report.corruptValues)
+	}
+
+	// Reopen the DB and verify its contents.
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+
+	for tableName := range tables {
+		table, err := db.GetTable(tableName)
+		require.NoError(t, err)
+		tables[tableName] = table
+	}
+
+	for tableName, expected := range expectedData {
+		for key, value := range expected {
+			actual, ok, err := tables[tableName].Get([]byte(key))
+			require.NoError(t, err)
+
+			if _, corrupt := corruptKeys[key]; corrupt && tableName == corruptTable {
+				require.False(t, ok)
+			} else {
+				require.True(t, ok)
+				require.Equal(t, value, actual)
+			}
+		}
+	}
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+// corruptValue flips the bits of the last byte of a value on disk.
+func corruptValue(t *testing.T, seg *segment.Segment, key *types.ScopedKey) {
+	valuePath := seg.GetValueFilePaths()[seg.GetShard(key.Key)]
+	data, err := os.ReadFile(valuePath)
+	require.NoError(t, err)
+
+	lastByte := key.Address.Offset() + 4 /* length */ + segment.ChecksumSize + key.ValueSize - 1
+	data[lastByte] ^= 0xff
+
+	err = os.WriteFile(valuePath, data, 0644)
+	require.NoError(t, err)
+}
@@ -15,6 +15,7 @@ package litt
 //   - dynamic multi-drive support (data can be spread across multiple physical volumes, and
 //     volume membership can be changed at runtime without stopping the DB)
 //   - incremental backups (both local and remote)
+//   - ordered iteration over keys, including prefix scans
 //
 // Unsupported features:
 // - mutating existing values (once a value is written, it cannot be changed)
@@ -43,6 +44,7 @@ type DB interface {
 	//
 	// Note that this size may not accurately reflect the size of the keymap. This is because some third party
 	// libraries used for certain keymap implementations do not provide an accurate way to measure size.
+	// The size of keymap.PebbleKeymapType keymaps is reported accurately.
 	Size() uint64
 
 	// KeyCount returns the number of keys in the database.
@@ -0,0 +1,365 @@
+package disktable
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"golang.org/x/time/rate"
+)
+
+// compactionBatchSize is the maximum number of values relocated by the compactor in a single batch.
+const compactionBatchSize = 64
+
+// compactor runs a goroutine that periodically compacts sparse segments. Compacting a segment means copying all of its
+// live values into the mutable segment. Once this is done, the segment no longer contains any live data, and can be
+// reclaimed by the garbage collector. The compactor also moves values with a TTL override out of expired segments,
+// since such values would otherwise prevent the garbage collector from reclaiming those segments (and all segments
+// after them). Relocated values keep the time at which they were originally written, and are deleted by the compactor
+// once they expire.
+type compactor struct {
+	logger logging.Logger
+
+	// the parent disk table
+	diskTable *DiskTable
+
+	// Responsible for handling fatal DB errors.
+	errorMonitor *util.ErrorMonitor
+
+	// The period between compaction runs.
+	period time.Duration
+
+	// A segment is compacted if the fraction of its keys that are live is less than or equal to this threshold. If
+	// zero, then only expired segments that contain values with a TTL override are compacted.
+	threshold float64
+
+	// Limits the rate at which data is copied.
+	rateLimiter *rate.Limiter
+
+	// The maximum number of bytes that can be requested from the rate limiter at once.
+	burst int
+
+	// Closed to signal the compactor goroutine to stop.
+	stopChan chan struct{}
+
+	// Closed by the compactor goroutine when it has stopped.
+	stoppedChan chan struct{}
+}
+
+// newCompactor creates a new compactor. The compactor does not start running until run() is called.
+func newCompactor(
+	logger logging.Logger,
+	diskTable *DiskTable,
+	errorMonitor *util.ErrorMonitor,
+	period time.Duration,
+	threshold float64,
+	bytesPerSecond uint64) *compactor {
+
+	burst := int(min(bytesPerSecond, math.MaxInt32))
+	limit := rate.Limit(bytesPerSecond)
+	if bytesPerSecond == 0 {
+		// The rate is unlimited.
+		burst = math.MaxInt32
+		limit = rate.Inf
+	}
+
+	return &compactor{
+		logger:       logger,
+		diskTable:    diskTable,
+		errorMonitor: errorMonitor,
+		period:       period,
+		threshold:    threshold,
+		rateLimiter:  rate.NewLimiter(limit, burst),
+		burst:        burst,
+		stopChan:     make(chan struct{}),
+		stoppedChan:  make(chan struct{}),
+	}
+}
+
+// run runs the compactor until it is stopped.
+func (c *compactor) run() {
+	defer close(c.stoppedChan)
+
+	ticker := time.NewTicker(c.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.errorMonitor.ImmediateShutdownRequired():
+			c.logger.Infof("context done, shutting down disk table compactor")
+			return
+		case <-c.stopChan:
+			return
+		case <-ticker.C:
+			err := c.compact()
+			if err != nil {
+				c.errorMonitor.Panic(fmt.Errorf("compaction failed for table %s: %w", c.diskTable.name, err))
+				return
+			}
+		}
+	}
+}
+
+// stop stops the compactor, blocking until the compactor goroutine has exited. If a batch of values is being
+// relocated when stop is called, then this method blocks until that batch has been flushed.
+func (c *compactor) stop() {
+	close(c.stopChan)
+	<-c.stoppedChan
+}
+
+// stopping returns true if the compactor has been asked to stop.
+func (c *compactor) stopping() bool {
+	select {
+	case <-c.stopChan:
+		return true
+	case <-c.errorMonitor.ImmediateShutdownRequired():
+		return true
+	default:
+		return false
+	}
+}
+
+// compact performs a single compaction run. Expired relocated values are deleted first, and then segments are
+// compacted one at a time until there are no more candidates.
+func (c *compactor) compact() error {
+	err := c.deleteExpiredValues()
+	if err != nil {
+		return fmt.Errorf("failed to delete expired values: %w", err)
+	}
+
+	var previousIndex uint32
+	firstSegment := true
+
+	for !c.stopping() {
+		seg, err := c.getCandidate()
+		if err != nil {
+			return fmt.Errorf("failed to get compaction candidate: %w", err)
+		}
+		if seg == nil {
+			return nil
+		}
+
+		if !firstSegment && seg.SegmentIndex() == previousIndex {
+			// This segment was compacted in this run but still contains live data, most likely because some of its
+			// keys had not yet been written to the keymap. Try again during the next run.
+			seg.Release()
+			return nil
+		}
+		firstSegment = false
+		previousIndex = seg.SegmentIndex()
+
+		err = c.compactSegment(seg)
+		seg.Release()
+		if err != nil {
+			return fmt.Errorf("failed to compact segment %d: %w", previousIndex, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteExpiredValues deletes relocated values that have outlived their original lifetime. Such values live in
+// segments that have not yet expired, and so they would otherwise remain readable after their lifetime has elapsed.
+func (c *compactor) deleteExpiredValues() error {
+	request := &controlLoopExpiredValuesRequest{
+		responseChan: make(chan []*types.ScopedKey, 1),
+	}
+	err := c.diskTable.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send expired values request: %w", err)
+	}
+
+	expired, err := util.Await(c.errorMonitor, request.responseChan)
+	if err != nil {
+		return fmt.Errorf("failed to await expired values: %w", err)
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	// Holding this lock prevents expired values from being relocated while they are being deleted.
+	c.diskTable.compactionLock.Lock()
+	defer c.diskTable.compactionLock.Unlock()
+
+	for _, key := range expired {
+		if _, ok := c.diskTable.unflushedDataCache.Load(util.UnsafeBytesToString(key.Key)); ok {
+			// The key has been overwritten, and the new value has not yet been written to the keymap.
+			continue
+		}
+
+		err = c.diskTable.deleteAt(key.Key, &key.Address)
+		if err != nil {
+			return fmt.Errorf("failed to delete expired value: %w", err)
+		}
+	}
+
+	c.logger.Infof("table %s: deleted %d expired relocated value(s)", c.diskTable.name, len(expired))
+
+	return nil
+}
+
+// getCandidate asks the control loop for a segment to compact. Returns nil if there is no segment that should be
+// compacted. The returned segment is reserved, and must be released by the caller.
+func (c *compactor) getCandidate() (*segment.Segment, error) {
+	request := &controlLoopCompactionCandidateRequest{
+		threshold:    c.threshold,
+		responseChan: make(chan *segment.Segment, 1),
+	}
+	err := c.diskTable.controlLoop.enqueue(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send compaction candidate request: %w", err)
+	}
+
+	seg, err := util.Await(c.errorMonitor, request.responseChan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to await compaction candidate: %w", err)
+	}
+	return seg, nil
+}
+
+// compactSegment relocates all live values in a segment into the mutable segment.
+func (c *compactor) compactSegment(seg *segment.Segment) error {
+	start := time.Now()
+
+	keys, err := seg.GetKeys()
+	if err != nil {
+		return fmt.Errorf("failed to get keys: %w", err)
+	}
+
+	relocated := 0
+	for batchStart := 0; batchStart < len(keys); batchStart += compactionBatchSize {
+		if c.stopping() {
+			return nil
+		}
+
+		batch := keys[batchStart:min(batchStart+compactionBatchSize, len(keys))]
+
+		batchSize := uint64(0)
+		for _, key := range batch {
+			batchSize += uint64(key.ValueSize)
+		}
+		if !c.throttle(batchSize) {
+			return nil
+		}
+
+		count, err := c.relocateBatch(seg, batch)
+		if err != nil {
+			return fmt.Errorf("failed to relocate batch: %w", err)
+		}
+		relocated += count
+	}
+
+	c.logger.Infof("table %s: compacted segment %d, relocated %d of %d value(s) in %v",
+		c.diskTable.name, seg.SegmentIndex(), relocated, len(keys), time.Since(start))
+
+	return nil
+}
+
+// relocateBatch relocates the live values from a batch of keys in a segment. Returns the number of values relocated.
+// Values that have already expired are not relocated.
+func (c *compactor) relocateBatch(seg *segment.Segment, keys []*types.ScopedKey) (int, error) {
+	// While holding this lock, no keys can be deleted. If a key were deleted between the time it is relocated and the
+	// time the relocation is written to the keymap, the tombstone would refer to the old address of the value.
+	c.diskTable.compactionLock.Lock()
+	defer c.diskTable.compactionLock.Unlock()
+
+	now := c.diskTable.clock()
+	ttl := c.diskTable.metadata.GetTTL()
+
+	values := make([]*types.KVPair, 0, len(keys))
+	previousAddresses := make([]types.Address, 0, len(keys))
+	lifetimes := make([]*types.Lifetime, 0, len(keys))
+
+	for _, key := range keys {
+		// The relocated value keeps the time at which it was originally written.
+		lifetime := &types.Lifetime{WriteTime: seg.GetSealTime()}
+		if key.Lifetime != nil {
+			lifetime.TTL = key.Lifetime.TTL
+			if !key.Lifetime.WriteTime.IsZero() {
+				lifetime.WriteTime = key.Lifetime.WriteTime
+			}
+		}
+		if ttl > 0 && !lifetime.Expiry(seg.GetSealTime(), ttl).After(now) {
+			continue
+		}
+
+		if _, deleted := c.diskTable.unflushedTombstones.Load(util.UnsafeBytesToString(key.Key)); deleted {
+			continue
+		}
+
+		// A value is live only if the keymap still points to it.
+		address, ok, err := c.diskTable.keymap.Get(key.Key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get address: %w", err)
+		}
+		if !ok || address != key.Address {
+			continue
+		}
+
+		value, err := seg.Read(key.Key, key.Address)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read value: %w", err)
+		}
+
+		values = append(values, &types.KVPair{Key: key.Key, Value: value})
+		previousAddresses = append(previousAddresses, key.Address)
+		lifetimes = append(lifetimes, lifetime)
+	}
+
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	// Make the values visible to readers until the keymap has been updated with their new addresses.
+	for _, kv := range values {
+		c.diskTable.unflushedDataCache.Store(util.UnsafeBytesToString(kv.Key), kv.Value)
+	}
+
+	request := &controlLoopRelocateRequest{
+		values:            values,
+		previousAddresses: previousAddresses,
+		lifetimes:         lifetimes,
+	}
+	err := c.diskTable.controlLoop.enqueue(request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send relocate request: %w", err)
+	}
+
+	// The relocated values must be durable and present in the keymap before the lock is released.
+	err = c.diskTable.flushInternal()
+	if err != nil {
+		return 0, fmt.Errorf("failed to flush relocated values: %w", err)
+	}
+
+	return len(values), nil
+}
+
+// throttle blocks until the I/O budget permits the given number of bytes to be copied. Returns false if the
+// compactor was stopped while waiting.
+func (c *compactor) throttle(bytes uint64) bool {
+	for bytes > 0 {
+		chunk := min(bytes, uint64(c.burst))
+		bytes -= chunk
+
+		delay := c.rateLimiter.ReserveN(time.Now(), int(chunk)).Delay()
+		if delay == 0 {
+			continue
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-c.stopChan:
+			timer.Stop()
+			return false
+		case <-c.errorMonitor.ImmediateShutdownRequired():
+			timer.Stop()
+			return false
+		}
+	}
+	return true
+}
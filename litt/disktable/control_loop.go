@@ -10,6 +10,7 @@ import (
 	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
 	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
 	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
@@ -55,6 +56,10 @@ type controlLoop struct {
 	// and in the control loop.
 	immutableSegmentSize uint64
 
+	// The number of bytes the immutable segments would occupy if their values were not compressed. For thread
+	// safety, this variable may only be read/written in the constructor and in the control loop.
+	immutableSegmentUncompressedSize uint64
+
 	// The target size for value files.
 	targetFileSize uint32
 
@@ -67,9 +72,22 @@ type controlLoop struct {
 	// The size of the disk table is stored here.
 	size *atomic.Uint64
 
+	// The size the disk table would have if its values were not compressed is stored here.
+	uncompressedSize *atomic.Uint64
+
 	// The number of keys in the table.
 	keyCount *atomic.Int64
 
+	// Tombstones that have been written to the mutable segment, but that have not yet been scheduled for a flush.
+	// Once a tombstone is durable, the deleted key is removed from the keymap. Only the control loop may read or
+	// write this value.
+	unflushedTombstones []*types.ScopedKey
+
+	// The previous locations of values that have been relocated into the mutable segment by the compactor, but that
+	// have not yet been scheduled for a flush. Once a relocation is durable, the keymap entry for the previous location
+	// is replaced. Only the control loop may read or write this value.
+	unflushedRelocations []*types.ScopedKey
+
 	// clock is the time source used by the disk table.
 	clock func() time.Time
 
@@ -85,6 +103,9 @@ type controlLoop struct {
 	// A source of randomness used for generating sharding salt.
 	saltShaker *rand.Rand
 
+	// If true, then values in newly created segments are stored alongside checksums.
+	checksums bool
+
 	// whether fsync mode is enabled.
 	fsync bool
 
@@ -131,10 +152,20 @@ func (c *controlLoop) run() {
 		case message := <-c.controllerChannel:
 			if req, ok := message.(*controlLoopWriteRequest); ok {
 				c.handleWriteRequest(req)
+			} else if req, ok := message.(*controlLoopDeleteRequest); ok {
+				c.handleDeleteRequest(req)
+			} else if req, ok := message.(*controlLoopRelocateRequest); ok {
+				c.handleRelocateRequest(req)
+			} else if req, ok := message.(*controlLoopCompactionCandidateRequest); ok {
+				c.handleCompactionCandidateRequest(req)
+			} else if req, ok := message.(*controlLoopExpiredValuesRequest); ok {
+				c.handleExpiredValuesRequest(req)
 			} else if req, ok := message.(*controlLoopFlushRequest); ok {
 				c.handleFlushRequest(req)
 			} else if req, ok := message.(*controlLoopSetShardingFactorRequest); ok {
 				c.handleControlLoopSetShardingFactorRequest(req)
+			} else if req, ok := message.(*controlLoopSetCompressionRequest); ok {
+				c.handleControlLoopSetCompressionRequest(req)
 			} else if req, ok := message.(*controlLoopShutdownRequest); ok {
 				c.handleShutdownRequest(req)
 				return
@@ -151,12 +182,18 @@ func (c *controlLoop) run() {
 	}
 }
 
-// doGarbageCollection performs garbage collection on all segments, deleting old ones as necessary.
+// doGarbageCollection performs garbage collection on all segments, deleting old ones as necessary. A segment is
+// deleted if it is older than the TTL and contains no live value with a longer lifetime (see isPinned), or if all data
+// in it has been deleted (see highestDeadSegment). Segments are always deleted in order, starting with the lowest
+// segment. A segment that is pinned by a value with a TTL override is emptied by the compactor, after which it (and
+// the segments after it) can be garbage collected.
 func (c *controlLoop) doGarbageCollection() {
 	start := c.clock()
 	ttl := c.metadata.GetTTL()
-	if ttl.Nanoseconds() <= 0 {
-		// No TTL set, so nothing to do.
+	deadIndex, deadSegmentsExist := c.highestDeadSegment()
+
+	if ttl.Nanoseconds() <= 0 && !deadSegmentsExist {
+		// No TTL set and no deleted data to reclaim, so nothing to do.
 		return
 	}
 
@@ -177,28 +214,37 @@ func (c *controlLoop) doGarbageCollection() {
 			return
 		}
 
-		sealTime := seg.GetSealTime()
-		segmentAge := start.Sub(sealTime)
-		if segmentAge < ttl {
-			// Segment is not old enough to be deleted.
-			return
-		}
+		if !deadSegmentsExist || index > deadIndex {
+			if ttl.Nanoseconds() <= 0 {
+				// No TTL set, so live data is never deleted.
+				return
+			}
 
-		// Segment is old enough to be deleted.
-		keys, err := seg.GetKeys()
-		if err != nil {
-			c.errorMonitor.Panic(fmt.Errorf("failed to get keys: %w", err))
-			return
-		}
+			sealTime := seg.GetSealTime()
+			segmentAge := start.Sub(sealTime)
+			if segmentAge < ttl {
+				// Segment is not old enough to be deleted.
+				return
+			}
 
-		for keyIndex := uint64(0); keyIndex < uint64(len(keys)); keyIndex += c.gcBatchSize {
-			lastIndex := keyIndex + c.gcBatchSize
-			if lastIndex > uint64(len(keys)) {
-				lastIndex = uint64(len(keys))
+			pinned, err := c.isPinned(seg, start, ttl)
+			if err != nil {
+				c.errorMonitor.Panic(fmt.Errorf("failed to check lifetimes of segment %d: %w", index, err))
+				return
+			}
+			if pinned {
+				// Segment contains a live value with a TTL override that has not yet expired.
+				return
 			}
-			err = c.keymap.Delete(keys[keyIndex:lastIndex])
+		}
+
+		// Segment is old enough to be deleted, or contains no live data. Keys in a segment with no live data have
+		// already been removed from the keymap (or now point to a relocated copy of the value), so only segments with
+		// live data need to have their keys removed from the keymap.
+		if !seg.IsDead() {
+			err := c.deleteKeysFromKeymap(seg)
 			if err != nil {
-				c.errorMonitor.Panic(fmt.Errorf("failed to delete keys: %w", err))
+				c.errorMonitor.Panic(fmt.Errorf("failed to delete keys for segment %d: %w", index, err))
 				return
 			}
 		}
@@ -209,7 +255,13 @@ func (c *controlLoop) doGarbageCollection() {
 		}
 
 		c.immutableSegmentSize -= seg.Size()
-		c.keyCount.Add(-1 * int64(seg.KeyCount()))
+		if seg.UncompressedSize() > c.immutableSegmentUncompressedSize {
+			c.immutableSegmentUncompressedSize = 0
+		} else {
+			c.immutableSegmentUncompressedSize -= seg.UncompressedSize()
+		}
+		// Deleted keys were subtracted from the key count when they were deleted.
+		c.keyCount.Add(-1 * int64(seg.KeyCount()-seg.DeletedKeyCount()))
 
 		// Deletion of segment files will happen when the segment is released by all reservation holders.
 		seg.Release()
@@ -221,6 +273,106 @@ func (c *controlLoop) doGarbageCollection() {
 	}
 }
 
+// isPinned returns true if a segment contains a live value whose lifetime extends beyond the given time. Values that
+// have since been deleted or relocated do not pin the segment.
+func (c *controlLoop) isPinned(seg *segment.Segment, now time.Time, ttl time.Duration) (bool, error) {
+	lifetimes, err := seg.GetLifetimes()
+	if err != nil {
+		return false, fmt.Errorf("failed to get lifetimes: %w", err)
+	}
+
+	for _, key := range lifetimes {
+		if !key.Lifetime.Expiry(seg.GetSealTime(), ttl).After(now) {
+			continue
+		}
+		live, err := c.isLive(key)
+		if err != nil {
+			return false, err
+		}
+		if live {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// isLive returns true if the keymap still points to the given key's address.
+func (c *controlLoop) isLive(key *types.ScopedKey) (bool, error) {
+	address, ok, err := c.keymap.Get(key.Key)
+	if err != nil {
+		return false, fmt.Errorf("failed to get address: %w", err)
+	}
+	return ok && address == key.Address, nil
+}
+
+// deleteKeysFromKeymap removes the keys in a segment from the keymap.
+func (c *controlLoop) deleteKeysFromKeymap(seg *segment.Segment) error {
+	keys, err := seg.GetKeys()
+	if err != nil {
+		return fmt.Errorf("failed to get keys: %w", err)
+	}
+
+	if seg.DeletedKeyCount() > 0 {
+		// Some keys in this segment were deleted or relocated. A relocated key must not be removed from the keymap,
+		// so only remove keys that still point to this segment.
+		keys, err = c.filterKeysInKeymap(keys)
+		if err != nil {
+			return fmt.Errorf("failed to filter keys: %w", err)
+		}
+	}
+
+	for keyIndex := uint64(0); keyIndex < uint64(len(keys)); keyIndex += c.gcBatchSize {
+		lastIndex := keyIndex + c.gcBatchSize
+		if lastIndex > uint64(len(keys)) {
+			lastIndex = uint64(len(keys))
+		}
+		err = c.keymap.Delete(keys[keyIndex:lastIndex])
+		if err != nil {
+			return fmt.Errorf("failed to delete keys: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// filterKeysInKeymap returns the subset of the given keys whose addresses match the addresses in the keymap.
+func (c *controlLoop) filterKeysInKeymap(keys []*types.ScopedKey) ([]*types.ScopedKey, error) {
+	filtered := make([]*types.ScopedKey, 0, len(keys))
+	for _, key := range keys {
+		live, err := c.isLive(key)
+		if err != nil {
+			return nil, err
+		}
+		if live {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered, nil
+}
+
+// highestDeadSegment returns the index of the highest sealed segment such that it and all segments before it contain
+// no live data, and that segment contains deleted data. Such segments can be garbage collected regardless of the TTL.
+// Segments that never contained any data are only garbage collected if a later segment with deleted data is also
+// garbage collected. Returns false if there are no dead segments.
+func (c *controlLoop) highestDeadSegment() (uint32, bool) {
+	deadIndex := uint32(0)
+	found := false
+
+	for index := c.lowestSegmentIndex; index <= c.highestSegmentIndex; index++ {
+		seg := c.segments[index]
+		if !seg.IsSealed() || !seg.IsDead() {
+			break
+		}
+		if seg.DeletedKeyCount() > 0 || seg.TombstoneCount() > 0 {
+			deadIndex = index
+			found = true
+		}
+	}
+
+	return deadIndex, found
+}
+
 // getReservedSegment returns the segment with the given index. Segment is reserved, and it is the caller's
 // responsibility to release the reservation when done. Returns true if the segment was found and reserved,
 // and false if the segment could not be found or could not be reserved.
@@ -242,6 +394,22 @@ func (c *controlLoop) getReservedSegment(index uint32) (*segment.Segment, bool)
 	return seg, true
 }
 
+// reserveAllSegments reserves every segment currently in use and returns them, keyed by segment index. It is the
+// caller's responsibility to release each reservation when done.
+func (c *controlLoop) reserveAllSegments() map[uint32]*segment.Segment {
+	c.segmentLock.RLock()
+	defer c.segmentLock.RUnlock()
+
+	reserved := make(map[uint32]*segment.Segment, len(c.segments))
+	for index, seg := range c.segments {
+		if seg.Reserve() {
+			reserved[index] = seg
+		}
+	}
+
+	return reserved
+}
+
 // getSegments returns the segments of the disk table. It is only legal to call this after the control loop has been
 // stopped.
 func (c *controlLoop) getSegments() (map[uint32]*segment.Segment, error) {
@@ -258,14 +426,30 @@ func (c *controlLoop) updateCurrentSize() {
 		c.metadata.Size()
 
 	c.size.Store(size)
+
+	uncompressedSize := c.immutableSegmentUncompressedSize +
+		c.segments[c.highestSegmentIndex].UncompressedSize() +
+		c.metadata.Size()
+
+	c.uncompressedSize.Store(uncompressedSize)
 }
 
 // handleWriteRequest handles a controlLoopWriteRequest control message.
 func (c *controlLoop) handleWriteRequest(req *controlLoopWriteRequest) {
+	if req.atomic {
+		c.handleAtomicWriteRequest(req)
+		return
+	}
+
+	var lifetime *types.Lifetime
+	if req.ttl > 0 {
+		lifetime = &types.Lifetime{TTL: req.ttl}
+	}
+
 	for _, kv := range req.values {
 		// Do the write.
 		seg := c.segments[c.highestSegmentIndex]
-		keyCount, keyFileSize, err := seg.Write(kv)
+		keyCount, keyFileSize, err := seg.WriteWithLifetime(kv, lifetime)
 		shardSize := seg.GetMaxShardSize()
 		if err != nil {
 			c.errorMonitor.Panic(
@@ -287,6 +471,226 @@ func (c *controlLoop) handleWriteRequest(req *controlLoopWriteRequest) {
 	c.updateCurrentSize()
 }
 
+// handleAtomicWriteRequest handles a controlLoopWriteRequest control message for an atomic batch. An atomic batch
+// is always written to a single segment, and so the segment is only expanded after the entire batch has been written.
+// As a consequence, a large atomic batch may cause a segment to exceed its target size.
+func (c *controlLoop) handleAtomicWriteRequest(req *controlLoopWriteRequest) {
+	seg := c.segments[c.highestSegmentIndex]
+	keyCount, keyFileSize, err := seg.WriteAtomicBatch(req.values)
+	if err != nil {
+		c.errorMonitor.Panic(
+			fmt.Errorf("failed to write atomic batch to segment %d: %w", c.highestSegmentIndex, err))
+		return
+	}
+	shardSize := seg.GetMaxShardSize()
+
+	// Check to see if the write caused the mutable segment to become full.
+	if shardSize > uint64(c.targetFileSize) || keyCount >= c.maxKeyCount || keyFileSize >= c.targetKeyFileSize {
+		err = c.expandSegments()
+		if err != nil {
+			c.errorMonitor.Panic(fmt.Errorf("failed to expand segments: %w", err))
+			return
+		}
+	}
+
+	c.updateCurrentSize()
+}
+
+// handleDeleteRequest handles a controlLoopDeleteRequest control message.
+func (c *controlLoop) handleDeleteRequest(req *controlLoopDeleteRequest) {
+	seg := c.segments[c.highestSegmentIndex]
+	keyFileSize, err := seg.WriteTombstone(req.tombstone.Key, req.tombstone.Address)
+	if err != nil {
+		c.errorMonitor.Panic(
+			fmt.Errorf("failed to write tombstone to segment %d: %w", c.highestSegmentIndex, err))
+		return
+	}
+	c.unflushedTombstones = append(c.unflushedTombstones, req.tombstone)
+
+	target, ok := c.segments[req.tombstone.Address.Index()]
+	if ok {
+		target.MarkKeyDeleted()
+	} else {
+		// The segment containing the deleted value was garbage collected before the tombstone was written. The key
+		// count was already reduced when the segment was garbage collected, so undo the reduction made by Delete().
+		c.keyCount.Add(1)
+	}
+
+	// Check to see if the tombstone caused the mutable segment to become full.
+	if keyFileSize >= c.targetKeyFileSize {
+		err = c.expandSegments()
+		if err != nil {
+			c.errorMonitor.Panic(fmt.Errorf("failed to expand segments: %w", err))
+			return
+		}
+	}
+
+	c.updateCurrentSize()
+}
+
+// handleRelocateRequest handles a controlLoopRelocateRequest control message. The values are written to the mutable
+// segment as a single atomic batch, each accompanied by a tombstone for its previous address. Unlike tombstones written
+// by handleDeleteRequest, these tombstones do not remove keys from the keymap at flush time. Instead, the keymap entry
+// for each relocated key is replaced with the value's new address.
+func (c *controlLoop) handleRelocateRequest(req *controlLoopRelocateRequest) {
+	seg := c.segments[c.highestSegmentIndex]
+	keyCount, keyFileSize, err := seg.WriteRelocatedBatch(req.values, req.previousAddresses, req.lifetimes)
+	if err != nil {
+		c.errorMonitor.Panic(
+			fmt.Errorf("failed to write relocated batch to segment %d: %w", c.highestSegmentIndex, err))
+		return
+	}
+	shardSize := seg.GetMaxShardSize()
+
+	for i, address := range req.previousAddresses {
+		c.unflushedRelocations = append(c.unflushedRelocations, &types.ScopedKey{
+			Key:     req.values[i].Key,
+			Address: address,
+		})
+
+		previous, ok := c.segments[address.Index()]
+		if ok {
+			previous.MarkKeyDeleted()
+		} else {
+			// The segment that previously held the value was garbage collected after the value was read. The key
+			// count was reduced when the segment was garbage collected, but the relocated copy is live.
+			c.keyCount.Add(1)
+		}
+	}
+
+	// Check to see if the write caused the mutable segment to become full.
+	if shardSize > uint64(c.targetFileSize) || keyCount >= c.maxKeyCount || keyFileSize >= c.targetKeyFileSize {
+		err = c.expandSegments()
+		if err != nil {
+			c.errorMonitor.Panic(fmt.Errorf("failed to expand segments: %w", err))
+			return
+		}
+	}
+
+	c.updateCurrentSize()
+}
+
+// handleCompactionCandidateRequest handles a controlLoopCompactionCandidateRequest control message. Since segments
+// are garbage collected in order, compacting a segment only frees disk space once all older segments have also been
+// freed. The only segment considered for compaction is therefore the oldest segment that still contains live data.
+// If the table has a TTL, then segments that are more than halfway to their expiration are not compacted, since
+// the garbage collector will soon reclaim them regardless. The exception is a segment that has expired but is pinned
+// by values with a TTL override. Such a segment is always a candidate, so that moving the long lived values out of it
+// allows it (and every segment after it) to be garbage collected.
+func (c *controlLoop) handleCompactionCandidateRequest(req *controlLoopCompactionCandidateRequest) {
+	req.responseChan <- c.findCompactionCandidate(req.threshold)
+}
+
+// findCompactionCandidate returns the reserved segment that should be compacted, or nil if there is none.
+func (c *controlLoop) findCompactionCandidate(threshold float64) *segment.Segment {
+	now := c.clock()
+	ttl := c.metadata.GetTTL()
+
+	for index := c.lowestSegmentIndex; index < c.highestSegmentIndex; index++ {
+		seg := c.segments[index]
+		if !seg.IsSealed() {
+			return nil
+		}
+		if seg.IsDead() {
+			// This segment will be reclaimed by the garbage collector, consider the next one.
+			continue
+		}
+
+		if ttl > 0 && now.Sub(seg.GetSealTime()) >= ttl {
+			pinned, err := c.isPinned(seg, now, ttl)
+			if err != nil {
+				c.errorMonitor.Panic(fmt.Errorf("failed to check lifetimes of segment %d: %w", index, err))
+				return nil
+			}
+			if !pinned {
+				// This segment has expired and will be reclaimed by the garbage collector, consider the next one.
+				continue
+			}
+			if !seg.Reserve() {
+				return nil
+			}
+			return seg
+		}
+
+		if threshold <= 0 {
+			return nil
+		}
+
+		liveKeyCount := seg.KeyCount() - seg.DeletedKeyCount()
+		if float64(liveKeyCount)/float64(seg.KeyCount()) > threshold {
+			return nil
+		}
+
+		if ttl > 0 && now.Sub(seg.GetSealTime()) >= ttl/2 {
+			return nil
+		}
+
+		if !seg.Reserve() {
+			return nil
+		}
+		return seg
+	}
+
+	return nil
+}
+
+// handleExpiredValuesRequest handles a controlLoopExpiredValuesRequest control message. A value relocated by the
+// compactor keeps the write time of the segment it was originally written to, and so it may expire long before the
+// segment it now lives in. Such values are returned so that the compactor can delete them. Values in segments that
+// have themselves expired are not returned, since they are reclaimed along with their segment.
+func (c *controlLoop) handleExpiredValuesRequest(req *controlLoopExpiredValuesRequest) {
+	expired := make([]*types.ScopedKey, 0)
+
+	now := c.clock()
+	ttl := c.metadata.GetTTL()
+	if ttl <= 0 {
+		req.responseChan <- expired
+		return
+	}
+
+	for index := c.lowestSegmentIndex; index <= c.highestSegmentIndex; index++ {
+		seg := c.segments[index]
+		if seg.IsSealed() && now.Sub(seg.GetSealTime()) >= ttl {
+			continue
+		}
+
+		lifetimes, err := seg.GetLifetimes()
+		if err != nil {
+			c.errorMonitor.Panic(fmt.Errorf("failed to get lifetimes of segment %d: %w", index, err))
+			return
+		}
+		for _, key := range lifetimes {
+			if key.Lifetime.WriteTime.IsZero() || key.Lifetime.Expiry(seg.GetSealTime(), ttl).After(now) {
+				continue
+			}
+			live, err := c.isLive(key)
+			if err != nil {
+				c.errorMonitor.Panic(fmt.Errorf("failed to check liveness of key: %w", err))
+				return
+			}
+			if live {
+				expired = append(expired, key)
+			}
+		}
+	}
+
+	req.responseChan <- expired
+}
+
+// takeUnflushedTombstones returns all tombstones that have not yet been scheduled for a flush, and resets the list.
+func (c *controlLoop) takeUnflushedTombstones() []*types.ScopedKey {
+	tombstones := c.unflushedTombstones
+	c.unflushedTombstones = nil
+	return tombstones
+}
+
+// takeUnflushedRelocations returns all relocations that have not yet been scheduled for a flush, and resets the list.
+func (c *controlLoop) takeUnflushedRelocations() []*types.ScopedKey {
+	relocations := c.unflushedRelocations
+	c.unflushedRelocations = nil
+	return relocations
+}
+
 // expandSegments seals the latest segment and creates a new mutable segment.
 func (c *controlLoop) expandSegments() error {
 	now := c.clock()
@@ -296,6 +700,8 @@ func (c *controlLoop) expandSegments() error {
 	request := &flushLoopSealRequest{
 		now:           now,
 		segmentToSeal: c.segments[c.highestSegmentIndex],
+		tombstones:    c.takeUnflushedTombstones(),
+		relocations:   c.takeUnflushedRelocations(),
 		responseChan:  flushLoopResponseChan,
 	}
 	err := c.flushLoop.enqueue(request)
@@ -313,6 +719,7 @@ func (c *controlLoop) expandSegments() error {
 
 	// Record the size of the segment.
 	c.immutableSegmentSize += c.segments[c.highestSegmentIndex].Size()
+	c.immutableSegmentUncompressedSize += c.segments[c.highestSegmentIndex].UncompressedSize()
 
 	// Create a new segment.
 	salt := [16]byte{}
@@ -328,6 +735,8 @@ func (c *controlLoop) expandSegments() error {
 		c.snapshottingEnabled,
 		c.metadata.GetShardingFactor(),
 		salt,
+		c.checksums,
+		c.metadata.GetCompression(),
 		c.fsync)
 	if err != nil {
 		return err
@@ -361,6 +770,8 @@ func (c *controlLoop) handleFlushRequest(req *controlLoopFlushRequest) {
 	// The flush loop is responsible for the remaining parts of the flush.
 	request := &flushLoopFlushRequest{
 		flushWaitFunction: flushWaitFunction,
+		tombstones:        c.takeUnflushedTombstones(),
+		relocations:       c.takeUnflushedRelocations(),
 		responseChan:      req.responseChan,
 	}
 	err = c.flushLoop.enqueue(request)
@@ -392,6 +803,29 @@ func (c *controlLoop) handleControlLoopSetShardingFactorRequest(req *controlLoop
 	}
 }
 
+// handleControlLoopSetCompressionRequest updates the compression type of the disk table. If the requested
+// compression type is the same as before, no action is taken. If it is different, the compression type is updated,
+// the current mutable segment is sealed, and a new mutable segment is created.
+func (c *controlLoop) handleControlLoopSetCompressionRequest(req *controlLoopSetCompressionRequest) {
+
+	if req.compression == c.metadata.GetCompression() {
+		// No action necessary.
+		return
+	}
+	err := c.metadata.SetCompression(req.compression)
+	if err != nil {
+		c.errorMonitor.Panic(fmt.Errorf("failed to set compression: %w", err))
+		return
+	}
+
+	// This seals the current mutable segment and creates a new one. The new segment will use the new compression.
+	err = c.expandSegments()
+	if err != nil {
+		c.errorMonitor.Panic(fmt.Errorf("failed to expand segments: %w", err))
+		return
+	}
+}
+
 // handleShutdownRequest performs tasks necessary to cleanly shut down the disk table.
 func (c *controlLoop) handleShutdownRequest(req *controlLoopShutdownRequest) {
 	// Instruct the flush loop to stop.
@@ -419,7 +853,7 @@ func (c *controlLoop) handleShutdownRequest(req *controlLoopShutdownRequest) {
 	}
 
 	// Flush the keys that are now durable in the segment.
-	err = c.diskTable.writeKeysToKeymap(durableKeys)
+	err = c.diskTable.writeKeysToKeymap(durableKeys, c.takeUnflushedTombstones(), c.takeUnflushedRelocations())
 	if err != nil {
 		c.errorMonitor.Panic(fmt.Errorf("failed to flush keys: %w", err))
 		return
@@ -1,6 +1,11 @@
 package disktable
 
-import "github.com/Layr-Labs/eigenda/litt/types"
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+)
 
 // This file contains various messages that can be sent to the disk table's control loop.
 
@@ -25,6 +30,59 @@ type controlLoopWriteRequest struct {
 
 	// values is a slice of key-value pairs to write.
 	values []*types.KVPair
+
+	// If true, then the values are written as a single atomic batch.
+	atomic bool
+
+	// A TTL override for the values, or 0 if the values use the table's TTL.
+	ttl time.Duration
+}
+
+// controlLoopDeleteRequest is a request to delete a key that is sent to the control loop.
+type controlLoopDeleteRequest struct {
+	controlLoopMessage
+
+	// The key to delete and the address of the value being deleted.
+	tombstone *types.ScopedKey
+}
+
+// controlLoopRelocateRequest is a request to move values from older segments into the mutable segment that is sent
+// to the control loop. Sent by the compactor.
+type controlLoopRelocateRequest struct {
+	controlLoopMessage
+
+	// The values to relocate.
+	values []*types.KVPair
+
+	// The current address of each value, in the same order as values.
+	previousAddresses []types.Address
+
+	// The lifetime of each value, in the same order as values. A relocated value keeps the time at which it was
+	// originally written, so that relocation does not extend its lifetime.
+	lifetimes []*types.Lifetime
+}
+
+// controlLoopCompactionCandidateRequest is a request for a segment that should be compacted that is sent to the
+// control loop. Sent by the compactor.
+type controlLoopCompactionCandidateRequest struct {
+	controlLoopMessage
+
+	// A segment is a candidate if the fraction of its keys that are live is less than or equal to this threshold.
+	// If zero, then only segments that are pinned by values with a TTL override are candidates.
+	threshold float64
+
+	// responseChan produces the reserved candidate segment, or nil if there is no candidate. It is the
+	// responsibility of the receiver to release the segment.
+	responseChan chan *segment.Segment
+}
+
+// controlLoopExpiredValuesRequest is a request for the relocated values that have outlived their original lifetime
+// that is sent to the control loop. Sent by the compactor.
+type controlLoopExpiredValuesRequest struct {
+	controlLoopMessage
+
+	// responseChan produces the keys and addresses of the expired values.
+	responseChan chan []*types.ScopedKey
 }
 
 // controlLoopSetShardingFactorRequest is a request to set the sharding factor that is sent to the control loop.
@@ -35,6 +93,14 @@ type controlLoopSetShardingFactorRequest struct {
 	shardingFactor uint32
 }
 
+// controlLoopSetCompressionRequest is a request to set the compression type that is sent to the control loop.
+type controlLoopSetCompressionRequest struct {
+	controlLoopMessage
+
+	// compression is the new compression type to set.
+	compression types.CompressionType
+}
+
 // controlLoopShutdownRequest is a request to shut down the table that is sent to the control loop.
 type controlLoopShutdownRequest struct {
 	controlLoopMessage
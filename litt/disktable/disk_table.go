@@ -63,6 +63,10 @@ type DiskTable struct {
 	// lookup table when data is requested from the table before it has been flushed to disk.
 	unflushedDataCache sync.Map
 
+	// unflushedTombstones contains keys that have been deleted, but whose deletion has not yet been applied to the
+	// keymap. Keys in this map are treated as if they do not exist.
+	unflushedTombstones sync.Map
+
 	// clock is the time source used by the disk table.
 	clock func() time.Time
 
@@ -70,6 +74,10 @@ type DiskTable struct {
 	// bytes that are on disk, not bytes in memory.
 	size atomic.Uint64
 
+	// The number of bytes that all segments would contain if values were not compressed. Used to compute the
+	// compression ratio of the table.
+	uncompressedSize atomic.Uint64
+
 	// The number of keys in the table.
 	keyCount atomic.Int64
 
@@ -79,6 +87,14 @@ type DiskTable struct {
 	// The flush loop is a goroutine responsible for blocking on flush operations.
 	flushLoop *flushLoop
 
+	// The compactor is a goroutine responsible for compacting sparse segments, and for moving long lived values out of
+	// expired segments.
+	compactor *compactor
+
+	// Held for writing by the compactor while it relocates values, and held for reading while a key is being deleted.
+	// Ensures that a tombstone always refers to the current address of the value it deletes.
+	compactionLock sync.RWMutex
+
 	// Encapsulates metrics for the database.
 	metrics *metrics.LittDBMetrics
 
@@ -159,7 +175,13 @@ func NewDiskTable(
 		// No metadata file exists yet. Create a new one in the first root.
 		var err error
 		metadataDir := qualifiedRoots[0]
-		metadata, err = newTableMetadata(config.Logger, metadataDir, config.TTL, config.ShardingFactor, config.Fsync)
+		metadata, err = newTableMetadata(
+			config.Logger,
+			metadataDir,
+			config.TTL,
+			config.ShardingFactor,
+			config.Compression,
+			config.Fsync)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create table metadata: %w", err)
 		}
@@ -207,15 +229,22 @@ func NewDiskTable(
 		return nil, fmt.Errorf("failed to gather segment files: %w", err)
 	}
 
+	tombstones, err := table.loadTombstones(segments, lowestSegmentIndex, highestSegmentIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tombstones: %w", err)
+	}
+
 	keyCount := int64(0)
 	for _, seg := range segments {
-		keyCount += int64(seg.KeyCount())
+		keyCount += int64(seg.KeyCount()) - int64(seg.DeletedKeyCount())
 	}
 	table.keyCount.Store(keyCount)
 
 	immutableSegmentSize := uint64(0)
+	immutableSegmentUncompressedSize := uint64(0)
 	for _, seg := range segments {
 		immutableSegmentSize += seg.Size()
+		immutableSegmentUncompressedSize += seg.UncompressedSize()
 	}
 
 	// Create the mutable segment
@@ -241,6 +270,8 @@ func NewDiskTable(
 		snapshottingEnabled,
 		metadata.GetShardingFactor(),
 		salt,
+		config.Checksums,
+		metadata.GetCompression(),
 		config.Fsync)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create mutable segment: %w", err)
@@ -253,12 +284,19 @@ func NewDiskTable(
 
 	if reloadKeymap {
 		config.Logger.Infof("reloading keymap from segments")
-		err = table.reloadKeymap(segments, lowestSegmentIndex, highestSegmentIndex)
+		err = table.reloadKeymap(segments, lowestSegmentIndex, highestSegmentIndex, tombstones)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load keymap from segments: %w", err)
 		}
 	}
 
+	// Tombstones are applied to the keymap after it is reloaded. This is idempotent, and also handles the case where
+	// the DB crashed after a tombstone became durable but before it was applied to the keymap.
+	err = table.applyTombstones(tombstones)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply tombstones to keymap: %w", err)
+	}
+
 	tableSaltShaker := rand.New(rand.NewSource(config.SaltShaker.Int63()))
 
 	var upperBoundSnapshotFile *BoundaryFile
@@ -298,6 +336,7 @@ func NewDiskTable(
 		highestSegmentIndex:     highestSegmentIndex,
 		segments:                segments,
 		size:                    &table.size,
+		uncompressedSize:        &table.uncompressedSize,
 		keyCount:                &table.keyCount,
 		targetFileSize:          config.TargetSegmentFileSize,
 		targetKeyFileSize:       config.TargetSegmentKeyFileSize,
@@ -307,6 +346,7 @@ func NewDiskTable(
 		snapshottingEnabled:     snapshottingEnabled,
 		saltShaker:              tableSaltShaker,
 		metadata:                metadata,
+		checksums:               config.Checksums,
 		fsync:                   config.Fsync,
 		metrics:                 metrics,
 		name:                    name,
@@ -315,12 +355,31 @@ func NewDiskTable(
 		flushLoop:               fLoop,
 		garbageCollectionPeriod: config.GCPeriod,
 		immutableSegmentSize:    immutableSegmentSize,
+
+		immutableSegmentUncompressedSize: immutableSegmentUncompressedSize,
 	}
 	cLoop.threadsafeHighestSegmentIndex.Store(highestSegmentIndex)
 	table.controlLoop = cLoop
 	cLoop.updateCurrentSize()
 	go cLoop.run()
 
+	// Start the compactor. Even if compaction of sparse segments is disabled, the compactor is still needed to move
+	// values with a TTL override out of expired segments, so that those segments can be garbage collected.
+	compactionPeriod := config.CompactionPeriod
+	compactionThreshold := config.CompactionThreshold
+	if compactionPeriod <= 0 {
+		compactionPeriod = config.GCPeriod
+		compactionThreshold = 0
+	}
+	table.compactor = newCompactor(
+		config.Logger,
+		table,
+		errorMonitor,
+		compactionPeriod,
+		compactionThreshold,
+		config.CompactionBytesPerSecond)
+	go table.compactor.run()
+
 	return table, nil
 }
 
@@ -329,7 +388,19 @@ func (d *DiskTable) KeyCount() uint64 {
 }
 
 func (d *DiskTable) Size() uint64 {
-	return d.size.Load()
+	size := d.size.Load()
+	if sizedKeymap, ok := d.keymap.(keymap.SizedKeymap); ok {
+		size += sizedKeymap.Size()
+	}
+	return size
+}
+
+func (d *DiskTable) CompressionRatio() float64 {
+	size := d.size.Load()
+	if size == 0 {
+		return 1.0
+	}
+	return float64(d.uncompressedSize.Load()) / float64(size)
 }
 
 // repairSnapshot is responsible for making any required repairs to the snapshot directories. This is needed
@@ -419,18 +490,128 @@ func (d *DiskTable) repairSnapshot(
 	return upperBoundSnapshotFile, nil
 }
 
+// loadedTombstone is a tombstone that was read from a segment while loading the table.
+type loadedTombstone struct {
+	// The deleted key and the address of the deleted value.
+	tombstone *types.ScopedKey
+
+	// If the tombstone was written when the value was relocated by the compactor, then this is the key and its new
+	// address. Otherwise nil.
+	relocation *types.ScopedKey
+}
+
+// loadTombstones reads the tombstones from all sealed segments, and marks the keys they delete in the segments
+// that contain the deleted values. Returns the tombstones that were found, in the order they were written.
+func (d *DiskTable) loadTombstones(
+	segments map[uint32]*segment.Segment,
+	lowestSegmentIndex uint32,
+	highestSegmentIndex uint32) ([]*loadedTombstone, error) {
+
+	tombstones := make([]*loadedTombstone, 0)
+	if len(segments) == 0 {
+		return tombstones, nil
+	}
+
+	for i := lowestSegmentIndex; i <= highestSegmentIndex; i++ {
+		if segments[i].TombstoneCount() == 0 {
+			continue
+		}
+
+		segmentTombstones, err := segments[i].GetTombstones()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tombstones from segment %d: %w", i, err)
+		}
+
+		// The compactor writes a relocated value and the tombstone for its old address to the same segment. If a
+		// segment contains both a tombstone and a value for the same key at a different address, then the tombstone
+		// was written by a relocation (or by a deletion followed by a new write, which is handled identically).
+		keys, err := segments[i].GetKeys()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get keys from segment %d: %w", i, err)
+		}
+		newestKeys := make(map[string]*types.ScopedKey, len(keys))
+		for _, key := range keys {
+			newestKeys[string(key.Key)] = key
+		}
+
+		for _, tombstone := range segmentTombstones {
+			target, ok := segments[tombstone.Address.Index()]
+			if ok {
+				target.MarkKeyDeleted()
+			}
+
+			loaded := &loadedTombstone{tombstone: tombstone}
+			if key, ok := newestKeys[string(tombstone.Key)]; ok && key.Address != tombstone.Address {
+				loaded.relocation = key
+			}
+			tombstones = append(tombstones, loaded)
+		}
+	}
+
+	return tombstones, nil
+}
+
+// applyTombstones applies tombstones to the keymap, in the order that they were written. If the keymap still points
+// to the address of the value that a tombstone deletes, then the key is removed from the keymap. If the tombstone was
+// written by a relocation, then the keymap is updated to point to the value's new address instead. A relocated key
+// may also be missing from the keymap entirely if the DB crashed while the keymap entry was being replaced.
+func (d *DiskTable) applyTombstones(tombstones []*loadedTombstone) error {
+	for _, loaded := range tombstones {
+		address, ok, err := d.keymap.Get(loaded.tombstone.Key)
+		if err != nil {
+			return fmt.Errorf("failed to get address: %w", err)
+		}
+		if ok && address != loaded.tombstone.Address {
+			// The tombstone has already been applied.
+			continue
+		}
+
+		if loaded.relocation != nil {
+			if ok {
+				err = d.keymap.Delete([]*types.ScopedKey{loaded.tombstone})
+				if err != nil {
+					return fmt.Errorf("failed to delete relocated key: %w", err)
+				}
+			}
+			err = d.keymap.Put([]*types.ScopedKey{loaded.relocation})
+			if err != nil {
+				return fmt.Errorf("failed to put relocated key: %w", err)
+			}
+		} else if ok {
+			err = d.keymap.Delete([]*types.ScopedKey{loaded.tombstone})
+			if err != nil {
+				return fmt.Errorf("failed to delete key: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // reloadKeymap reloads the keymap from the segments. This is necessary when the keymap is lost, the keymap doesn't
-// save its data on disk, or we are migrating from one keymap type to another.
+// save its data on disk, or we are migrating from one keymap type to another. Values that have been deleted or
+// relocated by a tombstone are not added to the keymap.
 func (d *DiskTable) reloadKeymap(
 	segments map[uint32]*segment.Segment,
 	lowestSegmentIndex uint32,
-	highestSegmentIndex uint32) error {
+	highestSegmentIndex uint32,
+	tombstones []*loadedTombstone) error {
 
 	start := d.clock()
 	defer func() {
 		d.logger.Infof("spent %v reloading keymap", d.clock().Sub(start))
 	}()
 
+	// Addresses are only unique within a shard, so deleted values are identified by both key and address.
+	type deletedValue struct {
+		key     string
+		address types.Address
+	}
+	deletedValues := make(map[deletedValue]struct{}, len(tombstones))
+	for _, loaded := range tombstones {
+		deletedValues[deletedValue{string(loaded.tombstone.Key), loaded.tombstone.Address}] = struct{}{}
+	}
+
 	batch := make([]*types.ScopedKey, 0, keymapReloadBatchSize)
 
 	for i := lowestSegmentIndex; i <= highestSegmentIndex; i++ {
@@ -446,6 +627,9 @@ func (d *DiskTable) reloadKeymap(
 		}
 		for keyIndex := len(keys) - 1; keyIndex >= 0; keyIndex-- {
 			key := keys[keyIndex]
+			if _, deleted := deletedValues[deletedValue{string(key.Key), key.Address}]; deleted {
+				continue
+			}
 
 			batch = append(batch, key)
 			if len(batch) == keymapReloadBatchSize {
@@ -500,6 +684,11 @@ func (d *DiskTable) Close() error {
 		return fmt.Errorf("cannot process Stop() request, DB is in panicked state due to error: %w", err)
 	}
 
+	if d.compactor != nil {
+		// The compactor flushes the table, so it must be stopped before the table stops accepting requests.
+		d.compactor.stop()
+	}
+
 	d.errorMonitor.Shutdown()
 
 	shutdownCompleteChan := make(chan struct{}, 1)
@@ -645,12 +834,37 @@ func (d *DiskTable) SetShardingFactor(shardingFactor uint32) error {
 	return nil
 }
 
+func (d *DiskTable) SetCompression(compression types.CompressionType) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf(
+			"cannot process SetCompression() request, DB is in panicked state due to error: %w", err)
+	}
+
+	if !compression.IsValid() {
+		return fmt.Errorf("unsupported compression type: %d", compression)
+	}
+
+	request := &controlLoopSetCompressionRequest{
+		compression: compression,
+	}
+	err := d.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send compression request: %w", err)
+	}
+
+	return nil
+}
+
 func (d *DiskTable) Get(key []byte) (value []byte, exists bool, err error) {
 	if ok, err := d.errorMonitor.IsOk(); !ok {
 		return nil, false, fmt.Errorf(
 			"cannot process Get() request, DB is in panicked state due to error: %w", err)
 	}
 
+	if _, deleted := d.unflushedTombstones.Load(util.UnsafeBytesToString(key)); deleted {
+		return nil, false, nil
+	}
+
 	// First, check if the key is in the unflushed data map.
 	// If so, return it from there.
 	if value, ok := d.unflushedDataCache.Load(util.UnsafeBytesToString(key)); ok {
@@ -693,6 +907,10 @@ func (d *DiskTable) CacheAwareGet(
 			"cannot process CacheAwareGet() request, DB is in panicked state due to error: %w", err)
 	}
 
+	if _, deleted := d.unflushedTombstones.Load(util.UnsafeBytesToString(key)); deleted {
+		return nil, false, false, nil
+	}
+
 	// First, check if the key is in the unflushed data map. If so, return it from there.
 	// Performance wise, this has equivalent semantics to reading the value from
 	// a cache, so we'd might as well count it as a cache hit.
@@ -739,10 +957,36 @@ func (d *DiskTable) Put(key []byte, value []byte) error {
 	return d.PutBatch([]*types.KVPair{{Key: key, Value: value}})
 }
 
+func (d *DiskTable) PutWithTTL(key []byte, value []byte, ttl time.Duration) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process PutWithTTL() request, DB is in panicked state due to error: %w", err)
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("TTL must be positive, got %v", ttl)
+	}
+	return d.putBatch([]*types.KVPair{{Key: key, Value: value}}, false, ttl)
+}
+
 func (d *DiskTable) PutBatch(batch []*types.KVPair) error {
 	if ok, err := d.errorMonitor.IsOk(); !ok {
 		return fmt.Errorf("cannot process PutBatch() request, DB is in panicked state due to error: %w", err)
 	}
+	return d.putBatch(batch, false, 0)
+}
+
+func (d *DiskTable) PutBatchAtomic(batch []*types.KVPair) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process PutBatchAtomic() request, DB is in panicked state due to error: %w", err)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return d.putBatch(batch, true, 0)
+}
+
+// putBatch writes a batch of values to the table. If atomic is true, the batch is written as a single atomic unit.
+// If ttl is non-zero, then the values are retained for at least that long, regardless of the table's TTL.
+func (d *DiskTable) putBatch(batch []*types.KVPair, atomic bool, ttl time.Duration) error {
 
 	if d.metrics != nil {
 		start := d.clock()
@@ -758,8 +1002,9 @@ func (d *DiskTable) PutBatch(batch []*types.KVPair) error {
 	}
 
 	for _, kv := range batch {
-		if len(kv.Key) > math.MaxUint32 {
-			return fmt.Errorf("key is too large, length must not exceed 2^32 bytes: %d bytes", len(kv.Key))
+		if len(kv.Key) >= math.MaxUint32 {
+			// A key length of 2^32-1 is reserved to mark the end of an atomic batch in key files.
+			return fmt.Errorf("key is too large, length must be less than 2^32-1 bytes: %d bytes", len(kv.Key))
 		}
 		if len(kv.Value) > math.MaxUint32 {
 			return fmt.Errorf("value is too large, length must not exceed 2^32 bytes: %d bytes", len(kv.Value))
@@ -776,6 +1021,8 @@ func (d *DiskTable) PutBatch(batch []*types.KVPair) error {
 
 	request := &controlLoopWriteRequest{
 		values: batch,
+		atomic: atomic,
+		ttl:    ttl,
 	}
 	err := d.controlLoop.enqueue(request)
 	if err != nil {
@@ -788,6 +1035,10 @@ func (d *DiskTable) PutBatch(batch []*types.KVPair) error {
 }
 
 func (d *DiskTable) Exists(key []byte) (bool, error) {
+	if _, deleted := d.unflushedTombstones.Load(util.UnsafeBytesToString(key)); deleted {
+		return false, nil
+	}
+
 	_, ok := d.unflushedDataCache.Load(util.UnsafeBytesToString(key))
 	if ok {
 		return true, nil
@@ -801,6 +1052,68 @@ func (d *DiskTable) Exists(key []byte) (bool, error) {
 	return ok, nil
 }
 
+func (d *DiskTable) Delete(key []byte) error {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return fmt.Errorf("cannot process Delete() request, DB is in panicked state due to error: %w", err)
+	}
+
+	if key == nil {
+		return fmt.Errorf("nil keys are not supported")
+	}
+
+	if _, ok := d.unflushedDataCache.Load(util.UnsafeBytesToString(key)); ok {
+		// A tombstone must refer to the address of the value it deletes, and the address of a value is not known
+		// until the value has been written to the keymap.
+		err := d.Flush()
+		if err != nil {
+			return fmt.Errorf("failed to flush before delete: %w", err)
+		}
+	}
+
+	// Prevent the compactor from relocating values until the tombstone has been enqueued.
+	d.compactionLock.RLock()
+	defer d.compactionLock.RUnlock()
+
+	return d.deleteAt(key, nil)
+}
+
+// deleteAt writes a tombstone for a key. If expectedAddress is not nil, then the key is only deleted if its value is
+// still stored at that address. The caller must hold the compaction lock.
+func (d *DiskTable) deleteAt(key []byte, expectedAddress *types.Address) error {
+	// Claim the key in the tombstone map before looking it up in the keymap. This ensures that concurrent deletions
+	// of the same key write at most one tombstone.
+	_, alreadyDeleted := d.unflushedTombstones.LoadOrStore(string(key), struct{}{})
+	if alreadyDeleted {
+		return nil
+	}
+
+	address, ok, err := d.keymap.Get(key)
+	if err != nil {
+		d.unflushedTombstones.Delete(util.UnsafeBytesToString(key))
+		return fmt.Errorf("failed to get address: %w", err)
+	}
+	if !ok || (expectedAddress != nil && address != *expectedAddress) {
+		// Deleting a key that does not exist is a no-op.
+		d.unflushedTombstones.Delete(util.UnsafeBytesToString(key))
+		return nil
+	}
+
+	request := &controlLoopDeleteRequest{
+		tombstone: &types.ScopedKey{
+			Key:     key,
+			Address: address,
+		},
+	}
+	err = d.controlLoop.enqueue(request)
+	if err != nil {
+		return fmt.Errorf("failed to send delete request: %w", err)
+	}
+
+	d.keyCount.Add(-1)
+
+	return nil
+}
+
 // Flush flushes all data to disk. Blocks until all data previously submitted to Put has been written to disk.
 func (d *DiskTable) Flush() error {
 	// The flush coordinator batches flush requests together to improve performance if
@@ -886,10 +1199,16 @@ func (d *DiskTable) RunGC() error {
 	return nil
 }
 
-// writeKeysToKeymap flushes all keys to the keymap. Once they are flushed, it also removes the keys from the
-// unflushedDataCache.
-func (d *DiskTable) writeKeysToKeymap(keys []*types.ScopedKey) error {
-	if len(keys) == 0 {
+// writeKeysToKeymap flushes all keys to the keymap, and then removes all deleted keys from the keymap. The keymap
+// entries for the previous locations of relocated values are removed before the new keys are written, since the keymap
+// may refuse to overwrite an existing key. Once this is done, it also removes the keys from the unflushedDataCache and
+// the tombstones from unflushedTombstones.
+func (d *DiskTable) writeKeysToKeymap(
+	keys []*types.ScopedKey,
+	tombstones []*types.ScopedKey,
+	relocations []*types.ScopedKey) error {
+
+	if len(keys) == 0 && len(tombstones) == 0 && len(relocations) == 0 {
 		// Nothing to flush.
 		return nil
 	}
@@ -903,9 +1222,26 @@ func (d *DiskTable) writeKeysToKeymap(keys []*types.ScopedKey) error {
 		}()
 	}
 
-	err := d.keymap.Put(keys)
-	if err != nil {
-		return fmt.Errorf("failed to flush keys: %w", err)
+	if len(relocations) > 0 {
+		// Readers continue to find relocated values in the unflushed data cache until the new keys are written.
+		err := d.keymap.Delete(relocations)
+		if err != nil {
+			return fmt.Errorf("failed to delete relocated keys: %w", err)
+		}
+	}
+
+	if len(keys) > 0 {
+		err := d.keymap.Put(keys)
+		if err != nil {
+			return fmt.Errorf("failed to flush keys: %w", err)
+		}
+	}
+
+	if len(tombstones) > 0 {
+		err := d.keymap.Delete(tombstones)
+		if err != nil {
+			return fmt.Errorf("failed to delete keys: %w", err)
+		}
 	}
 
 	// Keys are now durably written to both the segment and the keymap. It is therefore safe to remove them from the
@@ -913,6 +1249,9 @@ func (d *DiskTable) writeKeysToKeymap(keys []*types.ScopedKey) error {
 	for _, ka := range keys {
 		d.unflushedDataCache.Delete(util.UnsafeBytesToString(ka.Key))
 	}
+	for _, tombstone := range tombstones {
+		d.unflushedTombstones.Delete(util.UnsafeBytesToString(tombstone.Key))
+	}
 
 	return nil
 }
@@ -0,0 +1,250 @@
+package disktable
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+)
+
+var _ litt.Iterator = &diskTableIterator{}
+
+// diskTableIterator iterates over the keys in a DiskTable. Keys are drawn from two sources: data that has not yet
+// been flushed to the keymap (which lives in the unflushed data cache), and data that is present in the keymap.
+// The two sources are merged into a single lexicographically ordered stream.
+type diskTableIterator struct {
+	// The table being iterated over.
+	table *DiskTable
+
+	// Key-value pairs that were in the unflushed data cache when the iterator was created, sorted by key.
+	unflushed []*types.KVPair
+
+	// The index of the next entry in unflushed to consider.
+	unflushedIndex int
+
+	// Keys that were deleted but whose deletion had not yet been applied to the keymap when the iterator was created.
+	deleted map[string]struct{}
+
+	// Iterates over a snapshot of the keymap.
+	keymapIterator keymap.Iterator
+
+	// If true, then keymapIterator is positioned on an entry that has not yet been returned.
+	keymapPending bool
+
+	// If true, then keymapIterator has no more entries.
+	keymapExhausted bool
+
+	// Segments reserved by this iterator, keyed by segment index. Holding these reservations prevents the
+	// garbage collector from deleting value files out from under the iterator.
+	segments map[uint32]*segment.Segment
+
+	// The key at the current position.
+	currentKey []byte
+
+	// If true, then the current entry came from the unflushed data cache and its value is currentValue. Otherwise,
+	// the current entry came from the keymap and its value must be read from disk at currentAddress. This can't be
+	// inferred from currentValue, since a value stored in the cache may legitimately be nil or empty.
+	currentFromCache bool
+
+	// The value at the current position if the current entry came from the unflushed data cache.
+	currentValue []byte
+
+	// The address of the current entry if it came from the keymap.
+	currentAddress types.Address
+
+	// The first error encountered while iterating.
+	err error
+
+	// Set to true once the iterator has been closed.
+	closed bool
+}
+
+func (d *DiskTable) Iterator() (litt.Iterator, error) {
+	return d.PrefixIterator(nil)
+}
+
+func (d *DiskTable) PrefixIterator(prefix []byte) (litt.Iterator, error) {
+	if ok, err := d.errorMonitor.IsOk(); !ok {
+		return nil, fmt.Errorf(
+			"cannot process PrefixIterator() request, DB is in panicked state due to error: %w", err)
+	}
+
+	// The order of the following steps matters. Data moves from the unflushed data cache into the keymap, and
+	// so the cache must be captured before the keymap. If the order were reversed, a key flushed between the two
+	// steps could be missed entirely. With this ordering, the worst case is that a key is observed in both places,
+	// which is handled by de-duplication when the two sources are merged.
+	unflushed := make([]*types.KVPair, 0)
+	d.unflushedDataCache.Range(func(key, value any) bool {
+		keyBytes := []byte(key.(string))
+		if bytes.HasPrefix(keyBytes, prefix) {
+			unflushed = append(unflushed, &types.KVPair{Key: keyBytes, Value: value.([]byte)})
+		}
+		return true
+	})
+	sort.Slice(unflushed, func(a, b int) bool {
+		return bytes.Compare(unflushed[a].Key, unflushed[b].Key) < 0
+	})
+
+	// Tombstones must also be captured before the keymap. A tombstone is removed from the tombstone map once it has
+	// been applied to the keymap, and so a key deleted before this point may still be present in the keymap snapshot.
+	deleted := make(map[string]struct{})
+	d.unflushedTombstones.Range(func(key, _ any) bool {
+		if bytes.HasPrefix([]byte(key.(string)), prefix) {
+			deleted[key.(string)] = struct{}{}
+		}
+		return true
+	})
+
+	// Segments must be reserved before the keymap snapshot is taken. The garbage collector removes keys from the
+	// keymap before it releases a segment, so any key present in the snapshot refers either to a segment reserved
+	// here or to a segment created after this point.
+	segments := d.controlLoop.reserveAllSegments()
+
+	keymapIterator, err := d.keymap.Iterator(prefix)
+	if err != nil {
+		for _, seg := range segments {
+			seg.Release()
+		}
+		return nil, fmt.Errorf("failed to create keymap iterator: %w", err)
+	}
+
+	return &diskTableIterator{
+		table:          d,
+		unflushed:      unflushed,
+		deleted:        deleted,
+		keymapIterator: keymapIterator,
+		segments:       segments,
+	}, nil
+}
+
+func (i *diskTableIterator) Next() bool {
+	for i.advance() {
+		// Skip keys that were deleted but whose deletion had not yet been applied to the keymap when the iterator was
+		// created. Deletions made after that point are not visible to the iterator.
+		if _, deleted := i.deleted[util.UnsafeBytesToString(i.currentKey)]; deleted {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// advance moves the iterator to the next key from either source. Returns false if there are no more keys.
+func (i *diskTableIterator) advance() bool {
+	if i.closed || i.err != nil {
+		return false
+	}
+
+	if !i.keymapPending && !i.keymapExhausted {
+		if i.keymapIterator.Next() {
+			i.keymapPending = true
+		} else {
+			i.keymapExhausted = true
+			if err := i.keymapIterator.Error(); err != nil {
+				i.err = fmt.Errorf("failed to iterate over keymap: %w", err)
+				return false
+			}
+		}
+	}
+
+	unflushedPending := i.unflushedIndex < len(i.unflushed)
+
+	if !unflushedPending && !i.keymapPending {
+		i.currentKey = nil
+		i.currentFromCache = false
+		i.currentValue = nil
+		return false
+	}
+
+	var comparison int
+	if !unflushedPending {
+		comparison = 1
+	} else if !i.keymapPending {
+		comparison = -1
+	} else {
+		comparison = bytes.Compare(i.unflushed[i.unflushedIndex].Key, i.keymapIterator.Key())
+	}
+
+	if comparison <= 0 {
+		// The next key comes from the unflushed data cache. Its value is already in memory.
+		next := i.unflushed[i.unflushedIndex]
+		i.unflushedIndex++
+		i.currentKey = next.Key
+		i.currentFromCache = true
+		i.currentValue = next.Value
+
+		if comparison == 0 {
+			// This key was flushed while the iterator was being created and appears in both sources.
+			i.keymapPending = false
+		}
+	} else {
+		i.currentKey = i.keymapIterator.Key()
+		i.currentFromCache = false
+		i.currentValue = nil
+		i.currentAddress = i.keymapIterator.Address()
+		i.keymapPending = false
+	}
+
+	return true
+}
+
+func (i *diskTableIterator) Key() []byte {
+	return i.currentKey
+}
+
+func (i *diskTableIterator) Value() ([]byte, error) {
+	if i.closed {
+		return nil, fmt.Errorf("iterator is closed")
+	}
+	if i.currentKey == nil {
+		return nil, fmt.Errorf("iterator is not positioned on a key")
+	}
+	if i.currentFromCache {
+		return i.currentValue, nil
+	}
+
+	segmentIndex := i.currentAddress.Index()
+	seg, ok := i.segments[segmentIndex]
+	if !ok {
+		// The key was flushed into a segment that was created after the iterator was created.
+		// Newly created segments are never the target of garbage collection, so it is safe to reserve it now.
+		seg, ok = i.table.controlLoop.getReservedSegment(segmentIndex)
+		if !ok {
+			return nil, fmt.Errorf("segment %d is no longer available", segmentIndex)
+		}
+		i.segments[segmentIndex] = seg
+	}
+
+	value, err := seg.Read(i.currentKey, i.currentAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value: %w", err)
+	}
+
+	return value, nil
+}
+
+func (i *diskTableIterator) Error() error {
+	return i.err
+}
+
+func (i *diskTableIterator) Close() error {
+	if i.closed {
+		return nil
+	}
+	i.closed = true
+
+	i.keymapIterator.Release()
+	for _, seg := range i.segments {
+		seg.Release()
+	}
+	i.segments = nil
+	i.unflushed = nil
+	i.deleted = nil
+
+	return nil
+}
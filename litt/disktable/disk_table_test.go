@@ -1,10 +1,12 @@
 package disktable
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -104,6 +106,7 @@ func buildMemKeyDiskTableSingleShard(
 	config.Clock = clock
 	config.TargetSegmentFileSize = 100 // intentionally use a very small segment size
 	config.GCPeriod = time.Millisecond
+	config.CompactionPeriod = time.Hour
 	config.Fsync = false
 	config.SaltShaker = random.NewTestRandom().Rand
 	config.Logger = logger
@@ -151,6 +154,7 @@ func buildMemKeyDiskTableMultiShard(
 	config.Clock = clock
 	config.TargetSegmentFileSize = 100 // intentionally use a very small segment size
 	config.GCPeriod = time.Millisecond
+	config.CompactionPeriod = time.Hour
 	config.Fsync = false
 	config.SaltShaker = random.NewTestRandom().Rand
 	config.ShardingFactor = 4
@@ -198,6 +202,7 @@ func buildLevelDBKeyDiskTableSingleShard(
 	config.Clock = clock
 	config.TargetSegmentFileSize = 100 // intentionally use a very small segment size
 	config.GCPeriod = time.Millisecond
+	config.CompactionPeriod = time.Hour
 	config.Fsync = false
 	config.SaltShaker = random.NewTestRandom().Rand
 	config.Logger = logger
@@ -244,6 +249,7 @@ func buildLevelDBKeyDiskTableMultiShard(
 	config.Clock = clock
 	config.TargetSegmentFileSize = 100 // intentionally use a very small segment size
 	config.GCPeriod = time.Millisecond
+	config.CompactionPeriod = time.Hour
 	config.Fsync = false
 	config.SaltShaker = random.NewTestRandom().Rand
 	config.ShardingFactor = 4
@@ -1609,6 +1615,9 @@ func metadataPreservedOnRestartTest(t *testing.T, tableBuilder *tableBuilder) {
 	shardingFactor := rand.Uint32Range(1, 100)
 	err = table.SetShardingFactor(shardingFactor)
 	require.NoError(t, err)
+	compression := types.CompressionType(rand.Intn(int(types.ZstdCompression) + 1))
+	err = table.SetCompression(compression)
+	require.NoError(t, err)
 
 	// Stop the table
 	ok, _ := table.(*DiskTable).errorMonitor.IsOk()
@@ -1627,6 +1636,9 @@ func metadataPreservedOnRestartTest(t *testing.T, tableBuilder *tableBuilder) {
 	actualShardingFactor := (table.(*DiskTable)).metadata.GetShardingFactor()
 	require.Equal(t, shardingFactor, actualShardingFactor)
 
+	actualCompression := (table.(*DiskTable)).metadata.GetCompression()
+	require.Equal(t, compression, actualCompression)
+
 	err = table.Destroy()
 	require.NoError(t, err)
 }
@@ -2053,6 +2065,93 @@ func TestChangingShardingFactor(t *testing.T) {
 	}
 }
 
+func changingCompressionTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, []string{directory})
+	if err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	err = table.SetCompression(types.CompressionType(0xff))
+	require.Error(t, err)
+
+	compression := types.ZstdCompression
+	err = table.SetCompression(compression)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+
+	iterations := 1000
+	restartIteration := iterations/2 + int(rand.Int64Range(-10, 10))
+
+	for i := 0; i < iterations; i++ {
+
+		// Somewhere in the middle of the test, restart the table.
+		if i == restartIteration {
+			ok, _ := table.(*DiskTable).errorMonitor.IsOk()
+			require.True(t, ok)
+			err = table.Close()
+			require.NoError(t, err)
+
+			table, err = tableBuilder.builder(time.Now, tableName, []string{directory})
+			require.NoError(t, err)
+			require.Equal(t, compression, table.(*DiskTable).metadata.GetCompression())
+		}
+
+		// Write some highly compressible data.
+		key := rand.PrintableVariableBytes(32, 64)
+		value := bytes.Repeat(rand.PrintableBytes(8), rand.Intn(32)+16)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+
+		// Once in a while, change the compression type. Data that is already written must remain readable.
+		if rand.BoolWithProbability(0.01) {
+			compression = types.CompressionType(rand.Intn(int(types.ZstdCompression) + 1))
+			err = table.SetCompression(compression)
+			require.NoError(t, err)
+		}
+
+		// Once in a while, flush the table.
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+	}
+
+	err = table.Flush()
+	require.NoError(t, err)
+
+	for expectedKey, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(expectedKey))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+
+	// The first segments were written with compression, so the ratio must exceed 1.
+	require.Greater(t, table.CompressionRatio(), 1.0)
+
+	ok, _ := table.(*DiskTable).errorMonitor.IsOk()
+	require.True(t, ok)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestChangingCompression(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			changingCompressionTest(t, tb)
+		})
+	}
+}
+
 // verifies that the size reported by the table matches the actual size of the table on disk
 func tableSizeTest(t *testing.T, tableBuilder *tableBuilder) {
 	rand := random.NewTestRandom()
@@ -2113,7 +2212,12 @@ func tableSizeTest(t *testing.T, tableBuilder *tableBuilder) {
 				expectedValues[string(key)] = value
 				creationTimes[string(key)] = newTime
 			}
-			err = table.PutBatch(batch)
+			if rand.Bool() {
+				// Atomic batches write additional markers to the key file, which must be reflected in the size.
+				err = table.PutBatchAtomic(batch)
+			} else {
+				err = table.PutBatch(batch)
+			}
 			require.NoError(t, err)
 		}
 
@@ -2303,3 +2407,755 @@ func TestTableSize(t *testing.T) {
 		})
 	}
 }
+
+func deleteTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, []string{directory})
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	deletedKeys := make(map[string]struct{})
+
+	// verifyContents checks that the table contains exactly the expected values.
+	verifyContents := func() {
+		for key, expectedValue := range expectedValues {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+		for key := range deletedKeys {
+			_, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.False(t, ok)
+
+			ok, err = table.Exists([]byte(key))
+			require.NoError(t, err)
+			require.False(t, ok)
+		}
+		require.Equal(t, uint64(len(expectedValues)), table.KeyCount())
+
+		iterator, err := table.Iterator()
+		require.NoError(t, err)
+		visited := 0
+		for iterator.Next() {
+			_, expected := expectedValues[string(iterator.Key())]
+			require.True(t, expected)
+			visited++
+		}
+		require.NoError(t, iterator.Error())
+		require.NoError(t, iterator.Close())
+		require.Equal(t, len(expectedValues), visited)
+	}
+
+	iterations := 1000
+	restartIteration := iterations/2 + int(rand.Int64Range(-10, 10))
+
+	for i := 0; i < iterations; i++ {
+
+		// Somewhere in the middle of the test, restart the table.
+		if i == restartIteration {
+			verifyContents()
+
+			err = table.Close()
+			require.NoError(t, err)
+			awaitSegmentDeletion(t, table)
+
+			table, err = tableBuilder.builder(time.Now, tableName, []string{directory})
+			require.NoError(t, err)
+
+			verifyContents()
+		}
+
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+
+		// Once in a while, delete a random key. Some keys are deleted before they are flushed.
+		if rand.BoolWithProbability(0.25) {
+			for keyToDelete := range expectedValues {
+				err = table.Delete([]byte(keyToDelete))
+				require.NoError(t, err)
+				delete(expectedValues, keyToDelete)
+				deletedKeys[keyToDelete] = struct{}{}
+				break
+			}
+		}
+
+		// Once in a while, delete a key that does not exist.
+		if rand.BoolWithProbability(0.05) {
+			err = table.Delete(rand.PrintableVariableBytes(32, 64))
+			require.NoError(t, err)
+		}
+
+		// Once in a while, flush the table.
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+	}
+
+	err = table.Flush()
+	require.NoError(t, err)
+	verifyContents()
+
+	// Deleting a key a second time should have no effect.
+	for key := range deletedKeys {
+		err = table.Delete([]byte(key))
+		require.NoError(t, err)
+		break
+	}
+	require.Equal(t, uint64(len(expectedValues)), table.KeyCount())
+
+	// Delete all remaining keys. There is no TTL, but garbage collection should still reclaim all sealed segments,
+	// since none of them contain live data.
+	for key := range expectedValues {
+		err = table.Delete([]byte(key))
+		require.NoError(t, err)
+		deletedKeys[key] = struct{}{}
+	}
+	expectedValues = make(map[string][]byte)
+
+	// Seal the mutable segment so that it can be garbage collected.
+	err = table.SetShardingFactor(table.(*DiskTable).metadata.GetShardingFactor() + 1)
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	verifyContents()
+	controlLoop := table.(*DiskTable).controlLoop
+	require.Equal(t, controlLoop.highestSegmentIndex, controlLoop.lowestSegmentIndex)
+
+	// Deletions should be durable across a restart.
+	err = table.Close()
+	require.NoError(t, err)
+	awaitSegmentDeletion(t, table)
+	table, err = tableBuilder.builder(time.Now, tableName, []string{directory})
+	require.NoError(t, err)
+	verifyContents()
+
+	ok, _ := table.(*DiskTable).errorMonitor.IsOk()
+	require.True(t, ok)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+// awaitSegmentDeletion blocks until all segments that were garbage collected by a closed table have been removed
+// from disk. Segment files are deleted asynchronously, and so a table that is reopened immediately after it is closed
+// may otherwise observe partially deleted segments.
+func awaitSegmentDeletion(t *testing.T, table litt.ManagedTable) {
+	diskTable := table.(*DiskTable)
+	lowestSegmentIndex := diskTable.controlLoop.lowestSegmentIndex
+
+	require.Eventually(t, func() bool {
+		for _, segmentPath := range diskTable.segmentPaths {
+			entries, err := os.ReadDir(segmentPath.SegmentDirectory())
+			require.NoError(t, err)
+			for _, entry := range entries {
+				indexString := strings.FieldsFunc(entry.Name(), func(r rune) bool {
+					return r == '.' || r == '-'
+				})[0]
+				index, err := strconv.ParseUint(indexString, 10, 32)
+				if err == nil && uint32(index) < lowestSegmentIndex {
+					return false
+				}
+			}
+		}
+		return true
+	}, time.Second, time.Millisecond)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			deleteTest(t, tb)
+		})
+	}
+}
+
+func compactionTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, []string{directory})
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	deletedKeys := make(map[string]struct{})
+
+	// verifyContents checks that the table contains exactly the expected values.
+	verifyContents := func() {
+		for key, expectedValue := range expectedValues {
+			value, ok, err := table.Get([]byte(key))
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, expectedValue, value)
+		}
+		for key := range deletedKeys {
+			ok, err := table.Exists([]byte(key))
+			require.NoError(t, err)
+			require.False(t, ok)
+		}
+		require.Equal(t, uint64(len(expectedValues)), table.KeyCount())
+
+		iterator, err := table.Iterator()
+		require.NoError(t, err)
+		visited := 0
+		for iterator.Next() {
+			expectedValue, expected := expectedValues[string(iterator.Key())]
+			require.True(t, expected)
+			value, err := iterator.Value()
+			require.NoError(t, err)
+			require.Equal(t, expectedValue, value)
+			visited++
+		}
+		require.NoError(t, iterator.Error())
+		require.NoError(t, iterator.Close())
+		require.Equal(t, len(expectedValues), visited)
+	}
+
+	for i := 0; i < 500; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// Make every sealed segment sparse by deleting all but the first key in each segment. Segments with a single key
+	// end up with no live data at all.
+	diskTable := table.(*DiskTable)
+	highestSealedIndex := uint32(0)
+	segments := diskTable.controlLoop.reserveAllSegments()
+	for index, seg := range segments {
+		if seg.IsSealed() {
+			highestSealedIndex = max(highestSealedIndex, index)
+
+			keys, err := seg.GetKeys()
+			require.NoError(t, err)
+			for keyIndex, key := range keys {
+				if keyIndex == 0 && len(keys) > 1 {
+					continue
+				}
+				err = table.Delete(key.Key)
+				require.NoError(t, err)
+				delete(expectedValues, string(key.Key))
+				deletedKeys[string(key.Key)] = struct{}{}
+			}
+		}
+		seg.Release()
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+	verifyContents()
+
+	sizeBeforeCompaction := table.Size()
+
+	// Delete some of the remaining keys while compaction is running.
+	keysToDelete := make([][]byte, 0)
+	for key := range expectedValues {
+		if rand.BoolWithProbability(0.25) {
+			keysToDelete = append(keysToDelete, []byte(key))
+		}
+	}
+	deletionsComplete := make(chan struct{})
+	go func() {
+		defer close(deletionsComplete)
+		for _, key := range keysToDelete {
+			err := table.Delete(key)
+			require.NoError(t, err)
+		}
+	}()
+
+	err = diskTable.compactor.compact()
+	require.NoError(t, err)
+	<-deletionsComplete
+	for _, key := range keysToDelete {
+		delete(expectedValues, string(key))
+		deletedKeys[string(key)] = struct{}{}
+	}
+
+	err = table.Flush()
+	require.NoError(t, err)
+	err = table.RunGC()
+	require.NoError(t, err)
+	verifyContents()
+
+	// Every segment that existed prior to compaction should have been garbage collected.
+	require.Greater(t, diskTable.controlLoop.lowestSegmentIndex, highestSealedIndex)
+	require.Less(t, table.Size(), sizeBeforeCompaction)
+
+	// Relocated values should be durable across a restart.
+	err = table.Close()
+	require.NoError(t, err)
+	awaitSegmentDeletion(t, table)
+	table, err = tableBuilder.builder(time.Now, tableName, []string{directory})
+	require.NoError(t, err)
+	verifyContents()
+
+	ok, _ := table.(*DiskTable).errorMonitor.IsOk()
+	require.True(t, ok)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestCompaction(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			compactionTest(t, tb)
+		})
+	}
+}
+
+func compactionPreservesLifetimeTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+	advanceClock := func(delta time.Duration) {
+		newTime := fakeTime.Load().Add(delta)
+		fakeTime.Store(&newTime)
+	}
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(clock, tableName, []string{directory})
+	require.NoError(t, err)
+	diskTable := table.(*DiskTable)
+
+	ttl := 10 * time.Second
+	err = table.SetTTL(ttl)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 500; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// Make every sealed segment sparse by deleting all but the first key in each segment. Segments with a single key
+	// end up with no live data at all.
+	segments := diskTable.controlLoop.reserveAllSegments()
+	for _, seg := range segments {
+		if seg.IsSealed() {
+			keys, err := seg.GetKeys()
+			require.NoError(t, err)
+			for keyIndex, key := range keys {
+				if keyIndex == 0 && len(keys) > 1 {
+					continue
+				}
+				err = table.Delete(key.Key)
+				require.NoError(t, err)
+				delete(expectedValues, string(key.Key))
+			}
+		}
+		seg.Release()
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	originalAddresses := make(map[string]types.Address)
+	for key := range expectedValues {
+		address, ok, err := diskTable.keymap.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		originalAddresses[key] = address
+	}
+
+	// Compact before the segments are halfway to expiring.
+	advanceClock(ttl / 4)
+	err = diskTable.compactor.compact()
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+
+	relocatedKeys := make([]string, 0)
+	for key, expectedValue := range expectedValues {
+		address, ok, err := diskTable.keymap.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		if address == originalAddresses[key] {
+			continue
+		}
+		relocatedKeys = append(relocatedKeys, key)
+
+		value, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+	require.NotEmpty(t, relocatedKeys)
+
+	// Once the table's TTL has elapsed since the values were originally written, the relocated values are deleted,
+	// even though the segment they were relocated to (which was sealed no earlier than the compaction) has not yet
+	// expired.
+	advanceClock(ttl - ttl/8)
+	err = diskTable.compactor.compact()
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	for _, key := range relocatedKeys {
+		_, ok, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+
+	ok, _ := diskTable.errorMonitor.IsOk()
+	require.True(t, ok)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestCompactionPreservesLifetime(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			compactionPreservesLifetimeTest(t, tb)
+		})
+	}
+}
+
+func ttlOverrideTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+	advanceClock := func(delta time.Duration) {
+		newTime := fakeTime.Load().Add(delta)
+		fakeTime.Store(&newTime)
+	}
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(clock, tableName, []string{directory})
+	require.NoError(t, err)
+
+	ttl := 10 * time.Second
+	err = table.SetTTL(ttl)
+	require.NoError(t, err)
+
+	longTTL := 100 * time.Second
+	err = table.PutWithTTL(rand.PrintableBytes(32), rand.PrintableBytes(32), 0)
+	require.Error(t, err)
+
+	// Write values before and after a single value with a long TTL. Write enough values to span many segments.
+	expectedValues := make(map[string][]byte)
+	keys := make([]string, 0)
+	for i := 0; i < 200; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+		keys = append(keys, string(key))
+	}
+	longKey := rand.PrintableVariableBytes(32, 64)
+	longValue := rand.PrintableVariableBytes(1, 128)
+	err = table.PutWithTTL(longKey, longValue, longTTL)
+	require.NoError(t, err)
+	for i := 0; i < 200; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+		keys = append(keys, string(key))
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// segmentIndex returns the index of the segment containing a key, or false if the key is not in the table.
+	segmentIndex := func(key []byte) (uint32, bool) {
+		address, ok, err := table.(*DiskTable).keymap.Get(key)
+		require.NoError(t, err)
+		return address.Index(), ok
+	}
+	longSegment, ok := segmentIndex(longKey)
+	require.True(t, ok)
+	require.Less(t, longSegment, table.(*DiskTable).controlLoop.threadsafeHighestSegmentIndex.Load())
+
+	// Once the table's TTL has elapsed, only the segments before the long lived value may be garbage collected.
+	advanceClock(2 * ttl)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	value, ok, err := table.Get(longKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, longValue, value)
+
+	expiredCount := 0
+	for _, key := range keys {
+		index, ok := segmentIndex([]byte(key))
+		value, exists, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.Equal(t, ok, exists)
+		if !ok {
+			expiredCount++
+			continue
+		}
+		require.GreaterOrEqual(t, index, longSegment)
+		require.Equal(t, expectedValues[key], value)
+	}
+	require.Greater(t, expiredCount, 0)
+
+	// The compactor moves the long lived value out of its expired segment, after which every expired segment can be
+	// garbage collected.
+	diskTable := table.(*DiskTable)
+	mutableSegment := diskTable.controlLoop.threadsafeHighestSegmentIndex.Load()
+	err = diskTable.compactor.compact()
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	relocatedSegment, ok := segmentIndex(longKey)
+	require.True(t, ok)
+	require.GreaterOrEqual(t, relocatedSegment, mutableSegment)
+	value, ok, err = table.Get(longKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, longValue, value)
+
+	for _, key := range keys {
+		index, ok := segmentIndex([]byte(key))
+		_, exists, err := table.Get([]byte(key))
+		require.NoError(t, err)
+		require.Equal(t, ok, exists)
+		if ok {
+			require.GreaterOrEqual(t, index, mutableSegment)
+		}
+	}
+
+	// The lifetime of the relocated value must survive a restart.
+	err = table.Close()
+	require.NoError(t, err)
+	awaitSegmentDeletion(t, table)
+	table, err = tableBuilder.builder(clock, tableName, []string{directory})
+	require.NoError(t, err)
+	diskTable = table.(*DiskTable)
+
+	// The segment the value was relocated to has now expired, so the value is relocated a second time.
+	advanceClock(longTTL / 2)
+	err = table.RunGC()
+	require.NoError(t, err)
+	err = diskTable.compactor.compact()
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+	err = table.RunGC()
+	require.NoError(t, err)
+
+	value, ok, err = table.Get(longKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, longValue, value)
+	for _, key := range keys {
+		_, ok, err = table.Get([]byte(key))
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+
+	// Relocation does not extend the lifetime of a value. Once the long TTL has elapsed since the value was originally
+	// written, the value is deleted. If the segment that now contains it has not expired, the compactor deletes it.
+	// Otherwise, the garbage collector reclaims the segment.
+	advanceClock(longTTL/2 - ttl)
+	relocatedSegment, ok = segmentIndex(longKey)
+	require.True(t, ok)
+	inMutableSegment := relocatedSegment == diskTable.controlLoop.threadsafeHighestSegmentIndex.Load()
+	err = diskTable.compactor.compact()
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+	if inMutableSegment {
+		_, ok, err = table.Get(longKey)
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+	err = table.RunGC()
+	require.NoError(t, err)
+	_, ok, err = table.Get(longKey)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// The deletion is durable.
+	err = table.Close()
+	require.NoError(t, err)
+	awaitSegmentDeletion(t, table)
+	table, err = tableBuilder.builder(clock, tableName, []string{directory})
+	require.NoError(t, err)
+	_, ok, err = table.Get(longKey)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, _ = table.(*DiskTable).errorMonitor.IsOk()
+	require.True(t, ok)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestTTLOverride(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			ttlOverrideTest(t, tb)
+		})
+	}
+}
+
+func iteratorNilCachedValueTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, []string{directory})
+	require.NoError(t, err)
+
+	flushedKey := []byte("a-flushed")
+	flushedValue := rand.PrintableVariableBytes(1, 128)
+	err = table.Put(flushedKey, flushedValue)
+	require.NoError(t, err)
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// The compactor moves values through the unflushed data cache in their decompressed form, and decompressing an
+	// empty zstd value yields nil. Such a value must be returned from the cache, not read from disk.
+	cachedKey := "b-cached"
+	table.(*DiskTable).unflushedDataCache.Store(cachedKey, []byte(nil))
+
+	iterator, err := table.Iterator()
+	require.NoError(t, err)
+
+	require.True(t, iterator.Next())
+	require.Equal(t, flushedKey, iterator.Key())
+	value, err := iterator.Value()
+	require.NoError(t, err)
+	require.Equal(t, flushedValue, value)
+
+	require.True(t, iterator.Next())
+	require.Equal(t, []byte(cachedKey), iterator.Key())
+	value, err = iterator.Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	require.False(t, iterator.Next())
+	require.NoError(t, iterator.Error())
+	require.NoError(t, iterator.Close())
+
+	table.(*DiskTable).unflushedDataCache.Delete(cachedKey)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestIteratorNilCachedValue(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iteratorNilCachedValueTest(t, tb)
+		})
+	}
+}
+
+func iteratorIgnoresLaterDeletesTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, []string{directory})
+	require.NoError(t, err)
+
+	keys := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, values[i])
+		require.NoError(t, err)
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	iterator, err := table.Iterator()
+	require.NoError(t, err)
+
+	// The deletion is not flushed, so its tombstone stays in the table's unflushed tombstones while iterating.
+	err = table.Delete(keys[1])
+	require.NoError(t, err)
+
+	for i, key := range keys {
+		require.True(t, iterator.Next())
+		require.Equal(t, key, iterator.Key())
+		value, err := iterator.Value()
+		require.NoError(t, err)
+		require.Equal(t, values[i], value)
+	}
+	require.False(t, iterator.Next())
+	require.NoError(t, iterator.Error())
+	require.NoError(t, iterator.Close())
+
+	// A new iterator observes the deletion.
+	iterator, err = table.Iterator()
+	require.NoError(t, err)
+	require.True(t, iterator.Next())
+	require.Equal(t, keys[0], iterator.Key())
+	require.True(t, iterator.Next())
+	require.Equal(t, keys[2], iterator.Key())
+	require.False(t, iterator.Next())
+	require.NoError(t, iterator.Close())
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestIteratorIgnoresLaterDeletes(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iteratorIgnoresLaterDeletesTest(t, tb)
+		})
+	}
+}
@@ -82,7 +82,7 @@ func (f *flushLoop) handleSealRequest(req *flushLoopSealRequest) {
 	}
 
 	// Flush the keys that are now durable in the segment.
-	err = f.diskTable.writeKeysToKeymap(durableKeys)
+	err = f.diskTable.writeKeysToKeymap(durableKeys, req.tombstones, req.relocations)
 	if err != nil {
 		f.errorMonitor.Panic(fmt.Errorf("failed to flush keys: %w", err))
 		return
@@ -126,7 +126,7 @@ func (f *flushLoop) handleFlushRequest(req *flushLoopFlushRequest) {
 		f.metrics.ReportSegmentFlushLatency(f.name, delta)
 	}
 
-	err = f.diskTable.writeKeysToKeymap(durableKeys)
+	err = f.diskTable.writeKeysToKeymap(durableKeys, req.tombstones, req.relocations)
 	if err != nil {
 		f.errorMonitor.Panic(fmt.Errorf("failed to flush keys: %w", err))
 		return
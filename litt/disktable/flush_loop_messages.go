@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
 )
 
 // FlushLoopMessage is an interface for messages sent to the flush loop via flushLoop.enqueue.
@@ -19,6 +20,13 @@ type flushLoopFlushRequest struct {
 	// flushWaitFunction is the function that will wait for the flush to complete.
 	flushWaitFunction segment.FlushWaitFunction
 
+	// tombstones that will become durable once the flush is complete.
+	tombstones []*types.ScopedKey
+
+	// the previous locations of values that were relocated by the compactor, and that will become durable once the
+	// flush is complete.
+	relocations []*types.ScopedKey
+
 	// responseChan sends an object when the flush is complete.
 	responseChan chan struct{}
 }
@@ -31,6 +39,11 @@ type flushLoopSealRequest struct {
 	now time.Time
 	// segmentToSeal is the segment that is being sealed.
 	segmentToSeal *segment.Segment
+	// tombstones that will become durable once the seal is complete.
+	tombstones []*types.ScopedKey
+	// the previous locations of values that were relocated by the compactor, and that will become durable once the
+	// seal is complete.
+	relocations []*types.ScopedKey
 	// responseChan sends an object when the seal is complete.
 	responseChan chan struct{}
 }
@@ -0,0 +1,576 @@
+package disktable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+var _ litt.ManagedTable = &FollowerTable{}
+
+// errReadOnly is returned by all methods that would modify a FollowerTable.
+var errReadOnly = errors.New("table is opened in read-only follower mode")
+
+// FollowerTable is a read-only view of a table in a snapshot directory published by another LittDB instance
+// (the "primary"). The follower tails the snapshot directory, loading each segment after the primary has sealed it
+// and recorded it in the snapshot's upper bound file. Segments that an external process has pruned from the snapshot
+// (as recorded in the snapshot's lower bound file) are forgotten.
+//
+// A follower never modifies files on disk, and does not acquire any file locks. Data becomes visible to a follower
+// only once the segment containing it has been sealed by the primary, so a follower lags behind the primary by up to
+// one segment (plus the poll period).
+type FollowerTable struct {
+	logger logging.Logger
+
+	// The table's name.
+	name string
+
+	// The directory containing the table in the snapshot, i.e. where the boundary files are found.
+	tableDirectory string
+
+	// Configures the location where segment data is found.
+	segmentPaths []*segment.SegmentPath
+
+	// Handed to segments when they are loaded.
+	errorMonitor *util.ErrorMonitor
+
+	// A map of keys to their addresses. Keys are only added to the keymap after the segment that contains them has
+	// been added to segments.
+	keymap keymap.Keymap
+
+	// Loaded segments, keyed by segment index.
+	segments map[uint32]*segment.Segment
+
+	// Protects segments. Held for writing while the keymap and segments are updated together, so that readers never
+	// observe a key whose segment has not been loaded (or has already been forgotten).
+	lock sync.RWMutex
+
+	// The index of the next segment to load. Only meaningful if started is true.
+	nextSegmentIndex uint32
+
+	// True once the first segment to load has been determined.
+	started bool
+
+	// Serializes calls to refresh(). Protects nextSegmentIndex and started.
+	refreshLock sync.Mutex
+
+	// The number of bytes contained within all loaded segments.
+	size atomic.Uint64
+
+	// The number of bytes that all loaded segments would contain if values were not compressed.
+	uncompressedSize atomic.Uint64
+
+	// The number of keys in the table.
+	keyCount atomic.Int64
+
+	// Cancels the background goroutine that polls for new segments.
+	cancel context.CancelFunc
+
+	// Closed when the background goroutine exits.
+	stopped chan struct{}
+
+	// Set to true when the table is closed. This is used to prevent double closing.
+	closed atomic.Bool
+}
+
+// NewFollowerTable creates a new FollowerTable. The config's Paths are the root directories of the snapshot being
+// followed (i.e. the SnapshotDirectory of the primary). Returns an error if the table does not exist in the snapshot.
+func NewFollowerTable(config *litt.Config, name string) (litt.ManagedTable, error) {
+	if config.FollowerPollPeriod <= 0 {
+		return nil, errors.New("follower poll period must be greater than 0")
+	}
+
+	tableDirectory := path.Join(config.Paths[0], name)
+	exists, err := util.Exists(tableDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if table directory %s exists: %w", tableDirectory, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("table %s does not exist in snapshot %s", name, config.Paths[0])
+	}
+
+	segmentPaths, err := segment.BuildSegmentPaths(config.Paths, "", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build segment paths: %w", err)
+	}
+
+	kmap, _, err := keymap.NewMemKeymap(config.Logger, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create keymap: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(config.CTX)
+
+	table := &FollowerTable{
+		logger:         config.Logger,
+		name:           name,
+		tableDirectory: tableDirectory,
+		segmentPaths:   segmentPaths,
+		errorMonitor:   util.NewErrorMonitor(ctx, config.Logger, config.FatalErrorCallback),
+		keymap:         kmap,
+		segments:       make(map[uint32]*segment.Segment),
+		cancel:         cancel,
+		stopped:        make(chan struct{}),
+	}
+
+	err = table.refresh()
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to load table %s: %w", name, err)
+	}
+
+	go table.follow(ctx, config.FollowerPollPeriod)
+
+	return table, nil
+}
+
+// follow periodically checks the snapshot for new segments until the context is cancelled.
+func (f *FollowerTable) follow(ctx context.Context, period time.Duration) {
+	defer close(f.stopped)
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Errors are usually caused by racing against the primary or against an external process that is
+			// pruning the snapshot, and resolve themselves on a later attempt.
+			err := f.refresh()
+			if err != nil {
+				f.logger.Warnf("failed to refresh follower table %s: %v", f.name, err)
+			}
+		}
+	}
+}
+
+// refresh forgets segments that have been pruned from the snapshot, and loads segments that have been added to it.
+func (f *FollowerTable) refresh() error {
+	f.refreshLock.Lock()
+	defer f.refreshLock.Unlock()
+
+	lowerBound, err := LoadBoundaryFile(LowerBound, f.tableDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to load lower bound file: %w", err)
+	}
+	upperBound, err := LoadBoundaryFile(UpperBound, f.tableDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to load upper bound file: %w", err)
+	}
+
+	if lowerBound.IsDefined() {
+		err = f.forgetSegments(lowerBound.BoundaryIndex())
+		if err != nil {
+			return fmt.Errorf("failed to forget pruned segments: %w", err)
+		}
+	}
+
+	if !upperBound.IsDefined() {
+		// The primary has not yet sealed any segments.
+		return nil
+	}
+
+	if !f.started {
+		lowestSegmentIndex, found, err := segment.FindLowestSegmentIndex(f.logger, f.segmentPaths)
+		if err != nil {
+			return fmt.Errorf("failed to find lowest segment index: %w", err)
+		}
+		if !found {
+			return nil
+		}
+		if lowerBound.IsDefined() {
+			lowestSegmentIndex = max(lowestSegmentIndex, lowerBound.BoundaryIndex()+1)
+		}
+
+		f.nextSegmentIndex = lowestSegmentIndex
+		f.started = true
+	} else if lowerBound.IsDefined() && f.nextSegmentIndex <= lowerBound.BoundaryIndex() {
+		// Segments were pruned from the snapshot before we got a chance to load them.
+		f.nextSegmentIndex = lowerBound.BoundaryIndex() + 1
+	}
+
+	for f.nextSegmentIndex <= upperBound.BoundaryIndex() {
+		err = f.loadSegment(f.nextSegmentIndex)
+		if err != nil {
+			return fmt.Errorf("failed to load segment %d: %w", f.nextSegmentIndex, err)
+		}
+		f.nextSegmentIndex++
+	}
+
+	return nil
+}
+
+// loadSegment loads a sealed segment from the snapshot and adds its keys to the keymap.
+func (f *FollowerTable) loadSegment(index uint32) error {
+	// The segment is sealed (it is at or below the upper bound), so loading it does not modify any files.
+	seg, err := segment.LoadSegment(f.logger, f.errorMonitor, index, f.segmentPaths, false, time.Now(), false)
+	if err != nil {
+		return fmt.Errorf("failed to load segment: %w", err)
+	}
+	if !seg.IsSealed() {
+		return fmt.Errorf("segment %d is not sealed", index)
+	}
+
+	keys, err := seg.GetKeys()
+	if err != nil {
+		return fmt.Errorf("failed to get keys: %w", err)
+	}
+	tombstones := make([]*types.ScopedKey, 0)
+	if seg.TombstoneCount() > 0 {
+		tombstones, err = seg.GetTombstones()
+		if err != nil {
+			return fmt.Errorf("failed to get tombstones: %w", err)
+		}
+	}
+
+	// Addresses are only unique within a shard, so deleted values are identified by both key and address.
+	type deletedValue struct {
+		key     string
+		address types.Address
+	}
+	deletedValues := make(map[deletedValue]struct{}, len(tombstones))
+	for _, tombstone := range tombstones {
+		deletedValues[deletedValue{string(tombstone.Key), tombstone.Address}] = struct{}{}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.segments[index] = seg
+	f.size.Add(seg.Size())
+	f.uncompressedSize.Add(seg.UncompressedSize())
+
+	// Values relocated by the primary's compactor reappear in a later segment. Since the relocated value is written
+	// to the same segment as the tombstone for its old address, the relocated address replaces the old one here,
+	// and the tombstone for the old address is then ignored below.
+	for _, key := range keys {
+		if _, deleted := deletedValues[deletedValue{string(key.Key), key.Address}]; deleted {
+			continue
+		}
+
+		_, exists, err := f.keymap.Get(key.Key)
+		if err != nil {
+			return fmt.Errorf("failed to get address: %w", err)
+		}
+		err = f.keymap.Put([]*types.ScopedKey{key})
+		if err != nil {
+			return fmt.Errorf("failed to put key: %w", err)
+		}
+		if !exists {
+			f.keyCount.Add(1)
+		}
+	}
+
+	for _, tombstone := range tombstones {
+		address, ok, err := f.keymap.Get(tombstone.Key)
+		if err != nil {
+			return fmt.Errorf("failed to get address: %w", err)
+		}
+		if !ok || address != tombstone.Address {
+			continue
+		}
+		err = f.keymap.Delete([]*types.ScopedKey{tombstone})
+		if err != nil {
+			return fmt.Errorf("failed to delete key: %w", err)
+		}
+		f.keyCount.Add(-1)
+	}
+
+	return nil
+}
+
+// forgetSegments removes all segments at or below the given index, along with the keys that refer to them.
+func (f *FollowerTable) forgetSegments(highestPrunedIndex uint32) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	pruned := false
+	for index := range f.segments {
+		if index <= highestPrunedIndex {
+			pruned = true
+			break
+		}
+	}
+	if !pruned {
+		return nil
+	}
+
+	iterator, err := f.keymap.Iterator(nil)
+	if err != nil {
+		return fmt.Errorf("failed to create keymap iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	prunedKeys := make([]*types.ScopedKey, 0)
+	for iterator.Next() {
+		if iterator.Address().Index() <= highestPrunedIndex {
+			prunedKeys = append(prunedKeys, &types.ScopedKey{Key: iterator.Key(), Address: iterator.Address()})
+		}
+	}
+	if err = iterator.Error(); err != nil {
+		return fmt.Errorf("failed to iterate over keymap: %w", err)
+	}
+
+	err = f.keymap.Delete(prunedKeys)
+	if err != nil {
+		return fmt.Errorf("failed to delete keys: %w", err)
+	}
+	f.keyCount.Add(-int64(len(prunedKeys)))
+
+	// Segments are not released, since releasing a segment deletes its files. The snapshot is owned by other processes.
+	for index, seg := range f.segments {
+		if index <= highestPrunedIndex {
+			f.size.Add(-seg.Size())
+			f.uncompressedSize.Add(-seg.UncompressedSize())
+			delete(f.segments, index)
+		}
+	}
+
+	return nil
+}
+
+// lookup returns the segment and address for a key. Returns false if the key is not present.
+func (f *FollowerTable) lookup(key []byte) (*segment.Segment, types.Address, bool, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	address, ok, err := f.keymap.Get(key)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("failed to get address: %w", err)
+	}
+	if !ok {
+		return nil, 0, false, nil
+	}
+
+	seg, ok := f.segments[address.Index()]
+	if !ok {
+		return nil, 0, false, nil
+	}
+
+	return seg, address, true, nil
+}
+
+func (f *FollowerTable) Name() string {
+	return f.name
+}
+
+func (f *FollowerTable) Put(_ []byte, _ []byte) error {
+	return errReadOnly
+}
+
+func (f *FollowerTable) PutWithTTL(_ []byte, _ []byte, _ time.Duration) error {
+	return errReadOnly
+}
+
+func (f *FollowerTable) PutBatch(_ []*types.KVPair) error {
+	return errReadOnly
+}
+
+func (f *FollowerTable) PutBatchAtomic(_ []*types.KVPair) error {
+	return errReadOnly
+}
+
+func (f *FollowerTable) Get(key []byte) (value []byte, exists bool, err error) {
+	seg, address, ok, err := f.lookup(key)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	value, err = seg.Read(key, address)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read data: %w", err)
+	}
+
+	return value, true, nil
+}
+
+func (f *FollowerTable) CacheAwareGet(
+	key []byte,
+	onlyReadFromCache bool,
+) (value []byte, exists bool, hot bool, err error) {
+
+	if onlyReadFromCache {
+		exists, err = f.Exists(key)
+		return nil, exists, false, err
+	}
+
+	value, exists, err = f.Get(key)
+	return value, exists, false, err
+}
+
+func (f *FollowerTable) Exists(key []byte) (exists bool, err error) {
+	_, _, exists, err = f.lookup(key)
+	return exists, err
+}
+
+func (f *FollowerTable) Delete(_ []byte) error {
+	return errReadOnly
+}
+
+func (f *FollowerTable) Iterator() (litt.Iterator, error) {
+	return f.PrefixIterator(nil)
+}
+
+func (f *FollowerTable) PrefixIterator(prefix []byte) (litt.Iterator, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	keymapIterator, err := f.keymap.Iterator(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create keymap iterator: %w", err)
+	}
+
+	segments := make(map[uint32]*segment.Segment, len(f.segments))
+	for index, seg := range f.segments {
+		segments[index] = seg
+	}
+
+	return &followerTableIterator{
+		keymapIterator: keymapIterator,
+		segments:       segments,
+	}, nil
+}
+
+// Flush is a no-op, since a follower never has any data to flush.
+func (f *FollowerTable) Flush() error {
+	return nil
+}
+
+func (f *FollowerTable) Size() uint64 {
+	return f.size.Load()
+}
+
+func (f *FollowerTable) KeyCount() uint64 {
+	return uint64(f.keyCount.Load())
+}
+
+func (f *FollowerTable) SetTTL(_ time.Duration) error {
+	return errReadOnly
+}
+
+func (f *FollowerTable) SetShardingFactor(_ uint32) error {
+	return errReadOnly
+}
+
+func (f *FollowerTable) SetCompression(_ types.CompressionType) error {
+	return errReadOnly
+}
+
+func (f *FollowerTable) CompressionRatio() float64 {
+	size := f.size.Load()
+	if size == 0 {
+		return 1.0
+	}
+	return float64(f.uncompressedSize.Load()) / float64(size)
+}
+
+// SetWriteCacheSize does nothing, a follower table does not have a cache.
+func (f *FollowerTable) SetWriteCacheSize(_ uint64) error {
+	return nil
+}
+
+// SetReadCacheSize does nothing, a follower table does not have a cache.
+func (f *FollowerTable) SetReadCacheSize(_ uint64) error {
+	return nil
+}
+
+// Close stops following the snapshot. Files on disk are not modified.
+func (f *FollowerTable) Close() error {
+	firstTimeClosing := f.closed.CompareAndSwap(false, true)
+	if !firstTimeClosing {
+		return nil
+	}
+
+	f.cancel()
+	<-f.stopped
+	f.errorMonitor.Shutdown()
+
+	return f.keymap.Stop()
+}
+
+// Destroy always returns an error, a follower is not permitted to delete data that belongs to the primary.
+func (f *FollowerTable) Destroy() error {
+	return errReadOnly
+}
+
+// RunGC checks the snapshot for pruned and newly sealed segments, blocking until the check is complete.
+// Data that has been pruned from the snapshot is forgotten, which is the follower's equivalent of garbage collection.
+func (f *FollowerTable) RunGC() error {
+	return f.refresh()
+}
+
+var _ litt.Iterator = &followerTableIterator{}
+
+// followerTableIterator iterates over the keys in a FollowerTable.
+type followerTableIterator struct {
+	// Iterates over a snapshot of the keymap.
+	keymapIterator keymap.Iterator
+
+	// The segments that were loaded when the iterator was created.
+	segments map[uint32]*segment.Segment
+
+	// Set to true once the iterator has been closed.
+	closed bool
+}
+
+func (i *followerTableIterator) Next() bool {
+	if i.closed {
+		return false
+	}
+	return i.keymapIterator.Next()
+}
+
+func (i *followerTableIterator) Key() []byte {
+	return i.keymapIterator.Key()
+}
+
+func (i *followerTableIterator) Value() ([]byte, error) {
+	if i.closed {
+		return nil, fmt.Errorf("iterator is closed")
+	}
+
+	address := i.keymapIterator.Address()
+	seg, ok := i.segments[address.Index()]
+	if !ok {
+		return nil, fmt.Errorf("segment %d is not available", address.Index())
+	}
+
+	value, err := seg.Read(i.keymapIterator.Key(), address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value: %w", err)
+	}
+
+	return value, nil
+}
+
+func (i *followerTableIterator) Error() error {
+	return i.keymapIterator.Error()
+}
+
+func (i *followerTableIterator) Close() error {
+	if i.closed {
+		return nil
+	}
+	i.closed = true
+
+	i.keymapIterator.Release()
+	i.segments = nil
+
+	return nil
+}
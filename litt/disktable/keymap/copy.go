@@ -0,0 +1,48 @@
+package keymap
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+)
+
+// Copy copies all key-address pairs from the source keymap into the destination keymap, writing them in batches of
+// the given size. Returns the number of keys copied. The source keymap is not modified.
+func Copy(source Keymap, destination Keymap, batchSize int) (uint64, error) {
+	if batchSize <= 0 {
+		return 0, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+
+	iterator, err := source.Iterator(nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	count := uint64(0)
+	batch := make([]*types.ScopedKey, 0, batchSize)
+	for iterator.Next() {
+		batch = append(batch, &types.ScopedKey{Key: iterator.Key(), Address: iterator.Address()})
+		if len(batch) == batchSize {
+			err = destination.Put(batch)
+			if err != nil {
+				return 0, fmt.Errorf("failed to put keys: %w", err)
+			}
+			count += uint64(len(batch))
+			batch = make([]*types.ScopedKey, 0, batchSize)
+		}
+	}
+	if err = iterator.Error(); err != nil {
+		return 0, fmt.Errorf("failed to iterate over source keymap: %w", err)
+	}
+
+	if len(batch) > 0 {
+		err = destination.Put(batch)
+		if err != nil {
+			return 0, fmt.Errorf("failed to put keys: %w", err)
+		}
+		count += uint64(len(batch))
+	}
+
+	return count, nil
+}
@@ -0,0 +1,73 @@
+package keymap
+
+import "github.com/Layr-Labs/eigenda/litt/types"
+
+// Iterator walks over the key-address pairs in a keymap in lexicographic key order.
+//
+// An iterator is not goroutine safe. Each iterator should only be used by a single goroutine at a time.
+type Iterator interface {
+	// Next advances the iterator to the next key. Returns false when there are no more keys, or if an error
+	// was encountered (in which case Error() will return a non-nil value). Next must be called before the first
+	// call to Key() or Address().
+	Next() bool
+
+	// Key returns the key at the current position of the iterator. The returned slice is owned by the caller
+	// and remains valid after the iterator is advanced.
+	Key() []byte
+
+	// Address returns the address of the key at the current position of the iterator.
+	Address() types.Address
+
+	// Error returns the first error encountered by the iterator, if any.
+	Error() error
+
+	// Release releases all resources held by the iterator. It is safe to call Release more than once.
+	Release()
+}
+
+var _ Iterator = &sliceIterator{}
+
+// sliceIterator is an Iterator backed by a slice of keys that is already sorted.
+type sliceIterator struct {
+	// The entries being iterated over.
+	entries []*types.ScopedKey
+	// The index of the next entry to return.
+	nextIndex int
+	// The entry at the current position.
+	current *types.ScopedKey
+}
+
+// NewSliceIterator creates an Iterator over a slice of keys. The slice must already be sorted in lexicographic key
+// order, and must not be modified after it is passed to this function.
+func NewSliceIterator(entries []*types.ScopedKey) Iterator {
+	return &sliceIterator{
+		entries: entries,
+	}
+}
+
+func (s *sliceIterator) Next() bool {
+	if s.nextIndex >= len(s.entries) {
+		s.current = nil
+		return false
+	}
+	s.current = s.entries[s.nextIndex]
+	s.nextIndex++
+	return true
+}
+
+func (s *sliceIterator) Key() []byte {
+	return s.current.Key
+}
+
+func (s *sliceIterator) Address() types.Address {
+	return s.current.Address
+}
+
+func (s *sliceIterator) Error() error {
+	return nil
+}
+
+func (s *sliceIterator) Release() {
+	s.entries = nil
+	s.current = nil
+}
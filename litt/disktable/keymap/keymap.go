@@ -42,6 +42,13 @@ type Keymap interface {
 	// This includes the byte slices containing the keys.
 	Delete(keys []*types.ScopedKey) error
 
+	// Iterator returns an iterator over all keys in the keymap that start with the given prefix, in lexicographic
+	// key order. If the prefix is nil or empty, then all keys are returned. The iterator observes a consistent
+	// snapshot of the keymap taken at the time this method is called, and is unaffected by later modifications.
+	//
+	// The caller is responsible for calling Release() on the iterator when it is no longer needed.
+	Iterator(prefix []byte) (Iterator, error)
+
 	// Stop stops the keymap.
 	Stop() error
 
@@ -49,5 +56,14 @@ type Keymap interface {
 	Destroy() error
 }
 
+// SizedKeymap is a Keymap that is able to accurately report the amount of disk space it uses. Not all keymap
+// implementations are able to do this, since some third party libraries do not provide an accurate way to measure size.
+type SizedKeymap interface {
+	Keymap
+
+	// Size returns the number of bytes that the keymap occupies on disk.
+	Size() uint64
+}
+
 // BuildKeymap is a function that builds a Keymap.
 type BuildKeymap func(logger logging.Logger, keymapPath string, doubleWriteProtection bool) (Keymap, bool, error)
@@ -1,8 +1,11 @@
 package keymap
 
 import (
+	"fmt"
 	"os"
 	"path"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/Layr-Labs/eigenda/litt/types"
@@ -16,6 +19,7 @@ import (
 var builders = []keymapBuilder{
 	buildMemKeymap,
 	buildLevelDBKeymap,
+	buildPebbleKeymap,
 }
 
 type keymapBuilder func(logger logging.Logger, path string) (Keymap, error)
@@ -38,6 +42,15 @@ func buildLevelDBKeymap(logger logging.Logger, path string) (Keymap, error) {
 	return kmap, nil
 }
 
+func buildPebbleKeymap(logger logging.Logger, path string) (Keymap, error) {
+	kmap, _, err := NewUnsafePebbleKeymap(logger, path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return kmap, nil
+}
+
 func testBasicBehavior(t *testing.T, keymap Keymap) {
 	rand := random.NewTestRandom()
 
@@ -283,3 +296,164 @@ func TestRestart(t *testing.T) {
 	err = keymap.Destroy()
 	require.NoError(t, err)
 }
+
+func testIterator(t *testing.T, keymap Keymap) {
+	rand := random.NewTestRandom()
+
+	prefixes := []string{"a", "ab", "b", "c"}
+	expected := make(map[string]types.Address)
+	for i := 0; i < 500; i++ {
+		key := prefixes[rand.Intn(len(prefixes))] + rand.String(16)
+		address := types.Address(rand.Uint64())
+		err := keymap.Put([]*types.ScopedKey{{Key: []byte(key), Address: address}})
+		require.NoError(t, err)
+		expected[key] = address
+	}
+
+	for _, prefix := range append(prefixes, "") {
+		expectedKeys := make([]string, 0)
+		for key := range expected {
+			if strings.HasPrefix(key, prefix) {
+				expectedKeys = append(expectedKeys, key)
+			}
+		}
+		sort.Strings(expectedKeys)
+
+		iterator, err := keymap.Iterator([]byte(prefix))
+		require.NoError(t, err)
+
+		// Modifications made after the iterator is created must not be visible to the iterator.
+		extraKey := []byte(prefix + rand.String(16))
+		err = keymap.Put([]*types.ScopedKey{{Key: extraKey, Address: 1}})
+		require.NoError(t, err)
+		if len(expectedKeys) > 0 {
+			err = keymap.Delete([]*types.ScopedKey{{Key: []byte(expectedKeys[0])}})
+			require.NoError(t, err)
+		}
+
+		index := 0
+		for iterator.Next() {
+			require.Less(t, index, len(expectedKeys))
+			require.Equal(t, expectedKeys[index], string(iterator.Key()))
+			require.Equal(t, expected[expectedKeys[index]], iterator.Address())
+			index++
+		}
+		require.NoError(t, iterator.Error())
+		require.Equal(t, len(expectedKeys), index)
+		iterator.Release()
+
+		// Undo the modifications so that the next prefix sees the expected data.
+		err = keymap.Delete([]*types.ScopedKey{{Key: extraKey}})
+		require.NoError(t, err)
+		if len(expectedKeys) > 0 {
+			err = keymap.Put([]*types.ScopedKey{
+				{Key: []byte(expectedKeys[0]), Address: expected[expectedKeys[0]]}})
+			require.NoError(t, err)
+		}
+	}
+
+	err := keymap.Destroy()
+	require.NoError(t, err)
+}
+
+func TestIterator(t *testing.T) {
+	t.Parallel()
+	logger := test.GetLogger()
+
+	for i, builder := range builders {
+		dbDir := path.Join(t.TempDir(), fmt.Sprintf("keymap-%d", i))
+		keymap, err := builder(logger, dbDir)
+		require.NoError(t, err)
+		testIterator(t, keymap)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger := test.GetLogger()
+	testDir := t.TempDir()
+
+	for i, sourceBuilder := range builders {
+		for j, destinationBuilder := range builders {
+			source, err := sourceBuilder(logger, path.Join(testDir, fmt.Sprintf("source-%d-%d", i, j)))
+			require.NoError(t, err)
+			destination, err := destinationBuilder(logger, path.Join(testDir, fmt.Sprintf("destination-%d-%d", i, j)))
+			require.NoError(t, err)
+
+			expected := make(map[string]types.Address)
+			keyCount := rand.Int32Range(0, 100)
+			for k := int32(0); k < keyCount; k++ {
+				key := rand.String(32)
+				address := types.Address(rand.Uint64())
+				err = source.Put([]*types.ScopedKey{{Key: []byte(key), Address: address}})
+				require.NoError(t, err)
+				expected[key] = address
+			}
+
+			count, err := Copy(source, destination, 7)
+			require.NoError(t, err)
+			require.Equal(t, uint64(len(expected)), count)
+
+			for key, expectedAddress := range expected {
+				address, ok, err := destination.Get([]byte(key))
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, expectedAddress, address)
+
+				// The source should not be modified.
+				address, ok, err = source.Get([]byte(key))
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, expectedAddress, address)
+			}
+
+			_, err = Copy(source, destination, 0)
+			require.Error(t, err)
+
+			require.NoError(t, source.Destroy())
+			require.NoError(t, destination.Destroy())
+		}
+	}
+}
+
+func TestPebbleKeymapSize(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger := test.GetLogger()
+	dbDir := path.Join(t.TempDir(), "keymap")
+
+	kmap, requiresReload, err := NewUnsafePebbleKeymap(logger, dbDir, true)
+	require.NoError(t, err)
+	require.True(t, requiresReload)
+	pebbleKeymap := kmap.(*PebbleKeymap)
+
+	initialSize := pebbleKeymap.Size()
+	for i := 0; i < 1000; i++ {
+		err = kmap.Put([]*types.ScopedKey{{Key: []byte(rand.String(32)), Address: types.Address(rand.Uint64())}})
+		require.NoError(t, err)
+	}
+	require.Greater(t, pebbleKeymap.Size(), initialSize)
+
+	// Data should survive a restart, and the keymap should not require a reload.
+	err = kmap.Stop()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), pebbleKeymap.Size())
+
+	kmap, requiresReload, err = NewUnsafePebbleKeymap(logger, dbDir, true)
+	require.NoError(t, err)
+	require.False(t, requiresReload)
+	require.Greater(t, kmap.(*PebbleKeymap).Size(), initialSize)
+
+	err = kmap.Destroy()
+	require.NoError(t, err)
+}
+
+func TestPrefixUpperBound(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []byte("b"), prefixUpperBound([]byte("a")))
+	require.Equal(t, []byte("ac"), prefixUpperBound([]byte("ab")))
+	require.Equal(t, []byte{0x01}, prefixUpperBound([]byte{0x00, 0xff}))
+	require.Nil(t, prefixUpperBound([]byte{0xff, 0xff}))
+}
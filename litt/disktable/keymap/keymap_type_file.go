@@ -55,6 +55,10 @@ func LoadKeymapTypeFile(keymapPath string) (*KeymapTypeFile, error) {
 		keymapType = LevelDBKeymapType
 	case UnsafeLevelDBKeymapType:
 		keymapType = UnsafeLevelDBKeymapType
+	case PebbleKeymapType:
+		keymapType = PebbleKeymapType
+	case UnsafePebbleKeymapType:
+		keymapType = UnsafePebbleKeymapType
 	default:
 		return nil, fmt.Errorf("unknown keymap type: %s", string(fileContents))
 	}
@@ -101,6 +105,20 @@ func (k *KeymapTypeFile) Write() error {
 	return nil
 }
 
+// Update atomically replaces the keymap type recorded in the keymap type file. Unlike Write, this method may be
+// called when the file already exists.
+func (k *KeymapTypeFile) Update(keymapType KeymapType, fsync bool) error {
+	filePath := path.Join(k.keymapPath, KeymapTypeFileName)
+
+	err := util.AtomicWrite(filePath, []byte(keymapType), fsync)
+	if err != nil {
+		return fmt.Errorf("unable to write keymap type file: %w", err)
+	}
+	k.keymapType = keymapType
+
+	return nil
+}
+
 // Delete deletes the keymap type file.
 func (k *KeymapTypeFile) Delete() error {
 	exists, err := util.Exists(path.Join(k.keymapPath, KeymapTypeFileName))
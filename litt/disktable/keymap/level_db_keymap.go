@@ -10,7 +10,9 @@ import (
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	leveldbutil "github.com/syndtr/goleveldb/leveldb/util"
 )
 
 var _ Keymap = &LevelDBKeymap{}
@@ -148,6 +150,23 @@ func (l *LevelDBKeymap) Delete(keys []*types.ScopedKey) error {
 	return nil
 }
 
+func (l *LevelDBKeymap) Iterator(prefix []byte) (Iterator, error) {
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LevelDB snapshot: %w", err)
+	}
+
+	var keyRange *leveldbutil.Range
+	if len(prefix) > 0 {
+		keyRange = leveldbutil.BytesPrefix(prefix)
+	}
+
+	return &levelDBIterator{
+		snapshot: snapshot,
+		iterator: snapshot.NewIterator(keyRange, nil),
+	}, nil
+}
+
 func (l *LevelDBKeymap) Stop() error {
 	alive := l.alive.Swap(false)
 	if !alive {
@@ -175,3 +194,68 @@ func (l *LevelDBKeymap) Destroy() error {
 
 	return nil
 }
+
+var _ Iterator = &levelDBIterator{}
+
+// levelDBIterator iterates over a snapshot of a LevelDBKeymap.
+type levelDBIterator struct {
+	// The snapshot being iterated over. Holding the snapshot open ensures that the iterator is not affected by
+	// modifications made to the keymap after the iterator was created.
+	snapshot *leveldb.Snapshot
+	// The underlying LevelDB iterator.
+	iterator iterator.Iterator
+	// The key at the current position.
+	key []byte
+	// The address at the current position.
+	address types.Address
+	// The first error encountered while iterating.
+	err error
+	// Set to true once the iterator has been released.
+	released bool
+}
+
+func (i *levelDBIterator) Next() bool {
+	if i.released || i.err != nil {
+		return false
+	}
+
+	if !i.iterator.Next() {
+		i.err = i.iterator.Error()
+		return false
+	}
+
+	address, err := types.DeserializeAddress(i.iterator.Value())
+	if err != nil {
+		i.err = fmt.Errorf("failed to deserialize address: %w", err)
+		return false
+	}
+
+	// The slice returned by the LevelDB iterator is only valid until the next call to Next(), so make a copy.
+	rawKey := i.iterator.Key()
+	i.key = make([]byte, len(rawKey))
+	copy(i.key, rawKey)
+	i.address = address
+
+	return true
+}
+
+func (i *levelDBIterator) Key() []byte {
+	return i.key
+}
+
+func (i *levelDBIterator) Address() types.Address {
+	return i.address
+}
+
+func (i *levelDBIterator) Error() error {
+	return i.err
+}
+
+func (i *levelDBIterator) Release() {
+	if i.released {
+		return
+	}
+	i.released = true
+	i.iterator.Release()
+	i.snapshot.Release()
+}
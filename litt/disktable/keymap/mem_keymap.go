@@ -1,7 +1,10 @@
 package keymap
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/Layr-Labs/eigenda/litt/types"
@@ -77,6 +80,27 @@ func (m *memKeymap) Delete(keys []*types.ScopedKey) error {
 	return nil
 }
 
+func (m *memKeymap) Iterator(prefix []byte) (Iterator, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	// The map is not ordered, so the only way to provide a consistent and ordered view is to copy the matching
+	// entries and sort them.
+	stringPrefix := string(prefix)
+	entries := make([]*types.ScopedKey, 0)
+	for key, address := range m.data {
+		if strings.HasPrefix(key, stringPrefix) {
+			entries = append(entries, &types.ScopedKey{Key: []byte(key), Address: address})
+		}
+	}
+
+	sort.Slice(entries, func(a, b int) bool {
+		return bytes.Compare(entries[a].Key, entries[b].Key) < 0
+	})
+
+	return NewSliceIterator(entries), nil
+}
+
 func (m *memKeymap) Stop() error {
 	// nothing to do here
 	return nil
@@ -0,0 +1,339 @@
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/cockroachdb/pebble"
+)
+
+var _ SizedKeymap = &PebbleKeymap{}
+
+// PebbleKeymap is a keymap that uses Pebble as the underlying storage. Methods on this struct are goroutine safe.
+//
+// Compared to LevelDBKeymap, Pebble holds up better for very large keymaps (i.e. hundreds of millions of keys), and
+// is able to accurately report the amount of disk space it uses.
+type PebbleKeymap struct {
+	logger logging.Logger
+	db     *pebble.DB
+	// if true, then return an error if an update would overwrite an existing key
+	doubleWriteProtection bool
+	keymapPath            string
+	alive                 atomic.Bool
+	// Prevents the Pebble DB from being closed while its size is being measured.
+	sizeLock sync.RWMutex
+	// The options used for writes. If sync writes are disabled, writes are not synced to disk. This is a "test mode
+	// only" setting, see UnsafePebbleKeymapType.
+	writeOptions *pebble.WriteOptions
+}
+
+var _ BuildKeymap = NewPebbleKeymap
+
+// NewPebbleKeymap creates a new PebbleKeymap instance.
+func NewPebbleKeymap(
+	logger logging.Logger,
+	keymapPath string,
+	doubleWriteProtection bool) (kmap Keymap, requiresReload bool, err error) {
+
+	return newPebbleKeymap(logger, keymapPath, doubleWriteProtection, true)
+}
+
+// NewUnsafePebbleKeymap creates a new PebbleKeymap instance. It does not use sync writes. This makes it faster,
+// but unsafe if data consistency is critical (i.e. production use cases).
+func NewUnsafePebbleKeymap(
+	logger logging.Logger,
+	keymapPath string,
+	doubleWriteProtection bool) (kmap Keymap, requiresReload bool, err error) {
+
+	return newPebbleKeymap(logger, keymapPath, doubleWriteProtection, false)
+}
+
+// newPebbleKeymap creates a new PebbleKeymap instance.
+func newPebbleKeymap(
+	logger logging.Logger,
+	keymapPath string,
+	doubleWriteProtection bool,
+	syncWrites bool) (kmap *PebbleKeymap, requiresReload bool, err error) {
+
+	exists, err := util.Exists(keymapPath)
+	if err != nil {
+		return nil, false, fmt.Errorf("error checking for keymap directory: %w", err)
+	}
+
+	if !exists {
+		err = os.MkdirAll(keymapPath, 0755)
+		if err != nil {
+			return nil, false, fmt.Errorf("error creating keymap directory: %w", err)
+		}
+	}
+	requiresReload = !exists
+
+	db, err := pebble.Open(keymapPath, &pebble.Options{
+		Logger: &pebbleLogger{logger: logger},
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open Pebble: %w", err)
+	}
+
+	writeOptions := pebble.Sync
+	if !syncWrites {
+		writeOptions = pebble.NoSync
+	}
+
+	kmap = &PebbleKeymap{
+		logger:                logger,
+		db:                    db,
+		keymapPath:            keymapPath,
+		doubleWriteProtection: doubleWriteProtection,
+		writeOptions:          writeOptions,
+	}
+	kmap.alive.Store(true)
+
+	return kmap, requiresReload, nil
+}
+
+func (p *PebbleKeymap) Put(keys []*types.ScopedKey) error {
+
+	if p.doubleWriteProtection {
+		for _, k := range keys {
+			_, ok, err := p.Get(k.Key)
+			if err != nil {
+				return fmt.Errorf("failed to get key: %w", err)
+			}
+			if ok {
+				return fmt.Errorf("key %s already exists", k.Key)
+			}
+		}
+	}
+
+	batch := p.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+	for _, k := range keys {
+		err := batch.Set(k.Key, k.Address.Serialize(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to add key to Pebble batch: %w", err)
+		}
+	}
+
+	err := batch.Commit(p.writeOptions)
+	if err != nil {
+		return fmt.Errorf("failed to put batch to Pebble: %w", err)
+	}
+	return nil
+}
+
+func (p *PebbleKeymap) Get(key []byte) (types.Address, bool, error) {
+	addressBytes, closer, err := p.db.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to get key from Pebble: %w", err)
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+
+	// The slice returned by Pebble is only valid until the closer is closed. DeserializeAddress does not retain it.
+	address, err := types.DeserializeAddress(addressBytes)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to deserialize address: %w", err)
+	}
+
+	return address, true, nil
+}
+
+func (p *PebbleKeymap) Delete(keys []*types.ScopedKey) error {
+	batch := p.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+	for _, key := range keys {
+		err := batch.Delete(key.Key, nil)
+		if err != nil {
+			return fmt.Errorf("failed to add deletion to Pebble batch: %w", err)
+		}
+	}
+
+	err := batch.Commit(p.writeOptions)
+	if err != nil {
+		return fmt.Errorf("failed to delete keys from Pebble: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PebbleKeymap) Iterator(prefix []byte) (Iterator, error) {
+	snapshot := p.db.NewSnapshot()
+
+	options := &pebble.IterOptions{}
+	if len(prefix) > 0 {
+		options.LowerBound = prefix
+		options.UpperBound = prefixUpperBound(prefix)
+	}
+
+	iterator, err := snapshot.NewIter(options)
+	if err != nil {
+		_ = snapshot.Close()
+		return nil, fmt.Errorf("failed to create Pebble iterator: %w", err)
+	}
+
+	return &pebbleIterator{
+		snapshot: snapshot,
+		iterator: iterator,
+	}, nil
+}
+
+// Size returns the number of bytes used by Pebble on disk.
+func (p *PebbleKeymap) Size() uint64 {
+	p.sizeLock.RLock()
+	defer p.sizeLock.RUnlock()
+
+	if !p.alive.Load() {
+		return 0
+	}
+	return p.db.Metrics().DiskSpaceUsage()
+}
+
+func (p *PebbleKeymap) Stop() error {
+	p.sizeLock.Lock()
+	defer p.sizeLock.Unlock()
+
+	alive := p.alive.Swap(false)
+	if !alive {
+		return nil
+	}
+
+	err := p.db.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close Pebble: %w", err)
+	}
+	return nil
+}
+
+func (p *PebbleKeymap) Destroy() error {
+	err := p.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to stop Pebble: %w", err)
+	}
+
+	p.logger.Info(fmt.Sprintf("deleting Pebble keymap at path: %s", p.keymapPath))
+	err = os.RemoveAll(p.keymapPath)
+	if err != nil {
+		return fmt.Errorf("failed to remove Pebble data directory: %w", err)
+	}
+
+	return nil
+}
+
+// prefixUpperBound returns the smallest key that is larger than all keys with the given prefix, or nil if there is
+// no such key (i.e. the prefix consists entirely of 0xff bytes).
+func prefixUpperBound(prefix []byte) []byte {
+	upperBound := make([]byte, len(prefix))
+	copy(upperBound, prefix)
+	for i := len(upperBound) - 1; i >= 0; i-- {
+		if upperBound[i] < 0xff {
+			upperBound[i]++
+			return upperBound[:i+1]
+		}
+	}
+	return nil
+}
+
+var _ pebble.Logger = &pebbleLogger{}
+
+// pebbleLogger forwards Pebble's log messages to a LittDB logger.
+type pebbleLogger struct {
+	logger logging.Logger
+}
+
+func (l *pebbleLogger) Infof(format string, args ...interface{}) {
+	// Pebble logs a lot of routine information (e.g. about compactions) at the info level.
+	l.logger.Debugf(format, args...)
+}
+
+func (l *pebbleLogger) Fatalf(format string, args ...interface{}) {
+	l.logger.Fatalf(format, args...)
+}
+
+var _ Iterator = &pebbleIterator{}
+
+// pebbleIterator iterates over a snapshot of a PebbleKeymap.
+type pebbleIterator struct {
+	// The snapshot being iterated over. Holding the snapshot open ensures that the iterator is not affected by
+	// modifications made to the keymap after the iterator was created.
+	snapshot *pebble.Snapshot
+	// The underlying Pebble iterator.
+	iterator *pebble.Iterator
+	// True once the underlying iterator has been positioned on the first key.
+	started bool
+	// The key at the current position.
+	key []byte
+	// The address at the current position.
+	address types.Address
+	// The first error encountered while iterating.
+	err error
+	// Set to true once the iterator has been released.
+	released bool
+}
+
+func (i *pebbleIterator) Next() bool {
+	if i.released || i.err != nil {
+		return false
+	}
+
+	var valid bool
+	if i.started {
+		valid = i.iterator.Next()
+	} else {
+		i.started = true
+		valid = i.iterator.First()
+	}
+	if !valid {
+		i.err = i.iterator.Error()
+		return false
+	}
+
+	address, err := types.DeserializeAddress(i.iterator.Value())
+	if err != nil {
+		i.err = fmt.Errorf("failed to deserialize address: %w", err)
+		return false
+	}
+
+	// The slice returned by the Pebble iterator is only valid until the next call to Next(), so make a copy.
+	rawKey := i.iterator.Key()
+	i.key = make([]byte, len(rawKey))
+	copy(i.key, rawKey)
+	i.address = address
+
+	return true
+}
+
+func (i *pebbleIterator) Key() []byte {
+	return i.key
+}
+
+func (i *pebbleIterator) Address() types.Address {
+	return i.address
+}
+
+func (i *pebbleIterator) Error() error {
+	return i.err
+}
+
+func (i *pebbleIterator) Release() {
+	if i.released {
+		return
+	}
+	i.released = true
+	_ = i.iterator.Close()
+	_ = i.snapshot.Close()
+}
@@ -0,0 +1,24 @@
+package segment
+
+import (
+	"errors"
+	"hash/crc32"
+)
+
+// ErrChecksumMismatch is returned when a value read from disk does not match the checksum stored alongside it.
+// This indicates that the data on disk has been corrupted.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// checksumTable is the CRC32 table used to compute value checksums. The Castagnoli polynomial is used because
+// it is hardware accelerated on most modern CPUs.
+var checksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ChecksumSize is the size, in bytes, of the checksum stored alongside each value when checksums are enabled.
+const ChecksumSize = 4
+
+// computeChecksum computes the checksum for a key-value pair. The key is included in the checksum so that
+// a read that is directed at the wrong location in a value file is detected as corruption.
+func computeChecksum(key []byte, value []byte) uint32 {
+	checksum := crc32.Update(0, checksumTable, key)
+	return crc32.Update(checksum, checksumTable, value)
+}
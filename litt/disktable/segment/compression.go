@@ -0,0 +1,70 @@
+package segment
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdEncoder is shared by all segments. A zstd.Encoder is safe for concurrent use when using EncodeAll.
+var zstdEncoder *zstd.Encoder
+
+// zstdDecoder is shared by all segments. A zstd.Decoder is safe for concurrent use when using DecodeAll.
+var zstdDecoder *zstd.Decoder
+
+func init() {
+	var err error
+	zstdEncoder, err = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		panic(fmt.Sprintf("failed to create zstd encoder: %v", err))
+	}
+
+	// Values are at most 2^32 bytes in size, so there is never a reason to decompress anything larger.
+	zstdDecoder, err = zstd.NewReader(nil,
+		zstd.WithDecoderConcurrency(0),
+		zstd.WithDecoderMaxMemory(math.MaxUint32))
+	if err != nil {
+		panic(fmt.Sprintf("failed to create zstd decoder: %v", err))
+	}
+}
+
+// compress compresses a value using the given compression type. If the compression type is NoCompression,
+// the value is returned unmodified.
+func compress(compression types.CompressionType, value []byte) ([]byte, error) {
+	switch compression {
+	case types.NoCompression:
+		return value, nil
+	case types.SnappyCompression:
+		return snappy.Encode(nil, value), nil
+	case types.ZstdCompression:
+		return zstdEncoder.EncodeAll(value, make([]byte, 0, len(value))), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression type: %s", compression)
+	}
+}
+
+// decompress decompresses a value that was compressed using the given compression type. If the compression type is
+// NoCompression, the value is returned unmodified.
+func decompress(compression types.CompressionType, value []byte) ([]byte, error) {
+	switch compression {
+	case types.NoCompression:
+		return value, nil
+	case types.SnappyCompression:
+		decompressed, err := snappy.Decode(nil, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress snappy value: %w", err)
+		}
+		return decompressed, nil
+	case types.ZstdCompression:
+		decompressed, err := zstdDecoder.DecodeAll(value, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress zstd value: %w", err)
+		}
+		return decompressed, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression type: %s", compression)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"bufio"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"os"
 	"path"
 	"strconv"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
@@ -21,6 +23,77 @@ const KeyFileExtension = ".keys"
 // update key files.
 const KeyFileSwapExtension = KeyFileExtension + util.SwapFileExtension
 
+// markerKeyLength is a reserved key length. In key files with version AtomicBatchSegmentVersion or later,
+// a record that starts with this key length is not a key, but is instead a marker. A marker consists of this
+// reserved key length followed by a uint32 marker type, optionally followed by a payload that depends on the type.
+const markerKeyLength = math.MaxUint32
+
+// batchMarkerSize is the size of an atomic batch marker in the key file, in bytes.
+const batchMarkerSize = 8
+
+// markerType describes the type of marker in a key file.
+type markerType uint32
+
+const (
+	// batchBeginMarker signals that the keys that follow are part of an atomic batch.
+	batchBeginMarker markerType = 1
+
+	// batchCommitMarker signals that all keys in the current atomic batch have been written. A batch without a
+	// commit marker is discarded during crash recovery.
+	batchCommitMarker markerType = 2
+
+	// tombstoneMarker records the deletion of a key. The marker is followed by the uint32 length of the key,
+	// the key itself, and the uint64 address of the deleted value. Only present in key files with version
+	// TombstoneSegmentVersion or later.
+	tombstoneMarker markerType = 3
+
+	// lifetimeMarker records the lifetime of the key that immediately follows it. The marker is followed by the
+	// uint64 write time of the value (in nanoseconds since the epoch, or 0 if the value's age is measured from the
+	// segment's seal time) and the uint64 TTL override of the value (in nanoseconds, or 0 if there is no override).
+	// Only present in key files with version ValueLifetimeSegmentVersion or later. Since the marker is written before
+	// the key it describes, a key is never durable without its lifetime.
+	lifetimeMarker markerType = 4
+)
+
+// lifetimeMarkerSize is the size of a lifetime marker in the key file, in bytes.
+const lifetimeMarkerSize = batchMarkerSize + 8 /* uint64 write time */ + 8 /* uint64 TTL */
+
+// tombstoneSize returns the size of a tombstone for the given key in the key file, in bytes.
+func tombstoneSize(key []byte) uint64 {
+	return batchMarkerSize + 4 /* uint32 key length */ + uint64(len(key)) + 8 /* uint64 address */
+}
+
+// keyFileContents describes the records read from a key file.
+type keyFileContents struct {
+	// The keys in the key file, in the order they were written.
+	keys []*types.ScopedKey
+
+	// The atomic batches in the key file. Each batch refers to a range of keys.
+	batches []*atomicBatch
+
+	// The tombstones in the key file. Each tombstone contains a deleted key and the address of the deleted value.
+	tombstones []*types.ScopedKey
+}
+
+// atomicBatch describes a range of keys (and possibly tombstones) in a key file that were written as part of a single
+// atomic batch.
+type atomicBatch struct {
+	// The index of the first key in the batch.
+	start int
+
+	// The index one past the last key in the batch.
+	end int
+
+	// The index of the first tombstone in the batch.
+	tombstoneStart int
+
+	// The index one past the last tombstone in the batch.
+	tombstoneEnd int
+
+	// True if the batch's commit marker is present in the key file.
+	committed bool
+}
+
 // keyFile tracks the keys in a segment. It is used to do garbage collection on the keymap.
 //
 // This struct is NOT goroutine safe. It is unsafe to concurrently call write, flush, or seal on the same key file.
@@ -61,7 +134,7 @@ func createKeyFile(
 		logger:         logger,
 		index:          index,
 		segmentPath:    segmentPath,
-		segmentVersion: ValueSizeSegmentVersion,
+		segmentVersion: LatestSegmentVersion,
 		swap:           swap,
 	}
 
@@ -169,12 +242,19 @@ func (k *keyFile) atomicSwap(sync bool) error {
 	return nil
 }
 
-// write writes a key to the key file.
+// write writes a key to the key file. If the key has a lifetime, then a lifetime marker is written before the key.
 func (k *keyFile) write(scopedKey *types.ScopedKey) error {
 	if k.writer == nil {
 		return fmt.Errorf("key file is sealed")
 	}
 
+	if scopedKey.Lifetime != nil {
+		err := k.writeLifetime(scopedKey.Lifetime)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Write the length of the key.
 	err := binary.Write(k.writer, binary.BigEndian, uint32(len(scopedKey.Key)))
 	if err != nil {
@@ -208,6 +288,97 @@ func (k *keyFile) write(scopedKey *types.ScopedKey) error {
 	return nil
 }
 
+// writeBatchMarker writes an atomic batch marker to the key file.
+func (k *keyFile) writeBatchMarker(marker markerType) error {
+	if k.writer == nil {
+		return fmt.Errorf("key file is sealed")
+	}
+
+	err := k.writeMarkerHeader(marker)
+	if err != nil {
+		return err
+	}
+
+	k.size += batchMarkerSize
+
+	return nil
+}
+
+// writeTombstone writes a tombstone to the key file. The address is the address of the value being deleted.
+func (k *keyFile) writeTombstone(tombstone *types.ScopedKey) error {
+	if k.writer == nil {
+		return fmt.Errorf("key file is sealed")
+	}
+
+	err := k.writeMarkerHeader(tombstoneMarker)
+	if err != nil {
+		return err
+	}
+
+	// Write the length of the key.
+	err = binary.Write(k.writer, binary.BigEndian, uint32(len(tombstone.Key)))
+	if err != nil {
+		return fmt.Errorf("failed to write tombstone key length to key file: %w", err)
+	}
+
+	// Write the key itself.
+	_, err = k.writer.Write(tombstone.Key)
+	if err != nil {
+		return fmt.Errorf("failed to write tombstone key to key file: %w", err)
+	}
+
+	// Write the address of the deleted value.
+	err = binary.Write(k.writer, binary.BigEndian, tombstone.Address)
+	if err != nil {
+		return fmt.Errorf("failed to write tombstone address to key file: %w", err)
+	}
+
+	k.size += tombstoneSize(tombstone.Key)
+
+	return nil
+}
+
+// writeLifetime writes a lifetime marker to the key file. The lifetime applies to the next key written.
+func (k *keyFile) writeLifetime(lifetime *types.Lifetime) error {
+	err := k.writeMarkerHeader(lifetimeMarker)
+	if err != nil {
+		return err
+	}
+
+	writeTime := uint64(0)
+	if !lifetime.WriteTime.IsZero() {
+		writeTime = uint64(lifetime.WriteTime.UnixNano())
+	}
+	err = binary.Write(k.writer, binary.BigEndian, writeTime)
+	if err != nil {
+		return fmt.Errorf("failed to write lifetime write time to key file: %w", err)
+	}
+
+	err = binary.Write(k.writer, binary.BigEndian, uint64(lifetime.TTL.Nanoseconds()))
+	if err != nil {
+		return fmt.Errorf("failed to write lifetime TTL to key file: %w", err)
+	}
+
+	k.size += lifetimeMarkerSize
+
+	return nil
+}
+
+// writeMarkerHeader writes the reserved key length and the marker type that begin every marker.
+func (k *keyFile) writeMarkerHeader(marker markerType) error {
+	err := binary.Write(k.writer, binary.BigEndian, uint32(markerKeyLength))
+	if err != nil {
+		return fmt.Errorf("failed to write marker to key file: %w", err)
+	}
+
+	err = binary.Write(k.writer, binary.BigEndian, uint32(marker))
+	if err != nil {
+		return fmt.Errorf("failed to write marker type to key file: %w", err)
+	}
+
+	return nil
+}
+
 // getKeyFileIndex returns the index of the key file from the file name. Key file names have the form "X.keys",
 // where X is the segment index.
 func getKeyFileIndex(fileName string) (uint32, error) {
@@ -248,8 +419,47 @@ func (k *keyFile) seal() error {
 // readKeys reads all keys from the key file. This method returns an error if the key file is not sealed.
 // If there are keys that were only partially written (i.e. keys being written when the process crashed), then
 // those keys may not be returned. If a key is returned, it is guaranteed to be "whole" (i.e. a partial key will
-// never be returned).
+// never be returned). Keys that belong to an atomic batch that was never committed are not returned.
 func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
+	contents, err := k.readContents()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := contents.keys
+	for _, batch := range contents.batches {
+		if !batch.committed {
+			// Only the last batch in a key file can be uncommitted.
+			keys = keys[:batch.start]
+		}
+	}
+
+	return keys, nil
+}
+
+// readTombstones reads all tombstones from the key file. This method returns an error if the key file is not sealed.
+// Tombstones that belong to an atomic batch that was never committed are not returned.
+func (k *keyFile) readTombstones() ([]*types.ScopedKey, error) {
+	contents, err := k.readContents()
+	if err != nil {
+		return nil, err
+	}
+
+	tombstones := contents.tombstones
+	for _, batch := range contents.batches {
+		if !batch.committed {
+			// Only the last batch in a key file can be uncommitted.
+			tombstones = tombstones[:batch.tombstoneStart]
+		}
+	}
+
+	return tombstones, nil
+}
+
+// readContents reads all records from the key file: keys, the atomic batches that those keys belong to, and
+// tombstones. Unlike readKeys, keys that belong to an uncommitted atomic batch are returned, and the caller is
+// responsible for handling them. This method returns an error if the key file is not sealed.
+func (k *keyFile) readContents() (*keyFileContents, error) {
 	if !k.isSealed() {
 		return nil, fmt.Errorf("key file is not sealed")
 	}
@@ -271,8 +481,17 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 		return nil, fmt.Errorf("failed to read key file: %w", err)
 	}
 	keys := make([]*types.ScopedKey, 0)
+	batches := make([]*atomicBatch, 0)
+	tombstones := make([]*types.ScopedKey, 0)
+
+	// The batch currently being read, or nil if the current key is not part of an atomic batch.
+	var currentBatch *atomicBatch
+
+	// The lifetime of the next key, or nil if the next key has no lifetime.
+	var nextLifetime *types.Lifetime
 
 	index := 0
+records:
 	for {
 		// We need at least 4 bytes to read the length of the key.
 		if index+4 > len(keyBytes) { //nolint:staticcheck // QF1006
@@ -280,6 +499,76 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 			break
 		}
 		keyLength := int(binary.BigEndian.Uint32(keyBytes[index : index+4]))
+
+		if k.segmentVersion >= AtomicBatchSegmentVersion && uint32(keyLength) == markerKeyLength {
+			if index+batchMarkerSize > len(keyBytes) {
+				// There are insufficient bytes left in the file to read the marker.
+				break
+			}
+			marker := markerType(binary.BigEndian.Uint32(keyBytes[index+4 : index+8]))
+
+			switch marker {
+			case batchBeginMarker:
+				if currentBatch != nil {
+					return nil, fmt.Errorf("key file %s contains nested atomic batches", k.path())
+				}
+				currentBatch = &atomicBatch{start: len(keys), tombstoneStart: len(tombstones)}
+			case batchCommitMarker:
+				if currentBatch == nil {
+					return nil, fmt.Errorf("key file %s contains a commit marker outside of an atomic batch",
+						k.path())
+				}
+				currentBatch.end = len(keys)
+				currentBatch.tombstoneEnd = len(tombstones)
+				currentBatch.committed = true
+				batches = append(batches, currentBatch)
+				currentBatch = nil
+			case tombstoneMarker:
+				if k.segmentVersion < TombstoneSegmentVersion {
+					return nil, fmt.Errorf("key file %s contains a tombstone, but has segment version %d",
+						k.path(), k.segmentVersion)
+				}
+
+				// We need the 8 byte marker, the 4 byte key length, the key, and the 8 byte address.
+				if index+batchMarkerSize+4 > len(keyBytes) {
+					break records
+				}
+				tombstoneKeyLength := int(binary.BigEndian.Uint32(keyBytes[index+8 : index+12]))
+				if index+batchMarkerSize+4+tombstoneKeyLength+8 > len(keyBytes) {
+					break records
+				}
+				keyStart := index + batchMarkerSize + 4
+				key := keyBytes[keyStart : keyStart+tombstoneKeyLength]
+				address := types.Address(
+					binary.BigEndian.Uint64(keyBytes[keyStart+tombstoneKeyLength : keyStart+tombstoneKeyLength+8]))
+				tombstones = append(tombstones, &types.ScopedKey{Key: key, Address: address})
+				index += int(tombstoneSize(key))
+				continue
+			case lifetimeMarker:
+				if k.segmentVersion < ValueLifetimeSegmentVersion {
+					return nil, fmt.Errorf("key file %s contains a lifetime, but has segment version %d",
+						k.path(), k.segmentVersion)
+				}
+
+				if index+lifetimeMarkerSize > len(keyBytes) {
+					break records
+				}
+				nextLifetime = &types.Lifetime{
+					TTL: time.Duration(binary.BigEndian.Uint64(keyBytes[index+16 : index+24])),
+				}
+				writeTime := binary.BigEndian.Uint64(keyBytes[index+8 : index+16])
+				if writeTime != 0 {
+					nextLifetime.WriteTime = time.Unix(0, int64(writeTime))
+				}
+				index += lifetimeMarkerSize
+				continue
+			default:
+				return nil, fmt.Errorf("key file %s contains unknown marker type %d", k.path(), marker)
+			}
+			index += batchMarkerSize
+			continue
+		}
+
 		index += 4
 
 		if k.segmentVersion < ValueSizeSegmentVersion {
@@ -312,7 +601,9 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 			Key:       key,
 			Address:   address,
 			ValueSize: valueSize,
+			Lifetime:  nextLifetime,
 		})
+		nextLifetime = nil
 	}
 
 	if index != len(keyBytes) {
@@ -321,7 +612,18 @@ func (k *keyFile) readKeys() ([]*types.ScopedKey, error) {
 		k.logger.Warnf("key file %s has %d partial bytes", k.path(), len(keyBytes)-index)
 	}
 
-	return keys, nil
+	if currentBatch != nil {
+		// This can happen if there is a crash while an atomic batch is being written.
+		currentBatch.end = len(keys)
+		currentBatch.tombstoneEnd = len(tombstones)
+		batches = append(batches, currentBatch)
+	}
+
+	return &keyFileContents{
+		keys:       keys,
+		batches:    batches,
+		tombstones: tombstones,
+	}, nil
 }
 
 // snapshot creates a hard link to the file in the snapshot directory, and a soft link to the hard linked file in the
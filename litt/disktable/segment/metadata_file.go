@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 )
 
@@ -49,6 +50,60 @@ const (
 	// - 4 bytes for keyCount
 	// - and 1 byte for sealed.
 	V2MetadataSize = 37
+
+	// V3MetadataSize is the size of the metadata file at version 3 (aka ChecksumSegmentVersion).
+	// This is a constant, so it's convenient to have it here.
+	// - 4 bytes for version
+	// - 4 bytes for the sharding factor
+	// - 16 bytes for salt
+	// - 8 bytes for lastValueTimestamp
+	// - 4 bytes for keyCount
+	// - 1 byte for checksums
+	// - and 1 byte for sealed.
+	V3MetadataSize = 38
+
+	// V4MetadataSize is the size of the metadata file at version 4 (aka CompressionSegmentVersion).
+	// This is a constant, so it's convenient to have it here.
+	// - 4 bytes for version
+	// - 4 bytes for the sharding factor
+	// - 16 bytes for salt
+	// - 8 bytes for lastValueTimestamp
+	// - 4 bytes for keyCount
+	// - 8 bytes for uncompressedValueFileSize
+	// - 1 byte for checksums
+	// - 1 byte for compression
+	// - and 1 byte for sealed.
+	V4MetadataSize = 47
+
+	// V6MetadataSize is the size of the metadata file at version 6 (aka TombstoneSegmentVersion). Version 5
+	// (aka AtomicBatchSegmentVersion) uses the same metadata layout as version 4.
+	// This is a constant, so it's convenient to have it here.
+	// - 4 bytes for version
+	// - 4 bytes for the sharding factor
+	// - 16 bytes for salt
+	// - 8 bytes for lastValueTimestamp
+	// - 4 bytes for keyCount
+	// - 8 bytes for uncompressedValueFileSize
+	// - 4 bytes for tombstoneCount
+	// - 1 byte for checksums
+	// - 1 byte for compression
+	// - and 1 byte for sealed.
+	V6MetadataSize = 51
+
+	// V7MetadataSize is the size of the metadata file at version 7 (aka ValueLifetimeSegmentVersion).
+	// This is a constant, so it's convenient to have it here.
+	// - 4 bytes for version
+	// - 4 bytes for the sharding factor
+	// - 16 bytes for salt
+	// - 8 bytes for lastValueTimestamp
+	// - 4 bytes for keyCount
+	// - 8 bytes for uncompressedValueFileSize
+	// - 4 bytes for tombstoneCount
+	// - 4 bytes for lifetimeCount
+	// - 1 byte for checksums
+	// - 1 byte for compression
+	// - and 1 byte for sealed.
+	V7MetadataSize = 55
 )
 
 // metadataFile contains metadata about a segment. This file contains metadata about the data segment, such as
@@ -83,6 +138,25 @@ type metadataFile struct {
 	// This value is encoded in the file.
 	keyCount uint32
 
+	// If true, each value in this segment's value files is stored alongside a checksum.
+	// This value is encoded in the file.
+	checksums bool
+
+	// The algorithm used to compress values in this segment. This value is encoded in the file.
+	compression types.CompressionType
+
+	// The combined size that this segment's value files would have if values were not compressed. This value is
+	// undefined if the segment is not yet sealed. This value is encoded in the file.
+	uncompressedValueFileSize uint64
+
+	// The number of tombstones in this segment's key file. This value is undefined if the segment is not yet sealed.
+	// This value is encoded in the file.
+	tombstoneCount uint32
+
+	// The number of keys in this segment's key file that have a lifetime. This value is undefined if the segment is
+	// not yet sealed. This value is encoded in the file.
+	lifetimeCount uint32
+
 	// If true, the segment is sealed and no more data can be written to it. If false, then data can still be written
 	// to this segment. This value is encoded in the file.
 	sealed bool
@@ -101,6 +175,8 @@ func createMetadataFile(
 	index uint32,
 	shardingFactor uint32,
 	salt [16]byte,
+	checksums bool,
+	compression types.CompressionType,
 	path *SegmentPath,
 	fsync bool,
 ) (*metadataFile, error) {
@@ -114,6 +190,8 @@ func createMetadataFile(
 	file.segmentVersion = LatestSegmentVersion
 	file.shardingFactor = shardingFactor
 	file.salt = salt
+	file.checksums = checksums
+	file.compression = compression
 	err := file.write()
 	if err != nil {
 		return nil, fmt.Errorf("failed to write metadata file: %v", err)
@@ -173,8 +251,16 @@ func (m *metadataFile) Size() uint64 {
 		return V0MetadataSize
 	case SipHashSegmentVersion:
 		return V1MetadataSize
-	default:
+	case ValueSizeSegmentVersion:
 		return V2MetadataSize
+	case ChecksumSegmentVersion:
+		return V3MetadataSize
+	case CompressionSegmentVersion, AtomicBatchSegmentVersion:
+		return V4MetadataSize
+	case TombstoneSegmentVersion:
+		return V6MetadataSize
+	default:
+		return V7MetadataSize
 	}
 }
 
@@ -190,10 +276,19 @@ func (m *metadataFile) path() string {
 
 // Seal seals the segment. This action will atomically write the metadata file to disk one final time,
 // and should only be performed when all data that will be written to the key/value files has been made durable.
-func (m *metadataFile) seal(now time.Time, keyCount uint32) error {
+func (m *metadataFile) seal(
+	now time.Time,
+	keyCount uint32,
+	uncompressedValueFileSize uint64,
+	tombstoneCount uint32,
+	lifetimeCount uint32) error {
+
 	m.sealed = true
 	m.lastValueTimestamp = uint64(now.UnixNano())
 	m.keyCount = keyCount
+	m.uncompressedValueFileSize = uncompressedValueFileSize
+	m.tombstoneCount = tombstoneCount
+	m.lifetimeCount = lifetimeCount
 	err := m.write()
 	if err != nil {
 		return fmt.Errorf("failed to write sealed metadata file: %v", err)
@@ -201,6 +296,21 @@ func (m *metadataFile) seal(now time.Time, keyCount uint32) error {
 	return nil
 }
 
+// updateKeyCount atomically rewrites the metadata file with a new key count and a new count of keys with a lifetime.
+// Only legal for sealed segments.
+func (m *metadataFile) updateKeyCount(keyCount uint32, lifetimeCount uint32) error {
+	if !m.sealed {
+		return fmt.Errorf("metadata file %s is not sealed, cannot update key count", m.path())
+	}
+	m.keyCount = keyCount
+	m.lifetimeCount = lifetimeCount
+	err := m.write()
+	if err != nil {
+		return fmt.Errorf("failed to write metadata file: %v", err)
+	}
+	return nil
+}
+
 func (m *metadataFile) serializeV0Legacy() []byte {
 	data := make([]byte, V0MetadataSize)
 
@@ -251,15 +361,171 @@ func (m *metadataFile) serializeV1Legacy() []byte {
 	return data
 }
 
+func (m *metadataFile) serializeV2Legacy() []byte {
+	data := make([]byte, V2MetadataSize)
+
+	// Write the version
+	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
+
+	// Write the sharding factor
+	binary.BigEndian.PutUint32(data[4:8], m.shardingFactor)
+
+	// Write the salt
+	copy(data[8:24], m.salt[:])
+
+	// Write the lastValueTimestamp
+	binary.BigEndian.PutUint64(data[24:32], m.lastValueTimestamp)
+
+	// Write the key count
+	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
+
+	// Write the sealed flag
+	if m.sealed {
+		data[36] = 1
+	} else {
+		data[36] = 0
+	}
+
+	return data
+}
+
+func (m *metadataFile) serializeV3Legacy() []byte {
+	data := make([]byte, V3MetadataSize)
+
+	// Write the version
+	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
+
+	// Write the sharding factor
+	binary.BigEndian.PutUint32(data[4:8], m.shardingFactor)
+
+	// Write the salt
+	copy(data[8:24], m.salt[:])
+
+	// Write the lastValueTimestamp
+	binary.BigEndian.PutUint64(data[24:32], m.lastValueTimestamp)
+
+	// Write the key count
+	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
+
+	// Write the checksums flag
+	if m.checksums {
+		data[36] = 1
+	} else {
+		data[36] = 0
+	}
+
+	// Write the sealed flag
+	if m.sealed {
+		data[37] = 1
+	} else {
+		data[37] = 0
+	}
+
+	return data
+}
+
+func (m *metadataFile) serializeV4Legacy() []byte {
+	data := make([]byte, V4MetadataSize)
+
+	// Write the version
+	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
+
+	// Write the sharding factor
+	binary.BigEndian.PutUint32(data[4:8], m.shardingFactor)
+
+	// Write the salt
+	copy(data[8:24], m.salt[:])
+
+	// Write the lastValueTimestamp
+	binary.BigEndian.PutUint64(data[24:32], m.lastValueTimestamp)
+
+	// Write the key count
+	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
+
+	// Write the uncompressed value file size
+	binary.BigEndian.PutUint64(data[36:44], m.uncompressedValueFileSize)
+
+	// Write the checksums flag
+	if m.checksums {
+		data[44] = 1
+	} else {
+		data[44] = 0
+	}
+
+	// Write the compression type
+	data[45] = byte(m.compression)
+
+	// Write the sealed flag
+	if m.sealed {
+		data[46] = 1
+	} else {
+		data[46] = 0
+	}
+
+	return data
+}
+
+func (m *metadataFile) serializeV6Legacy() []byte {
+	data := make([]byte, V6MetadataSize)
+
+	// Write the version
+	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
+
+	// Write the sharding factor
+	binary.BigEndian.PutUint32(data[4:8], m.shardingFactor)
+
+	// Write the salt
+	copy(data[8:24], m.salt[:])
+
+	// Write the lastValueTimestamp
+	binary.BigEndian.PutUint64(data[24:32], m.lastValueTimestamp)
+
+	// Write the key count
+	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
+
+	// Write the uncompressed value file size
+	binary.BigEndian.PutUint64(data[36:44], m.uncompressedValueFileSize)
+
+	// Write the tombstone count
+	binary.BigEndian.PutUint32(data[44:48], m.tombstoneCount)
+
+	// Write the checksums flag
+	if m.checksums {
+		data[48] = 1
+	} else {
+		data[48] = 0
+	}
+
+	// Write the compression type
+	data[49] = byte(m.compression)
+
+	// Write the sealed flag
+	if m.sealed {
+		data[50] = 1
+	} else {
+		data[50] = 0
+	}
+
+	return data
+}
+
 // serialize serializes the metadata file to a byte array.
 func (m *metadataFile) serialize() []byte {
 	if m.segmentVersion == OldHashFunctionSegmentVersion {
 		return m.serializeV0Legacy()
 	} else if m.segmentVersion == SipHashSegmentVersion {
 		return m.serializeV1Legacy()
+	} else if m.segmentVersion == ValueSizeSegmentVersion {
+		return m.serializeV2Legacy()
+	} else if m.segmentVersion == ChecksumSegmentVersion {
+		return m.serializeV3Legacy()
+	} else if m.segmentVersion == CompressionSegmentVersion || m.segmentVersion == AtomicBatchSegmentVersion {
+		return m.serializeV4Legacy()
+	} else if m.segmentVersion == TombstoneSegmentVersion {
+		return m.serializeV6Legacy()
 	}
 
-	data := make([]byte, V2MetadataSize)
+	data := make([]byte, V7MetadataSize)
 
 	// Write the version
 	binary.BigEndian.PutUint32(data[0:4], uint32(m.segmentVersion))
@@ -276,11 +542,30 @@ func (m *metadataFile) serialize() []byte {
 	// Write the key count
 	binary.BigEndian.PutUint32(data[32:36], m.keyCount)
 
+	// Write the uncompressed value file size
+	binary.BigEndian.PutUint64(data[36:44], m.uncompressedValueFileSize)
+
+	// Write the tombstone count
+	binary.BigEndian.PutUint32(data[44:48], m.tombstoneCount)
+
+	// Write the lifetime count
+	binary.BigEndian.PutUint32(data[48:52], m.lifetimeCount)
+
+	// Write the checksums flag
+	if m.checksums {
+		data[52] = 1
+	} else {
+		data[52] = 0
+	}
+
+	// Write the compression type
+	data[53] = byte(m.compression)
+
 	// Write the sealed flag
 	if m.sealed {
-		data[36] = 1
+		data[54] = 1
 	} else {
-		data[36] = 0
+		data[54] = 0
 	}
 
 	return data
@@ -314,6 +599,78 @@ func (m *metadataFile) deserializeV1Legacy(data []byte) error {
 	return nil
 }
 
+func (m *metadataFile) deserializeV2Legacy(data []byte) error {
+	if len(data) != V2MetadataSize {
+		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
+			V2MetadataSize, len(data))
+	}
+
+	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
+	m.salt = [16]byte(data[8:24])
+	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
+	m.keyCount = binary.BigEndian.Uint32(data[32:36])
+	m.sealed = data[36] == 1
+	return nil
+}
+
+func (m *metadataFile) deserializeV3Legacy(data []byte) error {
+	if len(data) != V3MetadataSize {
+		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
+			V3MetadataSize, len(data))
+	}
+
+	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
+	m.salt = [16]byte(data[8:24])
+	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
+	m.keyCount = binary.BigEndian.Uint32(data[32:36])
+	m.checksums = data[36] == 1
+	m.sealed = data[37] == 1
+	return nil
+}
+
+func (m *metadataFile) deserializeV4Legacy(data []byte) error {
+	if len(data) != V4MetadataSize {
+		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
+			V4MetadataSize, len(data))
+	}
+
+	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
+	m.salt = [16]byte(data[8:24])
+	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
+	m.keyCount = binary.BigEndian.Uint32(data[32:36])
+	m.uncompressedValueFileSize = binary.BigEndian.Uint64(data[36:44])
+	m.checksums = data[44] == 1
+	m.compression = types.CompressionType(data[45])
+	if !m.compression.IsValid() {
+		return fmt.Errorf("unsupported compression type: %d", data[45])
+	}
+	m.sealed = data[46] == 1
+
+	return nil
+}
+
+func (m *metadataFile) deserializeV6Legacy(data []byte) error {
+	if len(data) != V6MetadataSize {
+		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
+			V6MetadataSize, len(data))
+	}
+
+	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
+	m.salt = [16]byte(data[8:24])
+	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
+	m.keyCount = binary.BigEndian.Uint32(data[32:36])
+	m.uncompressedValueFileSize = binary.BigEndian.Uint64(data[36:44])
+	m.tombstoneCount = binary.BigEndian.Uint32(data[44:48])
+	m.checksums = data[48] == 1
+	m.compression = types.CompressionType(data[49])
+	if !m.compression.IsValid() {
+		return fmt.Errorf("unsupported compression type: %d", data[49])
+	}
+	m.sealed = data[50] == 1
+
+	return nil
+}
+
 // deserialize deserializes the metadata file from a byte array.
 func (m *metadataFile) deserialize(data []byte) error {
 	if len(data) < 4 {
@@ -329,18 +686,34 @@ func (m *metadataFile) deserialize(data []byte) error {
 		return m.deserializeV0Legacy(data)
 	} else if m.segmentVersion == SipHashSegmentVersion {
 		return m.deserializeV1Legacy(data)
+	} else if m.segmentVersion == ValueSizeSegmentVersion {
+		return m.deserializeV2Legacy(data)
+	} else if m.segmentVersion == ChecksumSegmentVersion {
+		return m.deserializeV3Legacy(data)
+	} else if m.segmentVersion == CompressionSegmentVersion || m.segmentVersion == AtomicBatchSegmentVersion {
+		return m.deserializeV4Legacy(data)
+	} else if m.segmentVersion == TombstoneSegmentVersion {
+		return m.deserializeV6Legacy(data)
 	}
 
-	if len(data) != V2MetadataSize {
+	if len(data) != V7MetadataSize {
 		return fmt.Errorf("metadata file is not the correct size, expected %d, got %d",
-			V2MetadataSize, len(data))
+			V7MetadataSize, len(data))
 	}
 
 	m.shardingFactor = binary.BigEndian.Uint32(data[4:8])
 	m.salt = [16]byte(data[8:24])
 	m.lastValueTimestamp = binary.BigEndian.Uint64(data[24:32])
 	m.keyCount = binary.BigEndian.Uint32(data[32:36])
-	m.sealed = data[36] == 1
+	m.uncompressedValueFileSize = binary.BigEndian.Uint64(data[36:44])
+	m.tombstoneCount = binary.BigEndian.Uint32(data[44:48])
+	m.lifetimeCount = binary.BigEndian.Uint32(data[48:52])
+	m.checksums = data[52] == 1
+	m.compression = types.CompressionType(data[53])
+	if !m.compression.IsValid() {
+		return fmt.Errorf("unsupported compression type: %d", data[53])
+	}
+	m.sealed = data[54] == 1
 
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/test/random"
 	"github.com/stretchr/testify/require"
 )
@@ -27,6 +28,7 @@ func TestUnsealedSerialization(t *testing.T) {
 		shardingFactor:     shardingFactor,
 		salt:               salt,
 		lastValueTimestamp: timestamp,
+		checksums:          rand.Bool(),
 		sealed:             false,
 		segmentPath:        segmentPath,
 	}
@@ -74,6 +76,9 @@ func TestSealedSerialization(t *testing.T) {
 		shardingFactor:     shardingFactor,
 		salt:               salt,
 		lastValueTimestamp: timestamp,
+		checksums:          rand.Bool(),
+		tombstoneCount:     rand.Uint32(),
+		lifetimeCount:      rand.Uint32(),
 		sealed:             true,
 		segmentPath:        segmentPath,
 	}
@@ -108,18 +113,22 @@ func TestFreshFileSerialization(t *testing.T) {
 	directory := t.TempDir()
 
 	salt := ([16]byte)(rand.Bytes(16))
+	checksums := rand.Bool()
+	compression := types.CompressionType(rand.Intn(int(types.ZstdCompression) + 1))
 
 	index := rand.Uint32()
 	segmentPath, err := NewSegmentPath(directory, "", "table")
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	m, err := createMetadataFile(index, 1234, salt, segmentPath, false)
+	m, err := createMetadataFile(index, 1234, salt, checksums, compression, segmentPath, false)
 	require.NoError(t, err)
 
 	require.Equal(t, index, m.index)
 	require.Equal(t, LatestSegmentVersion, m.segmentVersion)
 	require.False(t, m.sealed)
+	require.Equal(t, checksums, m.checksums)
+	require.Equal(t, compression, m.compression)
 	require.Zero(t, m.lastValueTimestamp)
 
 	reportedSize := m.Size()
@@ -150,18 +159,20 @@ func TestSealing(t *testing.T) {
 	directory := t.TempDir()
 
 	salt := ([16]byte)(rand.Bytes(16))
+	checksums := rand.Bool()
+	compression := types.CompressionType(rand.Intn(int(types.ZstdCompression) + 1))
 
 	index := rand.Uint32()
 	segmentPath, err := NewSegmentPath(directory, "", "table")
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	m, err := createMetadataFile(index, 1234, salt, segmentPath, false)
+	m, err := createMetadataFile(index, 1234, salt, checksums, compression, segmentPath, false)
 	require.NoError(t, err)
 
 	// seal the file
 	sealTime := rand.Time()
-	err = m.seal(sealTime, 987)
+	err = m.seal(sealTime, 987, 123456, 42, 7)
 	require.NoError(t, err)
 
 	require.Equal(t, index, m.index)
@@ -171,6 +182,11 @@ func TestSealing(t *testing.T) {
 	require.Equal(t, salt, m.salt)
 	require.Equal(t, uint32(1234), m.shardingFactor)
 	require.Equal(t, uint32(987), m.keyCount)
+	require.Equal(t, uint64(123456), m.uncompressedValueFileSize)
+	require.Equal(t, uint32(42), m.tombstoneCount)
+	require.Equal(t, uint32(7), m.lifetimeCount)
+	require.Equal(t, checksums, m.checksums)
+	require.Equal(t, compression, m.compression)
 
 	// load the file
 	deserialized, err := loadMetadataFile(index, []*SegmentPath{segmentPath}, false)
@@ -188,3 +204,147 @@ func TestSealing(t *testing.T) {
 	_, err = os.Stat(filePath)
 	require.True(t, os.IsNotExist(err))
 }
+
+func TestLegacyV2Serialization(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	m := &metadataFile{
+		index:              index,
+		segmentVersion:     ValueSizeSegmentVersion,
+		shardingFactor:     rand.Uint32(),
+		salt:               ([16]byte)(rand.Bytes(16)),
+		lastValueTimestamp: rand.Uint64(),
+		keyCount:           rand.Uint32(),
+		sealed:             true,
+		segmentPath:        segmentPath,
+	}
+	err = m.write()
+	require.NoError(t, err)
+
+	stat, err := os.Stat(m.path())
+	require.NoError(t, err)
+	require.Equal(t, uint64(V2MetadataSize), uint64(stat.Size()))
+	require.Equal(t, uint64(V2MetadataSize), m.Size())
+
+	deserialized, err := loadMetadataFile(index, []*SegmentPath{segmentPath}, false)
+	require.NoError(t, err)
+	require.Equal(t, *m, *deserialized)
+	require.False(t, deserialized.checksums)
+}
+
+func TestLegacyV3Serialization(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	m := &metadataFile{
+		index:              index,
+		segmentVersion:     ChecksumSegmentVersion,
+		shardingFactor:     rand.Uint32(),
+		salt:               ([16]byte)(rand.Bytes(16)),
+		lastValueTimestamp: rand.Uint64(),
+		keyCount:           rand.Uint32(),
+		checksums:          rand.Bool(),
+		sealed:             true,
+		segmentPath:        segmentPath,
+	}
+	err = m.write()
+	require.NoError(t, err)
+
+	stat, err := os.Stat(m.path())
+	require.NoError(t, err)
+	require.Equal(t, uint64(V3MetadataSize), uint64(stat.Size()))
+	require.Equal(t, uint64(V3MetadataSize), m.Size())
+
+	deserialized, err := loadMetadataFile(index, []*SegmentPath{segmentPath}, false)
+	require.NoError(t, err)
+	require.Equal(t, *m, *deserialized)
+	require.Equal(t, types.NoCompression, deserialized.compression)
+}
+
+func TestLegacyV5Serialization(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	m := &metadataFile{
+		index:                     index,
+		segmentVersion:            AtomicBatchSegmentVersion,
+		shardingFactor:            rand.Uint32(),
+		salt:                      ([16]byte)(rand.Bytes(16)),
+		lastValueTimestamp:        rand.Uint64(),
+		keyCount:                  rand.Uint32(),
+		uncompressedValueFileSize: rand.Uint64(),
+		checksums:                 rand.Bool(),
+		compression:               types.SnappyCompression,
+		sealed:                    true,
+		segmentPath:               segmentPath,
+	}
+	err = m.write()
+	require.NoError(t, err)
+
+	stat, err := os.Stat(m.path())
+	require.NoError(t, err)
+	require.Equal(t, uint64(V4MetadataSize), uint64(stat.Size()))
+	require.Equal(t, uint64(V4MetadataSize), m.Size())
+
+	deserialized, err := loadMetadataFile(index, []*SegmentPath{segmentPath}, false)
+	require.NoError(t, err)
+	require.Equal(t, *m, *deserialized)
+	require.Equal(t, uint32(0), deserialized.tombstoneCount)
+}
+
+func TestLegacyV6Serialization(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	m := &metadataFile{
+		index:                     index,
+		segmentVersion:            TombstoneSegmentVersion,
+		shardingFactor:            rand.Uint32(),
+		salt:                      ([16]byte)(rand.Bytes(16)),
+		lastValueTimestamp:        rand.Uint64(),
+		keyCount:                  rand.Uint32(),
+		uncompressedValueFileSize: rand.Uint64(),
+		tombstoneCount:            rand.Uint32(),
+		checksums:                 rand.Bool(),
+		compression:               types.SnappyCompression,
+		sealed:                    true,
+		segmentPath:               segmentPath,
+	}
+	err = m.write()
+	require.NoError(t, err)
+
+	stat, err := os.Stat(m.path())
+	require.NoError(t, err)
+	require.Equal(t, uint64(V6MetadataSize), uint64(stat.Size()))
+	require.Equal(t, uint64(V6MetadataSize), m.Size())
+
+	deserialized, err := loadMetadataFile(index, []*SegmentPath{segmentPath}, false)
+	require.NoError(t, err)
+	require.Equal(t, *m, *deserialized)
+	require.Equal(t, uint32(0), deserialized.lifetimeCount)
+}
@@ -21,7 +21,8 @@ const unflushedKeysInitialCapacity = 128
 // shardControlChannelCapacity is the capacity of the channel used to send messages to the shard control loop.
 const shardControlChannelCapacity = 32
 
-// Segment is a chunk of data stored on disk. All data in a particular data segment is expired at the same time.
+// Segment is a chunk of data stored on disk. All data in a particular data segment is expired at the same time, except
+// for values that have a lifetime (see types.Lifetime).
 //
 // This struct is not safe for operations that mutate the segment, access control must be handled by the caller.
 type Segment struct {
@@ -53,12 +54,34 @@ type Segment struct {
 	// segment was loaded from disk, this value will be zero.
 	keyFileSize uint64
 
+	// The combined size the value files would have if values were not compressed. This is only tracked for mutable
+	// segments. Once sealed, this value is recorded in the metadata file.
+	uncompressedValueFileSize uint64
+
 	// The maximum size of all shards in this segment.
 	maxShardSize uint64
 
 	// The number of keys written to this segment.
 	keyCount uint32
 
+	// The number of tombstones written to this segment.
+	tombstoneCount uint32
+
+	// The number of keys with a lifetime written to this segment.
+	lifetimeCount uint32
+
+	// The keys in this segment that have a lifetime. For segments loaded from disk, this is populated from the key
+	// file the first time it is needed. Only the disk table's control loop may read or write this value.
+	lifetimes []*types.ScopedKey
+
+	// If true, then lifetimes contains every key in this segment that has a lifetime.
+	lifetimesLoaded bool
+
+	// The number of keys in this segment that have been deleted by a tombstone (the tombstone itself may live in this
+	// segment or in a later one). This value is not persisted, and is reconstructed from tombstones when the table is
+	// loaded. Only the disk table's control loop may read or write this value.
+	deletedKeyCount uint32
+
 	// shardChannels is a list of channels used to send messages to the goroutine responsible for writing to
 	// each shard. Indexed by shard number.
 	shardChannels []chan any
@@ -103,13 +126,15 @@ func CreateSegment(
 	snapshottingEnabled bool,
 	shardingFactor uint32,
 	salt [16]byte,
+	checksums bool,
+	compression types.CompressionType,
 	fsync bool) (*Segment, error) {
 
 	if len(segmentPaths) == 0 {
 		return nil, errors.New("no segment paths provided")
 	}
 
-	metadata, err := createMetadataFile(index, shardingFactor, salt, segmentPaths[0], fsync)
+	metadata, err := createMetadataFile(index, shardingFactor, salt, checksums, compression, segmentPaths[0], fsync)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open metadata file: %v", err)
 	}
@@ -129,7 +154,7 @@ func CreateSegment(
 		// use it for value files too.
 		segmentPath := segmentPaths[int(shard+1)%len(segmentPaths)]
 
-		values, err := createValueFile(logger, index, shard, segmentPath, fsync)
+		values, err := createValueFile(logger, index, shard, segmentPath, checksums, fsync)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open value file: %v", err)
 		}
@@ -162,6 +187,7 @@ func CreateSegment(
 		deletionChannel:     make(chan struct{}, 1),
 		snapshottingEnabled: snapshottingEnabled,
 		fsync:               fsync,
+		lifetimesLoaded:     true,
 	}
 
 	// Segments are returned with an initial reference count of 1, as the caller of the constructor is considered to
@@ -208,7 +234,7 @@ func LoadSegment(logger logging.Logger,
 	// Look for the value files. There should be one for each shard.
 	shards := make([]*valueFile, metadata.shardingFactor)
 	for shard := uint32(0); shard < metadata.shardingFactor; shard++ {
-		values, err := loadValueFile(logger, index, shard, segmentPaths)
+		values, err := loadValueFile(logger, index, shard, segmentPaths, metadata.checksums)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open value file: %v", err)
 		}
@@ -224,6 +250,8 @@ func LoadSegment(logger logging.Logger,
 		shards:              shards,
 		keyFileSize:         keyFileSize,
 		keyCount:            metadata.keyCount,
+		tombstoneCount:      metadata.tombstoneCount,
+		lifetimeCount:       metadata.lifetimeCount,
 		deletionChannel:     make(chan struct{}, 1),
 		snapshottingEnabled: snapshottingEnabled,
 		fsync:               fsync,
@@ -248,14 +276,72 @@ func (s *Segment) SegmentIndex() uint32 {
 	return s.index
 }
 
+// HasChecksums returns true if the values in this segment are stored alongside checksums.
+func (s *Segment) HasChecksums() bool {
+	return s.metadata.checksums
+}
+
+// Compression returns the algorithm used to compress values in this segment.
+func (s *Segment) Compression() types.CompressionType {
+	return s.metadata.compression
+}
+
 // sealLoadedSegment is responsible for sealing a segment loaded from disk that is not already sealed.
 // While doing this, it is responsible for making the key file consistent with the values present in the
 // value files.
 func (s *Segment) sealLoadedSegment(now time.Time) error {
-	scopedKeys, err := s.keys.readKeys()
+	contents, err := s.keys.readContents()
 	if err != nil {
 		return fmt.Errorf("failed to read keys: %w", err)
 	}
+	scopedKeys := contents.keys
+	batches := contents.batches
+
+	// For each key, true if the value is present in the value files.
+	present := make([]bool, len(scopedKeys))
+	for i, scopedKey := range scopedKeys {
+		shard := s.GetShard(scopedKey.Key)
+
+		requiredValueFileLength := uint64(scopedKey.Address.Offset()) +
+			s.shards[shard].recordOverhead() +
+			uint64(scopedKey.ValueSize)
+
+		present[i] = s.shards[shard].Size() >= requiredValueFileLength
+	}
+
+	// For each tombstone, true if the tombstone should be kept.
+	keepTombstone := make([]bool, len(contents.tombstones))
+	for i := range keepTombstone {
+		keepTombstone[i] = true
+	}
+	droppedTombstones := false
+
+	// An atomic batch is only kept if it was committed and all of its values are present. Otherwise, every key and
+	// tombstone in the batch is discarded.
+	for _, batch := range batches {
+		keep := batch.committed
+		for i := batch.start; i < batch.end && keep; i++ {
+			keep = present[i]
+		}
+		if !keep {
+			s.logger.Warnf("segment %d has an incomplete atomic batch with %d key(s) and %d tombstone(s), "+
+				"discarding batch", s.index, batch.end-batch.start, batch.tombstoneEnd-batch.tombstoneStart)
+			for i := batch.start; i < batch.end; i++ {
+				present[i] = false
+			}
+			for i := batch.tombstoneStart; i < batch.tombstoneEnd; i++ {
+				keepTombstone[i] = false
+				droppedTombstones = true
+			}
+		}
+	}
+
+	goodTombstones := make([]*types.ScopedKey, 0, len(contents.tombstones))
+	for i, tombstone := range contents.tombstones {
+		if keepTombstone[i] {
+			goodTombstones = append(goodTombstones, tombstone)
+		}
+	}
 
 	// keys with values that are not present in the value files
 	goodKeys := make([]*types.ScopedKey, 0, len(scopedKeys))
@@ -263,55 +349,97 @@ func (s *Segment) sealLoadedSegment(now time.Time) error {
 	// keys with values that weren't flushed out to the value files before the DB crashed
 	badKeys := make([]*types.ScopedKey, 0, len(scopedKeys))
 
-	for _, scopedKey := range scopedKeys {
-		shard := s.GetShard(scopedKey.Key)
-
-		requiredValueFileLength := uint64(scopedKey.Address.Offset()) +
-			4 /* value size uint32 */ +
-			uint64(scopedKey.ValueSize)
-
-		if s.shards[shard].Size() < requiredValueFileLength {
-			badKeys = append(badKeys, scopedKey)
-		} else {
+	for i, scopedKey := range scopedKeys {
+		if present[i] {
 			goodKeys = append(goodKeys, scopedKey)
+		} else {
+			badKeys = append(badKeys, scopedKey)
 		}
 	}
 
-	if len(badKeys) > 0 {
-		// We have at least one bad key. Rewrite the keyfile with only the good keys.
+	if len(badKeys) > 0 || droppedTombstones {
+		// We have at least one bad key or discarded tombstone. Rewrite the keyfile with only the good records.
 		s.logger.Warnf("segment %d has %d unflushed value(s)",
 			s.index, len(badKeys))
 
-		swapFile, err := createKeyFile(s.logger, s.index, s.keys.segmentPath, true)
+		err = s.rewriteKeyFile(goodKeys, goodTombstones)
 		if err != nil {
-			return fmt.Errorf("failed to create swap key file: %w", err)
+			return fmt.Errorf("failed to rewrite key file: %w", err)
 		}
+	}
 
+	uncompressedValueFileSize := uint64(0)
+	if s.metadata.compression == types.NoCompression {
+		for _, shard := range s.shards {
+			uncompressedValueFileSize += shard.Size()
+		}
+	} else {
+		// The uncompressed size of a segment is only recorded when the segment is sealed, so it must be
+		// reconstructed by decompressing each value.
 		for _, scopedKey := range goodKeys {
-			err = swapFile.write(scopedKey)
+			value, err := s.Read(scopedKey.Key, scopedKey.Address)
 			if err != nil {
-				return fmt.Errorf("failed to write key to swap file: %w", err)
+				return fmt.Errorf("failed to read value while sealing segment: %w", err)
 			}
+			uncompressedValueFileSize += uint64(len(value)) + s.shards[s.GetShard(scopedKey.Key)].recordOverhead()
 		}
-		err = swapFile.seal()
-		if err != nil {
-			return fmt.Errorf("failed to seal swap file: %w", err)
+	}
+
+	tombstoneCount := uint32(len(goodTombstones))
+	lifetimeCount := countLifetimes(goodKeys)
+	err = s.metadata.seal(now, uint32(len(goodKeys)), uncompressedValueFileSize, tombstoneCount, lifetimeCount)
+	if err != nil {
+		return fmt.Errorf("failed to seal metadata file: %w", err)
+	}
+	s.keyCount = uint32(len(goodKeys))
+	s.tombstoneCount = tombstoneCount
+	s.lifetimeCount = lifetimeCount
+
+	return nil
+}
+
+// countLifetimes returns the number of keys that have a lifetime.
+func countLifetimes(keys []*types.ScopedKey) uint32 {
+	count := uint32(0)
+	for _, key := range keys {
+		if key.Lifetime != nil {
+			count++
 		}
+	}
+	return count
+}
 
-		err = swapFile.atomicSwap(s.fsync)
+// rewriteKeyFile atomically replaces the key file with a new key file that contains only the given keys (along with
+// their lifetimes) and tombstones.
+func (s *Segment) rewriteKeyFile(keys []*types.ScopedKey, tombstones []*types.ScopedKey) error {
+	swapFile, err := createKeyFile(s.logger, s.index, s.keys.segmentPath, true)
+	if err != nil {
+		return fmt.Errorf("failed to create swap key file: %w", err)
+	}
+
+	for _, scopedKey := range keys {
+		err = swapFile.write(scopedKey)
 		if err != nil {
-			return fmt.Errorf("failed to swap key file: %w", err)
+			return fmt.Errorf("failed to write key to swap file: %w", err)
 		}
-
-		s.keys = swapFile
+	}
+	for _, tombstone := range tombstones {
+		err = swapFile.writeTombstone(tombstone)
+		if err != nil {
+			return fmt.Errorf("failed to write tombstone to swap file: %w", err)
+		}
+	}
+	err = swapFile.seal()
+	if err != nil {
+		return fmt.Errorf("failed to seal swap file: %w", err)
 	}
 
-	err = s.metadata.seal(now, uint32(len(goodKeys)))
+	err = swapFile.atomicSwap(s.fsync)
 	if err != nil {
-		return fmt.Errorf("failed to seal metadata file: %w", err)
+		return fmt.Errorf("failed to swap key file: %w", err)
 	}
-	s.keyCount = uint32(len(goodKeys))
 
+	s.keys = swapFile
 	return nil
 }
 
@@ -337,11 +465,57 @@ func (s *Segment) Size() uint64 {
 	return size
 }
 
-// KeyCount returns the number of keys in the segment.
+// UncompressedSize returns the size the segment would have in bytes if its values were not compressed. For segments
+// that do not use compression, this is equal to Size(). This method is not thread safe, and should not be called
+// concurrently with methods that modify the segment.
+func (s *Segment) UncompressedSize() uint64 {
+	if s.metadata.compression == types.NoCompression {
+		return s.Size()
+	}
+
+	size := s.metadata.Size()
+	if s.IsSealed() {
+		size += s.keys.Size() + s.metadata.uncompressedValueFileSize
+	} else {
+		size += s.keyFileSize + s.uncompressedValueFileSize
+	}
+
+	return size
+}
+
+// KeyCount returns the number of keys in the segment. Keys that have been deleted are included in this count.
 func (s *Segment) KeyCount() uint32 {
 	return s.keyCount
 }
 
+// TombstoneCount returns the number of tombstones in the segment.
+func (s *Segment) TombstoneCount() uint32 {
+	return s.tombstoneCount
+}
+
+// MarkKeyDeleted records that one of the keys in this segment has been deleted by a tombstone.
+// This method is not thread safe.
+func (s *Segment) MarkKeyDeleted() {
+	s.deletedKeyCount++
+}
+
+// DeletedKeyCount returns the number of keys in this segment that have been marked as deleted via MarkKeyDeleted.
+// This method is not thread safe.
+func (s *Segment) DeletedKeyCount() uint32 {
+	return s.deletedKeyCount
+}
+
+// IsDead returns true if this segment contains no live data, i.e. every key in the segment has been deleted (or
+// the segment has no keys at all). This method is not thread safe.
+func (s *Segment) IsDead() bool {
+	return s.keyCount == s.deletedKeyCount
+}
+
+// LifetimeCount returns the number of keys in the segment that have a lifetime (see types.Lifetime).
+func (s *Segment) LifetimeCount() uint32 {
+	return s.lifetimeCount
+}
+
 // lookForFile looks for a file in a list of directories. It returns an error if the file appears
 // in more than one directory, and nil if the file is not found. If the file is found and
 // there are no errors, this method returns the SegmentPath where the file was found.
@@ -395,10 +569,24 @@ func (s *Segment) GetShard(key []byte) uint32 {
 // This method does not ensure that the key-value pair is actually written to disk, only that it will eventually be
 // written to disk. Flush must be called to ensure that all data previously passed to Write is written to disk.
 func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64, err error) {
+	return s.WriteWithLifetime(data, nil)
+}
+
+// WriteWithLifetime is identical to Write, except that the value's lifetime is recorded alongside its key. If the
+// lifetime is nil, then the value is retained for the table's TTL after this segment is sealed.
+func (s *Segment) WriteWithLifetime(
+	data *types.KVPair,
+	lifetime *types.Lifetime) (keyCount uint32, keyFileSize uint64, err error) {
+
 	if s.metadata.sealed {
 		return 0, 0, fmt.Errorf("segment is sealed, cannot write data")
 	}
 
+	if uint64(len(data.Key)) >= markerKeyLength {
+		return 0, 0, fmt.Errorf("key is too large, length must be less than %d bytes: %d bytes",
+			uint64(markerKeyLength), len(data.Key))
+	}
+
 	shard := s.GetShard(data.Key)
 	currentSize := s.shardSizes[shard]
 
@@ -409,19 +597,31 @@ func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64
 		return 0, 0,
 			fmt.Errorf("value file already contains %d bytes, cannot add a new value", currentSize)
 	}
+	storedValue, err := compress(s.metadata.compression, data.Value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to compress value: %w", err)
+	}
+
 	s.unflushedKeyCount.Add(1)
 	firstByteIndex := uint32(currentSize)
 
-	s.shardSizes[shard] += uint64(len(data.Value)) + 4 /* uint32 length */
+	s.shardSizes[shard] += uint64(len(storedValue)) + s.shards[shard].recordOverhead()
+	s.uncompressedValueFileSize += uint64(len(data.Value)) + s.shards[shard].recordOverhead()
 	if s.shardSizes[shard] > s.maxShardSize {
 		s.maxShardSize = s.shardSizes[shard]
 	}
 	s.keyCount++
 	s.keyFileSize += uint64(len(data.Key)) + 4 /* uint32 length */ + 8 /* uint64 Address */ + 4 /* uint32 ValueSize */
+	if lifetime != nil {
+		s.lifetimeCount++
+		s.keyFileSize += lifetimeMarkerSize
+	}
+	address := types.NewAddress(s.index, firstByteIndex)
 
 	// Forward the value to the shard control loop, which asynchronously writes it to the value file.
 	shardRequest := &valueToWrite{
-		value:                  data.Value,
+		key:                    data.Key,
+		value:                  storedValue,
 		expectedFirstByteIndex: firstByteIndex,
 	}
 	err = util.Send(s.errorMonitor, s.shardChannels[shard], shardRequest)
@@ -433,8 +633,12 @@ func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64
 	// Forward the value to the key and its address file control loop, which asynchronously writes it to the key file.
 	keyRequest := &types.ScopedKey{
 		Key:       data.Key,
-		Address:   types.NewAddress(s.index, firstByteIndex),
-		ValueSize: uint32(len(data.Value)),
+		Address:   address,
+		ValueSize: uint32(len(storedValue)),
+		Lifetime:  lifetime,
+	}
+	if lifetime != nil {
+		s.lifetimes = append(s.lifetimes, keyRequest)
 	}
 
 	err = util.Send(s.errorMonitor, s.keyFileChannel, keyRequest)
@@ -446,6 +650,124 @@ func (s *Segment) Write(data *types.KVPair) (keyCount uint32, keyFileSize uint64
 	return s.keyCount, s.keyFileSize, nil
 }
 
+// WriteAtomicBatch records a batch of key-value pairs in the segment as a single atomic unit. The keys are bracketed
+// in the key file by a begin marker and a commit marker. If the process crashes before the entire batch (including
+// the commit marker) is durable on disk, then the batch is discarded in its entirety when the segment is loaded.
+// Returns the resulting number of keys in the segment and the size of the key file.
+//
+// Like Write, this method does not ensure that the batch is actually written to disk. Flush must be called to ensure
+// that the batch is durable.
+func (s *Segment) WriteAtomicBatch(batch []*types.KVPair) (keyCount uint32, keyFileSize uint64, err error) {
+	if s.metadata.sealed {
+		return 0, 0, fmt.Errorf("segment is sealed, cannot write data")
+	}
+
+	err = s.writeBatchMarker(batchBeginMarker)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to write batch begin marker: %w", err)
+	}
+
+	for _, kv := range batch {
+		_, _, err = s.Write(kv)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to write value in atomic batch: %w", err)
+		}
+	}
+
+	err = s.writeBatchMarker(batchCommitMarker)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to write batch commit marker: %w", err)
+	}
+
+	return s.keyCount, s.keyFileSize, nil
+}
+
+// WriteRelocatedBatch records a batch of key-value pairs that are being moved into this segment from older segments.
+// For each key-value pair, previousAddresses contains the address of the value being moved, and lifetimes contains
+// the lifetime of the value (so that moving a value does not change when it expires). Each value is written alongside
+// a tombstone for its previous address, and the entire batch is written as a single atomic unit (see
+// WriteAtomicBatch). Returns the resulting number of keys in the segment and the size of the key file.
+//
+// Like Write, this method does not ensure that the batch is actually written to disk. Flush must be called to ensure
+// that the batch is durable.
+func (s *Segment) WriteRelocatedBatch(
+	batch []*types.KVPair,
+	previousAddresses []types.Address,
+	lifetimes []*types.Lifetime) (keyCount uint32, keyFileSize uint64, err error) {
+
+	if s.metadata.sealed {
+		return 0, 0, fmt.Errorf("segment is sealed, cannot write data")
+	}
+	if len(batch) != len(previousAddresses) || len(batch) != len(lifetimes) {
+		return 0, 0, fmt.Errorf("batch has %d values but %d previous addresses and %d lifetimes",
+			len(batch), len(previousAddresses), len(lifetimes))
+	}
+
+	err = s.writeBatchMarker(batchBeginMarker)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to write batch begin marker: %w", err)
+	}
+
+	for i, kv := range batch {
+		_, _, err = s.WriteWithLifetime(kv, lifetimes[i])
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to write relocated value: %w", err)
+		}
+		_, err = s.WriteTombstone(kv.Key, previousAddresses[i])
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to write tombstone for relocated value: %w", err)
+		}
+	}
+
+	err = s.writeBatchMarker(batchCommitMarker)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to write batch commit marker: %w", err)
+	}
+
+	return s.keyCount, s.keyFileSize, nil
+}
+
+// writeBatchMarker forwards an atomic batch marker to the key file control loop.
+func (s *Segment) writeBatchMarker(marker markerType) error {
+	s.keyFileSize += batchMarkerSize
+	err := util.Send(s.errorMonitor, s.keyFileChannel, marker)
+	if err != nil {
+		return fmt.Errorf("failed to send batch marker to key file control loop: %v", err)
+	}
+	return nil
+}
+
+// WriteTombstone records the deletion of a key in this segment's key file. The address is the address of the value
+// being deleted, which may be in this segment or in an earlier segment. Returns the resulting size of the key file.
+//
+// Like Write, this method does not ensure that the tombstone is actually written to disk. Flush must be called to
+// ensure that the tombstone is durable.
+func (s *Segment) WriteTombstone(key []byte, address types.Address) (keyFileSize uint64, err error) {
+	if s.metadata.sealed {
+		return 0, fmt.Errorf("segment is sealed, cannot write tombstone")
+	}
+
+	if uint64(len(key)) >= markerKeyLength {
+		return 0, fmt.Errorf("key length must be less than %d, got %d", uint64(markerKeyLength), len(key))
+	}
+
+	tombstone := &tombstoneToWrite{
+		tombstone: &types.ScopedKey{
+			Key:     key,
+			Address: address,
+		},
+	}
+
+	s.tombstoneCount++
+	s.keyFileSize += tombstoneSize(key)
+	err = util.Send(s.errorMonitor, s.keyFileChannel, tombstone)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send tombstone to key file control loop: %v", err)
+	}
+
+	return s.keyFileSize, nil
+}
+
 // GetMaxShardSize returns the maximum size of all shards in this segment.
 func (s *Segment) GetMaxShardSize() uint64 {
 	return s.maxShardSize
@@ -458,10 +780,15 @@ func (s *Segment) Read(key []byte, dataAddress types.Address) ([]byte, error) {
 	shard := s.GetShard(key)
 	values := s.shards[shard]
 
-	value, err := values.read(dataAddress.Offset())
+	storedValue, err := values.read(key, dataAddress.Offset())
 	if err != nil {
 		return nil, fmt.Errorf("failed to read value: %w", err)
 	}
+
+	value, err := decompress(s.metadata.compression, storedValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress value: %w", err)
+	}
 	return value, nil
 }
 
@@ -478,6 +805,49 @@ func (s *Segment) GetKeys() ([]*types.ScopedKey, error) {
 	return keys, nil
 }
 
+// GetLifetimes returns all keys in the data segment that have a lifetime. The returned slice must not be modified.
+// Unlike GetKeys, this method may be called before the segment is sealed. This method is not thread safe, and may
+// only be called by the goroutine that writes to the segment.
+func (s *Segment) GetLifetimes() ([]*types.ScopedKey, error) {
+	if s.lifetimesLoaded || s.lifetimeCount == 0 {
+		return s.lifetimes, nil
+	}
+
+	keys, err := s.keys.readKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keys: %w", err)
+	}
+
+	lifetimes := make([]*types.ScopedKey, 0, s.lifetimeCount)
+	for _, key := range keys {
+		if key.Lifetime != nil {
+			lifetimes = append(lifetimes, key)
+		}
+	}
+	s.lifetimes = lifetimes
+	s.lifetimesLoaded = true
+	return lifetimes, nil
+}
+
+// GetTombstones returns all tombstones in the data segment. The address of each tombstone is the address of the
+// deleted value. Only permitted to be called after the segment has been sealed.
+func (s *Segment) GetTombstones() ([]*types.ScopedKey, error) {
+	if !s.metadata.sealed {
+		return nil, fmt.Errorf("segment is not sealed, cannot read tombstones")
+	}
+
+	if s.tombstoneCount == 0 {
+		// Avoid reading the key file if there is nothing to find.
+		return []*types.ScopedKey{}, nil
+	}
+
+	tombstones, err := s.keys.readTombstones()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tombstones: %w", err)
+	}
+	return tombstones, nil
+}
+
 // FlushWaitFunction is a function that waits for a flush operation to complete. It returns the addresses of the data
 // that was flushed, or an error if the flush operation failed.
 type FlushWaitFunction func() ([]*types.ScopedKey, error)
@@ -588,7 +958,7 @@ func (s *Segment) Seal(now time.Time) ([]*types.ScopedKey, error) {
 	}
 
 	// Seal the metadata file.
-	err = s.metadata.seal(now, s.keyCount)
+	err = s.metadata.seal(now, s.keyCount, s.uncompressedValueFileSize, s.tombstoneCount, s.lifetimeCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to seal metadata file: %w", err)
 	}
@@ -720,7 +1090,7 @@ func (s *Segment) handleShardFlushRequest(shard uint32, request *shardFlushReque
 
 // handleShardWrite applies a single write operation to a shard.
 func (s *Segment) handleShardWrite(shard uint32, data *valueToWrite) {
-	firstByteIndex, err := s.shards[shard].write(data.value)
+	firstByteIndex, err := s.shards[shard].write(data.key, data.value)
 	if err != nil {
 		s.errorMonitor.Panic(fmt.Errorf("failed to write value to value file: %w", err))
 	}
@@ -742,6 +1112,22 @@ func (s *Segment) handleKeyFileWrite(data *types.ScopedKey) {
 	}
 }
 
+// handleKeyFileBatchMarker writes an atomic batch marker to the key file.
+func (s *Segment) handleKeyFileBatchMarker(marker markerType) {
+	err := s.keys.writeBatchMarker(marker)
+	if err != nil {
+		s.errorMonitor.Panic(fmt.Errorf("failed to write batch marker to key file: %w", err))
+	}
+}
+
+// handleKeyFileTombstone writes a tombstone to the key file.
+func (s *Segment) handleKeyFileTombstone(data *tombstoneToWrite) {
+	err := s.keys.writeTombstone(data.tombstone)
+	if err != nil {
+		s.errorMonitor.Panic(fmt.Errorf("failed to write tombstone to key file: %w", err))
+	}
+}
+
 // handleKeyFileFlushRequest handles a request to flush the key file to disk.
 func (s *Segment) handleKeyFileFlushRequest(request *keyFileFlushRequest, unflushedKeys []*types.ScopedKey) {
 	if request.seal {
@@ -772,6 +1158,7 @@ type shardFlushRequest struct {
 
 // valueToWrite is a message sent to the shard control loop to request that it write a value to the value file.
 type valueToWrite struct {
+	key                    []byte
 	value                  []byte
 	expectedFirstByteIndex uint32
 }
@@ -802,6 +1189,11 @@ func (s *Segment) shardControlLoop(shard uint32) {
 	}
 }
 
+// tombstoneToWrite is a message sent to the key file control loop to request that it write a tombstone to the key file.
+type tombstoneToWrite struct {
+	tombstone *types.ScopedKey
+}
+
 // keyFileFlushRequest is a message sent to the key file control loop to request that it flush its data to disk.
 type keyFileFlushRequest struct {
 	// If true, seal the key file after flushing. If false, do not seal the key file.
@@ -843,6 +1235,12 @@ func (s *Segment) keyFileControlLoop() {
 				s.handleKeyFileWrite(data)
 				unflushedKeys = append(unflushedKeys, data)
 
+			} else if marker, ok := operation.(markerType); ok {
+				s.handleKeyFileBatchMarker(marker)
+
+			} else if data, ok := operation.(*tombstoneToWrite); ok {
+				s.handleKeyFileTombstone(data)
+
 			} else {
 				s.errorMonitor.Panic(
 					fmt.Errorf("unknown operation type in key file control loop: %T", operation))
@@ -104,6 +104,26 @@ func scanDirectories(logger logging.Logger, segmentPaths []*SegmentPath) (
 		nil
 }
 
+// FindLowestSegmentIndex returns the lowest index of any segment with a metadata file in the given segment paths.
+// Returns false if there are no metadata files. Unlike GatherSegmentFiles, this function never modifies files on disk.
+func FindLowestSegmentIndex(logger logging.Logger, segmentPaths []*SegmentPath) (uint32, bool, error) {
+	metadataFiles, _, _, _, _, _, err := scanDirectories(logger, segmentPaths)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to scan directories: %w", err)
+	}
+
+	if len(metadataFiles) == 0 {
+		return 0, false, nil
+	}
+
+	lowestSegmentIndex := uint32(math.MaxUint32)
+	for index := range metadataFiles {
+		lowestSegmentIndex = min(lowestSegmentIndex, index)
+	}
+
+	return lowestSegmentIndex, true, nil
+}
+
 // diagnoseMissingFile decides what to do with specific missing files. If the segment is either the segment
 // with the lowest index or the segment with the highest index, it is possible for files to be missing due to
 // non-catastrophic reasons (i.e. a crash during cleanup). If the segment is neither the lowest nor highest segment,
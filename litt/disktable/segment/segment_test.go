@@ -60,6 +60,8 @@ func TestWriteAndReadSegmentSingleShard(t *testing.T) {
 		false,
 		1,
 		salt,
+		false,
+		types.NoCompression,
 		false)
 
 	require.NoError(t, err)
@@ -211,6 +213,8 @@ func TestWriteAndReadSegmentMultiShard(t *testing.T) {
 		false,
 		shardCount,
 		salt,
+		false,
+		types.NoCompression,
 		false)
 
 	require.NoError(t, err)
@@ -371,6 +375,8 @@ func TestWriteAndReadColdShard(t *testing.T) {
 		false,
 		shardCount,
 		salt,
+		false,
+		types.NoCompression,
 		false)
 
 	require.NoError(t, err)
@@ -482,6 +488,8 @@ func TestGetFilePaths(t *testing.T) {
 		false,
 		shardingFactor,
 		([16]byte)(salt),
+		false,
+		types.NoCompression,
 		false)
 	require.NoError(t, err)
 
@@ -521,3 +529,729 @@ func TestGetFilePaths(t *testing.T) {
 		require.Equal(t, segment.shards[i].path(), valueFiles[i])
 	}
 }
+
+func TestVerifyAndDropKeys(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	rand := random.NewTestRandom()
+	logger := test.GetLogger()
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	valueCount := rand.Int32Range(100, 200)
+	shardCount := rand.Uint32Range(1, 4)
+	salt := ([16]byte)(rand.Bytes(16))
+
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	seg, err := CreateSegment(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		shardCount,
+		salt,
+		true,
+		types.NoCompression,
+		false)
+	require.NoError(t, err)
+	require.True(t, seg.HasChecksums())
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < int(valueCount); i++ {
+		key := rand.PrintableVariableBytes(32, 100)
+		value := rand.PrintableVariableBytes(1, 100)
+		expectedValues[string(key)] = value
+		_, _, err = seg.Write(&types.KVPair{Key: key, Value: value})
+		require.NoError(t, err)
+	}
+
+	_, err = seg.Seal(rand.Time())
+	require.NoError(t, err)
+
+	// An uncorrupted segment should pass verification.
+	corrupt, err := seg.Verify()
+	require.NoError(t, err)
+	require.Empty(t, corrupt)
+
+	// Corrupt the last byte of a randomly chosen value.
+	keys, err := seg.GetKeys()
+	require.NoError(t, err)
+	target := keys[rand.Intn(len(keys))]
+	valuePath := seg.shards[seg.GetShard(target.Key)].path()
+	fileBytes, err := os.ReadFile(valuePath)
+	require.NoError(t, err)
+	lastByte := target.Address.Offset() + 4 + ChecksumSize + target.ValueSize - 1
+	fileBytes[lastByte] ^= 0xff
+	err = os.WriteFile(valuePath, fileBytes, 0644)
+	require.NoError(t, err)
+
+	corrupt, err = seg.Verify()
+	require.NoError(t, err)
+	require.Len(t, corrupt, 1)
+	require.Equal(t, target.Key, corrupt[0].Key.Key)
+	require.Equal(t, target.Address, corrupt[0].Key.Address)
+	require.ErrorIs(t, corrupt[0].Err, ErrChecksumMismatch)
+
+	// Quarantine the corrupt key.
+	err = seg.DropKeys([]*types.ScopedKey{corrupt[0].Key})
+	require.NoError(t, err)
+	require.Equal(t, uint32(valueCount-1), seg.KeyCount())
+
+	// Reload the segment, the corrupt key should no longer be present.
+	seg2, err := LoadSegment(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		time.Now(),
+		false)
+	require.NoError(t, err)
+	require.True(t, seg2.HasChecksums())
+	require.Equal(t, uint32(valueCount-1), seg2.KeyCount())
+
+	keys, err = seg2.GetKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, int(valueCount-1))
+	for _, key := range keys {
+		require.NotEqual(t, target.Key, key.Key)
+		value, err := seg2.Read(key.Key, key.Address)
+		require.NoError(t, err)
+		require.Equal(t, expectedValues[string(key.Key)], value)
+	}
+
+	corrupt, err = seg2.Verify()
+	require.NoError(t, err)
+	require.Empty(t, corrupt)
+}
+
+func TestDropKeysWithCollidingAddresses(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	rand := random.NewTestRandom()
+	logger := test.GetLogger()
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	salt := ([16]byte)(rand.Bytes(16))
+
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	seg, err := CreateSegment(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		2,
+		salt,
+		true,
+		types.NoCompression,
+		false)
+	require.NoError(t, err)
+
+	// Write the first value of each shard. Both values are written at the same offset of their shard's value file,
+	// and so they have the same address.
+	firstKeys := make(map[uint32][]byte)
+	for len(firstKeys) < 2 {
+		key := rand.PrintableVariableBytes(32, 100)
+		shard := seg.GetShard(key)
+		if _, ok := firstKeys[shard]; ok {
+			continue
+		}
+		firstKeys[shard] = key
+		_, _, err = seg.Write(&types.KVPair{Key: key, Value: rand.PrintableVariableBytes(1, 100)})
+		require.NoError(t, err)
+	}
+	otherKey := rand.PrintableVariableBytes(101, 200)
+	_, _, err = seg.Write(&types.KVPair{Key: otherKey, Value: rand.PrintableVariableBytes(1, 100)})
+	require.NoError(t, err)
+
+	_, err = seg.Seal(rand.Time())
+	require.NoError(t, err)
+
+	keys, err := seg.GetKeys()
+	require.NoError(t, err)
+	toDrop := make([]*types.ScopedKey, 0, 2)
+	for _, key := range keys {
+		if !bytes.Equal(key.Key, otherKey) {
+			toDrop = append(toDrop, key)
+		}
+	}
+	require.Len(t, toDrop, 2)
+	require.Equal(t, toDrop[0].Address, toDrop[1].Address)
+
+	err = seg.DropKeys(toDrop)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), seg.KeyCount())
+
+	keys, err = seg.GetKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, otherKey, keys[0].Key)
+}
+
+func TestCompression(t *testing.T) {
+	t.Parallel()
+
+	for _, compression := range []types.CompressionType{types.SnappyCompression, types.ZstdCompression} {
+		t.Run(compression.String(), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			rand := random.NewTestRandom()
+			logger := test.GetLogger()
+			directory := t.TempDir()
+
+			index := rand.Uint32()
+			valueCount := rand.Int32Range(100, 200)
+			shardCount := rand.Uint32Range(1, 4)
+			salt := ([16]byte)(rand.Bytes(16))
+			checksums := rand.Bool()
+
+			segmentPath, err := NewSegmentPath(directory, "", "table")
+			require.NoError(t, err)
+			err = segmentPath.MakeDirectories(false)
+			require.NoError(t, err)
+			seg, err := CreateSegment(
+				logger,
+				util.NewErrorMonitor(ctx, logger, nil),
+				index,
+				[]*SegmentPath{segmentPath},
+				false,
+				shardCount,
+				salt,
+				checksums,
+				compression,
+				false)
+			require.NoError(t, err)
+			require.Equal(t, compression, seg.Compression())
+
+			// Highly repetitive values compress well.
+			expectedValues := make(map[string][]byte)
+			for i := 0; i < int(valueCount); i++ {
+				key := rand.PrintableVariableBytes(32, 100)
+				value := bytes.Repeat(rand.PrintableBytes(8), rand.Intn(100)+10)
+				expectedValues[string(key)] = value
+				_, _, err = seg.Write(&types.KVPair{Key: key, Value: value})
+				require.NoError(t, err)
+			}
+
+			_, err = seg.Seal(rand.Time())
+			require.NoError(t, err)
+
+			require.Greater(t, seg.UncompressedSize(), seg.Size())
+
+			keys, err := seg.GetKeys()
+			require.NoError(t, err)
+			require.Len(t, keys, int(valueCount))
+			for _, key := range keys {
+				require.Less(t, key.ValueSize, uint32(len(expectedValues[string(key.Key)])))
+				value, err := seg.Read(key.Key, key.Address)
+				require.NoError(t, err)
+				require.Equal(t, expectedValues[string(key.Key)], value)
+			}
+
+			corrupt, err := seg.Verify()
+			require.NoError(t, err)
+			require.Empty(t, corrupt)
+
+			// Reload the segment, compression information should be preserved.
+			seg2, err := LoadSegment(
+				logger,
+				util.NewErrorMonitor(ctx, logger, nil),
+				index,
+				[]*SegmentPath{segmentPath},
+				false,
+				time.Now(),
+				false)
+			require.NoError(t, err)
+			require.Equal(t, compression, seg2.Compression())
+			require.Equal(t, seg.Size(), seg2.Size())
+			require.Equal(t, seg.UncompressedSize(), seg2.UncompressedSize())
+
+			for _, key := range keys {
+				value, err := seg2.Read(key.Key, key.Address)
+				require.NoError(t, err)
+				require.Equal(t, expectedValues[string(key.Key)], value)
+			}
+		})
+	}
+}
+
+func TestAtomicBatchRecovery(t *testing.T) {
+	t.Parallel()
+
+	for _, truncateKeyFile := range []bool{true, false} {
+		name := "truncated value file"
+		if truncateKeyFile {
+			name = "truncated key file"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			rand := random.NewTestRandom()
+			logger := test.GetLogger()
+			directory := t.TempDir()
+
+			index := rand.Uint32()
+			shardCount := rand.Uint32Range(1, 4)
+			salt := ([16]byte)(rand.Bytes(16))
+
+			segmentPath, err := NewSegmentPath(directory, "", "table")
+			require.NoError(t, err)
+			err = segmentPath.MakeDirectories(false)
+			require.NoError(t, err)
+			seg, err := CreateSegment(
+				logger,
+				util.NewErrorMonitor(ctx, logger, nil),
+				index,
+				[]*SegmentPath{segmentPath},
+				false,
+				shardCount,
+				salt,
+				rand.Bool(),
+				types.NoCompression,
+				false)
+			require.NoError(t, err)
+
+			// Write some values that are not part of an atomic batch.
+			expectedValues := make(map[string][]byte)
+			regularKeyFileSize := 0
+			regularKeyCount := int(rand.Int32Range(1, 20))
+			for i := 0; i < regularKeyCount; i++ {
+				key := rand.PrintableVariableBytes(32, 64)
+				value := rand.PrintableVariableBytes(1, 100)
+				expectedValues[string(key)] = value
+				regularKeyFileSize += 4 + len(key) + 8 + 4
+				_, _, err = seg.Write(&types.KVPair{Key: key, Value: value})
+				require.NoError(t, err)
+			}
+
+			// Write an atomic batch.
+			batchKeys := make(map[string]struct{})
+			batch := make([]*types.KVPair, 0)
+			batchSize := int(rand.Int32Range(2, 20))
+			for i := 0; i < batchSize; i++ {
+				key := rand.PrintableVariableBytes(32, 64)
+				value := rand.PrintableVariableBytes(1, 100)
+				batchKeys[string(key)] = struct{}{}
+				batch = append(batch, &types.KVPair{Key: key, Value: value})
+			}
+			keyCount, _, err := seg.WriteAtomicBatch(batch)
+			require.NoError(t, err)
+			require.Equal(t, uint32(regularKeyCount+batchSize), keyCount)
+
+			_, err = seg.Seal(rand.Time())
+			require.NoError(t, err)
+
+			// The batch markers are counted in the segment's size, and are not returned as keys.
+			stat, err := os.Stat(seg.GetKeyFilePath())
+			require.NoError(t, err)
+			require.Equal(t, uint64(stat.Size()), seg.keys.Size())
+			keys, err := seg.GetKeys()
+			require.NoError(t, err)
+			require.Len(t, keys, regularKeyCount+batchSize)
+
+			if truncateKeyFile {
+				// Simulate a crash before the commit marker was written.
+				keyFileBytes, err := os.ReadFile(seg.GetKeyFilePath())
+				require.NoError(t, err)
+				newLength := rand.Intn(len(keyFileBytes)-regularKeyFileSize-1) + regularKeyFileSize + 1
+				err = os.WriteFile(seg.GetKeyFilePath(), keyFileBytes[:newLength], 0644)
+				require.NoError(t, err)
+			} else {
+				// Simulate a crash before one of the batch's values was written.
+				target := keys[regularKeyCount+rand.Intn(batchSize)]
+				valuePath := seg.GetValueFilePaths()[seg.GetShard(target.Key)]
+				valueFileBytes, err := os.ReadFile(valuePath)
+				require.NoError(t, err)
+				newLength := uint64(target.Address.Offset()) +
+					seg.shards[seg.GetShard(target.Key)].recordOverhead() + uint64(target.ValueSize) - 1
+				err = os.WriteFile(valuePath, valueFileBytes[:newLength], 0644)
+				require.NoError(t, err)
+			}
+
+			// Mark the segment as unsealed. The last byte of the metadata file is the sealed flag.
+			metadataBytes, err := os.ReadFile(seg.GetMetadataFilePath())
+			require.NoError(t, err)
+			metadataBytes[len(metadataBytes)-1] = 0
+			err = os.WriteFile(seg.GetMetadataFilePath(), metadataBytes, 0644)
+			require.NoError(t, err)
+
+			// When the segment is loaded, none of the keys in the batch should be present.
+			seg2, err := LoadSegment(
+				logger,
+				util.NewErrorMonitor(ctx, logger, nil),
+				index,
+				[]*SegmentPath{segmentPath},
+				false,
+				time.Now(),
+				false)
+			require.NoError(t, err)
+			require.Equal(t, uint32(regularKeyCount), seg2.KeyCount())
+
+			keys, err = seg2.GetKeys()
+			require.NoError(t, err)
+			require.Len(t, keys, regularKeyCount)
+			for _, key := range keys {
+				_, inBatch := batchKeys[string(key.Key)]
+				require.False(t, inBatch)
+				value, err := seg2.Read(key.Key, key.Address)
+				require.NoError(t, err)
+				require.Equal(t, expectedValues[string(key.Key)], value)
+			}
+		})
+	}
+}
+
+func TestTombstones(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	rand := random.NewTestRandom()
+	logger := test.GetLogger()
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	shardCount := rand.Uint32Range(1, 4)
+	salt := ([16]byte)(rand.Bytes(16))
+
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	seg, err := CreateSegment(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		shardCount,
+		salt,
+		rand.Bool(),
+		types.NoCompression,
+		false)
+	require.NoError(t, err)
+
+	// Write some values.
+	keyCount := int(rand.Int32Range(10, 20))
+	for i := 0; i < keyCount; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 100)
+		_, _, err = seg.Write(&types.KVPair{Key: key, Value: value})
+		require.NoError(t, err)
+	}
+
+	// Write some tombstones. Tombstones may reference values in other segments, and so the addresses are arbitrary.
+	expectedTombstones := make([]*types.ScopedKey, 0)
+	tombstoneCount := int(rand.Int32Range(1, 10))
+	for i := 0; i < tombstoneCount; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		address := types.Address(rand.Uint64())
+		expectedTombstones = append(expectedTombstones, &types.ScopedKey{Key: key, Address: address})
+		_, err = seg.WriteTombstone(key, address)
+		require.NoError(t, err)
+	}
+	require.Equal(t, uint32(tombstoneCount), seg.TombstoneCount())
+
+	_, err = seg.Seal(rand.Time())
+	require.NoError(t, err)
+
+	// Tombstones are counted in the segment's size, and are not returned as keys.
+	stat, err := os.Stat(seg.GetKeyFilePath())
+	require.NoError(t, err)
+	require.Equal(t, uint64(stat.Size()), seg.keys.Size())
+	keys, err := seg.GetKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, keyCount)
+	tombstones, err := seg.GetTombstones()
+	require.NoError(t, err)
+	require.Equal(t, expectedTombstones, tombstones)
+
+	// A segment is only dead once all of its keys have been deleted.
+	require.False(t, seg.IsDead())
+	for i := 0; i < keyCount; i++ {
+		seg.MarkKeyDeleted()
+	}
+	require.Equal(t, uint32(keyCount), seg.DeletedKeyCount())
+	require.True(t, seg.IsDead())
+
+	// Drop a key from the segment. Tombstones should survive the key file being rewritten.
+	err = seg.DropKeys(keys[:1])
+	require.NoError(t, err)
+
+	// Mark the segment as unsealed and reload it. Tombstones should survive crash recovery.
+	metadataBytes, err := os.ReadFile(seg.GetMetadataFilePath())
+	require.NoError(t, err)
+	metadataBytes[len(metadataBytes)-1] = 0
+	err = os.WriteFile(seg.GetMetadataFilePath(), metadataBytes, 0644)
+	require.NoError(t, err)
+
+	seg2, err := LoadSegment(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		time.Now(),
+		false)
+	require.NoError(t, err)
+	require.Equal(t, uint32(keyCount-1), seg2.KeyCount())
+	require.Equal(t, uint32(tombstoneCount), seg2.TombstoneCount())
+
+	// Deleted key counts are not persisted.
+	require.Equal(t, uint32(0), seg2.DeletedKeyCount())
+
+	tombstones, err = seg2.GetTombstones()
+	require.NoError(t, err)
+	require.Equal(t, expectedTombstones, tombstones)
+}
+
+func TestRelocatedBatchRecovery(t *testing.T) {
+	t.Parallel()
+
+	for _, crash := range []bool{true, false} {
+		name := "intact batch"
+		if crash {
+			name = "incomplete batch"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			rand := random.NewTestRandom()
+			logger := test.GetLogger()
+			directory := t.TempDir()
+
+			index := rand.Uint32()
+			shardCount := rand.Uint32Range(1, 4)
+			salt := ([16]byte)(rand.Bytes(16))
+
+			segmentPath, err := NewSegmentPath(directory, "", "table")
+			require.NoError(t, err)
+			err = segmentPath.MakeDirectories(false)
+			require.NoError(t, err)
+			seg, err := CreateSegment(
+				logger,
+				util.NewErrorMonitor(ctx, logger, nil),
+				index,
+				[]*SegmentPath{segmentPath},
+				false,
+				shardCount,
+				salt,
+				rand.Bool(),
+				types.NoCompression,
+				false)
+			require.NoError(t, err)
+
+			// Write some values that are not part of a batch.
+			regularKeyCount := int(rand.Int32Range(1, 20))
+			for i := 0; i < regularKeyCount; i++ {
+				key := rand.PrintableVariableBytes(32, 64)
+				value := rand.PrintableVariableBytes(1, 100)
+				_, _, err = seg.Write(&types.KVPair{Key: key, Value: value})
+				require.NoError(t, err)
+			}
+
+			// Relocate a batch of values from other segments.
+			batch := make([]*types.KVPair, 0)
+			expectedTombstones := make([]*types.ScopedKey, 0)
+			previousAddresses := make([]types.Address, 0)
+			lifetimes := make([]*types.Lifetime, 0)
+			batchSize := int(rand.Int32Range(2, 20))
+			for i := 0; i < batchSize; i++ {
+				key := rand.PrintableVariableBytes(32, 64)
+				value := rand.PrintableVariableBytes(1, 100)
+				address := types.Address(rand.Uint64())
+				batch = append(batch, &types.KVPair{Key: key, Value: value})
+				previousAddresses = append(previousAddresses, address)
+				lifetimes = append(lifetimes, &types.Lifetime{WriteTime: rand.Time()})
+				expectedTombstones = append(expectedTombstones, &types.ScopedKey{Key: key, Address: address})
+			}
+			keyCount, _, err := seg.WriteRelocatedBatch(batch, previousAddresses, lifetimes)
+			require.NoError(t, err)
+			require.Equal(t, uint32(regularKeyCount+batchSize), keyCount)
+			require.Equal(t, uint32(batchSize), seg.TombstoneCount())
+
+			_, err = seg.Seal(rand.Time())
+			require.NoError(t, err)
+
+			keys, err := seg.GetKeys()
+			require.NoError(t, err)
+			require.Len(t, keys, regularKeyCount+batchSize)
+			tombstones, err := seg.GetTombstones()
+			require.NoError(t, err)
+			require.Equal(t, expectedTombstones, tombstones)
+
+			if crash {
+				// Simulate a crash before one of the relocated values was written.
+				target := keys[regularKeyCount+rand.Intn(batchSize)]
+				valuePath := seg.GetValueFilePaths()[seg.GetShard(target.Key)]
+				valueFileBytes, err := os.ReadFile(valuePath)
+				require.NoError(t, err)
+				newLength := uint64(target.Address.Offset()) +
+					seg.shards[seg.GetShard(target.Key)].recordOverhead() + uint64(target.ValueSize) - 1
+				err = os.WriteFile(valuePath, valueFileBytes[:newLength], 0644)
+				require.NoError(t, err)
+			}
+
+			// Mark the segment as unsealed. The last byte of the metadata file is the sealed flag.
+			metadataBytes, err := os.ReadFile(seg.GetMetadataFilePath())
+			require.NoError(t, err)
+			metadataBytes[len(metadataBytes)-1] = 0
+			err = os.WriteFile(seg.GetMetadataFilePath(), metadataBytes, 0644)
+			require.NoError(t, err)
+
+			seg2, err := LoadSegment(
+				logger,
+				util.NewErrorMonitor(ctx, logger, nil),
+				index,
+				[]*SegmentPath{segmentPath},
+				false,
+				time.Now(),
+				false)
+			require.NoError(t, err)
+
+			keys, err = seg2.GetKeys()
+			require.NoError(t, err)
+			tombstones, err = seg2.GetTombstones()
+			require.NoError(t, err)
+
+			if crash {
+				// Neither the relocated values nor their tombstones should survive.
+				require.Len(t, keys, regularKeyCount)
+				require.Equal(t, uint32(0), seg2.TombstoneCount())
+				require.Empty(t, tombstones)
+			} else {
+				require.Len(t, keys, regularKeyCount+batchSize)
+				require.Equal(t, uint32(batchSize), seg2.TombstoneCount())
+				require.Equal(t, expectedTombstones, tombstones)
+			}
+		})
+	}
+}
+
+func TestLifetimes(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	rand := random.NewTestRandom()
+	logger := test.GetLogger()
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	salt := ([16]byte)(rand.Bytes(16))
+
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	seg, err := CreateSegment(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		rand.Uint32Range(1, 4),
+		salt,
+		rand.Bool(),
+		types.NoCompression,
+		false)
+	require.NoError(t, err)
+
+	// Write a mix of values with and without lifetimes.
+	expectedLifetimes := make(map[string]types.Lifetime)
+	keyCount := int(rand.Int32Range(1, 50))
+	for i := 0; i < keyCount; i++ {
+		kv := &types.KVPair{
+			Key:   rand.PrintableVariableBytes(32, 64),
+			Value: rand.PrintableVariableBytes(1, 100),
+		}
+		if rand.Bool() {
+			_, _, err = seg.Write(kv)
+			require.NoError(t, err)
+			continue
+		}
+
+		lifetime := &types.Lifetime{TTL: time.Duration(rand.Int64Range(1, int64(time.Hour)))}
+		if rand.Bool() {
+			lifetime.WriteTime = time.Unix(0, rand.Int64Range(1, 1<<62))
+		}
+		_, _, err = seg.WriteWithLifetime(kv, lifetime)
+		require.NoError(t, err)
+		expectedLifetimes[string(kv.Key)] = *lifetime
+	}
+
+	checkLifetimes := func(seg *Segment) {
+		require.Equal(t, uint32(len(expectedLifetimes)), seg.LifetimeCount())
+		lifetimes, err := seg.GetLifetimes()
+		require.NoError(t, err)
+		require.Len(t, lifetimes, len(expectedLifetimes))
+		for _, key := range lifetimes {
+			expected, ok := expectedLifetimes[string(key.Key)]
+			require.True(t, ok)
+			require.True(t, expected.WriteTime.Equal(key.Lifetime.WriteTime))
+			require.Equal(t, expected.TTL, key.Lifetime.TTL)
+		}
+	}
+
+	// Lifetimes are available before the segment is sealed.
+	checkLifetimes(seg)
+
+	flushWaitFunction, err := seg.Flush()
+	require.NoError(t, err)
+	_, err = flushWaitFunction()
+	require.NoError(t, err)
+
+	// Load the segment without sealing it first, as if the process crashed. The lifetimes must not be lost.
+	seg2, err := LoadSegment(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		time.Now(),
+		false)
+	require.NoError(t, err)
+	require.True(t, seg2.IsSealed())
+	require.Equal(t, uint32(keyCount), seg2.KeyCount())
+	checkLifetimes(seg2)
+
+	// Keys read from the key file carry their lifetimes.
+	keys, err := seg2.GetKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, keyCount)
+	for _, key := range keys {
+		expected, ok := expectedLifetimes[string(key.Key)]
+		if !ok {
+			require.Nil(t, key.Lifetime)
+			continue
+		}
+		require.NotNil(t, key.Lifetime)
+		require.Equal(t, expected.TTL, key.Lifetime.TTL)
+	}
+
+	// The lifetime count survives a reload of the sealed segment.
+	seg3, err := LoadSegment(
+		logger,
+		util.NewErrorMonitor(ctx, logger, nil),
+		index,
+		[]*SegmentPath{segmentPath},
+		false,
+		time.Now(),
+		false)
+	require.NoError(t, err)
+	checkLifetimes(seg3)
+}
@@ -14,7 +14,30 @@ const (
 	// ValueSizeSegmentVersion adds the length of values to the key file. Previously, only the key and the address were
 	// stored in the key file. It also adds the key count to the segment metadata file.
 	ValueSizeSegmentVersion SegmentVersion = 2
+
+	// ChecksumSegmentVersion adds a flag to the segment metadata file that indicates whether each value in the value
+	// files is stored alongside a checksum. If the flag is set, each value is prefixed by its length and a checksum.
+	ChecksumSegmentVersion SegmentVersion = 3
+
+	// CompressionSegmentVersion adds the compression type and the uncompressed size of the value files to the
+	// segment metadata file. Segments written prior to this version are never compressed.
+	CompressionSegmentVersion SegmentVersion = 4
+
+	// AtomicBatchSegmentVersion adds atomic batch markers to the key file. Keys written between a batch begin marker
+	// and a batch commit marker are discarded during crash recovery unless the entire batch is present on disk.
+	// The metadata file format is unchanged from CompressionSegmentVersion.
+	AtomicBatchSegmentVersion SegmentVersion = 5
+
+	// TombstoneSegmentVersion adds tombstones to the key file, and adds the number of tombstones in the key file to
+	// the segment metadata file. A tombstone records the deletion of a key that was previously written to the same
+	// segment or to an earlier segment.
+	TombstoneSegmentVersion SegmentVersion = 6
+
+	// ValueLifetimeSegmentVersion adds lifetimes to the key file, and adds the number of keys with a lifetime to the
+	// segment metadata file. A lifetime records the TTL override of a value, and for values moved to this segment by
+	// the compactor, the time at which the value was originally written.
+	ValueLifetimeSegmentVersion SegmentVersion = 7
 )
 
 // LatestSegmentVersion always refers to the latest version of the segment serialization format.
-const LatestSegmentVersion = ValueSizeSegmentVersion
+const LatestSegmentVersion = ValueLifetimeSegmentVersion
@@ -46,6 +46,10 @@ type valueFile struct {
 	// The current size of the file, only including flushed data. Protects against reads of partially written values.
 	flushedSize atomic.Uint64
 
+	// If true, each value is stored alongside a checksum of its key and value. The checksum is written immediately
+	// after the length prefix and is verified each time the value is read.
+	checksums bool
+
 	// Whether fsync mode is enabled. If fsync mode is enabled, then each flush operation will invoke the OS fsync
 	// operation before returning. An fsync operation is required to ensure that data is not sitting in OS level
 	// in-memory buffers (otherwise, an OS crash may lead to data loss). This option is provided for testing,
@@ -60,6 +64,7 @@ func createValueFile(
 	index uint32,
 	shard uint32,
 	segmentPath *SegmentPath,
+	checksums bool,
 	fsync bool,
 ) (*valueFile, error) {
 
@@ -68,6 +73,7 @@ func createValueFile(
 		index:       index,
 		shard:       shard,
 		segmentPath: segmentPath,
+		checksums:   checksums,
 		fsync:       fsync,
 	}
 
@@ -99,7 +105,8 @@ func loadValueFile(
 	logger logging.Logger,
 	index uint32,
 	shard uint32,
-	segmentPaths []*SegmentPath) (*valueFile, error) {
+	segmentPaths []*SegmentPath,
+	checksums bool) (*valueFile, error) {
 
 	valuesFileName := fmt.Sprintf("%d-%d%s", index, shard, ValuesFileExtension)
 	valuesPath, err := lookForFile(segmentPaths, valuesFileName)
@@ -115,6 +122,7 @@ func loadValueFile(
 		index:       index,
 		shard:       shard,
 		segmentPath: valuesPath,
+		checksums:   checksums,
 		fsync:       false,
 	}
 
@@ -189,8 +197,17 @@ func (v *valueFile) path() string {
 	return path.Join(v.segmentPath.SegmentDirectory(), v.name())
 }
 
-// read reads a value from the value file.
-func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
+// recordOverhead returns the number of bytes written to the value file for each value in addition to the value itself.
+func (v *valueFile) recordOverhead() uint64 {
+	if v.checksums {
+		return 4 + ChecksumSize
+	}
+	return 4
+}
+
+// read reads a value from the value file. If checksums are enabled, the checksum of the key and value is verified,
+// and an error wrapping ErrChecksumMismatch is returned if the data on disk is corrupt.
+func (v *valueFile) read(key []byte, firstByteIndex uint32) ([]byte, error) {
 	flushedSize := v.flushedSize.Load()
 	if uint64(firstByteIndex) >= flushedSize {
 		return nil, fmt.Errorf("index %d is out of bounds (current flushed size is %d)",
@@ -218,6 +235,19 @@ func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read value length from value file: %v", err)
 	}
 
+	var expectedChecksum uint32
+	if v.checksums {
+		err = binary.Read(reader, binary.BigEndian, &expectedChecksum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read checksum from value file: %v", err)
+		}
+	}
+
+	if uint64(firstByteIndex)+v.recordOverhead()+uint64(length) > flushedSize {
+		return nil, fmt.Errorf("value at index %d with length %d extends beyond the end of the file (size %d)",
+			firstByteIndex, length, flushedSize)
+	}
+
 	// Read the value itself.
 	value := make([]byte, length)
 	bytesRead, err := io.ReadFull(reader, value)
@@ -229,11 +259,20 @@ func (v *valueFile) read(firstByteIndex uint32) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read value from value file: read %d bytes, expected %d", bytesRead, length)
 	}
 
+	if v.checksums {
+		actualChecksum := computeChecksum(key, value)
+		if actualChecksum != expectedChecksum {
+			return nil, fmt.Errorf("%w: value at index %d in %s, expected %08x, got %08x",
+				ErrChecksumMismatch, firstByteIndex, v.path(), expectedChecksum, actualChecksum)
+		}
+	}
+
 	return value, nil
 }
 
-// write writes a value to the value file, returning the index of the first byte written.
-func (v *valueFile) write(value []byte) (uint32, error) {
+// write writes a value to the value file, returning the index of the first byte written. The key is only used
+// to compute the checksum, and is not itself written to the value file.
+func (v *valueFile) write(key []byte, value []byte) (uint32, error) {
 	if v.writer == nil {
 		return 0, fmt.Errorf("value file is sealed")
 	}
@@ -252,13 +291,21 @@ func (v *valueFile) write(value []byte) (uint32, error) {
 		return 0, fmt.Errorf("failed to write value length to value file: %v", err)
 	}
 
+	// If enabled, write the checksum.
+	if v.checksums {
+		err = binary.Write(v.writer, binary.BigEndian, computeChecksum(key, value))
+		if err != nil {
+			return 0, fmt.Errorf("failed to write checksum to value file: %v", err)
+		}
+	}
+
 	// Then, write the value itself.
 	_, err = v.writer.Write(value)
 	if err != nil {
 		return 0, fmt.Errorf("failed to write value to value file: %v", err)
 	}
 
-	v.size += uint64(len(value) + 4)
+	v.size += uint64(len(value)) + v.recordOverhead()
 
 	return firstByteIndex, nil
 }
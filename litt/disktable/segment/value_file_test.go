@@ -32,11 +32,11 @@ func TestWriteThenReadValues(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createValueFile(logger, index, shard, segmentPath, false)
+	file, err := createValueFile(logger, index, shard, segmentPath, false, false)
 	require.NoError(t, err)
 
 	for _, value := range values {
-		address, err := file.write(value)
+		address, err := file.write(nil, value)
 		require.NoError(t, err)
 		addressMap[address] = value
 
@@ -51,7 +51,7 @@ func TestWriteThenReadValues(t *testing.T) {
 			err = file.flush()
 			require.NoError(t, err)
 			for key, val := range addressMap {
-				readValue, err := file.read(key)
+				readValue, err := file.read(nil, key)
 				require.NoError(t, err)
 				require.Equal(t, val, readValue)
 			}
@@ -62,7 +62,7 @@ func TestWriteThenReadValues(t *testing.T) {
 	err = file.seal()
 	require.NoError(t, err)
 	for key, val := range addressMap {
-		readValue, err := file.read(key)
+		readValue, err := file.read(nil, key)
 		require.NoError(t, err)
 		require.Equal(t, val, readValue)
 	}
@@ -74,11 +74,11 @@ func TestWriteThenReadValues(t *testing.T) {
 	require.Equal(t, actualFileSize, reportedFileSize)
 
 	// Create a new in-memory instance from the on-disk file and verify that it behaves the same.
-	file2, err := loadValueFile(logger, index, shard, []*SegmentPath{segmentPath})
+	file2, err := loadValueFile(logger, index, shard, []*SegmentPath{segmentPath}, false)
 	require.NoError(t, err)
 	require.Equal(t, file.size, file2.size)
 	for key, val := range addressMap {
-		readValue, err := file2.read(key)
+		readValue, err := file2.read(nil, key)
 		require.NoError(t, err)
 		require.Equal(t, val, readValue)
 	}
@@ -116,12 +116,12 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	require.NoError(t, err)
 	err = segmentPath.MakeDirectories(false)
 	require.NoError(t, err)
-	file, err := createValueFile(logger, index, shard, segmentPath, false)
+	file, err := createValueFile(logger, index, shard, segmentPath, false, false)
 	require.NoError(t, err)
 
 	var lastAddress uint32
 	for _, value := range values {
-		address, err := file.write(value)
+		address, err := file.write(nil, value)
 		require.NoError(t, err)
 		addressMap[address] = value
 		lastAddress = address
@@ -144,16 +144,16 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	err = os.WriteFile(filePath, bytes, 0644)
 	require.NoError(t, err)
 
-	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath})
+	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath}, false)
 	require.NoError(t, err)
 
 	// We should be able to read all values except for the last one.
 	for key, val := range addressMap {
 		if key == lastAddress {
-			_, err := file.read(key)
+			_, err := file.read(nil, key)
 			require.Error(t, err)
 		} else {
-			readValue, err := file.read(key)
+			readValue, err := file.read(nil, key)
 			require.NoError(t, err)
 			require.Equal(t, val, readValue)
 		}
@@ -166,16 +166,16 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	err = os.WriteFile(filePath, bytes, 0644)
 	require.NoError(t, err)
 
-	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath})
+	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath}, false)
 	require.NoError(t, err)
 
 	// We should be able to read all values except for the last one.
 	for key, val := range addressMap {
 		if key == lastAddress {
-			_, err := file.read(key)
+			_, err := file.read(nil, key)
 			require.Error(t, err)
 		} else {
-			readValue, err := file.read(key)
+			readValue, err := file.read(nil, key)
 			require.NoError(t, err)
 			require.Equal(t, val, readValue)
 		}
@@ -191,3 +191,75 @@ func TestReadingTruncatedValueFile(t *testing.T) {
 	_, err = os.Stat(filePath)
 	require.True(t, os.IsNotExist(err))
 }
+
+func TestValueFileChecksums(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	logger := test.GetLogger()
+	directory := t.TempDir()
+
+	index := rand.Uint32()
+	shard := rand.Uint32()
+	valueCount := rand.Int32Range(100, 200)
+	keys := make([][]byte, valueCount)
+	values := make([][]byte, valueCount)
+	expectedFileSize := uint64(0)
+	for i := 0; i < int(valueCount); i++ {
+		keys[i] = rand.VariableBytes(1, 100)
+		values[i] = rand.VariableBytes(1, 100)
+		expectedFileSize += uint64(len(values[i])) + 4 /* length uint32 */ + ChecksumSize
+	}
+
+	segmentPath, err := NewSegmentPath(directory, "", "table")
+	require.NoError(t, err)
+	err = segmentPath.MakeDirectories(false)
+	require.NoError(t, err)
+	file, err := createValueFile(logger, index, shard, segmentPath, true, false)
+	require.NoError(t, err)
+
+	addresses := make([]uint32, valueCount)
+	for i := 0; i < int(valueCount); i++ {
+		addresses[i], err = file.write(keys[i], values[i])
+		require.NoError(t, err)
+	}
+	err = file.seal()
+	require.NoError(t, err)
+	require.Equal(t, expectedFileSize, file.Size())
+
+	stat, err := os.Stat(file.path())
+	require.NoError(t, err)
+	require.Equal(t, expectedFileSize, uint64(stat.Size()))
+
+	file, err = loadValueFile(logger, index, shard, []*SegmentPath{segmentPath}, true)
+	require.NoError(t, err)
+
+	for i := 0; i < int(valueCount); i++ {
+		readValue, err := file.read(keys[i], addresses[i])
+		require.NoError(t, err)
+		require.Equal(t, values[i], readValue)
+	}
+
+	// Reading a value with the wrong key should fail the checksum.
+	_, err = file.read(append(keys[0], 'x'), addresses[0])
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	// Flip a bit in one of the values.
+	corruptedIndex := rand.Intn(int(valueCount))
+	fileBytes, err := os.ReadFile(file.path())
+	require.NoError(t, err)
+	corruptedByte := addresses[corruptedIndex] + 4 + ChecksumSize +
+		uint32(rand.Intn(len(values[corruptedIndex])))
+	fileBytes[corruptedByte] ^= 1
+	err = os.WriteFile(file.path(), fileBytes, 0644)
+	require.NoError(t, err)
+
+	for i := 0; i < int(valueCount); i++ {
+		readValue, err := file.read(keys[i], addresses[i])
+		if i == corruptedIndex {
+			require.ErrorIs(t, err, ErrChecksumMismatch)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, values[i], readValue)
+		}
+	}
+}
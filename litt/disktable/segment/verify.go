@@ -0,0 +1,125 @@
+package segment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/litt/types"
+)
+
+// CorruptValue describes a value in a segment that failed verification.
+type CorruptValue struct {
+	// The key and address of the corrupt value.
+	Key *types.ScopedKey
+
+	// The reason why verification failed.
+	Err error
+}
+
+// Verify reads every value in a sealed segment and checks its integrity. If the segment was written with checksums,
+// each value's checksum is verified. Regardless of whether checksums are enabled, each value must be readable,
+// (for segment versions that record value sizes) must have the size recorded in the key file, and (if the segment
+// is compressed) must decompress successfully.
+//
+// Returns a list of values that failed verification. An error is returned only if the segment could not be
+// scanned at all (e.g. if the key file is unreadable).
+func (s *Segment) Verify() ([]*CorruptValue, error) {
+	keys, err := s.GetKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keys for segment %d: %w", s.index, err)
+	}
+
+	corrupt := make([]*CorruptValue, 0)
+	for _, key := range keys {
+		storedValue, err := s.shards[s.GetShard(key.Key)].read(key.Key, key.Address.Offset())
+		if err != nil {
+			corrupt = append(corrupt, &CorruptValue{Key: key, Err: err})
+			continue
+		}
+
+		if s.metadata.segmentVersion >= ValueSizeSegmentVersion && uint32(len(storedValue)) != key.ValueSize {
+			corrupt = append(corrupt, &CorruptValue{
+				Key: key,
+				Err: fmt.Errorf("value size mismatch, key file records %d bytes, value file contains %d bytes",
+					key.ValueSize, len(storedValue)),
+			})
+			continue
+		}
+
+		_, err = decompress(s.metadata.compression, storedValue)
+		if err != nil {
+			corrupt = append(corrupt, &CorruptValue{Key: key, Err: err})
+		}
+	}
+
+	return corrupt, nil
+}
+
+// DropKeys removes the given keys from this segment's key file. The values remain in the value files, but will no
+// longer be visible once the keymap is rebuilt from the segment key files. This is used to quarantine values that
+// are known to be corrupt. A key is only removed if both its key bytes and its address match an entry in the key file.
+//
+// This method is only legal to call on a sealed segment that is not a snapshot, and must not be called while the
+// segment is in use by a running DB.
+func (s *Segment) DropKeys(toDrop []*types.ScopedKey) error {
+	if !s.metadata.sealed {
+		return fmt.Errorf("segment %d is not sealed, cannot drop keys", s.index)
+	}
+
+	isSnapshot, err := s.IsSnapshot()
+	if err != nil {
+		return fmt.Errorf("failed to check if segment %d is a snapshot: %w", s.index, err)
+	}
+	if isSnapshot {
+		return errors.New("cannot drop keys from a snapshot segment")
+	}
+
+	keys, err := s.GetKeys()
+	if err != nil {
+		return fmt.Errorf("failed to get keys for segment %d: %w", s.index, err)
+	}
+	tombstones, err := s.GetTombstones()
+	if err != nil {
+		return fmt.Errorf("failed to get tombstones for segment %d: %w", s.index, err)
+	}
+
+	// Addresses are only unique within a shard, so the keys to drop are identified by both key and address.
+	type droppedKey struct {
+		key     string
+		address types.Address
+	}
+	dropSet := make(map[droppedKey]struct{}, len(toDrop))
+	for _, drop := range toDrop {
+		dropSet[droppedKey{string(drop.Key), drop.Address}] = struct{}{}
+	}
+
+	remainingKeys := make([]*types.ScopedKey, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := dropSet[droppedKey{string(key.Key), key.Address}]; ok {
+			continue
+		}
+		remainingKeys = append(remainingKeys, key)
+	}
+
+	if len(remainingKeys) == len(keys) {
+		// Nothing to drop.
+		return nil
+	}
+
+	err = s.rewriteKeyFile(remainingKeys, tombstones)
+	if err != nil {
+		return fmt.Errorf("failed to rewrite key file for segment %d: %w", s.index, err)
+	}
+
+	lifetimeCount := countLifetimes(remainingKeys)
+	err = s.metadata.updateKeyCount(uint32(len(remainingKeys)), lifetimeCount)
+	if err != nil {
+		return fmt.Errorf("failed to update key count for segment %d: %w", s.index, err)
+	}
+	s.keyCount = uint32(len(remainingKeys))
+	s.lifetimeCount = lifetimeCount
+	s.lifetimes = nil
+	s.lifetimesLoaded = false
+
+	return nil
+}
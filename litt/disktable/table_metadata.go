@@ -8,13 +8,18 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
-const tableMetadataSerializationVersion = 0
+const tableMetadataSerializationVersion = 1
 const TableMetadataFileName = "table.metadata"
-const tableMetadataSize = 16
+const tableMetadataSize = 17
+
+// legacyV0TableMetadataSize is the size of the table metadata file at serialization version 0, prior to the
+// addition of the compression type.
+const legacyV0TableMetadataSize = 16
 
 // tableMetadata contains table data that is preserved across restarts.
 type tableMetadata struct {
@@ -28,6 +33,9 @@ type tableMetadata struct {
 	// the table's sharding factor, accessed/modified by concurrent goroutines
 	shardingFactor atomic.Uint32
 
+	// the compression type used for newly written values, accessed/modified by concurrent goroutines
+	compression atomic.Uint32
+
 	// If true, metadata writes will be atomic. Should be set to true in production, but can be set to false
 	// to speed up unit tests.
 	fsync bool
@@ -39,6 +47,7 @@ func newTableMetadata(
 	tableDirectory string,
 	ttl time.Duration,
 	shardingFactor uint32,
+	compression types.CompressionType,
 	fsync bool) (*tableMetadata, error) {
 
 	metadata := &tableMetadata{
@@ -48,6 +57,7 @@ func newTableMetadata(
 	}
 	metadata.ttl.Store(&ttl)
 	metadata.shardingFactor.Store(shardingFactor)
+	metadata.compression.Store(uint32(compression))
 
 	err := metadata.write()
 	if err != nil {
@@ -115,6 +125,21 @@ func (t *tableMetadata) SetShardingFactor(shardingFactor uint32) error {
 	return nil
 }
 
+// GetCompression returns the compression type used for newly written values.
+func (t *tableMetadata) GetCompression() types.CompressionType {
+	return types.CompressionType(t.compression.Load())
+}
+
+// SetCompression sets the compression type used for newly written values.
+func (t *tableMetadata) SetCompression(compression types.CompressionType) error {
+	t.compression.Store(uint32(compression))
+	err := t.write()
+	if err != nil {
+		return fmt.Errorf("failed to update table metadata: %v", err)
+	}
+	return nil
+}
+
 // Store atomically stores the table metadata to disk.
 func (t *tableMetadata) write() error {
 	err := util.AtomicWrite(metadataPath(t.tableDirectory), t.serialize(), t.fsync)
@@ -130,6 +155,7 @@ func (t *tableMetadata) serialize() []byte {
 	// 4 bytes for version
 	// 8 bytes for TTL
 	// 4 bytes for sharding factor
+	// 1 byte for compression type
 	data := make([]byte, tableMetadataSize)
 
 	// Write the version
@@ -142,6 +168,9 @@ func (t *tableMetadata) serialize() []byte {
 	// Write the sharding factor
 	binary.BigEndian.PutUint32(data[12:16], t.GetShardingFactor())
 
+	// Write the compression type
+	data[16] = byte(t.GetCompression())
+
 	return data
 }
 
@@ -150,21 +179,42 @@ func deserialize(data []byte) (*tableMetadata, error) {
 	// 4 bytes for version
 	// 8 bytes for TTL
 	// 4 bytes for sharding factor
-	if len(data) != tableMetadataSize {
-		return nil, fmt.Errorf("metadata file is not the correct size, expected 16 bytes, got %d", len(data))
+	// 1 byte for compression type (version 1+)
+	if len(data) < 4 {
+		return nil, fmt.Errorf("metadata file is not the correct size, expected at least 4 bytes, got %d",
+			len(data))
 	}
 
 	serializationVersion := binary.BigEndian.Uint32(data[0:4])
-	if serializationVersion != tableMetadataSerializationVersion {
+	expectedSize := tableMetadataSize
+	switch serializationVersion {
+	case 0:
+		expectedSize = legacyV0TableMetadataSize
+	case tableMetadataSerializationVersion:
+	default:
 		return nil, fmt.Errorf("unsupported serialization version: %d", serializationVersion)
 	}
 
+	if len(data) != expectedSize {
+		return nil, fmt.Errorf("metadata file is not the correct size, expected %d bytes, got %d",
+			expectedSize, len(data))
+	}
+
 	ttl := time.Duration(binary.BigEndian.Uint64(data[4:12]))
 	shardingFactor := binary.BigEndian.Uint32(data[12:16])
 
+	compression := types.NoCompression
+	if serializationVersion >= 1 {
+		compression = types.CompressionType(data[16])
+		if !compression.IsValid() {
+			return nil, fmt.Errorf("unsupported compression type: %d", data[16])
+		}
+	}
+
 	metadata := &tableMetadata{}
 	metadata.ttl.Store(&ttl)
 	metadata.shardingFactor.Store(shardingFactor)
+	metadata.compression.Store(uint32(compression))
 
 	return metadata, nil
 }
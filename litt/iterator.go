@@ -0,0 +1,28 @@
+package litt
+
+// Iterator walks over the key-value pairs in a table in lexicographic key order. The set of keys visited by an
+// iterator is fixed at the moment the iterator is created. Values written after that point are not visited.
+//
+// An iterator holds resources that prevent the table from reclaiming disk space (i.e. data visited by an iterator
+// will not be garbage collected until the iterator is closed), so iterators should be closed promptly.
+//
+// Iterators are not thread safe. Each iterator should only be used by a single goroutine at a time.
+type Iterator interface {
+	// Next advances the iterator to the next key. Returns false when there are no more keys, or if an error was
+	// encountered (in which case Error() will return a non-nil value). Next must be called before the first call
+	// to Key() or Value().
+	Next() bool
+
+	// Key returns the key at the current position of the iterator. It is not safe to modify the returned slice.
+	Key() []byte
+
+	// Value returns the value associated with the key at the current position of the iterator. Values are read
+	// lazily, so iterating over keys without calling Value() is cheap. It is not safe to modify the returned slice.
+	Value() ([]byte, error)
+
+	// Error returns the first error encountered by the iterator, if any.
+	Error() error
+
+	// Close releases all resources held by the iterator. It is safe to call Close more than once.
+	Close() error
+}
@@ -26,8 +26,26 @@ var keymapBuilders = map[keymap.KeymapType]keymap.BuildKeymap{
 	keymap.MemKeymapType:           keymap.NewMemKeymap,
 	keymap.LevelDBKeymapType:       keymap.NewLevelDBKeymap,
 	keymap.UnsafeLevelDBKeymapType: keymap.NewUnsafeLevelDBKeymap,
+	keymap.PebbleKeymapType:        keymap.NewPebbleKeymap,
+	keymap.UnsafePebbleKeymapType:  keymap.NewUnsafePebbleKeymap,
 }
 
+// persistentKeymapTypes contains all keymap types that store their data on disk. When switching between two of these
+// types, the contents of the old keymap are copied into the new keymap instead of rebuilding the new keymap from
+// the segment files.
+var persistentKeymapTypes = map[keymap.KeymapType]struct{}{
+	keymap.LevelDBKeymapType:       {},
+	keymap.UnsafeLevelDBKeymapType: {},
+	keymap.PebbleKeymapType:        {},
+	keymap.UnsafePebbleKeymapType:  {},
+}
+
+// The name of the directory, inside the keymap directory, where a new keymap is built during a keymap migration.
+const keymapMigrationDirectoryName = "migration"
+
+// The number of keys copied at a time during a keymap migration.
+const keymapMigrationBatchSize = 10_000
+
 // cacheWeight is a function that calculates the weight of a cache entry.
 func cacheWeight(key string, value []byte) uint64 {
 	return uint64(len(key) + len(value))
@@ -140,8 +158,27 @@ func buildKeymap(
 		}
 
 	} else {
-		// A previous keymap exists. Check if the keymap type has changed.
-		if config.KeymapType != keymapTypeFile.Type() {
+		// A previous keymap exists. If a keymap migration was interrupted before the new keymap was complete,
+		// the old keymap is still intact and the partially built new keymap can be discarded.
+		err = os.RemoveAll(path.Join(keymapDirectory, keymapMigrationDirectoryName))
+		if err != nil {
+			return nil, "", nil, false,
+				fmt.Errorf("error deleting incomplete keymap migration: %w", err)
+		}
+
+		// Check if the keymap type has changed.
+		_, oldTypeIsPersistent := persistentKeymapTypes[keymapTypeFile.Type()]
+		_, newTypeIsPersistent := persistentKeymapTypes[config.KeymapType]
+
+		if config.KeymapType != keymapTypeFile.Type() && oldTypeIsPersistent && newTypeIsPersistent {
+			// Both keymap types store data on disk. Copying the old keymap is much faster than rebuilding the new
+			// keymap from the segment files, which requires reading every key file in the table.
+			err = migrateKeymap(logger, keymapDirectory, keymapTypeFile, config.KeymapType, config.Fsync)
+			if err != nil {
+				return nil, "", nil, false,
+					fmt.Errorf("error migrating keymap: %w", err)
+			}
+		} else if config.KeymapType != keymapTypeFile.Type() {
 			// The previously used keymap type is different from the one in the configuration.
 
 			keymapTypeFile = nil
@@ -193,6 +230,70 @@ func buildKeymap(
 	return kmap, keymapDirectory, keymapTypeFile, requiresReload || newKeymap, nil
 }
 
+// migrateKeymap copies the contents of an existing keymap into a new keymap of a different type. The new keymap is
+// built in a separate directory, and only replaces the old keymap once it is complete. If the process crashes
+// partway through a migration, then either the old keymap is left intact or the keymap is rebuilt from the segment
+// files on the next startup.
+func migrateKeymap(
+	logger logging.Logger,
+	keymapDirectory string,
+	keymapTypeFile *keymap.KeymapTypeFile,
+	newType keymap.KeymapType,
+	fsync bool) error {
+
+	oldType := keymapTypeFile.Type()
+	logger.Infof("migrating keymap at %s from %s to %s", keymapDirectory, oldType, newType)
+
+	dataDirectory := path.Join(keymapDirectory, keymap.KeymapDataDirectoryName)
+	migrationDirectory := path.Join(keymapDirectory, keymapMigrationDirectoryName)
+
+	source, _, err := keymapBuilders[oldType](logger, dataDirectory, false)
+	if err != nil {
+		return fmt.Errorf("error opening %s keymap: %w", oldType, err)
+	}
+	destination, _, err := keymapBuilders[newType](logger, migrationDirectory, false)
+	if err != nil {
+		_ = source.Stop()
+		return fmt.Errorf("error creating %s keymap: %w", newType, err)
+	}
+
+	count, err := keymap.Copy(source, destination, keymapMigrationBatchSize)
+	stopSourceErr := source.Stop()
+	stopDestinationErr := destination.Stop()
+	if err != nil {
+		return fmt.Errorf("error copying keymap: %w", err)
+	}
+	if stopSourceErr != nil {
+		return fmt.Errorf("error stopping %s keymap: %w", oldType, stopSourceErr)
+	}
+	if stopDestinationErr != nil {
+		return fmt.Errorf("error stopping %s keymap: %w", newType, stopDestinationErr)
+	}
+
+	// Once the initialized flag is removed, a crash will cause the keymap to be rebuilt from the segment files.
+	// This makes it safe to swap the data directories and update the keymap type file.
+	err = os.Remove(path.Join(keymapDirectory, keymap.KeymapInitializedFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting keymap initialized file: %w", err)
+	}
+	err = os.RemoveAll(dataDirectory)
+	if err != nil {
+		return fmt.Errorf("error deleting %s keymap: %w", oldType, err)
+	}
+	err = util.AtomicRename(migrationDirectory, dataDirectory, fsync)
+	if err != nil {
+		return fmt.Errorf("error moving %s keymap into place: %w", newType, err)
+	}
+	err = keymapTypeFile.Update(newType, fsync)
+	if err != nil {
+		return fmt.Errorf("error updating keymap type file: %w", err)
+	}
+
+	logger.Infof("migrated %d keys from %s to %s", count, oldType, newType)
+
+	return nil
+}
+
 // buildTable creates a new table based on the configuration.
 func buildTable(
 	config *litt.Config,
@@ -0,0 +1,93 @@
+package littbuilder
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/metrics"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// NewFollowerDB opens a read-only DB that follows the snapshot directory of another LittDB instance (the "primary").
+// The config's Paths must contain the primary's SnapshotDirectory. The follower picks up data as the primary seals
+// segments into the snapshot, checking for new segments every config.FollowerPollPeriod.
+//
+// A follower does not acquire the lock files used by the primary (or by the litt CLI), and never modifies files on
+// disk. This makes it safe to run any number of followers alongside a primary. All methods that would modify the DB
+// return an error, and GetTable returns an error if the table does not exist in the snapshot.
+//
+// Data in the snapshot is only deleted when an external process (e.g. "litt prune" or "litt push") prunes it, so a
+// follower may observe data that the primary has already garbage collected.
+func NewFollowerDB(config *litt.Config) (litt.DB, error) {
+	if config.Logger == nil {
+		var err error
+		config.Logger, err = buildLogger(config)
+		if err != nil {
+			return nil, fmt.Errorf("error building logger: %w", err)
+		}
+	}
+
+	err := config.SanityCheck()
+	if err != nil {
+		return nil, fmt.Errorf("error checking config: %w", err)
+	}
+
+	err = config.SanitizePaths()
+	if err != nil {
+		return nil, fmt.Errorf("error expanding tildes in config: %w", err)
+	}
+
+	if config.SnapshotDirectory != "" {
+		return nil, fmt.Errorf("a follower cannot publish snapshots, SnapshotDirectory must be empty")
+	}
+	if config.FollowerPollPeriod <= 0 {
+		return nil, fmt.Errorf("follower poll period must be greater than 0")
+	}
+
+	for _, rootPath := range config.Paths {
+		exists, err := util.Exists(rootPath)
+		if err != nil {
+			return nil, fmt.Errorf("error checking if path %s exists: %w", rootPath, err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("snapshot path %s does not exist", rootPath)
+		}
+	}
+
+	var dbMetrics *metrics.LittDBMetrics
+	var metricsServer *http.Server
+	if config.MetricsEnabled {
+		dbMetrics, metricsServer = buildMetrics(config, config.Logger)
+	}
+
+	config.Logger.Infof("Opening LittDB in read-only follower mode, following snapshot at %v", config.Paths)
+
+	database := &db{
+		ctx:    config.CTX,
+		logger: config.Logger,
+		clock:  config.Clock,
+		tableBuilder: func(
+			ctx context.Context,
+			logger logging.Logger,
+			name string,
+			metrics *metrics.LittDBMetrics) (litt.ManagedTable, error) {
+
+			return disktable.NewFollowerTable(config, name)
+		},
+		tables:        make(map[string]litt.ManagedTable),
+		metrics:       dbMetrics,
+		metricsServer: metricsServer,
+		// A follower does not hold any locks.
+		releaseLocks: func() {},
+	}
+
+	if config.MetricsEnabled {
+		go database.gatherMetrics(config.MetricsUpdateInterval)
+	}
+
+	return database, nil
+}
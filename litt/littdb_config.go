@@ -9,6 +9,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/litt/disktable/keymap"
+	"github.com/Layr-Labs/eigenda/litt/types"
 	"github.com/Layr-Labs/eigenda/litt/util"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/docker/go-units"
@@ -38,8 +39,10 @@ type Config struct {
 	// The logger configuration for the database. Ignored if Logger is not nil.
 	LoggerConfig *common.LoggerConfig
 
-	// The type of the keymap. Choices are keymap.MemKeymapType and keymap.LevelDBKeymapType.
-	// Default is keymap.LevelDBKeymapType.
+	// The type of the keymap. Choices are keymap.MemKeymapType, keymap.LevelDBKeymapType, and
+	// keymap.PebbleKeymapType. Default is keymap.LevelDBKeymapType. If the keymap type is changed between two
+	// keymap types that store data on disk (e.g. from LevelDB to Pebble), the existing keymap is migrated when the
+	// table is next loaded. Otherwise, the keymap is rebuilt from the data on disk.
 	KeymapType keymap.KeymapType
 
 	// The default TTL for newly created tables (either ones with data on disk or new tables).
@@ -72,6 +75,30 @@ type Config struct {
 	// The size of the keymap deletion batch for garbage collection. The default is 10,000.
 	GCBatchSize uint64
 
+	// The period between compaction runs. The default is 0, which disables compaction.
+	//
+	// Garbage collection can only reclaim a segment once all older segments have been reclaimed, and once every value
+	// in the segment has either expired or been deleted. If values are deleted from a table, then old segments may end
+	// up containing only a small amount of live data that pins the entire segment on disk. Compaction copies the
+	// remaining live values out of such segments and into the current mutable segment, after which the old segments
+	// can be garbage collected. Relocated values keep the time at which they were originally written, so compaction
+	// never extends the lifetime of a value.
+	//
+	// Regardless of this setting, values written with a TTL override are moved out of segments that have outlived the
+	// table's TTL, so that they do not prevent those segments from being garbage collected. If compaction is disabled,
+	// this is done once every GCPeriod.
+	CompactionPeriod time.Duration
+
+	// A segment is eligible for compaction if the fraction of its values that have not been deleted is less than
+	// or equal to this threshold. Must be greater than 0 and less than 1. The default is 0.5.
+	CompactionThreshold float64
+
+	// The I/O budget for compaction, in bytes per second. Compaction will not copy data faster than this rate, so
+	// that it does not compete with foreground reads and writes for disk bandwidth. The default is 16 MiB/s. Also
+	// limits the rate at which values with a TTL override are moved out of expired segments. If compaction is
+	// disabled, then 0 means that rate is unlimited.
+	CompactionBytesPerSecond uint64
+
 	// The sharding factor for the database. If the sharding factor is greater than 1, then values will be spread
 	// out across multiple files. (Note that individual values will always be written to a single file, but two
 	// different values may be written to different files.) These shard files are spead evenly across the paths
@@ -120,6 +147,19 @@ type Config struct {
 	// than keymap.MemKeymapType, performing this check may be very expensive. By default, this is false.
 	DoubleWriteProtection bool
 
+	// If enabled, each value written to newly created segments is stored alongside a checksum of the key and value.
+	// Checksums are verified each time a value is read from disk, and can be scrubbed offline using the
+	// "litt verify" command. Enabling checksums adds 4 bytes of overhead per value. Segments written with and without
+	// checksums can coexist in the same table, so this setting can be changed between restarts. By default, this is
+	// false.
+	Checksums bool
+
+	// The default compression type for newly created tables. Compression is applied transparently to values as they
+	// are written to disk, and can be changed for an individual table by calling Table.SetCompression(). Segments
+	// written with different compression types can coexist in the same table. Choices are types.NoCompression,
+	// types.SnappyCompression, and types.ZstdCompression. By default, values are not compressed.
+	Compression types.CompressionType
+
 	// If enabled, collect DB metrics and export them to prometheus. By default, this is false.
 	MetricsEnabled bool
 
@@ -157,6 +197,10 @@ type Config struct {
 	// disk space leak.
 	SnapshotDirectory string
 
+	// The period between checks for newly sealed segments when the database is opened in read-only follower mode
+	// (see littbuilder.NewFollowerDB). Ignored otherwise. The default is 1 second.
+	FollowerPollPeriod time.Duration
+
 	// If true, then purge all lock files prior to starting the database. This is potentially dangerous, as it will
 	// permit multiple databases to be opened against the same data directories. If ever there are two LittDB
 	// instances running against the same data directories, data corruption is almost a certainty.
@@ -193,6 +237,9 @@ func DefaultConfigNoPaths() *Config {
 		Clock:                    time.Now,
 		GCPeriod:                 5 * time.Minute,
 		GCBatchSize:              10_000,
+		CompactionPeriod:         0,
+		CompactionThreshold:      0.5,
+		CompactionBytesPerSecond: 16 * units.MiB,
 		ShardingFactor:           8,
 		SaltShaker:               saltShaker,
 		KeymapType:               keymap.LevelDBKeymapType,
@@ -202,11 +249,14 @@ func DefaultConfigNoPaths() *Config {
 		TargetSegmentKeyFileSize: 2 * units.MiB,
 		Fsync:                    true,
 		DoubleWriteProtection:    false,
+		Checksums:                false,
+		Compression:              types.NoCompression,
 		MetricsEnabled:           false,
 		MetricsNamespace:         "litt",
 		MetricsPort:              9101,
 		MetricsUpdateInterval:    time.Second,
 		PurgeLocks:               false,
+		FollowerPollPeriod:       time.Second,
 	}
 }
 
@@ -249,9 +299,24 @@ func (c *Config) SanityCheck() error {
 	if c.GCBatchSize == 0 {
 		return fmt.Errorf("gc batch size must be at least 1")
 	}
+	if c.CompactionPeriod < 0 {
+		return fmt.Errorf("compaction period must not be negative")
+	}
+	if c.CompactionPeriod > 0 {
+		if c.CompactionThreshold <= 0 || c.CompactionThreshold >= 1 {
+			return fmt.Errorf("compaction threshold must be greater than 0 and less than 1, got %f",
+				c.CompactionThreshold)
+		}
+		if c.CompactionBytesPerSecond == 0 {
+			return fmt.Errorf("compaction bytes per second must be at least 1")
+		}
+	}
 	if c.ShardingFactor == 0 {
 		return fmt.Errorf("sharding factor must be at least 1")
 	}
+	if !c.Compression.IsValid() {
+		return fmt.Errorf("unsupported compression type: %d", c.Compression)
+	}
 	if c.ControlChannelSize == 0 {
 		return fmt.Errorf("control channel size must be at least 1")
 	}
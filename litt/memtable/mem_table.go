@@ -1,7 +1,10 @@
 package memtable
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -19,6 +22,8 @@ type expirationRecord struct {
 	creationTime time.Time
 	// A stringified version of the key.
 	key string
+	// A TTL override for the key, or 0 if the key uses the table's TTL.
+	ttl time.Duration
 }
 
 // memTable is a simple implementation of a Table that stores its data in memory.
@@ -93,10 +98,23 @@ func (m *memTable) KeyCount() uint64 {
 }
 
 func (m *memTable) Put(key []byte, value []byte) error {
+	return m.put(key, value, 0)
+}
+
+func (m *memTable) PutWithTTL(key []byte, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("TTL must be positive, got %v", ttl)
+	}
+	return m.put(key, value, ttl)
+}
+
+// put stores a value in the table. A ttl of 0 means that the value uses the table's TTL.
+func (m *memTable) put(key []byte, value []byte, ttl time.Duration) error {
 	stringKey := string(key)
 	expiration := &expirationRecord{
 		creationTime: m.clock(),
 		key:          stringKey,
+		ttl:          ttl,
 	}
 
 	m.lock.Lock()
@@ -122,6 +140,37 @@ func (m *memTable) PutBatch(batch []*types.KVPair) error {
 	return nil
 }
 
+func (m *memTable) PutBatchAtomic(batch []*types.KVPair) error {
+	now := m.clock()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// Validate the entire batch before making any changes, so that the batch is either written in full or not at all.
+	batchKeys := make(map[string]struct{}, len(batch))
+	for _, kv := range batch {
+		stringKey := string(kv.Key)
+		if _, ok := m.data[stringKey]; ok {
+			return fmt.Errorf("key %x already exists", kv.Key)
+		}
+		if _, ok := batchKeys[stringKey]; ok {
+			return fmt.Errorf("key %x appears more than once in batch", kv.Key)
+		}
+		batchKeys[stringKey] = struct{}{}
+	}
+
+	for _, kv := range batch {
+		stringKey := string(kv.Key)
+		m.data[stringKey] = kv.Value
+		m.expirationQueue.Push(&expirationRecord{
+			creationTime: now,
+			key:          stringKey,
+		})
+	}
+
+	return nil
+}
+
 func (m *memTable) Get(key []byte) (value []byte, exists bool, err error) {
 	value, exists, _, err = m.CacheAwareGet(key, false)
 	return value, exists, err
@@ -146,6 +195,41 @@ func (m *memTable) Exists(key []byte) (exists bool, err error) {
 	return exists, nil
 }
 
+func (m *memTable) Delete(key []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// The expiration record for the key is left in the queue. Removing a key that is already gone is harmless.
+	delete(m.data, string(key))
+	return nil
+}
+
+func (m *memTable) Iterator() (litt.Iterator, error) {
+	return m.PrefixIterator(nil)
+}
+
+func (m *memTable) PrefixIterator(prefix []byte) (litt.Iterator, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	stringPrefix := string(prefix)
+	entries := make([]*types.KVPair, 0)
+	for key, value := range m.data {
+		if strings.HasPrefix(key, stringPrefix) {
+			entries = append(entries, &types.KVPair{Key: []byte(key), Value: value})
+		}
+	}
+
+	sort.Slice(entries, func(a, b int) bool {
+		return bytes.Compare(entries[a].Key, entries[b].Key) < 0
+	})
+
+	return &memTableIterator{
+		entries:   entries,
+		nextIndex: 0,
+	}, nil
+}
+
 func (m *memTable) Flush() error {
 	// This is a no-op for a memory table. Memory tables are ephemeral by nature.
 	return nil
@@ -186,6 +270,15 @@ func (m *memTable) SetShardingFactor(shardingFactor uint32) error {
 	return nil
 }
 
+func (m *memTable) SetCompression(compression types.CompressionType) error {
+	// the memory table does not store data on disk, so there is nothing to compress
+	return nil
+}
+
+func (m *memTable) CompressionRatio() float64 {
+	return 1.0
+}
+
 func (m *memTable) RunGC() error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -195,13 +288,15 @@ func (m *memTable) RunGC() error {
 	}
 
 	now := m.clock()
-	earliestPermittedCreationTime := now.Add(-m.ttl)
 
+	// Like the disk table, data is deleted in the order it was written. A key with a long TTL override prevents
+	// keys written after it from being deleted until it expires.
 	for {
 		expiration, ok := m.expirationQueue.TryPeek()
 		if !ok {
 			break
 		}
+		earliestPermittedCreationTime := now.Add(-max(m.ttl, expiration.ttl))
 		if expiration.creationTime.After(earliestPermittedCreationTime) {
 			break
 		}
@@ -211,3 +306,49 @@ func (m *memTable) RunGC() error {
 
 	return nil
 }
+
+var _ litt.Iterator = &memTableIterator{}
+
+// memTableIterator iterates over a copy of the data in a memTable.
+type memTableIterator struct {
+	// The entries being iterated over, sorted by key.
+	entries []*types.KVPair
+	// The index of the next entry to return.
+	nextIndex int
+	// The entry at the current position.
+	current *types.KVPair
+}
+
+func (i *memTableIterator) Next() bool {
+	if i.nextIndex >= len(i.entries) {
+		i.current = nil
+		return false
+	}
+	i.current = i.entries[i.nextIndex]
+	i.nextIndex++
+	return true
+}
+
+func (i *memTableIterator) Key() []byte {
+	if i.current == nil {
+		return nil
+	}
+	return i.current.Key
+}
+
+func (i *memTableIterator) Value() ([]byte, error) {
+	if i.current == nil {
+		return nil, fmt.Errorf("iterator is not positioned on a key")
+	}
+	return i.current.Value, nil
+}
+
+func (i *memTableIterator) Error() error {
+	return nil
+}
+
+func (i *memTableIterator) Close() error {
+	i.entries = nil
+	i.current = nil
+	return nil
+}
@@ -31,6 +31,9 @@ type LittDBMetrics struct {
 	// The number of keys in individual tables in the database.
 	tableKeyCount *prometheus.GaugeVec
 
+	// The ratio between the uncompressed and on-disk size of individual tables in the database.
+	tableCompressionRatio *prometheus.GaugeVec
+
 	// The number of bytes read from disk since startup.
 	bytesReadCounter *prometheus.CounterVec
 
@@ -107,6 +110,15 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 		[]string{"table"},
 	)
 
+	tableCompressionRatio := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "table_compression_ratio",
+			Help:      "The ratio between the uncompressed and on-disk size of individual tables in the database.",
+		},
+		[]string{"table"},
+	)
+
 	bytesReadCounter := promauto.With(registry).NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: namespace,
@@ -258,6 +270,7 @@ func NewLittDBMetrics(registry *prometheus.Registry, namespace string) *LittDBMe
 	return &LittDBMetrics{
 		tableSizeInBytes:         tableSizeInBytes,
 		tableKeyCount:            tableKeyCount,
+		tableCompressionRatio:    tableCompressionRatio,
 		bytesReadCounter:         bytesReadCounter,
 		keysReadCounter:          keysReadCounter,
 		cacheHitCounter:          cacheHitCounter,
@@ -292,6 +305,8 @@ func (m *LittDBMetrics) CollectPeriodicMetrics(tables map[string]litt.ManagedTab
 
 		tableKeyCount := table.KeyCount()
 		m.tableKeyCount.WithLabelValues(tableName).Set(float64(tableKeyCount))
+
+		m.tableCompressionRatio.WithLabelValues(tableName).Set(table.CompressionRatio())
 	}
 }
 
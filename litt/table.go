@@ -11,7 +11,8 @@ import (
 var TableNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
 // Table is a key-value store with a namespace that does not overlap with other tables.
-// Values may be written to the table, but once written, they may not be changed or deleted (except via TTL).
+// Values may be written to the table, but once written, they may not be changed. Values may be removed explicitly
+// via Delete, or implicitly via TTL.
 //
 // All methods in this interface are thread safe.
 type Table interface {
@@ -22,7 +23,8 @@ type Table interface {
 	// Note that when this method returns, data written may not be crash durable on disk
 	// (although the write does have atomicity). In order to ensure crash durability, call Flush().
 	//
-	// The maximum size of the key is 2^32 bytes. The maximum size of the value is 2^32 bytes.
+	// The maximum size of the key is 2^32-2 bytes (a key length of 2^32-1 is reserved). The maximum size of the
+	// value is 2^32 bytes.
 	// This database has been optimized under the assumption that values are generally much larger than keys.
 	// This affects performance, but not correctness.
 	//
@@ -30,18 +32,47 @@ type Table interface {
 	// (both the key and the value).
 	Put(key []byte, value []byte) error
 
+	// PutWithTTL is identical to Put, except that the value is retained for at least the given TTL, even if the
+	// table's TTL is shorter. If the table's TTL is longer than the given TTL, or if the table's TTL is disabled,
+	// then the table's TTL takes precedence. The TTL must be positive.
+	//
+	// Values are garbage collected a segment at a time. Once the table's TTL has elapsed, values with a longer TTL are
+	// copied into a newer segment so that the rest of their original segment can be garbage collected. A copied value
+	// keeps the time at which it was originally written. This method is intended for a small number of values that
+	// must outlive the rest of the table (for example, data that is needed for an ongoing dispute), not as a general
+	// replacement for a table-wide TTL.
+	//
+	// It is not safe to modify the byte slices passed to this function after the call
+	// (both the key and the value).
+	PutWithTTL(key []byte, value []byte, ttl time.Duration) error
+
 	// PutBatch stores multiple values in the database. Similar to Put, but allows for multiple values to be written
 	// at once. This may improve performance, but it otherwise has identical properties to a sequence of Put calls
 	// (i.e. this method does not atomically write the entire batch).
 	//
-	// The maximum size of a key is 2^32 bytes. The maximum size of a value is 2^32 bytes.
-	// This database has been optimized under the assumption that values are generally much larger than keys.
-	// This affects performance, but not correctness.
+	// The maximum size of a key is 2^32-2 bytes (a key length of 2^32-1 is reserved). The maximum size of a value
+	// is 2^32 bytes. This database has been optimized under the assumption that values are generally much larger
+	// than keys. This affects performance, but not correctness.
 	//
 	// It is not safe to modify the byte slices passed to this function after the call
 	// (including the key byte slices and the value byte slices).
 	PutBatch(batch []*types.KVPair) error
 
+	// PutBatchAtomic stores multiple values in the database as a single atomic unit. Similar to PutBatch, except
+	// that after a crash, either all values in the batch are present or none of them are. As with all writes, the
+	// batch is only guaranteed to be durable after Flush has been called.
+	//
+	// Atomicity applies to crash recovery, not to visibility. Concurrent readers may observe some values in the
+	// batch before others. All values in an atomic batch are written to the same segment, so a very large batch
+	// may cause a segment to exceed its target size.
+	//
+	// The maximum size of a key is 2^32-2 bytes (a key length of 2^32-1 is reserved). The maximum size of a value
+	// is 2^32 bytes.
+	//
+	// It is not safe to modify the byte slices passed to this function after the call
+	// (including the key byte slices and the value byte slices).
+	PutBatchAtomic(batch []*types.KVPair) error
+
 	// Get retrieves a value from the database. The returned boolean indicates whether the key exists in the database
 	// (returns false if the key does not exist). If an error is returned, the value of the other returned values are
 	// undefined.
@@ -67,6 +98,31 @@ type Table interface {
 	// It is not safe to modify the key byte slice after it is passed to this method.
 	Exists(key []byte) (exists bool, err error)
 
+	// Delete removes a key and its value from the table. Deleting a key that does not exist is a no-op. Once this
+	// method returns, the key is no longer visible to Get, Exists, or newly created iterators. Like Put, a deletion
+	// is only guaranteed to be crash durable after Flush() has been called.
+	//
+	// Deletion is implemented by writing a tombstone. Disk space used by a deleted value is reclaimed by the
+	// garbage collector once every value in the same segment has been deleted or has expired. Re-writing a key
+	// after it has been deleted is not supported, for the same reasons that overwriting a key is not supported.
+	//
+	// It is not safe to modify the key byte slice after it is passed to this method.
+	Delete(key []byte) error
+
+	// Iterator returns an iterator over all key-value pairs in the table, in lexicographic key order. The iterator
+	// observes a consistent snapshot of the table's keys taken at the time this method is called. Keys written
+	// after the iterator is created are not visited. Data visited by the iterator is not garbage collected until
+	// the iterator is closed.
+	//
+	// The caller is responsible for calling Close() on the returned iterator.
+	Iterator() (Iterator, error)
+
+	// PrefixIterator is identical to Iterator, except that it only visits keys that start with the given prefix.
+	// A nil or empty prefix visits all keys in the table.
+	//
+	// It is not safe to modify the prefix byte slice after it is passed to this method.
+	PrefixIterator(prefix []byte) (Iterator, error)
+
 	// Flush ensures that all data written to the database is crash durable on disk. When this method returns,
 	// all data written by Put() operations is guaranteed to be crash durable. Put() operations that overlap with calls
 	// to Flush() may not be crash durable after this method returns.
@@ -83,7 +139,7 @@ type Table interface {
 	//
 	// Due to technical limitations, this size may or may not accurately reflect the size of the keymap. This is
 	// because some third party libraries used for certain keymap implementations do not provide an accurate way to
-	// measure size.
+	// measure size. The size of keymap.PebbleKeymapType keymaps is reported accurately.
 	Size() uint64
 
 	// KeyCount returns the number of keys in the table.
@@ -99,6 +155,16 @@ type Table interface {
 	// writes that can be performed.
 	SetShardingFactor(shardingFactor uint32) error
 
+	// SetCompression sets the compression type applied to values written to this table. The new compression type
+	// only applies to newly written values, data already on disk is left as it is. Values are decompressed
+	// transparently when read, regardless of the compression type in use when they were written.
+	SetCompression(compression types.CompressionType) error
+
+	// CompressionRatio returns the ratio between the uncompressed and the on-disk size of the data in this table.
+	// A ratio of 1.0 means that the data is not compressed (or is not compressible). Like Size(), this is an
+	// approximation.
+	CompressionRatio() float64
+
 	// SetWriteCacheSize sets the write cache size, in bytes, for the table. For table implementations without a cache,
 	// this method does nothing. The cache is used to store recently written data. When reading from the table,
 	// if the requested data is present in this cache, the cache is used instead of reading from disk. Reading from the
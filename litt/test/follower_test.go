@@ -0,0 +1,254 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/litt"
+	"github.com/Layr-Labs/eigenda/litt/disktable"
+	"github.com/Layr-Labs/eigenda/litt/disktable/segment"
+	"github.com/Layr-Labs/eigenda/litt/littbuilder"
+	"github.com/Layr-Labs/eigenda/litt/util"
+	"github.com/Layr-Labs/eigenda/test"
+	"github.com/Layr-Labs/eigenda/test/random"
+	"github.com/stretchr/testify/require"
+)
+
+// checkFollowerConsistency verifies that every key visible to a follower has the value that was written by the
+// primary, and that a follower never sees keys that were never written.
+func checkFollowerConsistency(t *testing.T, table litt.Table, writtenData map[string][]byte) {
+	iterator, err := table.Iterator()
+	require.NoError(t, err)
+	for iterator.Next() {
+		expectedValue, ok := writtenData[string(iterator.Key())]
+		require.True(t, ok, "follower has unexpected key %s", iterator.Key())
+		value, err := iterator.Value()
+		require.NoError(t, err)
+		require.Equal(t, expectedValue, value)
+	}
+	require.NoError(t, iterator.Error())
+	require.NoError(t, iterator.Close())
+}
+
+func TestFollower(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	logger := test.GetLogger()
+	rand := random.NewTestRandom()
+	testDirectory := t.TempDir()
+
+	rootPaths := []string{path.Join(testDirectory, "root-0"), path.Join(testDirectory, "root-1")}
+	snapshotDir := path.Join(testDirectory, "snapshot")
+
+	config, err := litt.DefaultConfig(rootPaths...)
+	require.NoError(t, err)
+	config.Fsync = false
+	config.DoubleWriteProtection = true
+	config.TargetSegmentFileSize = 100
+	config.SnapshotDirectory = snapshotDir
+
+	primary, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	primaryTable, err := primary.GetTable("table")
+	require.NoError(t, err)
+
+	followerConfig, err := litt.DefaultConfig(snapshotDir)
+	require.NoError(t, err)
+	followerConfig.Fsync = false
+	followerConfig.FollowerPollPeriod = time.Millisecond
+
+	follower, err := littbuilder.NewFollowerDB(followerConfig)
+	require.NoError(t, err)
+	followerTable, err := follower.GetTable("table")
+	require.NoError(t, err)
+
+	// Tables that do not exist in the snapshot cannot be created by a follower.
+	_, err = follower.GetTable("missing-table")
+	require.Error(t, err)
+
+	// A follower is read only.
+	require.Error(t, followerTable.Put([]byte("key"), []byte("value")))
+	require.Error(t, followerTable.Delete([]byte("key")))
+	require.Error(t, followerTable.SetTTL(time.Hour))
+	require.Error(t, follower.DropTable("table"))
+
+	// A follower must not take the locks held by the primary or by the CLI.
+	exists, err := util.Exists(path.Join(snapshotDir, util.LockfileName))
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	writtenData := make(map[string][]byte)
+	expectedData := make(map[string][]byte)
+	deletedKeys := make(map[string]struct{})
+
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 100; i++ {
+			key := rand.String(32)
+			value := rand.PrintableVariableBytes(1, 100)
+			err = primaryTable.Put([]byte(key), value)
+			require.NoError(t, err)
+			writtenData[key] = value
+			expectedData[key] = value
+
+			if rand.BoolWithProbability(0.1) {
+				for keyToDelete := range expectedData {
+					err = primaryTable.Delete([]byte(keyToDelete))
+					require.NoError(t, err)
+					delete(expectedData, keyToDelete)
+					deletedKeys[keyToDelete] = struct{}{}
+					break
+				}
+			}
+		}
+		err = primaryTable.Flush()
+		require.NoError(t, err)
+
+		// The follower may lag behind the primary, but it should never observe anything the primary didn't write.
+		checkFollowerConsistency(t, followerTable, writtenData)
+	}
+
+	// Restart the primary. This seals the last segment and adds it to the snapshot, after which the follower
+	// should eventually see all data.
+	err = primary.Close()
+	require.NoError(t, err)
+	primary, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	_, err = primary.GetTable("table")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return followerTable.KeyCount() == uint64(len(expectedData))
+	}, 10*time.Second, time.Millisecond)
+
+	for key, expectedValue := range expectedData {
+		value, ok, err := followerTable.Get([]byte(key))
+		require.NoError(t, err)
+		require.True(t, ok, "key %s missing from follower", key)
+		require.Equal(t, expectedValue, value)
+	}
+	for key := range deletedKeys {
+		ok, err := followerTable.Exists([]byte(key))
+		require.NoError(t, err)
+		require.False(t, ok, "deleted key %s present in follower", key)
+	}
+	checkFollowerConsistency(t, followerTable, writtenData)
+
+	// Simulate an external process pruning the oldest segments from the snapshot.
+	errorMonitor := util.NewErrorMonitor(ctx, logger, nil)
+	snapshotSegmentPath, err := segment.NewSegmentPath(snapshotDir, "", "table")
+	require.NoError(t, err)
+	lowestSegmentIndex, highestSegmentIndex, segments, err := segment.GatherSegmentFiles(
+		logger,
+		errorMonitor,
+		[]*segment.SegmentPath{snapshotSegmentPath},
+		false,
+		time.Now(),
+		false,
+		false)
+	require.NoError(t, err)
+	require.Greater(t, highestSegmentIndex, lowestSegmentIndex+1)
+	prunedIndex := lowestSegmentIndex + (highestSegmentIndex-lowestSegmentIndex)/2
+
+	prunedKeys := make(map[string]struct{})
+	for index := lowestSegmentIndex; index <= prunedIndex; index++ {
+		keys, err := segments[index].GetKeys()
+		require.NoError(t, err)
+		for _, key := range keys {
+			prunedKeys[string(key.Key)] = struct{}{}
+		}
+		for _, filePath := range segments[index].GetFilePaths() {
+			err = os.Remove(filePath)
+			require.NoError(t, err)
+		}
+	}
+	lowerBoundFile, err := disktable.LoadBoundaryFile(disktable.LowerBound, path.Join(snapshotDir, "table"))
+	require.NoError(t, err)
+	err = lowerBoundFile.Update(prunedIndex)
+	require.NoError(t, err)
+
+	remainingCount := 0
+	for key := range expectedData {
+		if _, pruned := prunedKeys[key]; !pruned {
+			remainingCount++
+		}
+	}
+	require.Eventually(t, func() bool {
+		return followerTable.KeyCount() == uint64(remainingCount)
+	}, 10*time.Second, time.Millisecond)
+
+	for key, expectedValue := range expectedData {
+		value, ok, err := followerTable.Get([]byte(key))
+		require.NoError(t, err)
+		if _, pruned := prunedKeys[key]; pruned {
+			require.False(t, ok, "pruned key %s present in follower", key)
+		} else {
+			require.True(t, ok, "key %s missing from follower", key)
+			require.Equal(t, expectedValue, value)
+		}
+	}
+
+	err = follower.Close()
+	require.NoError(t, err)
+	err = primary.Close()
+	require.NoError(t, err)
+
+	ok, err := errorMonitor.IsOk()
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+// A follower opened before the primary has sealed any segments should pick up data once the primary does so.
+func TestFollowerStartsEmpty(t *testing.T) {
+	t.Parallel()
+
+	rand := random.NewTestRandom()
+	testDirectory := t.TempDir()
+	snapshotDir := path.Join(testDirectory, "snapshot")
+
+	config, err := litt.DefaultConfig(path.Join(testDirectory, "root"))
+	require.NoError(t, err)
+	config.Fsync = false
+	config.TargetSegmentFileSize = 100
+	config.SnapshotDirectory = snapshotDir
+
+	primary, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	primaryTable, err := primary.GetTable("table")
+	require.NoError(t, err)
+
+	followerConfig, err := litt.DefaultConfig(snapshotDir)
+	require.NoError(t, err)
+	followerConfig.FollowerPollPeriod = time.Millisecond
+	follower, err := littbuilder.NewFollowerDB(followerConfig)
+	require.NoError(t, err)
+	followerTable, err := follower.GetTable("table")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), followerTable.KeyCount())
+
+	// Write enough data to seal a bunch of segments.
+	firstKeys := make(map[string][]byte)
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		value := rand.PrintableBytes(100)
+		err = primaryTable.Put([]byte(key), value)
+		require.NoError(t, err)
+		firstKeys[key] = value
+		err = primaryTable.Flush()
+		require.NoError(t, err)
+	}
+
+	// Each segment holds one value, so all but the last value are in sealed segments.
+	require.Eventually(t, func() bool {
+		return followerTable.KeyCount() >= uint64(len(firstKeys)-1)
+	}, 10*time.Second, time.Millisecond)
+	checkFollowerConsistency(t, followerTable, firstKeys)
+
+	err = follower.Close()
+	require.NoError(t, err)
+	err = primary.Close()
+	require.NoError(t, err)
+}
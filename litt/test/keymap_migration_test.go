@@ -289,3 +289,120 @@ func TestFailedKeymapMigration(t *testing.T) {
 		require.Equal(t, expectedValue, value)
 	}
 }
+
+// Tests the migration of a LevelDB keymap to a Pebble keymap. Unlike other keymap migrations, this migration copies
+// the keymap's data instead of rebuilding it from the segment files.
+func TestLevelDBToPebbleKeymapMigration(t *testing.T) {
+	t.Parallel()
+	rand := random.NewTestRandom()
+	directory := t.TempDir()
+
+	config, err := litt.DefaultConfig(directory)
+	require.NoError(t, err)
+	config.KeymapType = keymap.UnsafeLevelDBKeymapType
+	config.Fsync = false // fsync is too slow for unit test workloads
+	config.DoubleWriteProtection = true
+	config.TargetSegmentFileSize = 1024
+	// The shard a value is stored in is derived from its key. Using a single shard allows the test to create a key
+	// that points to another key's value.
+	config.ShardingFactor = 1
+
+	db, err := littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err := db.GetTable("test")
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	for i := 0; i < 1000; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// The size of a LevelDB keymap is not reported.
+	levelDBSize := table.Size()
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	// Add a key directly to LevelDB that points to the same address as an existing key. This key does not exist in
+	// the segment files, so it will only be visible after the migration if the keymap was copied (and not rebuilt).
+	keymapPath := path.Join(directory, "test", keymap.KeymapDirectoryName)
+	levelDBPath := path.Join(keymapPath, keymap.KeymapDataDirectoryName)
+	ldb, err := leveldb.OpenFile(levelDBPath, nil)
+	require.NoError(t, err)
+
+	var copiedKey string
+	for key := range expectedValues {
+		copiedKey = key
+		break
+	}
+	address, err := ldb.Get([]byte(copiedKey), nil)
+	require.NoError(t, err)
+	sentinelKey := "sentinel"
+	err = ldb.Put([]byte(sentinelKey), address, nil)
+	require.NoError(t, err)
+	err = ldb.Close()
+	require.NoError(t, err)
+
+	// Reopen the DB with a Pebble keymap.
+	config.KeymapType = keymap.UnsafePebbleKeymapType
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err = db.GetTable("test")
+	require.NoError(t, err)
+
+	for expectedKey, expectedValue := range expectedValues {
+		value, ok, err := table.Get([]byte(expectedKey))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expectedValue, value)
+	}
+
+	value, ok, err := table.Get([]byte(sentinelKey))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, expectedValues[copiedKey], value)
+
+	// The size of a Pebble keymap is included in the table size.
+	require.Greater(t, table.Size(), levelDBSize)
+
+	// New data can be written after the migration.
+	key := rand.PrintableVariableBytes(32, 64)
+	newValue := rand.PrintableVariableBytes(1, 128)
+	err = table.Put(key, newValue)
+	require.NoError(t, err)
+	value, ok, err = table.Get(key)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, newValue, value)
+
+	err = db.Close()
+	require.NoError(t, err)
+
+	keymapTypeFile, err := keymap.LoadKeymapTypeFile(keymapPath)
+	require.NoError(t, err)
+	require.Equal(t, keymap.KeymapType(keymap.UnsafePebbleKeymapType), keymapTypeFile.Type())
+
+	exists, err := util.Exists(path.Join(keymapPath, "migration"))
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// Reopening the DB with the same keymap type should not trigger another migration.
+	db, err = littbuilder.NewDB(config)
+	require.NoError(t, err)
+	table, err = db.GetTable("test")
+	require.NoError(t, err)
+
+	value, ok, err = table.Get([]byte(sentinelKey))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, expectedValues[copiedKey], value)
+
+	err = db.Destroy()
+	require.NoError(t, err)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -552,3 +554,230 @@ func TestInvalidTableName(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, table)
 }
+
+// verifyIteration checks that iterating over the table with the given prefix visits exactly the expected keys,
+// in order, with the expected values.
+func verifyIteration(t *testing.T, table litt.Table, prefix []byte, expectedValues map[string][]byte) {
+	expectedKeys := make([]string, 0)
+	for key := range expectedValues {
+		if strings.HasPrefix(key, string(prefix)) {
+			expectedKeys = append(expectedKeys, key)
+		}
+	}
+	sort.Strings(expectedKeys)
+
+	iterator, err := table.PrefixIterator(prefix)
+	require.NoError(t, err)
+
+	// Data written after the iterator is created must not be visited.
+	lateKey := []byte(fmt.Sprintf("%slate-key-%d", prefix, len(expectedValues)))
+	err = table.Put(lateKey, []byte("late-value"))
+	require.NoError(t, err)
+
+	index := 0
+	for iterator.Next() {
+		require.Less(t, index, len(expectedKeys))
+		require.Equal(t, expectedKeys[index], string(iterator.Key()))
+		value, err := iterator.Value()
+		require.NoError(t, err)
+		require.Equal(t, expectedValues[expectedKeys[index]], value)
+		index++
+	}
+	require.NoError(t, iterator.Error())
+	require.Equal(t, len(expectedKeys), index)
+
+	err = iterator.Close()
+	require.NoError(t, err)
+
+	expectedValues[string(lateKey)] = []byte("late-value")
+}
+
+func iteratorTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, directory)
+	require.NoError(t, err)
+
+	prefixes := []string{"alpha-", "beta-", "gamma-"}
+	expectedValues := make(map[string][]byte)
+
+	iterations := 10
+	for i := 0; i < iterations; i++ {
+		for j := 0; j < 20; j++ {
+			key := []byte(prefixes[rand.Intn(len(prefixes))] + rand.String(16))
+			value := rand.PrintableVariableBytes(1, 128)
+			err = table.Put(key, value)
+			require.NoError(t, err)
+			expectedValues[string(key)] = value
+		}
+
+		// Flush some of the time so that iteration covers both flushed and unflushed data.
+		if rand.Bool() {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+
+		prefix := []byte(prefixes[rand.Intn(len(prefixes))])
+		verifyIteration(t, table, prefix, expectedValues)
+		verifyIteration(t, table, nil, expectedValues)
+	}
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestIterator(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			iteratorTest(t, tb)
+		})
+	}
+}
+
+func deleteTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(time.Now, tableName, directory)
+	require.NoError(t, err)
+
+	expectedValues := make(map[string][]byte)
+	deletedKeys := make(map[string]struct{})
+
+	iterations := 500
+	for i := 0; i < iterations; i++ {
+		key := rand.PrintableVariableBytes(32, 64)
+		value := rand.PrintableVariableBytes(1, 128)
+		err = table.Put(key, value)
+		require.NoError(t, err)
+		expectedValues[string(key)] = value
+
+		// Read the value back some of the time, so that it is present in the read cache (if there is one).
+		if rand.Bool() {
+			_, _, err = table.Get(key)
+			require.NoError(t, err)
+		}
+
+		// Once in a while, delete a random key.
+		if rand.BoolWithProbability(0.25) {
+			for keyToDelete := range expectedValues {
+				err = table.Delete([]byte(keyToDelete))
+				require.NoError(t, err)
+				delete(expectedValues, keyToDelete)
+				deletedKeys[keyToDelete] = struct{}{}
+				break
+			}
+		}
+
+		// Once in a while, flush the table.
+		if rand.BoolWithProbability(0.1) {
+			err = table.Flush()
+			require.NoError(t, err)
+		}
+	}
+
+	// Deleting a key that does not exist is a no-op.
+	err = table.Delete(rand.PrintableVariableBytes(32, 64))
+	require.NoError(t, err)
+
+	for key := range deletedKeys {
+		ok, err := table.Exists([]byte(key))
+		require.NoError(t, err)
+		require.False(t, ok)
+		_, ok, err = table.Get([]byte(key))
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+	require.Equal(t, uint64(len(expectedValues)), table.KeyCount())
+	verifyIteration(t, table, nil, expectedValues)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+	for _, tb := range tableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			deleteTest(t, tb)
+		})
+	}
+}
+
+func ttlOverrideTest(t *testing.T, tableBuilder *tableBuilder) {
+	rand := random.NewTestRandom()
+
+	directory := t.TempDir()
+
+	startTime := rand.Time()
+	var fakeTime atomic.Pointer[time.Time]
+	fakeTime.Store(&startTime)
+	clock := func() time.Time {
+		return *fakeTime.Load()
+	}
+
+	tableName := rand.String(8)
+	table, err := tableBuilder.builder(clock, tableName, directory)
+	require.NoError(t, err)
+
+	ttl := 10 * time.Second
+	err = table.SetTTL(ttl)
+	require.NoError(t, err)
+
+	// The TTL override must be positive.
+	err = table.PutWithTTL(rand.PrintableBytes(32), rand.PrintableBytes(32), 0)
+	require.Error(t, err)
+
+	longTTL := 100 * time.Second
+	longKey := rand.PrintableVariableBytes(32, 64)
+	longValue := rand.PrintableVariableBytes(1, 128)
+	err = table.PutWithTTL(longKey, longValue, longTTL)
+	require.NoError(t, err)
+
+	// Write enough additional data to ensure that the long lived value is not in the mutable segment.
+	for i := 0; i < 100; i++ {
+		err = table.Put(rand.PrintableVariableBytes(32, 64), rand.PrintableVariableBytes(1, 128))
+		require.NoError(t, err)
+	}
+	err = table.Flush()
+	require.NoError(t, err)
+
+	// The value outlives the table's TTL.
+	newTime := startTime.Add(2 * ttl)
+	fakeTime.Store(&newTime)
+	err = table.RunGC()
+	require.NoError(t, err)
+	value, ok, err := table.Get(longKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, longValue, value)
+
+	// The value does not outlive its own TTL.
+	newTime = startTime.Add(2 * longTTL)
+	fakeTime.Store(&newTime)
+	test.AssertEventuallyTrue(t, func() bool {
+		err = table.RunGC()
+		require.NoError(t, err)
+		ok, err := table.Exists(longKey)
+		require.NoError(t, err)
+		return !ok
+	}, time.Second)
+
+	err = table.Destroy()
+	require.NoError(t, err)
+}
+
+func TestTTLOverride(t *testing.T) {
+	t.Parallel()
+	for _, tb := range noCacheTableBuilders {
+		t.Run(tb.name, func(t *testing.T) {
+			ttlOverrideTest(t, tb)
+		})
+	}
+}
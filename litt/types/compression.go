@@ -0,0 +1,38 @@
+package types
+
+import "fmt"
+
+// CompressionType describes the algorithm used to compress values before they are written to disk.
+type CompressionType uint8
+
+const (
+	// NoCompression means that values are written to disk exactly as they are provided.
+	NoCompression CompressionType = 0
+
+	// SnappyCompression compresses values using snappy. Snappy is very fast, but achieves a lower compression ratio
+	// than zstd.
+	SnappyCompression CompressionType = 1
+
+	// ZstdCompression compresses values using zstd. Zstd is slower than snappy, but achieves a higher compression
+	// ratio.
+	ZstdCompression CompressionType = 2
+)
+
+// String returns a human-readable name for the compression type.
+func (c CompressionType) String() string {
+	switch c {
+	case NoCompression:
+		return "none"
+	case SnappyCompression:
+		return "snappy"
+	case ZstdCompression:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
+
+// IsValid returns true if the compression type is a known compression type.
+func (c CompressionType) IsValid() bool {
+	return c <= ZstdCompression
+}
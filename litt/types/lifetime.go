@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+// Lifetime describes how long a value is retained, for values that are not simply retained for the table's TTL after
+// the segment that contains them is sealed.
+type Lifetime struct {
+	// The time at which the value was originally written. If zero, the value was written to the segment that contains
+	// it, and its age is measured from the time that segment was sealed. Values that are moved to a new segment by
+	// the compactor keep the write time of the segment they were originally written to.
+	WriteTime time.Time
+
+	// The per-value TTL override, or 0 if the value has no override. A value is retained for the longer of the
+	// table's TTL and its TTL override.
+	TTL time.Duration
+}
+
+// Expiry returns the time at which a value with this lifetime expires. The seal time is the seal time of the segment
+// that contains the value, and the table TTL is the TTL of the table (which must be positive).
+func (l *Lifetime) Expiry(sealTime time.Time, tableTTL time.Duration) time.Time {
+	writeTime := sealTime
+	if !l.WriteTime.IsZero() {
+		writeTime = l.WriteTime
+	}
+	return writeTime.Add(max(tableTTL, l.TTL))
+}
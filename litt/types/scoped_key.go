@@ -6,6 +6,10 @@ type ScopedKey struct {
 	Key []byte
 	// The location where the value associated with the key is stored.
 	Address Address
-	// The length of the value associated with the key.
+	// The length of the value associated with the key, as stored on disk. If the value is compressed, this is the
+	// length of the compressed value.
 	ValueSize uint32
+	// The lifetime of the value, or nil if the value is retained for the table's TTL after its segment is sealed.
+	// Only tracked in segment key files, the keymap does not store lifetimes.
+	Lifetime *Lifetime
 }
@@ -9,6 +9,7 @@ import (
 	proxyconfig "github.com/Layr-Labs/eigenda/api/proxy/config"
 	proxymetrics "github.com/Layr-Labs/eigenda/api/proxy/metrics"
 	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest"
+	"github.com/Layr-Labs/eigenda/api/proxy/servers/rest/middleware"
 	"github.com/Layr-Labs/eigenda/api/proxy/store/builder"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -65,7 +66,8 @@ func NewProxyWrapper(
 		return nil, fmt.Errorf("build store manager: %w", err)
 	}
 
-	proxyServer := rest.NewServer(proxyConfig.RestSvrCfg, certMgr, keccakMgr, logger, proxyMetrics)
+	proxyServer := rest.NewServer(proxyConfig.RestSvrCfg, certMgr, keccakMgr, nil,
+		middleware.NewAuthenticator(proxyConfig.RestSvrCfg.Auth), logger, proxyMetrics)
 
 	router := mux.NewRouter()
 	proxyServer.RegisterRoutes(router)
@@ -0,0 +1,50 @@
+package eigenda
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/Layr-Labs/eigenda/tools/integration_utils/altdacommitment_parser"
+)
+
+// EncodeCert encodes a cert into a standard DA commitment, i.e. [version_byte][rlp_certificate], which is the format
+// returned by eigenda-proxy.
+func EncodeCert(cert coretypes.EigenDACert) ([]byte, error) {
+	var versionByte certs.VersionByte
+	switch cert.(type) {
+	case *coretypes.EigenDACertV3:
+		versionByte = certs.V2VersionByte
+	case *coretypes.EigenDACertV4:
+		versionByte = certs.V3VersionByte
+	default:
+		return nil, fmt.Errorf("unsupported cert type: %T", cert)
+	}
+
+	serializedCert, err := cert.Serialize(coretypes.CertSerializationRLP)
+	if err != nil {
+		return nil, fmt.Errorf("serialize cert: %w", err)
+	}
+	return certs.NewVersionedCert(serializedCert, versionByte).Encode(), nil
+}
+
+// ParseCert parses a hex-encoded DA commitment, in either the standard or the Optimism generic commitment mode, into
+// a cert. V2 certs are converted to V3 certs.
+func ParseCert(hexString string) (coretypes.EigenDACert, error) {
+	_, versionedCert, err := altdacommitment_parser.ParseAltDACommitmentFromHex(hexString)
+	if err != nil {
+		return nil, fmt.Errorf("parse DA commitment: %w", err)
+	}
+
+	certVersion, err := versionedCert.Version.IntoCertVersion()
+	if err != nil {
+		return nil, fmt.Errorf("get cert version: %w", err)
+	}
+
+	cert, err := coretypes.DeserializeEigenDACert(
+		versionedCert.SerializedCert, certVersion, coretypes.CertSerializationRLP)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize cert: %w", err)
+	}
+	return cert, nil
+}
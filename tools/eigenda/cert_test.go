@@ -0,0 +1,51 @@
+package eigenda
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/proxy/common/types/certs"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func readCertHex(t *testing.T, name string) string {
+	data, err := os.ReadFile("../integration_utils/data/" + name)
+	require.NoError(t, err)
+	return strings.TrimSpace(string(data))
+}
+
+func TestEncodeAndParseCert(t *testing.T) {
+	// the fixture is an Optimism generic commitment: [0x01][0x00][version_byte][rlp_certificate]
+	opCommitment := readCertHex(t, "cert_v3.sepolia.rlp.hex")
+
+	cert, err := ParseCert(opCommitment)
+	require.NoError(t, err)
+	require.IsType(t, &coretypes.EigenDACertV3{}, cert)
+
+	commitment, err := EncodeCert(cert)
+	require.NoError(t, err)
+	require.Equal(t, byte(certs.V2VersionByte), commitment[0])
+	require.Equal(t, hexutil.MustDecode(opCommitment)[2:], commitment)
+
+	parsed, err := ParseCert(hexutil.Encode(commitment))
+	require.NoError(t, err)
+	require.Equal(t, cert, parsed)
+}
+
+func TestParseV2Cert(t *testing.T) {
+	cert, err := ParseCert(readCertHex(t, "cert_v2.sepolia.rlp.hex"))
+	require.NoError(t, err)
+	// V2 certs are converted to V3 certs
+	require.IsType(t, &coretypes.EigenDACertV3{}, cert)
+}
+
+func TestParseCertInvalid(t *testing.T) {
+	_, err := ParseCert("0x1234")
+	require.Error(t, err)
+
+	_, err = ParseCert("not hex")
+	require.Error(t, err)
+}
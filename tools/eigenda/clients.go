@@ -0,0 +1,271 @@
+package eigenda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"time"
+
+	clients "github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/dispersal"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/metrics"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/payloadretrieval"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	proxycommon "github.com/Layr-Labs/eigenda/api/proxy/common"
+	srs "github.com/Layr-Labs/eigenda/api/proxy/resources"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/disperser"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
+	"github.com/Layr-Labs/eigenda/core/eth/directory"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/v2/kzg/committer"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// chainClients holds the clients of the commands that read EigenDA contracts.
+type chainClients struct {
+	ethClient         common.EthClient
+	chainID           *big.Int
+	contractDirectory *directory.ContractDirectory
+}
+
+func buildChainClients(ctx context.Context, logger logging.Logger, config *Config) (*chainClients, error) {
+	if config.EthRPCURL == "" {
+		return nil, errors.New("an eth RPC URL must be provided")
+	}
+	if config.EigenDADirectory == "" {
+		return nil, errors.New("either an EigenDA network or an EigenDA directory address must be provided")
+	}
+
+	ethClientConfig := geth.EthClientConfig{
+		RPCURLs:    []string{config.EthRPCURL},
+		NumRetries: 3,
+	}
+	ethClient, chainID, err := proxycommon.BuildEthClient(ctx, logger, ethClientConfig, config.Network)
+	if err != nil {
+		return nil, fmt.Errorf("build eth client: %w", err)
+	}
+
+	parsedChainID, ok := new(big.Int).SetString(chainID, 10)
+	if !ok {
+		return nil, fmt.Errorf("parse chain ID %s", chainID)
+	}
+
+	contractDirectory, err := directory.NewContractDirectory(
+		ctx, logger, ethClient, gethcommon.HexToAddress(config.EigenDADirectory))
+	if err != nil {
+		return nil, fmt.Errorf("new contract directory: %w", err)
+	}
+
+	return &chainClients{
+		ethClient:         ethClient,
+		chainID:           parsedChainID,
+		contractDirectory: contractDirectory,
+	}, nil
+}
+
+func buildCertVerifier(
+	ctx context.Context,
+	logger logging.Logger,
+	config *Config,
+	chain *chainClients,
+) (*verification.CertVerifier, error) {
+	var provider clients.CertVerifierAddressProvider
+	if config.CertVerifierAddress != "" {
+		provider = verification.NewStaticCertVerifierAddressProvider(
+			gethcommon.HexToAddress(config.CertVerifierAddress))
+	} else {
+		routerAddr, err := chain.contractDirectory.GetContractAddress(ctx, directory.CertVerifierRouter)
+		if err != nil {
+			return nil, fmt.Errorf("get cert verifier router address: %w", err)
+		}
+		provider, err = verification.BuildRouterAddressProvider(routerAddr, chain.ethClient, logger)
+		if err != nil {
+			return nil, fmt.Errorf("build router address provider: %w", err)
+		}
+	}
+
+	certVerifier, err := verification.NewCertVerifier(logger, chain.ethClient, provider)
+	if err != nil {
+		return nil, fmt.Errorf("new cert verifier: %w", err)
+	}
+	return certVerifier, nil
+}
+
+func buildSigner(config *Config) (*auth.LocalBlobRequestSigner, error) {
+	if config.SignerPaymentKey == "" {
+		return nil, errors.New("a signer payment key must be provided")
+	}
+	signer, err := auth.NewLocalBlobRequestSigner(config.SignerPaymentKey)
+	if err != nil {
+		return nil, fmt.Errorf("new local blob request signer: %w", err)
+	}
+	return signer, nil
+}
+
+// buildDisperserClient creates a DisperserClient for the commands that query the disperser without dispersing blobs.
+// Such clients never compute blob commitments, so their committer is built from the SRS generators only, instead of
+// deserializing the whole embedded SRS.
+func buildDisperserClient(logger logging.Logger, config *Config) (*dispersal.DisperserClient, error) {
+	if config.DisperserClientConfig.GrpcUri == "" {
+		return nil, errors.New("either an EigenDA network or a disperser RPC must be provided")
+	}
+
+	signer, err := buildSigner(config)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, g1Generator, g2Generator := bn254.Generators()
+	kzgCommitter, err := committer.New(
+		[]bn254.G1Affine{g1Generator}, []bn254.G2Affine{g2Generator}, []bn254.G2Affine{g2Generator})
+	if err != nil {
+		return nil, fmt.Errorf("new kzg committer: %w", err)
+	}
+
+	disperserClientConfig := config.DisperserClientConfig
+	disperserClient, err := dispersal.NewDisperserClient(
+		logger, &disperserClientConfig, signer, kzgCommitter, metrics.NoopDispersalMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("new disperser client: %w", err)
+	}
+	return disperserClient, nil
+}
+
+func buildPayloadDisperser(
+	ctx context.Context,
+	logger logging.Logger,
+	config *Config,
+	chain *chainClients,
+) (*dispersal.PayloadDisperser, error) {
+	if config.DisperserClientConfig.GrpcUri == "" {
+		return nil, errors.New("either an EigenDA network or a disperser RPC must be provided")
+	}
+
+	signer, err := buildSigner(config)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, err := signer.GetAccountID()
+	if err != nil {
+		return nil, fmt.Errorf("get account ID: %w", err)
+	}
+	logger.Info("Using account ID", "accountID", accountID.Hex())
+
+	kzgCommitter, err := committer.New(srs.GetG1SRS(), srs.GetG2SRS(), srs.GetG2TrailingSRS())
+	if err != nil {
+		return nil, fmt.Errorf("new kzg committer: %w", err)
+	}
+
+	multiplexerConfig := dispersal.DefaultDisperserClientMultiplexerConfig()
+	multiplexerConfig.UseSecureGrpcFlag = config.DisperserClientConfig.UseSecureGrpcFlag
+	multiplexerConfig.ChainID = chain.chainID
+
+	disperserClientMultiplexer, err := dispersal.NewDisperserClientMultiplexer(
+		logger,
+		multiplexerConfig,
+		disperser.NewLegacyDisperserRegistry(config.DisperserClientConfig.GrpcUri),
+		signer,
+		kzgCommitter,
+		metrics.NoopDispersalMetrics,
+		rand.New(rand.NewSource(time.Now().UnixNano())),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("new disperser client multiplexer: %w", err)
+	}
+
+	clientLedger, err := dispersal.BuildClientLedger(
+		ctx,
+		logger,
+		chain.ethClient,
+		chain.contractDirectory,
+		accountID,
+		config.ClientLedgerMode,
+		// the CLI disperses a single payload, so there is no point in following updates to the PaymentVault
+		time.Hour,
+		metrics.NoopAccountantMetrics,
+		disperserClientMultiplexer)
+	if err != nil {
+		return nil, fmt.Errorf("build client ledger: %w", err)
+	}
+
+	certVerifier, err := buildCertVerifier(ctx, logger, config, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	operatorStateRetrieverAddr, err := chain.contractDirectory.GetContractAddress(
+		ctx, directory.OperatorStateRetriever)
+	if err != nil {
+		return nil, fmt.Errorf("get operator state retriever address: %w", err)
+	}
+	registryCoordinatorAddr, err := chain.contractDirectory.GetContractAddress(ctx, directory.RegistryCoordinator)
+	if err != nil {
+		return nil, fmt.Errorf("get registry coordinator address: %w", err)
+	}
+
+	certBuilder, err := clients.NewCertBuilder(
+		logger, operatorStateRetrieverAddr, registryCoordinatorAddr, chain.ethClient)
+	if err != nil {
+		return nil, fmt.Errorf("new cert builder: %w", err)
+	}
+
+	blockNumMonitor, err := verification.NewBlockNumberMonitor(logger, chain.ethClient, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("new block number monitor: %w", err)
+	}
+
+	payloadDisperser, err := dispersal.NewPayloadDisperser(
+		logger,
+		config.PayloadDisperserConfig,
+		disperserClientMultiplexer,
+		blockNumMonitor,
+		certBuilder,
+		certVerifier,
+		clientLedger,
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("new payload disperser: %w", err)
+	}
+	return payloadDisperser, nil
+}
+
+func buildRelayPayloadRetriever(
+	ctx context.Context,
+	logger logging.Logger,
+	config *Config,
+	chain *chainClients,
+) (*payloadretrieval.RelayPayloadRetriever, error) {
+	relayRegistryAddr, err := chain.contractDirectory.GetContractAddress(ctx, directory.RelayRegistry)
+	if err != nil {
+		return nil, fmt.Errorf("get relay registry address: %w", err)
+	}
+
+	g1Srs := srs.GetG1SRS()
+	maxBlobSizeBytes := uint(len(g1Srs)) * encoding.BYTES_PER_SYMBOL
+	relayClientConfig := &relay.RelayClientConfig{
+		UseSecureGrpcFlag: config.DisperserClientConfig.UseSecureGrpcFlag,
+		// 10% of max blob size is added for additional safety
+		MaxGRPCMessageSize: maxBlobSizeBytes + maxBlobSizeBytes/10,
+	}
+
+	relayPayloadRetriever, err := payloadretrieval.BuildRelayPayloadRetriever(
+		logger,
+		config.RelayPayloadRetrieverConfig,
+		relayClientConfig,
+		chain.ethClient,
+		relayRegistryAddr,
+		g1Srs,
+		metrics.NoopRetrievalMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("build relay payload retriever: %w", err)
+	}
+	return relayPayloadRetriever, nil
+}
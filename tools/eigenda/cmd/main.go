@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/tools/eigenda"
+	"github.com/Layr-Labs/eigenda/tools/eigenda/flags"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   = ""
+	gitCommit = ""
+	gitDate   = ""
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s,%s,%s", version, gitCommit, gitDate)
+	app.Name = "eigenda"
+	app.Description = "Command-line client for dispersing payloads to and retrieving payloads from EigenDA"
+	app.Usage = "eigenda [global options] <command> [command options]"
+	app.Flags = flags.Flags
+
+	app.Commands = []cli.Command{
+		{
+			Name:  "disperse",
+			Usage: "Disperse a file to EigenDA, and print the DA commitment of its cert",
+			Description: "Disperses the content of a file as a payload, waits for it to be signed, and prints the " +
+				"hex-encoded DA commitment of the verified cert. Requires --eth-rpc, --signer-payment-key-hex, and " +
+				"either --network or both --disperser-rpc and --eigenda-directory.",
+			Flags:  flags.DisperseFlags,
+			Action: eigenda.Disperse,
+		},
+		{
+			Name:  "status",
+			Usage: "Print the status of a blob",
+			Description: "Prints the status of a blob, as reported by the disperser. Requires " +
+				"--signer-payment-key-hex, and either --network or --disperser-rpc.",
+			Flags:  flags.StatusFlags,
+			Action: eigenda.Status,
+		},
+		{
+			Name:  "retrieve",
+			Usage: "Fetch the payload of a cert from the EigenDA relays",
+			Description: "Fetches the payload of a cert from the EigenDA relays, verifies it against the cert's " +
+				"commitment, and writes it to a file. Requires --eth-rpc, and either --network or --eigenda-directory.",
+			Flags:  flags.RetrieveFlags,
+			Action: eigenda.Retrieve,
+		},
+		{
+			Name:        "decode",
+			Usage:       "Decode and pretty-print a DA commitment",
+			Description: "Decodes a hex-encoded DA commitment containing a V2, V3 or V4 cert, and prints its content.",
+			Flags:       flags.DecodeFlags,
+			Action:      eigenda.Decode,
+		},
+		{
+			Name:  "verify",
+			Usage: "Verify a cert with the EigenDACertVerifier contract",
+			Description: "Calls checkDACert on the EigenDACertVerifier contract, and fails if the cert is invalid. " +
+				"Requires --eth-rpc, and either --network or --eigenda-directory.",
+			Flags:  flags.VerifyFlags,
+			Action: eigenda.Verify,
+		},
+		{
+			Name:  "payment-state",
+			Usage: "Print the payment state of the signer's account",
+			Description: "Prints the reservation and on-demand payment state of the signer's account, as reported " +
+				"by the disperser. Requires --signer-payment-key-hex, and either --network or --disperser-rpc.",
+			Action: eigenda.PaymentState,
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,398 @@
+package eigenda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/dispersal"
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/tools/eigenda/flags"
+	"github.com/Layr-Labs/eigenda/tools/integration_utils/altdacommitment_parser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func setup(ctx *cli.Context) (*Config, logging.Logger, error) {
+	config, err := NewConfig(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new config: %w", err)
+	}
+
+	logger, err := common.NewLogger(&config.LoggerConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new logger: %w", err)
+	}
+	return config, logger, nil
+}
+
+// Disperse disperses the content of a file as a payload, and prints the DA commitment of the resulting cert.
+func Disperse(ctx *cli.Context) error {
+	config, logger, err := setup(ctx)
+	if err != nil {
+		return err
+	}
+	runCtx := context.Background()
+
+	payload, err := readInput(ctx.String(flags.FileFlag.Name))
+	if err != nil {
+		return fmt.Errorf("read payload: %w", err)
+	}
+
+	chain, err := buildChainClients(runCtx, logger, config)
+	if err != nil {
+		return err
+	}
+
+	payloadDisperser, err := buildPayloadDisperser(runCtx, logger, config, chain)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := payloadDisperser.Close(); err != nil {
+			logger.Error("Failed to close payload disperser", "err", err)
+		}
+	}()
+
+	logger.Info("Dispersing payload", "size", len(payload))
+	cert, err := payloadDisperser.SendPayload(runCtx, coretypes.Payload(payload))
+	if err != nil {
+		return fmt.Errorf("send payload: %w", err)
+	}
+
+	blobKey, err := cert.ComputeBlobKey()
+	if err != nil {
+		return fmt.Errorf("compute blob key: %w", err)
+	}
+	logger.Info("Payload dispersed", "blobKey", blobKey.Hex(), "referenceBlockNumber", cert.ReferenceBlockNumber())
+
+	commitment, err := EncodeCert(cert)
+	if err != nil {
+		return fmt.Errorf("encode cert: %w", err)
+	}
+	return writeOutput(ctx.String(flags.OutputFileFlag.Name), []byte(hexutil.Encode(commitment)+"\n"))
+}
+
+// Status prints the status of a blob. With --follow, status updates are printed until the blob reaches a terminal
+// status.
+func Status(ctx *cli.Context) error {
+	config, logger, err := setup(ctx)
+	if err != nil {
+		return err
+	}
+	runCtx := context.Background()
+
+	blobKeyBytes, err := hexutil.Decode(ensureHexPrefix(ctx.String(flags.BlobKeyFlag.Name)))
+	if err != nil {
+		return fmt.Errorf("decode blob key: %w", err)
+	}
+	blobKey, err := corev2.BytesToBlobKey(blobKeyBytes)
+	if err != nil {
+		return fmt.Errorf("parse blob key: %w", err)
+	}
+
+	disperserClient, err := buildDisperserClient(logger, config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := disperserClient.Close(); err != nil {
+			logger.Error("Failed to close disperser client", "err", err)
+		}
+	}()
+
+	if !ctx.Bool(flags.FollowFlag.Name) {
+		reply, err := disperserClient.GetBlobStatus(runCtx, blobKey)
+		if err != nil {
+			return fmt.Errorf("get blob status: %w", err)
+		}
+		printBlobStatus(reply)
+		return nil
+	}
+
+	err = followBlobStatus(runCtx, disperserClient, blobKey)
+	if errors.Is(err, dispersal.ErrBlobStatusSubscriptionUnsupported) {
+		logger.Info("Disperser does not support blob status subscriptions, polling instead")
+		return pollBlobStatus(runCtx, disperserClient, blobKey, config.PayloadDisperserConfig.BlobStatusPollInterval)
+	}
+	return err
+}
+
+// followBlobStatus prints the status updates streamed by the disperser, until the stream ends. Returns
+// ErrBlobStatusSubscriptionUnsupported if the disperser doesn't support SubscribeBlobStatus.
+func followBlobStatus(ctx context.Context, disperserClient *dispersal.DisperserClient, blobKey corev2.BlobKey) error {
+	stream, err := disperserClient.SubscribeBlobStatus(ctx, blobKey)
+	if err != nil {
+		return fmt.Errorf("subscribe blob status: %w", err)
+	}
+
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if status.Code(err) == codes.Unimplemented {
+			return dispersal.ErrBlobStatusSubscriptionUnsupported
+		}
+		if err != nil {
+			return fmt.Errorf("receive blob status: %w", err)
+		}
+		printBlobStatus(reply)
+	}
+}
+
+// pollBlobStatus polls the disperser for the status of a blob, and prints it every time it changes, until the blob
+// reaches a terminal status.
+func pollBlobStatus(
+	ctx context.Context,
+	disperserClient *dispersal.DisperserClient,
+	blobKey corev2.BlobKey,
+	pollInterval time.Duration,
+) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var previousStatus *dispgrpc.BlobStatus
+	for {
+		reply, err := disperserClient.GetBlobStatus(ctx, blobKey)
+		if err != nil {
+			return fmt.Errorf("get blob status: %w", err)
+		}
+		if previousStatus == nil || reply.GetStatus() != *previousStatus ||
+			reply.GetStatus() == dispgrpc.BlobStatus_GATHERING_SIGNATURES {
+			printBlobStatus(reply)
+		}
+		blobStatus := reply.GetStatus()
+		previousStatus = &blobStatus
+
+		switch blobStatus {
+		case dispgrpc.BlobStatus_QUEUED, dispgrpc.BlobStatus_ENCODED, dispgrpc.BlobStatus_GATHERING_SIGNATURES:
+		default:
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("poll blob status: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func printBlobStatus(reply *dispgrpc.BlobStatusReply) {
+	fmt.Printf("Status: %s\n", reply.GetStatus())
+
+	signedBatch := reply.GetSignedBatch()
+	if signedBatch == nil {
+		return
+	}
+	fmt.Printf("  Reference Block Number: %d\n", signedBatch.GetHeader().GetReferenceBlockNumber())
+
+	attestation := signedBatch.GetAttestation()
+	percentages := attestation.GetQuorumSignedPercentages()
+	for i, quorum := range attestation.GetQuorumNumbers() {
+		if i < len(percentages) {
+			fmt.Printf("  Quorum %d Signed: %d%%\n", quorum, percentages[i])
+		}
+	}
+}
+
+// Retrieve fetches the payload of a cert from the relays, and writes it to a file.
+func Retrieve(ctx *cli.Context) error {
+	config, logger, err := setup(ctx)
+	if err != nil {
+		return err
+	}
+	runCtx := context.Background()
+
+	cert, err := ParseCert(ctx.String(flags.CertHexFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	chain, err := buildChainClients(runCtx, logger, config)
+	if err != nil {
+		return err
+	}
+
+	retriever, err := buildRelayPayloadRetriever(runCtx, logger, config, chain)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := retriever.Close(); err != nil {
+			logger.Error("Failed to close relay payload retriever", "err", err)
+		}
+	}()
+
+	payload, err := retriever.GetPayload(runCtx, cert)
+	if err != nil {
+		return fmt.Errorf("get payload: %w", err)
+	}
+	logger.Info("Payload retrieved", "size", len(payload))
+
+	return writeOutput(ctx.String(flags.RequiredOutputFileFlag.Name), payload)
+}
+
+// Decode pretty-prints a DA commitment and the cert it contains.
+func Decode(ctx *cli.Context) error {
+	hexString := ctx.String(flags.CertHexFlag.Name)
+
+	prefix, versionedCert, err := altdacommitment_parser.ParseAltDACommitmentFromHex(hexString)
+	if err != nil {
+		return fmt.Errorf("parse DA commitment: %w", err)
+	}
+	altdacommitment_parser.DisplayPrefixInfo(prefix)
+
+	cert, err := ParseCert(hexString)
+	if err != nil {
+		return err
+	}
+	blobKey, err := cert.ComputeBlobKey()
+	if err != nil {
+		return fmt.Errorf("compute blob key: %w", err)
+	}
+	fmt.Printf("  Blob Key: %s\n", blobKey.Hex())
+
+	err = altdacommitment_parser.DisplayCertData(versionedCert.SerializedCert)
+	if err != nil {
+		return fmt.Errorf("display cert: %w", err)
+	}
+	return nil
+}
+
+// Verify checks a cert against the EigenDACertVerifier contract.
+func Verify(ctx *cli.Context) error {
+	config, logger, err := setup(ctx)
+	if err != nil {
+		return err
+	}
+	runCtx := context.Background()
+
+	cert, err := ParseCert(ctx.String(flags.CertHexFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	chain, err := buildChainClients(runCtx, logger, config)
+	if err != nil {
+		return err
+	}
+
+	certVerifier, err := buildCertVerifier(runCtx, logger, config, chain)
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(runCtx, config.PayloadDisperserConfig.ContractCallTimeout)
+	defer cancel()
+	err = certVerifier.CheckDACert(timeoutCtx, cert)
+	if err != nil {
+		return fmt.Errorf("check DA cert: %w", err)
+	}
+
+	fmt.Println("Cert is valid")
+	return nil
+}
+
+// PaymentState prints the payment state of the account of the signer, as known by the disperser.
+func PaymentState(ctx *cli.Context) error {
+	config, logger, err := setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	disperserClient, err := buildDisperserClient(logger, config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := disperserClient.Close(); err != nil {
+			logger.Error("Failed to close disperser client", "err", err)
+		}
+	}()
+
+	reply, err := disperserClient.GetPaymentState(context.Background())
+	if err != nil {
+		return fmt.Errorf("get payment state: %w", err)
+	}
+
+	globalParams := reply.GetPaymentGlobalParams()
+	fmt.Println("Global Payment Parameters:")
+	fmt.Printf("  Global Symbols Per Second: %d\n", globalParams.GetGlobalSymbolsPerSecond())
+	fmt.Printf("  Min Num Symbols: %d\n", globalParams.GetMinNumSymbols())
+	fmt.Printf("  Price Per Symbol: %d wei\n", globalParams.GetPricePerSymbol())
+	fmt.Printf("  Reservation Window: %ds\n", globalParams.GetReservationWindow())
+	fmt.Printf("  On-Demand Quorums: %v\n", globalParams.GetOnDemandQuorumNumbers())
+
+	fmt.Println("Reservation:")
+	if reservation := reply.GetReservation(); reservation != nil {
+		fmt.Printf("  Symbols Per Second: %d\n", reservation.GetSymbolsPerSecond())
+		fmt.Printf("  Start: %s\n", time.Unix(int64(reservation.GetStartTimestamp()), 0).UTC())
+		fmt.Printf("  End: %s\n", time.Unix(int64(reservation.GetEndTimestamp()), 0).UTC())
+		fmt.Printf("  Quorums: %v\n", reservation.GetQuorumNumbers())
+		for _, record := range reply.GetPeriodRecords() {
+			fmt.Printf("  Period %d Usage: %d symbols\n", record.GetIndex(), record.GetUsage())
+		}
+	} else {
+		fmt.Println("  None")
+	}
+
+	fmt.Println("On-Demand:")
+	fmt.Printf("  Cumulative Payment: %s wei\n", new(big.Int).SetBytes(reply.GetCumulativePayment()))
+	fmt.Printf("  Onchain Deposit: %s wei\n", new(big.Int).SetBytes(reply.GetOnchainCumulativePayment()))
+	return nil
+}
+
+// ensureHexPrefix adds the 0x prefix to a hex string if it is missing.
+func ensureHexPrefix(hexString string) string {
+	if strings.HasPrefix(hexString, "0x") || strings.HasPrefix(hexString, "0X") {
+		return hexString
+	}
+	return "0x" + hexString
+}
+
+// readInput reads a file, or stdin if path is "-".
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("read stdin: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// writeOutput writes data to a file, or to stdout if path is empty.
+func writeOutput(path string, data []byte) error {
+	if path == "" {
+		_, err := os.Stdout.Write(data)
+		if err != nil {
+			return fmt.Errorf("write to stdout: %w", err)
+		}
+		return nil
+	}
+
+	err := os.WriteFile(path, data, 0o644)
+	if err != nil {
+		return fmt.Errorf("write file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package eigenda
+
+import (
+	"fmt"
+	"os"
+
+	clients "github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/dispersal"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/payloadretrieval"
+	proxycommon "github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core/payments/clientledger"
+	"github.com/Layr-Labs/eigenda/tools/eigenda/flags"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	LoggerConfig common.LoggerConfig
+
+	// empty if no network was specified
+	Network proxycommon.EigenDANetwork
+	// empty if no eth RPC was specified, in which case only the commands that don't read contracts can be used
+	EthRPCURL           string
+	EigenDADirectory    string
+	CertVerifierAddress string
+	// empty if no key was specified, in which case only the commands that don't talk to the disperser can be used
+	SignerPaymentKey string
+	ClientLedgerMode clientledger.ClientLedgerMode
+
+	DisperserClientConfig       dispersal.DisperserClientConfig
+	PayloadDisperserConfig      dispersal.PayloadDisperserConfig
+	RelayPayloadRetrieverConfig payloadretrieval.RelayPayloadRetrieverConfig
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("read logger config: %w", err)
+	}
+	// stdout is reserved for the output of the commands
+	if loggerConfig.OutputWriter == os.Stdout {
+		loggerConfig.OutputWriter = os.Stderr
+	}
+
+	var network proxycommon.EigenDANetwork
+	if networkString := ctx.GlobalString(flags.NetworkFlag.Name); networkString != "" {
+		network, err = proxycommon.EigenDANetworkFromString(networkString)
+		if err != nil {
+			return nil, fmt.Errorf("parse network: %w", err)
+		}
+	}
+
+	disperserGrpcUri := ctx.GlobalString(flags.DisperserRPCFlag.Name)
+	if disperserGrpcUri == "" && network != "" {
+		disperserGrpcUri = network.GetDisperserGrpcUri()
+	}
+
+	eigenDADirectory := ctx.GlobalString(flags.EigenDADirectoryFlag.Name)
+	if eigenDADirectory == "" && network != "" {
+		eigenDADirectory = network.GetEigenDADirectory()
+	}
+
+	clientLedgerMode := clientledger.ClientLedgerMode(ctx.GlobalString(flags.ClientLedgerModeFlag.Name))
+	switch clientLedgerMode {
+	case clientledger.ClientLedgerModeReservationOnly,
+		clientledger.ClientLedgerModeOnDemandOnly,
+		clientledger.ClientLedgerModeReservationAndOnDemand:
+	default:
+		return nil, fmt.Errorf("unsupported client ledger mode: %s", clientLedgerMode)
+	}
+
+	payloadClientConfig := *clients.GetDefaultPayloadClientConfig()
+	// #nosec G115 - only overflow on incorrect user input
+	payloadClientConfig.BlobVersion = uint16(ctx.GlobalUint(flags.BlobVersionFlag.Name))
+
+	return &Config{
+		LoggerConfig:        *loggerConfig,
+		Network:             network,
+		EthRPCURL:           ctx.GlobalString(flags.EthRPCURLFlag.Name),
+		EigenDADirectory:    eigenDADirectory,
+		CertVerifierAddress: ctx.GlobalString(flags.CertVerifierAddressFlag.Name),
+		SignerPaymentKey:    ctx.GlobalString(flags.SignerPaymentKeyHexFlag.Name),
+		ClientLedgerMode:    clientLedgerMode,
+		DisperserClientConfig: dispersal.DisperserClientConfig{
+			GrpcUri:           disperserGrpcUri,
+			UseSecureGrpcFlag: !ctx.GlobalBool(flags.DisableTLSFlag.Name),
+		},
+		PayloadDisperserConfig: dispersal.PayloadDisperserConfig{
+			PayloadClientConfig:    payloadClientConfig,
+			DisperseBlobTimeout:    ctx.GlobalDuration(flags.DisperseBlobTimeoutFlag.Name),
+			BlobCompleteTimeout:    ctx.GlobalDuration(flags.BlobCertifiedTimeoutFlag.Name),
+			BlobStatusPollInterval: ctx.GlobalDuration(flags.BlobStatusPollIntervalFlag.Name),
+			ContractCallTimeout:    ctx.GlobalDuration(flags.ContractCallTimeoutFlag.Name),
+			DispersalJournalPath:   ctx.GlobalString(flags.DispersalJournalPathFlag.Name),
+		},
+		RelayPayloadRetrieverConfig: payloadretrieval.RelayPayloadRetrieverConfig{
+			PayloadClientConfig: payloadClientConfig,
+			RelayTimeout:        ctx.GlobalDuration(flags.RelayTimeoutFlag.Name),
+		},
+	}, nil
+}
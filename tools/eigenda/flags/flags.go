@@ -0,0 +1,199 @@
+package flags
+
+import (
+	"fmt"
+	"time"
+
+	proxycommon "github.com/Layr-Labs/eigenda/api/proxy/common"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core/payments/clientledger"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = ""
+	envPrefix  = "EIGENDA_CLI"
+)
+
+var (
+	/* Global Flags */
+	NetworkFlag = cli.StringFlag{
+		Name: common.PrefixFlag(FlagPrefix, "network"),
+		Usage: fmt.Sprintf("The EigenDA network to use, which sets the default disperser and EigenDA directory. "+
+			"One of %s, %s, %s or %s.",
+			proxycommon.MainnetEigenDANetwork,
+			proxycommon.SepoliaTestnetEigenDANetwork,
+			proxycommon.HoodiTestnetEigenDANetwork,
+			proxycommon.HoodiPreprodEigenDANetwork),
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "NETWORK"),
+	}
+	DisperserRPCFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-rpc"),
+		Usage:    "RPC endpoint (<hostname>:<port>) of the EigenDA disperser. Overrides the network default.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DISPERSER_RPC"),
+	}
+	DisableTLSFlag = cli.BoolFlag{
+		Name:   common.PrefixFlag(FlagPrefix, "disable-tls"),
+		Usage:  "Disable TLS for gRPC communication with the EigenDA disperser and relays.",
+		EnvVar: common.PrefixEnvVar(envPrefix, "DISABLE_TLS"),
+	}
+	EthRPCURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "eth-rpc"),
+		Usage:    "Ethereum RPC URL. Required by all commands that read EigenDA contracts.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "ETH_RPC"),
+	}
+	EigenDADirectoryFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "eigenda-directory"),
+		Usage:    "Address of the EigenDA directory contract. Overrides the network default.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "EIGENDA_DIRECTORY"),
+	}
+	CertVerifierAddressFlag = cli.StringFlag{
+		Name: common.PrefixFlag(FlagPrefix, "cert-verifier-address"),
+		Usage: "Address of an immutable EigenDACertVerifier contract. If not set, the EigenDACertVerifierRouter " +
+			"of the EigenDA directory is used.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CERT_VERIFIER_ADDRESS"),
+	}
+	SignerPaymentKeyHexFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "signer-payment-key-hex"),
+		Usage:    "Hex-encoded private key of the account paying for dispersals. Required by disperser commands.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SIGNER_PAYMENT_KEY_HEX"),
+	}
+	ClientLedgerModeFlag = cli.StringFlag{
+		Name: common.PrefixFlag(FlagPrefix, "client-ledger-mode"),
+		Usage: fmt.Sprintf("Payment mode used when dispersing. One of '%s', '%s' or '%s'.",
+			clientledger.ClientLedgerModeReservationOnly,
+			clientledger.ClientLedgerModeOnDemandOnly,
+			clientledger.ClientLedgerModeReservationAndOnDemand),
+		Value:    string(clientledger.ClientLedgerModeReservationOnly),
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CLIENT_LEDGER_MODE"),
+	}
+	BlobVersionFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-version"),
+		Usage:    "Blob params version used when dispersing.",
+		Value:    0,
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_VERSION"),
+	}
+	DisperseBlobTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperse-blob-timeout"),
+		Usage:    "Maximum amount of time to wait for the disperser to accept a blob.",
+		Value:    2 * time.Minute,
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DISPERSE_BLOB_TIMEOUT"),
+	}
+	BlobCertifiedTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-certified-timeout"),
+		Usage:    "Maximum amount of time to wait for a dispersed blob to be signed.",
+		Value:    2 * time.Minute,
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_CERTIFIED_TIMEOUT"),
+	}
+	BlobStatusPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-status-poll-interval"),
+		Usage:    "Interval at which the blob status is polled, if the disperser doesn't support subscriptions.",
+		Value:    1 * time.Second,
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_STATUS_POLL_INTERVAL"),
+	}
+	ContractCallTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "contract-call-timeout"),
+		Usage:    "Timeout for calls to EigenDA contracts.",
+		Value:    10 * time.Second,
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CONTRACT_CALL_TIMEOUT"),
+	}
+	RelayTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-timeout"),
+		Usage:    "Timeout for calls to relays when retrieving a payload.",
+		Value:    10 * time.Second,
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RELAY_TIMEOUT"),
+	}
+	DispersalJournalPathFlag = cli.StringFlag{
+		Name: common.PrefixFlag(FlagPrefix, "dispersal-journal-path"),
+		Usage: "Directory in which dispersals waiting for signatures are journaled. Dispersing the same file " +
+			"again after an interruption resumes the journaled dispersal instead of paying for a new one.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DISPERSAL_JOURNAL_PATH"),
+	}
+
+	/* Command Flags */
+	FileFlag = cli.StringFlag{
+		Name:     "file",
+		Usage:    "Path of the file to disperse. Use '-' to read from stdin.",
+		Required: true,
+	}
+	OutputFileFlag = cli.StringFlag{
+		Name:     "out",
+		Usage:    "Path of the file to write the output to, instead of stdout",
+		Required: false,
+	}
+	RequiredOutputFileFlag = cli.StringFlag{
+		Name:     OutputFileFlag.Name,
+		Usage:    "Path of the file to write the payload to",
+		Required: true,
+	}
+	BlobKeyFlag = cli.StringFlag{
+		Name:     "blob-key",
+		Usage:    "Hex-encoded key of the blob",
+		Required: true,
+	}
+	FollowFlag = cli.BoolFlag{
+		Name:  "follow",
+		Usage: "Keep printing status updates until the blob reaches a terminal status",
+	}
+	CertHexFlag = cli.StringFlag{
+		Name: "cert",
+		Usage: "Hex-encoded DA commitment, as printed by the disperse command or returned by eigenda-proxy " +
+			"(can include 0x prefix)",
+		Required: true,
+	}
+)
+
+var Flags = append([]cli.Flag{
+	NetworkFlag,
+	DisperserRPCFlag,
+	DisableTLSFlag,
+	EthRPCURLFlag,
+	EigenDADirectoryFlag,
+	CertVerifierAddressFlag,
+	SignerPaymentKeyHexFlag,
+	ClientLedgerModeFlag,
+	BlobVersionFlag,
+	DisperseBlobTimeoutFlag,
+	BlobCertifiedTimeoutFlag,
+	BlobStatusPollIntervalFlag,
+	ContractCallTimeoutFlag,
+	RelayTimeoutFlag,
+	DispersalJournalPathFlag,
+}, common.LoggerCLIFlags(envPrefix, FlagPrefix)...)
+
+var DisperseFlags = []cli.Flag{
+	FileFlag,
+	OutputFileFlag,
+}
+
+var StatusFlags = []cli.Flag{
+	BlobKeyFlag,
+	FollowFlag,
+}
+
+var RetrieveFlags = []cli.Flag{
+	CertHexFlag,
+	RequiredOutputFileFlag,
+}
+
+var DecodeFlags = []cli.Flag{
+	CertHexFlag,
+}
+
+var VerifyFlags = []cli.Flag{
+	CertHexFlag,
+}